@@ -9,12 +9,11 @@ import (
 
 	"github.com/gin-gonic/gin"
 	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
-	"github.com/hankimmy/PtmrBackend/pkg/middleware"
+	"github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
 	"github.com/hankimmy/PtmrBackend/pkg/service"
-	"github.com/hankimmy/PtmrBackend/pkg/token"
 	"github.com/hankimmy/PtmrBackend/pkg/worker"
 	"github.com/hibiken/asynq"
-	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
 )
 
 type createApplicationRequest struct {
@@ -22,7 +21,7 @@ type createApplicationRequest struct {
 	EmployerID        int64                `json:"employer_id,omitempty"`
 	ApplicationStatus db.ApplicationStatus `json:"application_status" binding:"required"`
 	ApplicationDoc    json.RawMessage      `json:"application_doc" binding:"required"`
-	JobDocID          string               `json:"job_doc_id,omitempty"` // Only for candidate applications
+	JobDocID          string               `json:"job_doc_id,omitempty"`
 }
 
 func (server *Server) createApplication(ctx *gin.Context, isEmployer bool) {
@@ -31,11 +30,31 @@ func (server *Server) createApplication(ctx *gin.Context, isEmployer bool) {
 		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
 		return
 	}
-	if err := validateIDs(req.CandidateID, req.EmployerID); err != nil {
+	if isEmployer {
+		if err := validateIDs(req.CandidateID, req.EmployerID); err != nil {
+			ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
+			return
+		}
+	} else if req.CandidateID == 0 {
+		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(errors.New("candidate_id must be provided")))
+		return
+	}
+	if err := db.ValidateInitialApplicationStatus(req.ApplicationStatus); err != nil {
 		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
 		return
 	}
-	authPayload := ctx.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload)
+	kind := db.ApplicationKindCandidate
+	if isEmployer {
+		kind = db.ApplicationKindEmployer
+	}
+	authPayload, ok := authorize(ctx, createApplicationPolicy, applicationResource{
+		Kind:        kind,
+		CandidateID: req.CandidateID,
+		EmployerID:  req.EmployerID,
+	})
+	if !ok {
+		return
+	}
 	var docID string
 	appDoc, err := convertApplicationDoc(req.ApplicationDoc)
 	if err != nil {
@@ -44,49 +63,63 @@ func (server *Server) createApplication(ctx *gin.Context, isEmployer bool) {
 	}
 
 	if isEmployer {
-		if authPayload.Role != db.RoleEmployer || authPayload.RoleID != req.EmployerID {
-			ctx.JSON(http.StatusUnauthorized, service.ErrorResponse(errors.New("account is not employer or belong to user")))
+		server.createInvitation(ctx, req, authPayload.RoleID, appDoc)
+	} else {
+		if req.JobDocID == "" {
+			ctx.JSON(http.StatusBadRequest, service.ErrorResponse(errors.New("job_doc_id is required for candidate applications")))
 			return
 		}
-		message, err := getMessageFromApplicationDoc(req.ApplicationDoc)
+		job, status, err := server.getApplicationJob(req.JobDocID)
 		if err != nil {
-			ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
+			ctx.JSON(status, service.ErrorResponse(err))
 			return
 		}
-		arg := db.CreateEmployerApplicationParams{
-			EmployerID:        authPayload.RoleID,
-			CandidateID:       req.CandidateID,
-			Message:           message,
-			ApplicationStatus: req.ApplicationStatus,
+		// The application goes to whoever posted the job.
+		if req.EmployerID != 0 && req.EmployerID != job.EmployerID {
+			ctx.JSON(http.StatusBadRequest, service.ErrorResponse(errors.New("employer_id doesn't match the job's employer")))
+			return
 		}
-		result, err := server.store.CreateEmployerApplication(ctx, arg)
-		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, service.ErrorResponse(err))
+		if !job.AcceptsApplications(time.Now()) {
+			ctx.JSON(http.StatusConflict, service.ErrorResponse(errors.New("job is not accepting applications")))
 			return
 		}
-		ctx.JSON(http.StatusOK, result)
-	} else {
-		if authPayload.Role != db.RoleCandidate || authPayload.RoleID != req.CandidateID {
-			ctx.JSON(http.StatusUnauthorized, service.ErrorResponse(errors.New("account is not candidate or belong to user")))
+		if err := validateApplicationAnswers(job, appDoc); err != nil {
+			ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
 			return
 		}
-		docID = fmt.Sprintf("%d_%d", authPayload.RoleID, req.EmployerID)
+		docID = elasticsearch.CandidateApplicationDocID(authPayload.RoleID, job.ID)
+		appDoc["candidate_id"] = authPayload.RoleID
+		appDoc["employer_id"] = job.EmployerID
+		appDoc["job_id"] = job.ID
 		arg := db.CreateCandidateApplicationTxParams{
 			CreateCandidateApplicationParams: db.CreateCandidateApplicationParams{
 				CandidateID:        authPayload.RoleID,
-				EmployerID:         req.EmployerID,
+				EmployerID:         job.EmployerID,
 				ElasticsearchDocID: docID,
 				JobDocID:           req.JobDocID,
 				ApplicationStatus:  req.ApplicationStatus,
 			},
-			AppDoc:      appDoc,
-			AfterCreate: server.afterCandidateCreateApp(ctx),
+			MaxApplications:   job.MaxApplications,
+			ReapplyCooldown:   server.reapplyCooldown(),
+			AppDoc:            appDoc,
+			AfterCreate:       server.afterCandidateCreateApp(ctx),
+			AfterLimitReached: server.afterJobFilled(ctx, job.ID),
 		}
 		result, err := server.store.CreateCandidateApplicationTx(ctx, arg)
 		if err != nil {
+			if errors.Is(err, db.ErrApplicationLimitReached) || errors.Is(err, db.ErrReapplyTooSoon) {
+				ctx.JSON(http.StatusConflict, service.ErrorResponse(err))
+				return
+			}
+			if db.ErrorCode(err) == db.UniqueViolation {
+				ctx.JSON(http.StatusConflict, service.ErrorResponse(errors.New("already applied to this job")))
+				return
+			}
 			ctx.JSON(http.StatusInternalServerError, service.ErrorResponse(err))
 			return
 		}
+		worker.RecordJobStat(ctx, server.taskDistributor, worker.JobStatApplication, *job)
+		server.notifyApplicationReceived(ctx, result.CandidateApplication)
 		ctx.JSON(http.StatusOK, result.CandidateApplication)
 	}
 }
@@ -96,76 +129,157 @@ type getApplicationsRequest struct {
 	EmployerID  int64 `uri:"employer_id,omitempty"`
 }
 
+// getApplications lists a page of the applications a candidate received from
+// employers, or an employer received from candidates, newest first unless
+// asked otherwise. Withdrawn applications are left out of an employer's
+// pipeline unless they are asked for by status. Employers can also rank the
+// applicants of one job by how well they fit it.
 func (server *Server) getApplications(ctx *gin.Context, isEmployer bool) {
 	var req getApplicationsRequest
 	if err := ctx.ShouldBindUri(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
 		return
 	}
+	var query listApplicationsQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
+		return
+	}
+	filter, err := query.filter()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
+		return
+	}
+	if filter.byFit && !isEmployer {
+		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(errors.New("sort=fit is only for an employer's applicants")))
+		return
+	}
 
-	var err error
-	var res []map[string]interface{}
-	authPayload := ctx.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload)
+	// Employers list the candidate applications they received, candidates
+	// the employer applications.
+	resource := applicationResource{Kind: db.ApplicationKindEmployer, CandidateID: req.CandidateID}
+	if isEmployer {
+		resource = applicationResource{Kind: db.ApplicationKindCandidate, EmployerID: req.EmployerID}
+	}
+	if _, ok := authorize(ctx, listApplicationsPolicy, resource); !ok {
+		return
+	}
+	if filter.byFit {
+		server.listApplicationsByFit(ctx, req.EmployerID, query, filter)
+		return
+	}
 
 	if !isEmployer {
-		var applications []db.EmployerApplication
-		applications, err = server.store.GetEmployerApplicationsByCandidate(ctx, req.CandidateID)
+		applications, err := server.store.ListEmployerApplications(ctx, db.ListEmployerApplicationsParams{
+			CandidateID:     req.CandidateID,
+			Statuses:        filter.statuses,
+			CreatedAfter:    filter.createdAfter,
+			CreatedBefore:   filter.createdBefore,
+			CursorCreatedAt: filter.cursorCreatedAt,
+			OldestFirst:     filter.oldestFirst,
+			CursorID:        filter.cursorID,
+			Limit:           filter.pageSize + 1,
+		})
 		if err != nil {
 			ctx.JSON(http.StatusInternalServerError, service.ErrorResponse(err))
 			return
 		}
-
-		// Authorization check
-		if len(applications) > 0 && applications[0].CandidateID != authPayload.RoleID {
-			ctx.JSON(http.StatusUnauthorized, service.ErrorResponse(errors.New("account doesn't belong to the authenticated user")))
-			return
+		var nextCursor string
+		if len(applications) > int(filter.pageSize) {
+			applications = applications[:filter.pageSize]
+			last := applications[len(applications)-1]
+			nextCursor = encodeApplicationCursor(applicationCursor{CreatedAt: last.CreatedAt, ID: last.EmployerID})
 		}
 
-		ctx.JSON(http.StatusOK, applications)
-	} else {
-		var applications []db.CandidateApplication
-		applications, err = server.store.GetCandidateApplicationsByEmployer(ctx, req.EmployerID)
+		keys := make([]applicationKey, 0, len(applications))
+		for _, application := range applications {
+			keys = append(keys, employerApplicationKey(application))
+		}
+		history, err := server.listPageStatusHistory(ctx, db.ApplicationKindEmployer, keys)
 		if err != nil {
 			ctx.JSON(http.StatusInternalServerError, service.ErrorResponse(err))
 			return
 		}
-
-		// Authorization check
-		if len(applications) > 0 && applications[0].EmployerID != authPayload.RoleID {
-			ctx.JSON(http.StatusUnauthorized, service.ErrorResponse(errors.New("account doesn't belong to the authenticated user")))
-			return
-		}
-
-		// Fetching Elasticsearch documents
+		res := make([]employerApplicationResponse, 0, len(applications))
 		for _, application := range applications {
-			esResult, err := server.esClient.GetCandidateApplication(ctx, application.ElasticsearchDocID)
-			if err != nil {
-				ctx.JSON(http.StatusInternalServerError, service.ErrorResponse(fmt.Errorf("failed to get application in Elasticsearch: %v", err)))
-				return
-			}
-			res = append(res, map[string]interface{}{
-				"metadata": application,
-				"document": esResult,
+			res = append(res, employerApplicationResponse{
+				EmployerApplication: application,
+				StatusHistory:       statusHistoryOf(history, employerApplicationKey(application)),
 			})
 		}
-		ctx.JSON(http.StatusOK, res)
+		ctx.JSON(http.StatusOK, listApplicationsResponse{Applications: res, NextCursor: nextCursor})
+		return
 	}
+
+	applications, err := server.listCandidateApplications(ctx, db.ListCandidateApplicationsNewestFirstParams{
+		EmployerID:      req.EmployerID,
+		Statuses:        filter.statuses,
+		JobDocIds:       query.JobDocID,
+		CreatedAfter:    filter.createdAfter,
+		CreatedBefore:   filter.createdBefore,
+		CursorCreatedAt: filter.cursorCreatedAt,
+		CursorID:        filter.cursorID,
+		CursorJobDocID:  filter.cursorJobID,
+		Limit:           filter.pageSize + 1,
+	}, filter.oldestFirst)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, service.ErrorResponse(err))
+		return
+	}
+	var nextCursor string
+	if len(applications) > int(filter.pageSize) {
+		applications = applications[:filter.pageSize]
+		last := applications[len(applications)-1]
+		nextCursor = encodeApplicationCursor(applicationCursor{CreatedAt: last.CreatedAt, ID: last.CandidateID, JobID: last.JobDocID})
+	}
+
+	history, docs, err := server.candidateApplicationPage(ctx, applications)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, service.ErrorResponse(err))
+		return
+	}
+	res := make([]map[string]interface{}, 0, len(applications))
+	for _, application := range applications {
+		res = append(res, map[string]interface{}{
+			"metadata":       application,
+			"document":       docs[application.ElasticsearchDocID],
+			"status_history": statusHistoryOf(history, candidateApplicationKey(application)),
+		})
+	}
+	ctx.JSON(http.StatusOK, listApplicationsResponse{Applications: res, NextCursor: nextCursor})
+}
+
+// candidateApplicationPage loads the status history and the Elasticsearch
+// documents of a page of candidate applications, the documents keyed by ID.
+func (server *Server) candidateApplicationPage(ctx *gin.Context, applications []db.CandidateApplication) (map[applicationKey][]db.ApplicationStatusHistory, map[string]map[string]interface{}, error) {
+	keys := make([]applicationKey, 0, len(applications))
+	docIDs := make([]string, 0, len(applications))
+	for _, application := range applications {
+		keys = append(keys, candidateApplicationKey(application))
+		docIDs = append(docIDs, application.ElasticsearchDocID)
+	}
+	history, err := server.listPageStatusHistory(ctx, db.ApplicationKindCandidate, keys)
+	if err != nil {
+		return nil, nil, err
+	}
+	docs, err := server.esClient.MGetCandidateApplications(ctx, docIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get applications in Elasticsearch: %v", err)
+	}
+	return history, docs, nil
 }
 
 type updateApplicationRequest struct {
-	CandidateID       int64                `uri:"candidate_id,omitempty"`
-	EmployerID        int64                `uri:"employer_id,omitempty"`
+	applicationURI
 	ApplicationStatus db.ApplicationStatus `json:"application_status"`
 	ApplicationDoc    json.RawMessage      `json:"application_doc"`
+	Reason            string               `json:"reason" binding:"max=500"`
 }
 
-func (req *updateApplicationRequest) validateIDs() error {
-	if req.CandidateID == 0 || req.EmployerID == 0 {
-		return errors.New("either candidate_id or employer_id must be provided")
-	}
-	return nil
-}
-
+// updateApplication changes an application's status and, for candidate
+// applications, its document. Status changes go through the application
+// state machine, so each party can only make the changes that are theirs to
+// make, and every change is added to the application's status history.
 func (server *Server) updateApplication(ctx *gin.Context, isEmployer bool) {
 	var req updateApplicationRequest
 	if err := ctx.ShouldBindUri(&req); err != nil {
@@ -176,129 +290,134 @@ func (server *Server) updateApplication(ctx *gin.Context, isEmployer bool) {
 		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
 		return
 	}
-
-	if err := req.validateIDs(); err != nil {
-		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
+	// Withdrawing needs a structured reason, so it has its own action.
+	if req.ApplicationStatus == db.ApplicationStatusWithdrawn {
+		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(errors.New("use the withdraw action to withdraw an application")))
 		return
 	}
 
-	authPayload := ctx.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload)
-	if authPayload.RoleID != req.CandidateID && authPayload.RoleID != req.EmployerID {
-		ctx.JSON(http.StatusUnauthorized, service.ErrorResponse(errors.New("account doesn't belong to the authenticated user")))
+	kind := db.ApplicationKindCandidate
+	if isEmployer {
+		kind = db.ApplicationKindEmployer
+	}
+	resource, current, ok := server.resolveApplication(ctx, kind, req.applicationURI)
+	if !ok {
+		return
+	}
+	authPayload, ok := authorize(ctx, updateApplicationPolicy, resource)
+	if !ok {
 		return
 	}
-	var docID string
-	var arg interface{}
-	var application interface{}
-	var err error
 
-	if isEmployer {
-		docID = fmt.Sprintf("%d_%d", req.EmployerID, req.CandidateID)
-		arg = db.UpdateEmployerApplicationParams{
-			CandidateID: req.CandidateID,
-			EmployerID:  req.EmployerID,
-			ApplicationStatus: db.NullApplicationStatus{
-				ApplicationStatus: req.ApplicationStatus,
-				Valid:             req.ApplicationStatus != "",
-			},
+	var result db.TransitionApplicationStatusTxResult
+	result.CandidateApplication = current
+	var applicationDoc map[string]interface{}
+	if req.ApplicationDoc != nil && !isEmployer {
+		if _, ok := authorize(ctx, editApplicationDocumentPolicy, resource); !ok {
+			return
 		}
-		application, err = server.store.UpdateEmployerApplication(ctx, arg.(db.UpdateEmployerApplicationParams))
-	} else {
-		docID = fmt.Sprintf("%d_%d", req.CandidateID, req.EmployerID)
-		arg = db.UpdateCandidateApplicationParams{
-			CandidateID: req.CandidateID,
-			EmployerID:  req.EmployerID,
-			ApplicationStatus: db.NullApplicationStatus{
-				ApplicationStatus: req.ApplicationStatus,
-				Valid:             req.ApplicationStatus != "",
-			},
-			ElasticsearchDocID: pgtype.Text{
-				String: docID,
-				Valid:  true,
-			},
+		if err := json.Unmarshal(req.ApplicationDoc, &applicationDoc); err != nil {
+			ctx.JSON(http.StatusBadRequest, service.ErrorResponse(fmt.Errorf("invalid application_doc: %v", err)))
+			return
+		}
+		job, status, err := server.getApplicationJob(current.JobDocID)
+		if err != nil {
+			ctx.JSON(status, service.ErrorResponse(err))
+			return
+		}
+		if err := validateApplicationAnswers(job, applicationDoc); err != nil {
+			ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
+			return
 		}
-		application, err = server.store.UpdateCandidateApplication(ctx, arg.(db.UpdateCandidateApplicationParams))
 	}
 
+	var err error
+	if req.ApplicationStatus != "" {
+		result, err = server.store.TransitionApplicationStatusTx(ctx, db.TransitionApplicationStatusTxParams{
+			Kind:        kind,
+			CandidateID: resource.CandidateID,
+			EmployerID:  resource.EmployerID,
+			JobDocID:    current.JobDocID,
+			ActorRole:   authPayload.Role,
+			ActorID:     authPayload.RoleID,
+			Status:      req.ApplicationStatus,
+			Reason:      req.Reason,
+			AfterUpdate: server.afterApplicationDecision(ctx),
+		})
+	} else if isEmployer {
+		result.EmployerApplication, err = server.store.GetEmployerApplication(ctx, db.GetEmployerApplicationParams{
+			EmployerID:  resource.EmployerID,
+			CandidateID: resource.CandidateID,
+		})
+	}
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, service.ErrorResponse(err))
+		handleTransitionError(ctx, err)
 		return
 	}
 
-	if req.ApplicationDoc != nil && !isEmployer {
-		var applicationDoc map[string]interface{}
-		if err := json.Unmarshal(req.ApplicationDoc, &applicationDoc); err != nil {
-			ctx.JSON(http.StatusBadRequest, service.ErrorResponse(fmt.Errorf("invalid application_doc: %v", err)))
-			return
-		}
-		if err := server.esClient.UpdateCandidateApplication(ctx, docID, applicationDoc); err != nil {
+	if applicationDoc != nil {
+		if err := server.esClient.UpdateCandidateApplication(ctx, current.ElasticsearchDocID, applicationDoc); err != nil {
 			ctx.JSON(http.StatusInternalServerError, service.ErrorResponse(fmt.Errorf("failed to update application in Elasticsearch: %v", err)))
 			return
 		}
 	}
 
-	ctx.JSON(http.StatusOK, application)
-}
-
-type deleteApplicationRequest struct {
-	CandidateUsername string `uri:"candidate_username,omitempty""`
-	EmployerUsername  string `uri:"employer_username,omitempty""`
-	CandidateID       int64  `json:"candidate_id,omitempty"`
-	EmployerID        int64  `json:"employer_id,omitempty"`
+	history, err := server.listStatusHistory(ctx, kind, resource.CandidateID, resource.EmployerID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, service.ErrorResponse(err))
+		return
+	}
+	if isEmployer {
+		ctx.JSON(http.StatusOK, employerApplicationResponse{
+			EmployerApplication: result.EmployerApplication,
+			StatusHistory:       statusHistoryOf(history, employerApplicationKey(result.EmployerApplication)),
+		})
+		return
+	}
+	ctx.JSON(http.StatusOK, candidateApplicationResponse{
+		CandidateApplication: result.CandidateApplication,
+		StatusHistory:        statusHistoryOf(history, candidateApplicationKey(result.CandidateApplication)),
+	})
 }
 
+// deleteApplication lets the sender take back an application.
 func (server *Server) deleteApplication(ctx *gin.Context, isEmployer bool) {
-	var req deleteApplicationRequest
+	var req applicationURI
 	if err := ctx.ShouldBindUri(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
 		return
 	}
-	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
+	kind := db.ApplicationKindCandidate
+	if isEmployer {
+		kind = db.ApplicationKindEmployer
+	}
+	resource, current, ok := server.resolveApplication(ctx, kind, req)
+	if !ok {
 		return
 	}
-	if err := validateIDs(req.CandidateID, req.EmployerID); err != nil {
-		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
+	if _, ok := authorize(ctx, deleteApplicationPolicy, resource); !ok {
 		return
 	}
-	authPayload := ctx.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload)
-	var docID string
-	var err error
+
+	arg := db.DeleteApplicationTxParams{
+		IsEmployer: isEmployer,
+		DocID:      current.ElasticsearchDocID,
+	}
 	if isEmployer {
-		if authPayload.Role != db.RoleEmployer || authPayload.RoleID != req.EmployerID {
-			ctx.JSON(http.StatusUnauthorized, service.ErrorResponse(errors.New("account is not employer or belong to user")))
-			return
-		}
-		docID = fmt.Sprintf("%d_%d", authPayload.RoleID, req.CandidateID)
-		arg := db.DeleteApplicationTxParams{
-			DeleteEmployerApplicationParams: db.DeleteEmployerApplicationParams{
-				CandidateID: req.CandidateID,
-				EmployerID:  authPayload.RoleID,
-			},
-			IsEmployer:  isEmployer,
-			DocID:       docID,
-			AfterDelete: server.afterEmployerDeleteApp(ctx),
+		arg.DocID = elasticsearch.EmployerApplicationDocID(resource.EmployerID, resource.CandidateID)
+		arg.DeleteEmployerApplicationParams = db.DeleteEmployerApplicationParams{
+			EmployerID:  resource.EmployerID,
+			CandidateID: resource.CandidateID,
 		}
-		err = server.store.DeleteApplicationTx(ctx, arg)
+		arg.AfterDelete = server.afterEmployerDeleteApp(ctx)
 	} else {
-		if authPayload.Role != db.RoleCandidate || authPayload.RoleID != req.CandidateID {
-			ctx.JSON(http.StatusUnauthorized, service.ErrorResponse(errors.New("account is not employer or belong to user")))
-			return
-		}
-		docID = fmt.Sprintf("%d_%d", authPayload.RoleID, req.EmployerID)
-		arg := db.DeleteApplicationTxParams{
-			DeleteCandidateApplicationParams: db.DeleteCandidateApplicationParams{
-				CandidateID: authPayload.RoleID,
-				EmployerID:  req.EmployerID,
-			},
-			IsEmployer:  isEmployer,
-			DocID:       docID,
-			AfterDelete: server.afterCandidateDeleteApp(ctx),
+		arg.DeleteCandidateApplicationParams = db.DeleteCandidateApplicationParams{
+			CandidateID: current.CandidateID,
+			JobDocID:    current.JobDocID,
 		}
-		err = server.store.DeleteApplicationTx(ctx, arg)
+		arg.AfterDelete = server.afterCandidateDeleteApp(ctx)
 	}
-
-	if err != nil {
+	if err := server.store.DeleteApplicationTx(ctx, arg); err != nil {
 		ctx.JSON(http.StatusInternalServerError, service.ErrorResponse(err))
 		return
 	}
@@ -310,8 +429,23 @@ func (server *Server) afterCandidateCreateApp(ctx *gin.Context) func(docID strin
 	return server.enqueueCreateAppTask(worker.TaskCreateCandidateApp, ctx)
 }
 
-func (server *Server) afterEmployerCreateApp(ctx *gin.Context) func(docID string, appDoc map[string]interface{}) error {
-	return server.enqueueCreateAppTask(worker.TaskCreateEmployerApp, ctx)
+// notifyApplicationReceived enqueues an email to the employer about a new
+// application. The application is already saved, so a failure to enqueue is
+// only logged.
+func (server *Server) notifyApplicationReceived(ctx *gin.Context, application db.CandidateApplication) {
+	payload := &worker.PayloadNotifyApplicationReceived{
+		CandidateID: application.CandidateID,
+		EmployerID:  application.EmployerID,
+		JobID:       application.JobDocID,
+	}
+	opts := []asynq.Option{
+		asynq.MaxRetry(10),
+		asynq.ProcessIn(10 * time.Second),
+		asynq.Queue(worker.QueueMail),
+	}
+	if err := server.taskDistributor.DistributeTaskNotifyApplicationReceived(ctx, payload, opts...); err != nil {
+		log.Error().Err(err).Str("job_id", application.JobDocID).Msg("failed to enqueue application received notification")
+	}
 }
 
 func (server *Server) enqueueCreateAppTask(taskType string, ctx *gin.Context) func(docID string, appDoc map[string]interface{}) error {
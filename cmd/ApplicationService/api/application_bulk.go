@@ -0,0 +1,136 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	"github.com/hankimmy/PtmrBackend/pkg/service"
+	"github.com/hankimmy/PtmrBackend/pkg/worker"
+	"github.com/hibiken/asynq"
+)
+
+// decisionEmailBatchSize is how many candidates each notification task of a
+// bulk update emails.
+const decisionEmailBatchSize = 50
+
+type bulkUpdateApplicationsRequest struct {
+	JobID  string               `json:"job_id" binding:"required"`
+	Status db.ApplicationStatus `json:"status" binding:"required,oneof=accepted rejected"`
+	// CandidateIDs lists the applications to update. FromStatuses picks them
+	// by status instead, and only when no candidates are listed.
+	CandidateIDs []int64                `json:"candidate_ids" binding:"max=200,dive,min=1"`
+	FromStatuses []db.ApplicationStatus `json:"from_statuses" binding:"dive,oneof=pending submitted"`
+	Reason       string                 `json:"reason" binding:"max=500"`
+}
+
+type bulkApplicationResult struct {
+	CandidateID int64 `json:"candidate_id"`
+	// Result is updated, unchanged when the application already had the
+	// status, or failed.
+	Result      string                   `json:"result"`
+	Error       string                   `json:"error,omitempty"`
+	Application *db.CandidateApplication `json:"application,omitempty"`
+}
+
+type bulkUpdateApplicationsResponse struct {
+	Updated int                     `json:"updated"`
+	Failed  int                     `json:"failed"`
+	Results []bulkApplicationResult `json:"results"`
+}
+
+// bulkUpdateApplications accepts or rejects many applications to one of the
+// employer's jobs at once, either the candidates listed or every application
+// with one of the given statuses. Each application follows the same rules
+// as a single decision; the ones that can't be updated are reported in the
+// results without holding back the others. The candidates are emailed in
+// batches.
+func (server *Server) bulkUpdateApplications(ctx *gin.Context) {
+	var req bulkUpdateApplicationsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
+		return
+	}
+	if len(req.CandidateIDs) == 0 && len(req.FromStatuses) == 0 {
+		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(errors.New("candidate_ids or from_statuses is required")))
+		return
+	}
+	job, status, err := server.getApplicationJob(req.JobID)
+	if err != nil {
+		ctx.JSON(status, service.ErrorResponse(err))
+		return
+	}
+	if _, ok := authorize(ctx, decideApplicationPolicy, applicationResource{
+		Kind:       db.ApplicationKindCandidate,
+		EmployerID: job.EmployerID,
+	}); !ok {
+		return
+	}
+
+	results, err := server.store.BulkTransitionApplicationsTx(ctx, db.BulkTransitionApplicationsTxParams{
+		EmployerID:   job.EmployerID,
+		JobDocID:     job.ID,
+		CandidateIDs: req.CandidateIDs,
+		FromStatuses: req.FromStatuses,
+		Status:       req.Status,
+		Reason:       req.Reason,
+		AfterUpdate:  server.afterBulkApplicationDecision(ctx, job.EmployerID, job.ID, req),
+	})
+	if err != nil {
+		handleTransitionError(ctx, err)
+		return
+	}
+
+	res := bulkUpdateApplicationsResponse{Results: make([]bulkApplicationResult, 0, len(results))}
+	for _, result := range results {
+		item := bulkApplicationResult{CandidateID: result.CandidateID}
+		switch {
+		case result.Err != nil:
+			item.Result = "failed"
+			item.Error = result.Err.Error()
+			res.Failed++
+		case result.Changed:
+			item.Result = "updated"
+			res.Updated++
+		default:
+			item.Result = "unchanged"
+		}
+		if result.Err == nil {
+			application := result.CandidateApplication
+			item.Application = &application
+		}
+		res.Results = append(res.Results, item)
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// afterBulkApplicationDecision enqueues the emails to the candidates whose
+// applications changed, a batch of them per task. It runs inside the bulk
+// transaction, so nothing is updated if a batch can't be enqueued.
+func (server *Server) afterBulkApplicationDecision(ctx *gin.Context, employerID int64, jobID string, req bulkUpdateApplicationsRequest) func(changed []db.TransitionApplicationStatusTxResult) error {
+	return func(changed []db.TransitionApplicationStatusTxResult) error {
+		opts := []asynq.Option{
+			asynq.MaxRetry(10),
+			asynq.ProcessIn(10 * time.Second),
+			asynq.Queue(worker.QueueMail),
+		}
+		for start := 0; start < len(changed); start += decisionEmailBatchSize {
+			end := min(start+decisionEmailBatchSize, len(changed))
+			payload := &worker.PayloadNotifyApplicationDecisions{
+				EmployerID: employerID,
+				JobID:      jobID,
+				Status:     req.Status,
+				Reason:     req.Reason,
+			}
+			for _, result := range changed[start:end] {
+				payload.CandidateIDs = append(payload.CandidateIDs, result.CandidateApplication.CandidateID)
+			}
+			if err := server.taskDistributor.DistributeTaskNotifyApplicationDecisions(ctx, payload, opts...); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
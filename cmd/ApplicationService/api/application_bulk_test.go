@@ -0,0 +1,239 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/hankimmy/PtmrBackend/pkg/db/mock"
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	"github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
+	mockes "github.com/hankimmy/PtmrBackend/pkg/elasticsearch/mock"
+	"github.com/hankimmy/PtmrBackend/pkg/middleware"
+	"github.com/hankimmy/PtmrBackend/pkg/token"
+	"github.com/hankimmy/PtmrBackend/pkg/util"
+	"github.com/hankimmy/PtmrBackend/pkg/worker"
+	mockwk "github.com/hankimmy/PtmrBackend/pkg/worker/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkUpdateApplicationsAPI(t *testing.T) {
+	employerID := util.RandomInt(1, 1000)
+	job := elasticsearch.RandomJob(employerID)
+
+	// Enough rejected applications to need two notification batches.
+	var changed []db.TransitionApplicationStatusTxResult
+	var results []db.BulkApplicationResult
+	for i := int64(1); i <= decisionEmailBatchSize+1; i++ {
+		application := db.RandomCandidateApplication(employerID)
+		application.CandidateID = i
+		application.JobDocID = job.ID
+		application.ApplicationStatus = db.ApplicationStatusRejected
+		result := db.TransitionApplicationStatusTxResult{CandidateApplication: application, Changed: true}
+		changed = append(changed, result)
+		results = append(results, db.BulkApplicationResult{CandidateID: i, TransitionApplicationStatusTxResult: result})
+	}
+	results = append(results, db.BulkApplicationResult{CandidateID: 999, Err: db.ErrRecordNotFound})
+
+	employerAuth := func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+		middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, "employer", db.RoleEmployer, time.Minute, employerID)
+	}
+	getJob := func(esClient *mockes.MockESClient) {
+		esClient.EXPECT().
+			GetJob(gomock.Eq(job.ID)).
+			Times(1).
+			Return(&job, nil)
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "RejectAllPending",
+			body: gin.H{
+				"job_id":        job.ID,
+				"status":        db.ApplicationStatusRejected,
+				"from_statuses": []db.ApplicationStatus{db.ApplicationStatusPending},
+				"reason":        "Position filled",
+			},
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				getJob(esClient)
+				store.EXPECT().
+					BulkTransitionApplicationsTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.BulkTransitionApplicationsTxParams) ([]db.BulkApplicationResult, error) {
+						require.Equal(t, employerID, arg.EmployerID)
+						require.Equal(t, job.ID, arg.JobDocID)
+						require.Empty(t, arg.CandidateIDs)
+						require.Equal(t, []db.ApplicationStatus{db.ApplicationStatusPending}, arg.FromStatuses)
+						require.Equal(t, db.ApplicationStatusRejected, arg.Status)
+						require.Equal(t, "Position filled", arg.Reason)
+						return results, arg.AfterUpdate(changed)
+					})
+				taskDistributor.EXPECT().
+					DistributeTaskNotifyApplicationDecisions(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(2).
+					DoAndReturn(func(_ interface{}, payload *worker.PayloadNotifyApplicationDecisions, _ ...interface{}) error {
+						require.Equal(t, employerID, payload.EmployerID)
+						require.Equal(t, job.ID, payload.JobID)
+						require.Equal(t, db.ApplicationStatusRejected, payload.Status)
+						require.NotEmpty(t, payload.CandidateIDs)
+						require.LessOrEqual(t, len(payload.CandidateIDs), decisionEmailBatchSize)
+						return nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var got bulkUpdateApplicationsResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, decisionEmailBatchSize+1, got.Updated)
+				require.Equal(t, 1, got.Failed)
+				require.Len(t, got.Results, decisionEmailBatchSize+2)
+				require.Equal(t, "updated", got.Results[0].Result)
+				require.NotNil(t, got.Results[0].Application)
+				failed := got.Results[len(got.Results)-1]
+				require.Equal(t, "failed", failed.Result)
+				require.Equal(t, int64(999), failed.CandidateID)
+				require.NotEmpty(t, failed.Error)
+				require.Nil(t, failed.Application)
+			},
+		},
+		{
+			name: "AcceptListedCandidates",
+			body: gin.H{
+				"job_id":        job.ID,
+				"status":        db.ApplicationStatusAccepted,
+				"candidate_ids": []int64{3, 1},
+			},
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				getJob(esClient)
+				store.EXPECT().
+					BulkTransitionApplicationsTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.BulkTransitionApplicationsTxParams) ([]db.BulkApplicationResult, error) {
+						require.Equal(t, []int64{3, 1}, arg.CandidateIDs)
+						return []db.BulkApplicationResult{
+							{CandidateID: 1, Err: db.ErrInvalidStatusTransition},
+							{CandidateID: 3, TransitionApplicationStatusTxResult: db.TransitionApplicationStatusTxResult{CandidateApplication: changed[2].CandidateApplication}},
+						}, nil
+					})
+				taskDistributor.EXPECT().DistributeTaskNotifyApplicationDecisions(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var got bulkUpdateApplicationsResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Zero(t, got.Updated)
+				require.Equal(t, 1, got.Failed)
+				require.Equal(t, "unchanged", got.Results[1].Result)
+			},
+		},
+		{
+			name: "NoSelection",
+			body: gin.H{
+				"job_id": job.ID,
+				"status": db.ApplicationStatusRejected,
+			},
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().GetJob(gomock.Any()).Times(0)
+				store.EXPECT().BulkTransitionApplicationsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidStatus",
+			body: gin.H{
+				"job_id":        job.ID,
+				"status":        db.ApplicationStatusSubmitted,
+				"candidate_ids": []int64{1},
+			},
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().BulkTransitionApplicationsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "EnqueueFails",
+			body: gin.H{
+				"job_id":        job.ID,
+				"status":        db.ApplicationStatusRejected,
+				"from_statuses": []db.ApplicationStatus{db.ApplicationStatusPending},
+			},
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				getJob(esClient)
+				store.EXPECT().
+					BulkTransitionApplicationsTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.BulkTransitionApplicationsTxParams) ([]db.BulkApplicationResult, error) {
+						return nil, arg.AfterUpdate(changed)
+					})
+				taskDistributor.EXPECT().
+					DistributeTaskNotifyApplicationDecisions(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(errors.New("redis unavailable"))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "OtherEmployersJob",
+			body: gin.H{
+				"job_id":        job.ID,
+				"status":        db.ApplicationStatusRejected,
+				"from_statuses": []db.ApplicationStatus{db.ApplicationStatusPending},
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, "employer", db.RoleEmployer, time.Minute, employerID+1)
+			},
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				getJob(esClient)
+				store.EXPECT().BulkTransitionApplicationsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+			esClient := mockes.NewMockESClient(ctrl)
+			taskDistributor := mockwk.NewMockTaskDistributor(ctrl)
+			tc.buildStubs(store, esClient, taskDistributor)
+
+			server := newTestServer(t, store, esClient, taskDistributor)
+			recorder := httptest.NewRecorder()
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+			request, err := http.NewRequest(http.MethodPatch, "/candidate_applications/bulk", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,339 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	"github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
+	"github.com/hankimmy/PtmrBackend/pkg/service"
+	"github.com/hankimmy/PtmrBackend/pkg/spreadsheet"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// exportPageSize is how many applications are loaded and written at a
+	// time while an export streams.
+	exportPageSize = 200
+	// maxExportRange is the longest date range exported across jobs.
+	maxExportRange   = 366 * 24 * time.Hour
+	exportDateFormat = "2006-01-02"
+)
+
+// exportColumns are the columns every export starts with, before the
+// questions of the application forms.
+var exportColumns = []string{
+	"Candidate ID", "Name", "Email", "Phone", "Location", "Education", "Skills", "Certificates",
+	"Job ID", "Job title", "Status", "Applied at",
+}
+
+var unsafeFilenameChars = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+type exportApplicationsRequest struct {
+	EmployerID int64 `uri:"employer_id" binding:"required,min=1"`
+}
+
+// exportApplicationsQuery picks either one job's applications or the
+// applications to all of the employer's jobs made in a date range.
+type exportApplicationsQuery struct {
+	Format        spreadsheet.Format     `form:"format" binding:"omitempty,oneof=csv xlsx"`
+	JobDocID      string                 `form:"job_doc_id"`
+	Status        []db.ApplicationStatus `form:"status" binding:"dive,oneof=pending submitted accepted rejected withdrawn"`
+	CreatedAfter  time.Time              `form:"created_after" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedBefore time.Time              `form:"created_before" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+func (query exportApplicationsQuery) validate() error {
+	hasAfter, hasBefore := !query.CreatedAfter.IsZero(), !query.CreatedBefore.IsZero()
+	if hasAfter && hasBefore && !query.CreatedAfter.Before(query.CreatedBefore) {
+		return errors.New("created_after must be before created_before")
+	}
+	if query.JobDocID != "" {
+		return nil
+	}
+	if !hasAfter || !hasBefore {
+		return errors.New("job_doc_id or both created_after and created_before are required")
+	}
+	if query.CreatedBefore.Sub(query.CreatedAfter) > maxExportRange {
+		return errors.New("the date range can't be longer than a year")
+	}
+	return nil
+}
+
+// exportApplications streams an employer's candidate applications as a CSV
+// or XLSX file, one row per application with the candidate's profile and a
+// column per question of the application forms. Withdrawn applications are
+// only included when asked for by status.
+func (server *Server) exportApplications(ctx *gin.Context) {
+	var req exportApplicationsRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
+		return
+	}
+	var query exportApplicationsQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
+		return
+	}
+	if err := query.validate(); err != nil {
+		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
+		return
+	}
+	if query.Format == "" {
+		query.Format = spreadsheet.FormatCSV
+	}
+	if _, ok := authorize(ctx, listApplicationsPolicy, applicationResource{
+		Kind:       db.ApplicationKindCandidate,
+		EmployerID: req.EmployerID,
+	}); !ok {
+		return
+	}
+
+	arg := db.ListCandidateApplicationsOldestFirstParams{
+		EmployerID:    req.EmployerID,
+		Statuses:      db.PipelineStatuses,
+		CreatedAfter:  pgtype.Timestamptz{Time: query.CreatedAfter, Valid: !query.CreatedAfter.IsZero()},
+		CreatedBefore: pgtype.Timestamptz{Time: query.CreatedBefore, Valid: !query.CreatedBefore.IsZero()},
+		Limit:         exportPageSize,
+	}
+	if len(query.Status) > 0 {
+		arg.Statuses = nil
+		for _, status := range query.Status {
+			arg.Statuses = append(arg.Statuses, string(status))
+		}
+	}
+	jobs, status, err := server.exportJobs(ctx, req.EmployerID, query, arg)
+	if err != nil {
+		ctx.JSON(status, service.ErrorResponse(err))
+		return
+	}
+	if query.JobDocID != "" {
+		arg.JobDocIds = []string{query.JobDocID}
+	}
+	columns := newQuestionColumns(jobs)
+	jobsByID := make(map[string]*elasticsearch.Job, len(jobs))
+	for i := range jobs {
+		jobsByID[jobs[i].ID] = &jobs[i]
+	}
+
+	// The first page is loaded before anything is written so that failing
+	// to load it can still be reported.
+	applications, err := server.store.ListCandidateApplicationsOldestFirst(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, service.ErrorResponse(err))
+		return
+	}
+	writer, err := spreadsheet.NewWriter(query.Format, ctx.Writer, exportSheetName(query, jobs))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, service.ErrorResponse(err))
+		return
+	}
+	ctx.Header("Content-Type", query.Format.ContentType())
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, exportFilename(query)))
+	ctx.Status(http.StatusOK)
+	if err := server.streamExport(ctx, writer, arg, applications, jobsByID, columns); err != nil {
+		// The file is already on its way, so all that can be done is to cut
+		// it short.
+		log.Error().Err(err).Int64("employer_id", req.EmployerID).Msg("failed to export applications")
+	}
+}
+
+// streamExport writes the header and the applications a page at a time,
+// starting from the page already loaded, flushing each page to the client.
+func (server *Server) streamExport(ctx *gin.Context, writer spreadsheet.Writer, arg db.ListCandidateApplicationsOldestFirstParams,
+	applications []db.CandidateApplication, jobs map[string]*elasticsearch.Job, columns questionColumns) error {
+	if err := writer.WriteRow(append(append([]string{}, exportColumns...), columns.headers...)); err != nil {
+		return err
+	}
+	for {
+		if err := server.writeExportRows(ctx, writer, applications, jobs, columns); err != nil {
+			return err
+		}
+		if err := writer.Flush(); err != nil {
+			return err
+		}
+		ctx.Writer.Flush()
+		if len(applications) < exportPageSize {
+			return writer.Close()
+		}
+		last := applications[len(applications)-1]
+		arg.CursorCreatedAt = pgtype.Timestamptz{Time: last.CreatedAt, Valid: true}
+		arg.CursorID = pgtype.Int8{Int64: last.CandidateID, Valid: true}
+		arg.CursorJobDocID = pgtype.Text{String: last.JobDocID, Valid: true}
+		var err error
+		applications, err = server.store.ListCandidateApplicationsOldestFirst(ctx, arg)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// exportJobs returns the jobs whose applications are exported, which are the
+// job asked for or every job with an application in the date range. Jobs that
+// no longer exist are left out, and their applications exported without
+// answers.
+func (server *Server) exportJobs(ctx *gin.Context, employerID int64, query exportApplicationsQuery, arg db.ListCandidateApplicationsOldestFirstParams) ([]elasticsearch.Job, int, error) {
+	if query.JobDocID != "" {
+		job, status, err := server.getApplicationJob(query.JobDocID)
+		if err != nil {
+			return nil, status, err
+		}
+		if job.EmployerID != employerID {
+			return nil, http.StatusNotFound, fmt.Errorf("job %s not found", query.JobDocID)
+		}
+		return []elasticsearch.Job{*job}, http.StatusOK, nil
+	}
+	jobIDs, err := server.store.ListCandidateApplicationJobIDs(ctx, db.ListCandidateApplicationJobIDsParams{
+		EmployerID:    employerID,
+		Statuses:      arg.Statuses,
+		CreatedAfter:  arg.CreatedAfter,
+		CreatedBefore: arg.CreatedBefore,
+	})
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	jobs, err := server.esClient.MGetJobs(ctx, jobIDs)
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to get jobs in Elasticsearch: %v", err)
+	}
+	return jobs, http.StatusOK, nil
+}
+
+// writeExportRows writes a page of applications along with the profiles of
+// their candidates and their answers.
+func (server *Server) writeExportRows(ctx *gin.Context, writer spreadsheet.Writer, applications []db.CandidateApplication,
+	jobs map[string]*elasticsearch.Job, columns questionColumns) error {
+	if len(applications) == 0 {
+		return nil
+	}
+	candidateIDs := make([]int64, 0, len(applications))
+	docIDs := make([]string, 0, len(applications))
+	for _, application := range applications {
+		candidateIDs = append(candidateIDs, application.CandidateID)
+		docIDs = append(docIDs, application.ElasticsearchDocID)
+	}
+	profileRows, err := server.store.ListCandidateProfiles(ctx, candidateIDs)
+	if err != nil {
+		return err
+	}
+	profiles := make(map[int64]db.ListCandidateProfilesRow, len(profileRows))
+	for _, profile := range profileRows {
+		profiles[profile.ID] = profile
+	}
+	docs, err := server.esClient.MGetCandidateApplications(ctx, docIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get applications in Elasticsearch: %v", err)
+	}
+
+	for _, application := range applications {
+		profile := profiles[application.CandidateID]
+		var jobTitle string
+		if job, ok := jobs[application.JobDocID]; ok {
+			jobTitle = job.Title
+		}
+		row := []string{
+			strconv.FormatInt(application.CandidateID, 10),
+			profile.FullName,
+			profile.Email,
+			profile.PhoneNumber,
+			profile.Location,
+			string(profile.Education),
+			strings.Join(profile.SkillSet, "; "),
+			strings.Join(profile.Certificates, "; "),
+			application.JobDocID,
+			jobTitle,
+			string(application.ApplicationStatus),
+			application.CreatedAt.UTC().Format(time.RFC3339),
+		}
+		answers := make([]string, len(columns.headers))
+		if raw, ok := docs[application.ElasticsearchDocID][answersKey].(map[string]interface{}); ok {
+			for questionID, answer := range raw {
+				if column, ok := columns.index[application.JobDocID][questionID]; ok {
+					answers[column] = formatAnswer(answer)
+				}
+			}
+		}
+		if err := writer.WriteRow(append(row, answers...)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// questionColumns maps the questions of the exported jobs to columns.
+// Questions asked the same way by different jobs share a column so an export
+// across jobs stays readable.
+type questionColumns struct {
+	headers []string
+	// index holds the column of each question by job and question ID.
+	index map[string]map[string]int
+}
+
+func newQuestionColumns(jobs []elasticsearch.Job) questionColumns {
+	columns := questionColumns{index: make(map[string]map[string]int, len(jobs))}
+	byText := make(map[string]int)
+	for _, job := range jobs {
+		questions := append(elasticsearch.ApplicationForm{}, job.JobApplication...)
+		sort.SliceStable(questions, func(i, j int) bool { return questions[i].Order < questions[j].Order })
+		jobColumns := make(map[string]int, len(questions))
+		used := make(map[int]bool, len(questions))
+		for _, question := range questions {
+			text := strings.ToLower(strings.TrimSpace(question.Question))
+			column, ok := byText[text]
+			if !ok || used[column] {
+				column = len(columns.headers)
+				columns.headers = append(columns.headers, question.Question)
+				if !ok {
+					byText[text] = column
+				}
+			}
+			used[column] = true
+			jobColumns[question.ID] = column
+		}
+		columns.index[job.ID] = jobColumns
+	}
+	return columns
+}
+
+func formatAnswer(answer interface{}) string {
+	switch v := answer.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		if v {
+			return "yes"
+		}
+		return "no"
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
+
+func exportFilename(query exportApplicationsQuery) string {
+	name := "applications-" + query.CreatedAfter.Format(exportDateFormat) + "-to-" + query.CreatedBefore.Format(exportDateFormat)
+	if query.JobDocID != "" {
+		name = "applications-" + strings.Trim(unsafeFilenameChars.ReplaceAllString(query.JobDocID, "-"), "-")
+	}
+	return name + "." + string(query.Format)
+}
+
+func exportSheetName(query exportApplicationsQuery, jobs []elasticsearch.Job) string {
+	if query.JobDocID != "" && len(jobs) == 1 && jobs[0].Title != "" {
+		return jobs[0].Title
+	}
+	return "Applications"
+}
@@ -0,0 +1,324 @@
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/hankimmy/PtmrBackend/pkg/db/mock"
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	"github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
+	mockes "github.com/hankimmy/PtmrBackend/pkg/elasticsearch/mock"
+	"github.com/hankimmy/PtmrBackend/pkg/middleware"
+	"github.com/hankimmy/PtmrBackend/pkg/spreadsheet"
+	"github.com/hankimmy/PtmrBackend/pkg/token"
+	"github.com/hankimmy/PtmrBackend/pkg/util"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportApplicationsAPI(t *testing.T) {
+	employerID := util.RandomInt(1, 1000)
+	job := elasticsearch.RandomJob(employerID)
+	otherJob := elasticsearch.RandomJob(employerID)
+	otherJob.JobApplication = elasticsearch.ApplicationForm{
+		{ID: "name", Type: elasticsearch.QuestionTypeText, Question: "what is your full name?", Order: 1},
+		{ID: "hours", Type: elasticsearch.QuestionTypeNumber, Question: "How many hours a week?", Order: 2},
+	}
+	createdAt := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	// A full page of applications to the job and one more on the next page.
+	applications := make([]db.CandidateApplication, exportPageSize+1)
+	for i := range applications {
+		applications[i] = db.RandomCandidateApplication(employerID)
+		applications[i].CandidateID = int64(i + 1)
+		applications[i].JobDocID = job.ID
+		applications[i].CreatedAt = createdAt.Add(time.Duration(i) * time.Minute)
+	}
+	profile := db.ListCandidateProfilesRow{
+		ID:           1,
+		FullName:     "Ann Lee",
+		Email:        "ann@example.com",
+		PhoneNumber:  "(646) 555-0100",
+		Education:    db.EducationBachelor,
+		SkillSet:     []string{"espresso", "latte art"},
+		Certificates: []string{"Food Handler"},
+	}
+	answers := map[string]map[string]interface{}{
+		applications[0].ElasticsearchDocID: {answersKey: map[string]interface{}{
+			"q1": "Ann Lee",
+			"q3": "Bachelor's Degree",
+			"q4": true,
+		}},
+	}
+
+	employerAuth := func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+		middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, "employer", db.RoleEmployer, time.Minute, employerID)
+	}
+
+	testCases := []struct {
+		name          string
+		query         url.Values
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore, esClient *mockes.MockESClient)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "CSVForJob",
+			query:     url.Values{"job_doc_id": {job.ID}},
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				esClient.EXPECT().
+					GetJob(gomock.Eq(job.ID)).
+					Times(1).
+					Return(&job, nil)
+				first := store.EXPECT().
+					ListCandidateApplicationsOldestFirst(gomock.Any(), gomock.Eq(db.ListCandidateApplicationsOldestFirstParams{
+						EmployerID: employerID,
+						Statuses:   db.PipelineStatuses,
+						JobDocIds:  []string{job.ID},
+						Limit:      exportPageSize,
+					})).
+					Times(1).
+					Return(applications[:exportPageSize], nil)
+				last := applications[exportPageSize-1]
+				store.EXPECT().
+					ListCandidateApplicationsOldestFirst(gomock.Any(), gomock.Eq(db.ListCandidateApplicationsOldestFirstParams{
+						EmployerID:      employerID,
+						Statuses:        db.PipelineStatuses,
+						JobDocIds:       []string{job.ID},
+						CursorCreatedAt: pgtype.Timestamptz{Time: last.CreatedAt, Valid: true},
+						CursorID:        pgtype.Int8{Int64: last.CandidateID, Valid: true},
+						CursorJobDocID:  pgtype.Text{String: last.JobDocID, Valid: true},
+						Limit:           exportPageSize,
+					})).
+					Times(1).
+					After(first).
+					Return(applications[exportPageSize:], nil)
+				store.EXPECT().
+					ListCandidateProfiles(gomock.Any(), gomock.Any()).
+					Times(2).
+					Return([]db.ListCandidateProfilesRow{profile}, nil)
+				esClient.EXPECT().
+					MGetCandidateApplications(gomock.Any(), gomock.Any()).
+					Times(2).
+					Return(answers, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, spreadsheet.FormatCSV.ContentType(), recorder.Header().Get("Content-Type"))
+				require.Contains(t, recorder.Header().Get("Content-Disposition"), ".csv")
+
+				rows, err := csv.NewReader(recorder.Body).ReadAll()
+				require.NoError(t, err)
+				require.Len(t, rows, exportPageSize+2)
+				header := rows[0]
+				require.Equal(t, exportColumns, header[:len(exportColumns)])
+				require.Equal(t, []string{
+					"What is your full name?",
+					"What is your highest level of education?",
+					"Do you have a valid driver's license?",
+				}, header[len(exportColumns):])
+
+				first := rows[1]
+				require.Equal(t, "1", first[0])
+				require.Equal(t, "Ann Lee", first[1])
+				require.Equal(t, "ann@example.com", first[2])
+				require.Equal(t, "espresso; latte art", first[6])
+				require.Equal(t, job.Title, first[9])
+				require.Equal(t, "2024-01-10T12:00:00Z", first[11])
+				require.Equal(t, []string{"Ann Lee", "Bachelor's Degree", "yes"}, first[len(exportColumns):])
+
+				// Candidates without a profile or answers still get a row.
+				require.Equal(t, "2", rows[2][0])
+				require.Empty(t, rows[2][1])
+				require.Equal(t, []string{"", "", ""}, rows[2][len(exportColumns):])
+			},
+		},
+		{
+			name: "XLSXForDateRange",
+			query: url.Values{
+				"format":         {"xlsx"},
+				"status":         {"accepted"},
+				"created_after":  {"2024-01-01T00:00:00Z"},
+				"created_before": {"2024-02-01T00:00:00Z"},
+			},
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				after := pgtype.Timestamptz{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true}
+				before := pgtype.Timestamptz{Time: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), Valid: true}
+				store.EXPECT().
+					ListCandidateApplicationJobIDs(gomock.Any(), gomock.Eq(db.ListCandidateApplicationJobIDsParams{
+						EmployerID:    employerID,
+						Statuses:      []string{"accepted"},
+						CreatedAfter:  after,
+						CreatedBefore: before,
+					})).
+					Times(1).
+					Return([]string{job.ID, otherJob.ID}, nil)
+				esClient.EXPECT().
+					MGetJobs(gomock.Any(), gomock.Eq([]string{job.ID, otherJob.ID})).
+					Times(1).
+					Return([]elasticsearch.Job{job, otherJob}, nil)
+				other := applications[1]
+				other.JobDocID = otherJob.ID
+				store.EXPECT().
+					ListCandidateApplicationsOldestFirst(gomock.Any(), gomock.Eq(db.ListCandidateApplicationsOldestFirstParams{
+						EmployerID:    employerID,
+						Statuses:      []string{"accepted"},
+						CreatedAfter:  after,
+						CreatedBefore: before,
+						Limit:         exportPageSize,
+					})).
+					Times(1).
+					Return([]db.CandidateApplication{applications[0], other}, nil)
+				store.EXPECT().
+					ListCandidateProfiles(gomock.Any(), gomock.Eq([]int64{1, 2})).
+					Times(1).
+					Return([]db.ListCandidateProfilesRow{profile}, nil)
+				esClient.EXPECT().
+					MGetCandidateApplications(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(map[string]map[string]interface{}{
+						other.ElasticsearchDocID: {answersKey: map[string]interface{}{"name": "Bo Kim", "hours": 12.5}},
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, spreadsheet.FormatXLSX.ContentType(), recorder.Header().Get("Content-Type"))
+				require.Contains(t, recorder.Header().Get("Content-Disposition"), "applications-2024-01-01-to-2024-02-01.xlsx")
+
+				archive, err := zip.NewReader(bytes.NewReader(recorder.Body.Bytes()), int64(recorder.Body.Len()))
+				require.NoError(t, err)
+				var sheet []byte
+				for _, f := range archive.File {
+					if f.Name == "xl/worksheets/sheet1.xml" {
+						r, err := f.Open()
+						require.NoError(t, err)
+						sheet, err = io.ReadAll(r)
+						require.NoError(t, err)
+					}
+				}
+				// Both jobs ask for the full name, so they share its column,
+				// and the hours get a column of their own.
+				require.Contains(t, string(sheet), "What is your full name?")
+				require.NotContains(t, string(sheet), "what is your full name?")
+				require.Contains(t, string(sheet), "How many hours a week?")
+				require.Contains(t, string(sheet), fmt.Sprintf(`<c r="M3" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, "Bo Kim"))
+				require.Contains(t, string(sheet), fmt.Sprintf(`<c r="P3" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, "12.5"))
+			},
+		},
+		{
+			name:      "NoJobOrRange",
+			query:     url.Values{"created_after": {"2024-01-01T00:00:00Z"}},
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				store.EXPECT().ListCandidateApplicationsOldestFirst(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "RangeTooLong",
+			query: url.Values{
+				"created_after":  {"2023-01-01T00:00:00Z"},
+				"created_before": {"2024-02-01T00:00:00Z"},
+			},
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				store.EXPECT().ListCandidateApplicationJobIDs(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:      "UnsupportedFormat",
+			query:     url.Values{"job_doc_id": {job.ID}, "format": {"pdf"}},
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				esClient.EXPECT().GetJob(gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:      "OtherEmployersJob",
+			query:     url.Values{"job_doc_id": {"other_job"}},
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				foreign := elasticsearch.RandomJob(employerID + 1)
+				esClient.EXPECT().
+					GetJob(gomock.Eq("other_job")).
+					Times(1).
+					Return(&foreign, nil)
+				store.EXPECT().ListCandidateApplicationsOldestFirst(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:      "ListFails",
+			query:     url.Values{"job_doc_id": {job.ID}},
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				esClient.EXPECT().
+					GetJob(gomock.Eq(job.ID)).
+					Times(1).
+					Return(&job, nil)
+				store.EXPECT().
+					ListCandidateApplicationsOldestFirst(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, db.ErrRecordNotFound)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Contains(t, recorder.Header().Get("Content-Type"), "application/json")
+			},
+		},
+		{
+			name:  "OtherEmployer",
+			query: url.Values{"job_doc_id": {job.ID}},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, "employer", db.RoleEmployer, time.Minute, employerID+1)
+			},
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				esClient.EXPECT().GetJob(gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+			esClient := mockes.NewMockESClient(ctrl)
+			tc.buildStubs(store, esClient)
+
+			server := newTestServer(t, store, esClient, nil)
+			recorder := httptest.NewRecorder()
+			path := fmt.Sprintf("/candidate_applications/%d/export?%s", employerID, tc.query.Encode())
+			request, err := http.NewRequest(http.MethodGet, path, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
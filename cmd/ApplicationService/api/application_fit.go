@@ -0,0 +1,168 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	"github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
+	"github.com/hankimmy/PtmrBackend/pkg/service"
+)
+
+// maxRankedApplications caps how many of a job's applications are ranked by
+// fit. When a job has more, only the newest ones are ranked and listed, and
+// the response is marked truncated; the rest can be listed by date.
+const maxRankedApplications = 500
+
+// listApplicationsByFit lists a page of the applications to one of the
+// employer's jobs, best fit first. Every ranked application is scored so the
+// ranking holds across pages; applications with the same score stay newest
+// first.
+func (server *Server) listApplicationsByFit(ctx *gin.Context, employerID int64, query listApplicationsQuery, filter applicationFilter) {
+	if len(query.JobDocID) != 1 {
+		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(errors.New("sort=fit needs exactly one job_doc_id")))
+		return
+	}
+	job, status, err := server.getApplicationJob(query.JobDocID[0])
+	if err != nil {
+		ctx.JSON(status, service.ErrorResponse(err))
+		return
+	}
+	if job.EmployerID != employerID {
+		ctx.JSON(http.StatusNotFound, service.ErrorResponse(fmt.Errorf("job %s not found", job.ID)))
+		return
+	}
+
+	applications, err := server.listCandidateApplications(ctx, db.ListCandidateApplicationsNewestFirstParams{
+		EmployerID:    employerID,
+		Statuses:      filter.statuses,
+		JobDocIds:     []string{job.ID},
+		CreatedAfter:  filter.createdAfter,
+		CreatedBefore: filter.createdBefore,
+		Limit:         maxRankedApplications + 1,
+	}, false)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, service.ErrorResponse(err))
+		return
+	}
+	truncated := len(applications) > maxRankedApplications
+	if truncated {
+		applications = applications[:maxRankedApplications]
+	}
+	candidateIDs := make([]int64, 0, len(applications))
+	for _, application := range applications {
+		candidateIDs = append(candidateIDs, application.CandidateID)
+	}
+	profiles, err := server.candidateProfiles(ctx, candidateIDs)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, service.ErrorResponse(err))
+		return
+	}
+
+	locations, err := server.candidateLocations(ctx, profiles)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, service.ErrorResponse(err))
+		return
+	}
+
+	fits := make([]elasticsearch.ApplicantFit, len(applications))
+	for i, application := range applications {
+		profile := profiles[application.CandidateID]
+		fits[i] = elasticsearch.ScoreApplicantFit(job, profile, locations[strings.TrimSpace(profile.Location)])
+	}
+	order := make([]int, len(applications))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return fits[order[i]].Score > fits[order[j]].Score
+	})
+
+	var nextCursor string
+	start := min(filter.offset, len(order))
+	end := min(start+int(filter.pageSize), len(order))
+	if end < len(order) {
+		nextCursor = encodeApplicationCursor(applicationCursor{Offset: end})
+	}
+
+	page := make([]db.CandidateApplication, 0, end-start)
+	for _, i := range order[start:end] {
+		page = append(page, applications[i])
+	}
+	history, docs, err := server.candidateApplicationPage(ctx, page)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, service.ErrorResponse(err))
+		return
+	}
+	res := make([]map[string]interface{}, 0, end-start)
+	for _, i := range order[start:end] {
+		application := applications[i]
+		res = append(res, map[string]interface{}{
+			"metadata":       application,
+			"document":       docs[application.ElasticsearchDocID],
+			"status_history": statusHistoryOf(history, candidateApplicationKey(application)),
+			"fit":            fits[i],
+		})
+	}
+	ctx.JSON(http.StatusOK, listApplicationsResponse{Applications: res, NextCursor: nextCursor, Truncated: truncated})
+}
+
+// candidateProfiles loads what the candidates are ranked on, keyed by
+// candidate ID. Candidates without a row are left out and score as empty
+// profiles.
+func (server *Server) candidateProfiles(ctx *gin.Context, candidateIDs []int64) (map[int64]elasticsearch.CandidateProfile, error) {
+	candidates, err := server.store.ListCandidatesByIDs(ctx, candidateIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list candidates: %w", err)
+	}
+	experiences, err := server.store.ListPastExperiencesByCandidates(ctx, candidateIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list past experiences: %w", err)
+	}
+	experiencesByCandidate := make(map[int64][]db.PastExperience)
+	for _, experience := range experiences {
+		experiencesByCandidate[experience.CandidateID] = append(experiencesByCandidate[experience.CandidateID], experience)
+	}
+
+	now := time.Now()
+	profiles := make(map[int64]elasticsearch.CandidateProfile, len(candidates))
+	for _, candidate := range candidates {
+		profiles[candidate.ID] = elasticsearch.NewCandidateProfile(candidate, experiencesByCandidate[candidate.ID], now)
+	}
+	return profiles, nil
+}
+
+// candidateLocations reads the stored coordinates of the candidates'
+// addresses, keyed by address. Addresses are geocoded when profiles are
+// written, so one that is missing is left out and its candidate is scored
+// without a distance.
+func (server *Server) candidateLocations(ctx *gin.Context, profiles map[int64]elasticsearch.CandidateProfile) (map[string]*elasticsearch.GeoPoint, error) {
+	seen := make(map[string]bool, len(profiles))
+	addresses := make([]string, 0, len(profiles))
+	for _, profile := range profiles {
+		address := strings.TrimSpace(profile.Location)
+		if address == "" || seen[address] {
+			continue
+		}
+		seen[address] = true
+		addresses = append(addresses, address)
+	}
+	if len(addresses) == 0 {
+		return nil, nil
+	}
+
+	rows, err := server.store.ListGeocodedLocations(ctx, addresses)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list candidate locations: %w", err)
+	}
+	locations := make(map[string]*elasticsearch.GeoPoint, len(rows))
+	for _, row := range rows {
+		locations[row.Address] = &elasticsearch.GeoPoint{Lat: row.Latitude, Lon: row.Longitude}
+	}
+	return locations, nil
+}
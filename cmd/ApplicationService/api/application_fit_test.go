@@ -0,0 +1,304 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/hankimmy/PtmrBackend/pkg/db/mock"
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	"github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
+	mockes "github.com/hankimmy/PtmrBackend/pkg/elasticsearch/mock"
+	"github.com/hankimmy/PtmrBackend/pkg/middleware"
+	"github.com/hankimmy/PtmrBackend/pkg/token"
+	"github.com/hankimmy/PtmrBackend/pkg/util"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListApplicationsByFitAPI(t *testing.T) {
+	employerID := util.RandomInt(1, 1000)
+	job := elasticsearch.RandomJob(employerID)
+	job.Title = "Barista"
+	job.Description = "Pull espresso shots and work the register."
+
+	// Newest first, as the store lists them.
+	applications := make([]db.CandidateApplication, 6)
+	for i := range applications {
+		applications[i] = db.RandomCandidateApplication(employerID)
+		applications[i].CandidateID = int64(10 + i)
+		applications[i].JobDocID = job.ID
+		applications[i].CreatedAt = time.Now().Add(-time.Duration(i) * time.Hour)
+	}
+	candidates := []db.Candidate{
+		{ID: 10, Location: "near", SkillSet: []string{"espresso"}},
+		{ID: 12, Location: "near", SkillSet: []string{"espresso", "register"}},
+		{ID: 13, Location: "nowhere"},
+	}
+	experienceStart := time.Now().AddDate(-2, 0, 0)
+	experiences := []db.PastExperience{
+		{
+			CandidateID: 12,
+			Industry:    job.Industry,
+			StartDate:   pgtype.Date{Time: experienceStart, Valid: true},
+			EndDate:     pgtype.Date{Time: experienceStart.AddDate(2, 0, 0), Valid: true},
+		},
+	}
+
+	employerAuth := func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+		middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, "employer", db.RoleEmployer, time.Minute, employerID)
+	}
+	rankStubs := func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+		esClient.EXPECT().
+			GetJob(gomock.Eq(job.ID)).
+			Times(1).
+			Return(&job, nil)
+		store.EXPECT().
+			ListCandidateApplicationsNewestFirst(gomock.Any(), gomock.Eq(db.ListCandidateApplicationsNewestFirstParams{
+				EmployerID: employerID,
+				Statuses:   db.PipelineStatuses,
+				JobDocIds:  []string{job.ID},
+				Limit:      maxRankedApplications + 1,
+			})).
+			Times(1).
+			Return(applications, nil)
+		candidateIDs := []int64{10, 11, 12, 13, 14, 15}
+		store.EXPECT().
+			ListCandidatesByIDs(gomock.Any(), gomock.Eq(candidateIDs)).
+			Times(1).
+			Return(candidates, nil)
+		store.EXPECT().
+			ListPastExperiencesByCandidates(gomock.Any(), gomock.Eq(candidateIDs)).
+			Times(1).
+			Return(experiences, nil)
+		// Each address is read once; "nowhere" has not been geocoded.
+		store.EXPECT().
+			ListGeocodedLocations(gomock.Any(), gomock.InAnyOrder([]string{"near", "nowhere"})).
+			Times(1).
+			Return([]db.GeocodedLocation{
+				{Address: "near", Latitude: job.PreciseLocation.Lat, Longitude: job.PreciseLocation.Lon},
+			}, nil)
+		store.EXPECT().
+			ListApplicationStatusHistoryByKeys(gomock.Any(), gomock.Any()).
+			Times(1).
+			Return([]db.ApplicationStatusHistory{}, nil)
+	}
+
+	type rankedApplication struct {
+		Metadata db.CandidateApplication    `json:"metadata"`
+		Fit      elasticsearch.ApplicantFit `json:"fit"`
+	}
+	type rankedResponse struct {
+		Applications []rankedApplication `json:"applications"`
+		NextCursor   string              `json:"next_cursor"`
+		Truncated    bool                `json:"truncated"`
+	}
+
+	testCases := []struct {
+		name          string
+		path          string
+		query         url.Values
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore, esClient *mockes.MockESClient)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "FirstPage",
+			path:      fmt.Sprintf("/candidate_applications/%d", employerID),
+			query:     url.Values{"sort": {"fit"}, "job_doc_id": {job.ID}, "page_size": {"5"}},
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				rankStubs(store, esClient)
+				esClient.EXPECT().
+					MGetCandidateApplications(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, ids []string) (map[string]map[string]interface{}, error) {
+						require.Len(t, ids, 5)
+						return map[string]map[string]interface{}{}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var res rankedResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+
+				var order []int64
+				for _, application := range res.Applications {
+					order = append(order, application.Metadata.CandidateID)
+				}
+				require.Equal(t, []int64{12, 10, 11, 13, 14}, order)
+
+				best := res.Applications[0].Fit
+				require.Equal(t, []string{"espresso", "register"}, best.MatchedSkills)
+				require.Equal(t, 2.0, best.ExperienceYears)
+				require.NotNil(t, best.Distance)
+				require.Equal(t, 1.0, *best.Distance)
+				require.Nil(t, best.Availability)
+				require.Nil(t, res.Applications[3].Fit.Distance)
+
+				cursor, err := decodeApplicationCursor(res.NextCursor, true)
+				require.NoError(t, err)
+				require.Equal(t, 5, cursor.Offset)
+				require.False(t, res.Truncated)
+			},
+		},
+		{
+			name:      "TooManyToRank",
+			path:      fmt.Sprintf("/candidate_applications/%d", employerID),
+			query:     url.Values{"sort": {"fit"}, "job_doc_id": {job.ID}, "page_size": {"5"}},
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				many := make([]db.CandidateApplication, maxRankedApplications+1)
+				for i := range many {
+					many[i] = db.RandomCandidateApplication(employerID)
+					many[i].CandidateID = int64(100 + i)
+					many[i].JobDocID = job.ID
+				}
+				esClient.EXPECT().
+					GetJob(gomock.Eq(job.ID)).
+					Times(1).
+					Return(&job, nil)
+				store.EXPECT().
+					ListCandidateApplicationsNewestFirst(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(many, nil)
+				store.EXPECT().
+					ListCandidatesByIDs(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, ids []int64) ([]db.Candidate, error) {
+						require.Len(t, ids, maxRankedApplications)
+						return []db.Candidate{}, nil
+					})
+				store.EXPECT().
+					ListPastExperiencesByCandidates(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.PastExperience{}, nil)
+				store.EXPECT().
+					ListApplicationStatusHistoryByKeys(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ApplicationStatusHistory{}, nil)
+				esClient.EXPECT().
+					MGetCandidateApplications(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, ids []string) (map[string]map[string]interface{}, error) {
+						require.Len(t, ids, 5)
+						return map[string]map[string]interface{}{}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var res rankedResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Len(t, res.Applications, 5)
+				require.NotEmpty(t, res.NextCursor)
+				require.True(t, res.Truncated)
+			},
+		},
+		{
+			name: "LastPage",
+			path: fmt.Sprintf("/candidate_applications/%d", employerID),
+			query: url.Values{
+				"sort":       {"fit"},
+				"job_doc_id": {job.ID},
+				"page_size":  {"5"},
+				"cursor":     {encodeApplicationCursor(applicationCursor{Offset: 5})},
+			},
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				rankStubs(store, esClient)
+				esClient.EXPECT().
+					MGetCandidateApplications(gomock.Any(), gomock.Eq([]string{applications[5].ElasticsearchDocID})).
+					Times(1).
+					Return(map[string]map[string]interface{}{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var res rankedResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Len(t, res.Applications, 1)
+				require.Equal(t, int64(15), res.Applications[0].Metadata.CandidateID)
+				require.Empty(t, res.NextCursor)
+			},
+		},
+		{
+			name:      "NeedsOneJob",
+			path:      fmt.Sprintf("/candidate_applications/%d", employerID),
+			query:     url.Values{"sort": {"fit"}},
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				store.EXPECT().ListCandidateApplicationsNewestFirst(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:      "TimeCursor",
+			path:      fmt.Sprintf("/candidate_applications/%d", employerID),
+			query:     url.Values{"sort": {"fit"}, "job_doc_id": {job.ID}, "cursor": {encodeApplicationCursor(applicationCursor{CreatedAt: time.Now(), ID: 3})}},
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				store.EXPECT().ListCandidateApplicationsNewestFirst(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:      "OtherEmployersJob",
+			path:      fmt.Sprintf("/candidate_applications/%d", employerID),
+			query:     url.Values{"sort": {"fit"}, "job_doc_id": {"other_job"}},
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				otherJob := elasticsearch.RandomJob(employerID + 1)
+				esClient.EXPECT().
+					GetJob(gomock.Eq("other_job")).
+					Times(1).
+					Return(&otherJob, nil)
+				store.EXPECT().ListCandidateApplicationsNewestFirst(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:  "CandidateListing",
+			path:  "/employer_applications/7",
+			query: url.Values{"sort": {"fit"}},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, "candidate", db.RoleCandidate, time.Minute, 7)
+			},
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				store.EXPECT().ListEmployerApplications(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+			esClient := mockes.NewMockESClient(ctrl)
+			tc.buildStubs(store, esClient)
+
+			server := newTestServer(t, store, esClient, nil)
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, tc.path+"?"+tc.query.Encode(), nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
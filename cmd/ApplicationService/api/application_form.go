@@ -0,0 +1,37 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
+)
+
+const answersKey = "answers"
+
+// getApplicationJob loads the job a candidate application refers to. It
+// returns the HTTP status to respond with when the job cannot be loaded.
+func (server *Server) getApplicationJob(jobDocID string) (*elasticsearch.Job, int, error) {
+	job, err := server.esClient.GetJob(jobDocID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if job == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("job %s not found", jobDocID)
+	}
+	return job, http.StatusOK, nil
+}
+
+// validateApplicationAnswers checks the answers in a candidate's
+// application_doc against the form of the job they are applying to.
+func validateApplicationAnswers(job *elasticsearch.Job, appDoc map[string]interface{}) error {
+	answers := map[string]interface{}{}
+	if raw, ok := appDoc[answersKey]; ok && raw != nil {
+		answers, ok = raw.(map[string]interface{})
+		if !ok {
+			return errors.New("application_doc answers must be an object keyed by question id")
+		}
+	}
+	return job.JobApplication.ValidateAnswers(answers)
+}
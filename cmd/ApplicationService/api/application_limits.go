@@ -0,0 +1,22 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/hankimmy/PtmrBackend/pkg/worker"
+	"github.com/hibiken/asynq"
+)
+
+// afterJobFilled asks the worker to close a job once an application takes
+// its last slot. It runs inside the application transaction, so the
+// application is rolled back if the task can't be enqueued; nothing else
+// closes a full job.
+func (server *Server) afterJobFilled(ctx *gin.Context, jobID string) func() error {
+	return func() error {
+		payload := &worker.PayloadApplyJobSchedule{JobID: jobID}
+		opts := []asynq.Option{
+			asynq.MaxRetry(10),
+			asynq.Queue(worker.QueueDefault),
+		}
+		return server.taskDistributor.DistributeTaskApplyJobSchedule(ctx, payload, opts...)
+	}
+}
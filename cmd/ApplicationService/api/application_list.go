@@ -0,0 +1,128 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const defaultApplicationPageSize = 20
+
+// listApplicationsQuery holds the filters and paging options shared by both
+// application listings. Job filters only apply to candidate applications,
+// since employer applications aren't tied to a job. Sorting by fit ranks the
+// applicants of a single job by how well they fit it, so it needs exactly one
+// job and is only for employers.
+type listApplicationsQuery struct {
+	Status        []db.ApplicationStatus `form:"status" binding:"dive,oneof=pending submitted accepted rejected withdrawn"`
+	JobDocID      []string               `form:"job_doc_id"`
+	CreatedAfter  time.Time              `form:"created_after" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedBefore time.Time              `form:"created_before" time_format:"2006-01-02T15:04:05Z07:00"`
+	Sort          string                 `form:"sort" binding:"omitempty,oneof=newest oldest fit"`
+	PageSize      int32                  `form:"page_size" binding:"omitempty,min=5,max=50"`
+	Cursor        string                 `form:"cursor"`
+}
+
+// applicationCursor points just past the last application of a page. The ID
+// is the other party's ID, which together with the job of candidate
+// applications breaks ties between applications created at the same time.
+// Applicants ranked by fit are paged by their position in the ranking
+// instead.
+type applicationCursor struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        int64     `json:"id"`
+	JobID     string    `json:"job_id,omitempty"`
+	Offset    int       `json:"offset,omitempty"`
+}
+
+// applicationFilter is a validated listApplicationsQuery in the form the
+// list queries take.
+type applicationFilter struct {
+	statuses        []string
+	createdAfter    pgtype.Timestamptz
+	createdBefore   pgtype.Timestamptz
+	cursorCreatedAt pgtype.Timestamptz
+	cursorID        pgtype.Int8
+	cursorJobID     pgtype.Text
+	oldestFirst     bool
+	byFit           bool
+	offset          int
+	pageSize        int32
+}
+
+type listApplicationsResponse struct {
+	Applications interface{} `json:"applications"`
+	NextCursor   string      `json:"next_cursor,omitempty"`
+	// Truncated is set when sorting by fit and the job has more than
+	// maxRankedApplications applications; the older ones are left out of
+	// every page.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+func (query listApplicationsQuery) filter() (applicationFilter, error) {
+	filter := applicationFilter{
+		createdAfter:  pgtype.Timestamptz{Time: query.CreatedAfter, Valid: !query.CreatedAfter.IsZero()},
+		createdBefore: pgtype.Timestamptz{Time: query.CreatedBefore, Valid: !query.CreatedBefore.IsZero()},
+		oldestFirst:   query.Sort == "oldest",
+		byFit:         query.Sort == "fit",
+		pageSize:      query.PageSize,
+	}
+	if filter.createdAfter.Valid && filter.createdBefore.Valid && !query.CreatedAfter.Before(query.CreatedBefore) {
+		return applicationFilter{}, errors.New("created_after must be before created_before")
+	}
+	if filter.pageSize == 0 {
+		filter.pageSize = defaultApplicationPageSize
+	}
+	for _, status := range query.Status {
+		filter.statuses = append(filter.statuses, string(status))
+	}
+	if query.Cursor != "" {
+		cursor, err := decodeApplicationCursor(query.Cursor, filter.byFit)
+		if err != nil {
+			return applicationFilter{}, err
+		}
+		if filter.byFit {
+			filter.offset = cursor.Offset
+			return filter, nil
+		}
+		filter.cursorCreatedAt = pgtype.Timestamptz{Time: cursor.CreatedAt, Valid: true}
+		filter.cursorID = pgtype.Int8{Int64: cursor.ID, Valid: true}
+		filter.cursorJobID = pgtype.Text{String: cursor.JobID, Valid: true}
+	}
+	return filter, nil
+}
+
+// listCandidateApplications lists a page of an employer's candidate
+// applications newest or oldest first. Without a status filter the pipeline
+// statuses are listed.
+func (server *Server) listCandidateApplications(ctx context.Context, arg db.ListCandidateApplicationsNewestFirstParams, oldestFirst bool) ([]db.CandidateApplication, error) {
+	if len(arg.Statuses) == 0 {
+		arg.Statuses = db.PipelineStatuses
+	}
+	if oldestFirst {
+		return server.store.ListCandidateApplicationsOldestFirst(ctx, db.ListCandidateApplicationsOldestFirstParams(arg))
+	}
+	return server.store.ListCandidateApplicationsNewestFirst(ctx, arg)
+}
+
+func encodeApplicationCursor(cursor applicationCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeApplicationCursor(cursor string, byFit bool) (applicationCursor, error) {
+	var decoded applicationCursor
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err == nil {
+		err = json.Unmarshal(data, &decoded)
+	}
+	if err != nil || (byFit && decoded.Offset <= 0) || (!byFit && decoded.CreatedAt.IsZero()) {
+		return applicationCursor{}, errors.New("invalid cursor")
+	}
+	return decoded, nil
+}
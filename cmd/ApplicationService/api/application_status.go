@@ -0,0 +1,243 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	"github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
+	"github.com/hankimmy/PtmrBackend/pkg/service"
+	"github.com/hankimmy/PtmrBackend/pkg/worker"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type candidateApplicationResponse struct {
+	db.CandidateApplication
+	StatusHistory []db.ApplicationStatusHistory `json:"status_history"`
+}
+
+type employerApplicationResponse struct {
+	db.EmployerApplication
+	StatusHistory []db.ApplicationStatusHistory `json:"status_history"`
+	// Application is the candidate application an accepted invitation turned
+	// into.
+	Application *db.CandidateApplication `json:"application,omitempty"`
+}
+
+// applicationKey identifies an application in its status history. Employer
+// applications have no job.
+type applicationKey struct {
+	candidateID int64
+	employerID  int64
+	jobID       string
+}
+
+func candidateApplicationKey(application db.CandidateApplication) applicationKey {
+	return applicationKey{candidateID: application.CandidateID, employerID: application.EmployerID, jobID: application.JobDocID}
+}
+
+func employerApplicationKey(application db.EmployerApplication) applicationKey {
+	return applicationKey{candidateID: application.CandidateID, employerID: application.EmployerID}
+}
+
+// listStatusHistory loads the status history of an employer's or a
+// candidate's applications of one kind, grouped by application. Pass zero for
+// the side that shouldn't be filtered on.
+func (server *Server) listStatusHistory(ctx *gin.Context, kind db.ApplicationKind, candidateID, employerID int64) (map[applicationKey][]db.ApplicationStatusHistory, error) {
+	history, err := server.store.ListApplicationStatusHistory(ctx, db.ListApplicationStatusHistoryParams{
+		ApplicationKind: string(kind),
+		CandidateID:     pgtype.Int8{Int64: candidateID, Valid: candidateID != 0},
+		EmployerID:      pgtype.Int8{Int64: employerID, Valid: employerID != 0},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return groupStatusHistory(history), nil
+}
+
+// listPageStatusHistory loads the status history of a page of applications of
+// one kind, grouped by application.
+func (server *Server) listPageStatusHistory(ctx *gin.Context, kind db.ApplicationKind, keys []applicationKey) (map[applicationKey][]db.ApplicationStatusHistory, error) {
+	arg := db.ListApplicationStatusHistoryByKeysParams{
+		ApplicationKind: string(kind),
+		CandidateIds:    make([]int64, 0, len(keys)),
+		EmployerIds:     make([]int64, 0, len(keys)),
+		JobDocIds:       make([]string, 0, len(keys)),
+	}
+	for _, key := range keys {
+		arg.CandidateIds = append(arg.CandidateIds, key.candidateID)
+		arg.EmployerIds = append(arg.EmployerIds, key.employerID)
+		arg.JobDocIds = append(arg.JobDocIds, key.jobID)
+	}
+	history, err := server.store.ListApplicationStatusHistoryByKeys(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+	return groupStatusHistory(history), nil
+}
+
+func groupStatusHistory(history []db.ApplicationStatusHistory) map[applicationKey][]db.ApplicationStatusHistory {
+	grouped := make(map[applicationKey][]db.ApplicationStatusHistory)
+	for _, entry := range history {
+		key := applicationKey{candidateID: entry.CandidateID, employerID: entry.EmployerID, jobID: entry.JobDocID}
+		grouped[key] = append(grouped[key], entry)
+	}
+	return grouped
+}
+
+// statusHistoryOf returns the history of one application, never nil so that
+// it is rendered as an empty list.
+func statusHistoryOf(grouped map[applicationKey][]db.ApplicationStatusHistory, key applicationKey) []db.ApplicationStatusHistory {
+	if history, ok := grouped[key]; ok {
+		return history
+	}
+	return []db.ApplicationStatusHistory{}
+}
+
+func handleTransitionError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, db.ErrRecordNotFound):
+		ctx.JSON(http.StatusNotFound, service.ErrorResponse(err))
+	case errors.Is(err, db.ErrStatusTransitionNotAllowed):
+		ctx.JSON(http.StatusForbidden, service.ErrorResponse(err))
+	case errors.Is(err, db.ErrInvalidStatusTransition):
+		ctx.JSON(http.StatusConflict, service.ErrorResponse(err))
+	case errors.Is(err, db.ErrInvitationExpired):
+		ctx.JSON(http.StatusGone, service.ErrorResponse(err))
+	case errors.Is(err, db.ErrApplicationLimitReached):
+		ctx.JSON(http.StatusConflict, service.ErrorResponse(err))
+	case db.ErrorCode(err) == db.UniqueViolation:
+		ctx.JSON(http.StatusConflict, service.ErrorResponse(errors.New("already applied to this job")))
+	default:
+		ctx.JSON(http.StatusInternalServerError, service.ErrorResponse(err))
+	}
+}
+
+type decideApplicationRequest struct {
+	applicationURI
+	Reason string `json:"reason" binding:"max=500"`
+	// ApplicationDoc optionally answers the job's questions when a candidate
+	// accepts an invitation to apply.
+	ApplicationDoc json.RawMessage `json:"application_doc"`
+}
+
+// decideApplication accepts or rejects an application on behalf of the party
+// that received it: employers decide on candidate applications and candidates
+// decide on employer applications. The decision is recorded as a swipe so the
+// pair drops out of both feeds, and the sender is notified. A candidate
+// accepting an invitation to a job applies to it in the same transaction.
+func (server *Server) decideApplication(ctx *gin.Context, isEmployer bool, decision db.ApplicationStatus) {
+	var req decideApplicationRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
+		return
+	}
+	// The reason is optional, so the body may be left out.
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
+			return
+		}
+	}
+
+	kind := db.ApplicationKindCandidate
+	if isEmployer {
+		kind = db.ApplicationKindEmployer
+	}
+	resource, current, ok := server.resolveApplication(ctx, kind, req.applicationURI)
+	if !ok {
+		return
+	}
+	if _, ok := authorize(ctx, decideApplicationPolicy, resource); !ok {
+		return
+	}
+
+	var result db.TransitionApplicationStatusTxResult
+	var job *elasticsearch.Job
+	var err error
+	if isEmployer {
+		var application *db.CreateCandidateApplicationTxParams
+		if decision == db.ApplicationStatusAccepted {
+			if application, job, ok = server.invitationApplication(ctx, resource, req.ApplicationDoc); !ok {
+				return
+			}
+		}
+		result, err = server.store.UpdateEmployerApplicationStatusTx(ctx, db.UpdateEmployerApplicationStatusTxParams{
+			UpdateEmployerApplicationStatusParams: db.UpdateEmployerApplicationStatusParams{
+				EmployerID:        resource.EmployerID,
+				CandidateID:       resource.CandidateID,
+				ApplicationStatus: decision,
+			},
+			Reason:      req.Reason,
+			Application: application,
+			AfterUpdate: server.afterApplicationDecision(ctx),
+		})
+	} else {
+		result, err = server.store.UpdateCandidateApplicationStatusTx(ctx, db.UpdateCandidateApplicationStatusTxParams{
+			UpdateCandidateApplicationStatusParams: db.UpdateCandidateApplicationStatusParams{
+				CandidateID:       current.CandidateID,
+				JobDocID:          current.JobDocID,
+				ApplicationStatus: decision,
+			},
+			EmployerID:  current.EmployerID,
+			Reason:      req.Reason,
+			AfterUpdate: server.afterApplicationDecision(ctx),
+		})
+	}
+	if err != nil {
+		handleTransitionError(ctx, err)
+		return
+	}
+
+	history, err := server.listStatusHistory(ctx, kind, resource.CandidateID, resource.EmployerID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, service.ErrorResponse(err))
+		return
+	}
+	if isEmployer {
+		res := employerApplicationResponse{
+			EmployerApplication: result.EmployerApplication,
+			StatusHistory:       statusHistoryOf(history, employerApplicationKey(result.EmployerApplication)),
+		}
+		if job != nil && result.CandidateApplication.JobDocID != "" {
+			res.Application = &result.CandidateApplication
+			worker.RecordJobStat(ctx, server.taskDistributor, worker.JobStatApplication, *job)
+		}
+		ctx.JSON(http.StatusOK, res)
+		return
+	}
+	ctx.JSON(http.StatusOK, candidateApplicationResponse{
+		CandidateApplication: result.CandidateApplication,
+		StatusHistory:        statusHistoryOf(history, candidateApplicationKey(result.CandidateApplication)),
+	})
+}
+
+// afterApplicationDecision enqueues an email to the sender of an application
+// once it has been accepted or rejected. It runs inside the status
+// transaction, so the decision is rolled back if the task can't be enqueued.
+func (server *Server) afterApplicationDecision(ctx *gin.Context) func(result db.TransitionApplicationStatusTxResult) error {
+	return func(result db.TransitionApplicationStatusTxResult) error {
+		status := result.History.ToStatus
+		if status != db.ApplicationStatusAccepted && status != db.ApplicationStatusRejected {
+			return nil
+		}
+		taskPayload := &worker.PayloadNotifyApplicationDecision{
+			Kind:        db.ApplicationKind(result.History.ApplicationKind),
+			CandidateID: result.History.CandidateID,
+			EmployerID:  result.History.EmployerID,
+			JobID:       result.CandidateApplication.JobDocID,
+			Status:      status,
+			Reason:      result.History.Reason,
+		}
+		opts := []asynq.Option{
+			asynq.MaxRetry(10),
+			asynq.ProcessIn(10 * time.Second),
+			asynq.Queue(worker.QueueMail),
+		}
+		return server.taskDistributor.DistributeTaskNotifyApplicationDecision(ctx, taskPayload, opts...)
+	}
+}
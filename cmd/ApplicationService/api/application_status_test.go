@@ -0,0 +1,604 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/hankimmy/PtmrBackend/pkg/db/mock"
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	"github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
+	mockes "github.com/hankimmy/PtmrBackend/pkg/elasticsearch/mock"
+	"github.com/hankimmy/PtmrBackend/pkg/middleware"
+	"github.com/hankimmy/PtmrBackend/pkg/token"
+	"github.com/hankimmy/PtmrBackend/pkg/worker"
+	mockwk "github.com/hankimmy/PtmrBackend/pkg/worker/mock"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecideCandidateApplicationAPI(t *testing.T) {
+	user, _ := db.RandomUser(db.RoleCandidate)
+	candidate := db.RandomCandidate(user.Username)
+	var employerID int64 = 7
+	application := db.CandidateApplication{
+		CandidateID:        candidate.ID,
+		EmployerID:         employerID,
+		ElasticsearchDocID: fmt.Sprintf("%d_%d_cook", candidate.ID, employerID),
+		JobDocID:           fmt.Sprintf("%d_cook", employerID),
+		ApplicationStatus:  db.ApplicationStatusSubmitted,
+	}
+	decided := func(status db.ApplicationStatus, reason string) db.TransitionApplicationStatusTxResult {
+		result := db.TransitionApplicationStatusTxResult{
+			CandidateApplication: application,
+			Changed:              true,
+			History: db.ApplicationStatusHistory{
+				ID:              1,
+				ApplicationKind: string(db.ApplicationKindCandidate),
+				CandidateID:     candidate.ID,
+				EmployerID:      employerID,
+				ActorRole:       db.RoleEmployer,
+				ActorID:         employerID,
+				JobDocID:        application.JobDocID,
+				FromStatus:      db.ApplicationStatusSubmitted,
+				ToStatus:        status,
+				Reason:          reason,
+			},
+		}
+		result.CandidateApplication.ApplicationStatus = status
+		return result
+	}
+	historyArg := db.ListApplicationStatusHistoryParams{
+		ApplicationKind: string(db.ApplicationKindCandidate),
+		CandidateID:     pgtype.Int8{Int64: candidate.ID, Valid: true},
+		EmployerID:      pgtype.Int8{Int64: employerID, Valid: true},
+	}
+	// getApplication stands in for loading the application in the route,
+	// which tells the handler whose application it is.
+	getApplication := func(store *mockdb.MockStore) {
+		store.EXPECT().
+			GetCandidateApplication(gomock.Any(), gomock.Eq(db.GetCandidateApplicationParams{
+				CandidateID: candidate.ID,
+				JobDocID:    application.JobDocID,
+			})).
+			Times(1).
+			Return(application, nil)
+	}
+	employerAuth := func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+		middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, "employer", db.RoleEmployer, time.Minute, employerID)
+	}
+	// runHook stands in for the transaction and calls the after update hook
+	// with the result it would have committed.
+	runHook := func(result db.TransitionApplicationStatusTxResult) func(context.Context, db.UpdateCandidateApplicationStatusTxParams) (db.TransitionApplicationStatusTxResult, error) {
+		return func(_ context.Context, arg db.UpdateCandidateApplicationStatusTxParams) (db.TransitionApplicationStatusTxResult, error) {
+			if err := arg.AfterUpdate(result); err != nil {
+				return db.TransitionApplicationStatusTxResult{}, err
+			}
+			return result, nil
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		decision      string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "Accept",
+			decision:  "accept",
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				result := decided(db.ApplicationStatusAccepted, "")
+				getApplication(store)
+				store.EXPECT().
+					UpdateCandidateApplicationStatusTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, arg db.UpdateCandidateApplicationStatusTxParams) (db.TransitionApplicationStatusTxResult, error) {
+						require.Equal(t, db.UpdateCandidateApplicationStatusParams{
+							CandidateID:       candidate.ID,
+							JobDocID:          application.JobDocID,
+							ApplicationStatus: db.ApplicationStatusAccepted,
+						}, arg.UpdateCandidateApplicationStatusParams)
+						require.Equal(t, employerID, arg.EmployerID)
+						return runHook(result)(ctx, arg)
+					})
+				taskDistributor.EXPECT().
+					DistributeTaskNotifyApplicationDecision(gomock.Any(), gomock.Eq(&worker.PayloadNotifyApplicationDecision{
+						Kind:        db.ApplicationKindCandidate,
+						CandidateID: candidate.ID,
+						EmployerID:  employerID,
+						JobID:       application.JobDocID,
+						Status:      db.ApplicationStatusAccepted,
+					}), gomock.Any()).
+					Times(1).
+					Return(nil)
+				store.EXPECT().
+					ListApplicationStatusHistory(gomock.Any(), gomock.Eq(historyArg)).
+					Times(1).
+					Return([]db.ApplicationStatusHistory{result.History}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res candidateApplicationResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Equal(t, db.ApplicationStatusAccepted, res.ApplicationStatus)
+				require.Len(t, res.StatusHistory, 1)
+				require.Equal(t, db.ApplicationStatusAccepted, res.StatusHistory[0].ToStatus)
+			},
+		},
+		{
+			name:      "RejectWithReason",
+			decision:  "reject",
+			body:      gin.H{"reason": "position filled"},
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				result := decided(db.ApplicationStatusRejected, "position filled")
+				getApplication(store)
+				store.EXPECT().
+					UpdateCandidateApplicationStatusTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, arg db.UpdateCandidateApplicationStatusTxParams) (db.TransitionApplicationStatusTxResult, error) {
+						require.Equal(t, db.ApplicationStatusRejected, arg.ApplicationStatus)
+						require.Equal(t, "position filled", arg.Reason)
+						return runHook(result)(ctx, arg)
+					})
+				taskDistributor.EXPECT().
+					DistributeTaskNotifyApplicationDecision(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, payload *worker.PayloadNotifyApplicationDecision, _ ...interface{}) error {
+						require.Equal(t, db.ApplicationStatusRejected, payload.Status)
+						require.Equal(t, "position filled", payload.Reason)
+						return nil
+					})
+				store.EXPECT().
+					ListApplicationStatusHistory(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ApplicationStatusHistory{result.History}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "CandidateCannotDecideOwn",
+			decision: "accept",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleCandidate, time.Minute, candidate.ID)
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				getApplication(store)
+				store.EXPECT().
+					UpdateCandidateApplicationStatusTx(gomock.Any(), gomock.Any()).
+					Times(0)
+				taskDistributor.EXPECT().
+					DistributeTaskNotifyApplicationDecision(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "OtherEmployer",
+			decision: "accept",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, "other", db.RoleEmployer, time.Minute, employerID+1)
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				getApplication(store)
+				store.EXPECT().
+					UpdateCandidateApplicationStatusTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:      "NotFoundLooksLikeOutsider",
+			decision:  "reject",
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetCandidateApplication(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CandidateApplication{}, db.ErrRecordNotFound)
+				store.EXPECT().
+					UpdateCandidateApplicationStatusTx(gomock.Any(), gomock.Any()).
+					Times(0)
+				taskDistributor.EXPECT().
+					DistributeTaskNotifyApplicationDecision(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:      "AlreadyDecided",
+			decision:  "reject",
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				getApplication(store)
+				store.EXPECT().
+					UpdateCandidateApplicationStatusTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.TransitionApplicationStatusTxResult{}, fmt.Errorf("%w: accepted to rejected", db.ErrInvalidStatusTransition))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:      "EnqueueFails",
+			decision:  "accept",
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				getApplication(store)
+				store.EXPECT().
+					UpdateCandidateApplicationStatusTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(runHook(decided(db.ApplicationStatusAccepted, "")))
+				taskDistributor.EXPECT().
+					DistributeTaskNotifyApplicationDecision(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(errors.New("redis unavailable"))
+				store.EXPECT().
+					ListApplicationStatusHistory(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:      "ReasonTooLong",
+			decision:  "reject",
+			body:      gin.H{"reason": string(make([]byte, 501))},
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					UpdateCandidateApplicationStatusTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			taskDistributor := mockwk.NewMockTaskDistributor(ctrl)
+			tc.buildStubs(store, taskDistributor)
+
+			server := newTestServer(t, store, nil, taskDistributor)
+			recorder := httptest.NewRecorder()
+
+			var body []byte
+			if tc.body != nil {
+				data, err := json.Marshal(tc.body)
+				require.NoError(t, err)
+				body = data
+			}
+
+			url := fmt.Sprintf("/candidate_applications/%d/%s/%s", candidate.ID, application.JobDocID, tc.decision)
+			request, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(body))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestDecideEmployerApplicationAPI(t *testing.T) {
+	user, _ := db.RandomUser(db.RoleCandidate)
+	candidate := db.RandomCandidate(user.Username)
+	var employerID int64 = 7
+	result := db.TransitionApplicationStatusTxResult{
+		EmployerApplication: db.EmployerApplication{
+			EmployerID:        employerID,
+			CandidateID:       candidate.ID,
+			ApplicationStatus: db.ApplicationStatusAccepted,
+		},
+		Changed: true,
+		History: db.ApplicationStatusHistory{
+			ID:              1,
+			ApplicationKind: string(db.ApplicationKindEmployer),
+			CandidateID:     candidate.ID,
+			EmployerID:      employerID,
+			ActorRole:       db.RoleCandidate,
+			ActorID:         candidate.ID,
+			FromStatus:      db.ApplicationStatusSubmitted,
+			ToStatus:        db.ApplicationStatusAccepted,
+		},
+	}
+	candidateAuth := func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+		middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleCandidate, time.Minute, candidate.ID)
+	}
+	job := elasticsearch.RandomJob(employerID)
+	invitationParams := db.GetEmployerApplicationParams{EmployerID: employerID, CandidateID: candidate.ID}
+	invitation := db.EmployerApplication{
+		EmployerID:        employerID,
+		CandidateID:       candidate.ID,
+		Message:           "We'd love to have you",
+		ApplicationStatus: db.ApplicationStatusSubmitted,
+		JobDocID:          job.ID,
+		ExpiresAt:         pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true},
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "Accept",
+			setupAuth: candidateAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				// Invitations sent before they were tied to a job are only
+				// answered.
+				store.EXPECT().
+					GetEmployerApplication(gomock.Any(), gomock.Eq(invitationParams)).
+					Times(1).
+					Return(db.EmployerApplication{EmployerID: employerID, CandidateID: candidate.ID, ApplicationStatus: db.ApplicationStatusSubmitted}, nil)
+				store.EXPECT().
+					UpdateEmployerApplicationStatusTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.UpdateEmployerApplicationStatusTxParams) (db.TransitionApplicationStatusTxResult, error) {
+						require.Equal(t, db.UpdateEmployerApplicationStatusParams{
+							EmployerID:        employerID,
+							CandidateID:       candidate.ID,
+							ApplicationStatus: db.ApplicationStatusAccepted,
+						}, arg.UpdateEmployerApplicationStatusParams)
+						require.Nil(t, arg.Application)
+						return result, arg.AfterUpdate(result)
+					})
+				taskDistributor.EXPECT().
+					DistributeTaskNotifyApplicationDecision(gomock.Any(), gomock.Eq(&worker.PayloadNotifyApplicationDecision{
+						Kind:        db.ApplicationKindEmployer,
+						CandidateID: candidate.ID,
+						EmployerID:  employerID,
+						Status:      db.ApplicationStatusAccepted,
+					}), gomock.Any()).
+					Times(1).
+					Return(nil)
+				store.EXPECT().
+					ListApplicationStatusHistory(gomock.Any(), gomock.Eq(db.ListApplicationStatusHistoryParams{
+						ApplicationKind: string(db.ApplicationKindEmployer),
+						CandidateID:     pgtype.Int8{Int64: candidate.ID, Valid: true},
+						EmployerID:      pgtype.Int8{Int64: employerID, Valid: true},
+					})).
+					Times(1).
+					Return([]db.ApplicationStatusHistory{result.History}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res employerApplicationResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Equal(t, db.ApplicationStatusAccepted, res.ApplicationStatus)
+				require.Len(t, res.StatusHistory, 1)
+			},
+		},
+		{
+			name:      "AcceptInvitationToJob",
+			setupAuth: candidateAuth,
+			body:      gin.H{"application_doc": gin.H{"answers": gin.H{"q1": "Jane Doe", "q3": "Bachelor's Degree", "q4": "yes"}}},
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetEmployerApplication(gomock.Any(), gomock.Eq(invitationParams)).
+					Times(1).
+					Return(invitation, nil)
+				esClient.EXPECT().
+					GetJob(gomock.Eq(job.ID)).
+					Times(1).
+					Return(&job, nil)
+				application := db.CandidateApplication{
+					CandidateID:        candidate.ID,
+					EmployerID:         employerID,
+					ElasticsearchDocID: elasticsearch.CandidateApplicationDocID(candidate.ID, job.ID),
+					JobDocID:           job.ID,
+					ApplicationStatus:  db.ApplicationStatusPending,
+				}
+				store.EXPECT().
+					UpdateEmployerApplicationStatusTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.UpdateEmployerApplicationStatusTxParams) (db.TransitionApplicationStatusTxResult, error) {
+						require.NotNil(t, arg.Application)
+						require.Equal(t, db.CreateCandidateApplicationParams{
+							CandidateID:        application.CandidateID,
+							EmployerID:         application.EmployerID,
+							ElasticsearchDocID: application.ElasticsearchDocID,
+							JobDocID:           application.JobDocID,
+							ApplicationStatus:  application.ApplicationStatus,
+						}, arg.Application.CreateCandidateApplicationParams)
+						require.Equal(t, invitation.Message, arg.Application.AppDoc[invitationMessageKey])
+						require.NoError(t, arg.Application.AfterCreate(application.ElasticsearchDocID, arg.Application.AppDoc))
+
+						accepted := result
+						accepted.CandidateApplication = application
+						return accepted, arg.AfterUpdate(accepted)
+					})
+				taskDistributor.EXPECT().
+					DistributeTaskCreateCandidateApplication(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+				taskDistributor.EXPECT().
+					DistributeTaskNotifyApplicationDecision(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+				taskDistributor.EXPECT().
+					DistributeTaskIncrementJobStats(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+				store.EXPECT().
+					ListApplicationStatusHistory(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ApplicationStatusHistory{result.History}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res employerApplicationResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.NotNil(t, res.Application)
+				require.Equal(t, job.ID, res.Application.JobDocID)
+			},
+		},
+		{
+			name:      "AcceptInvitationTakesLastSlot",
+			setupAuth: candidateAuth,
+			body:      gin.H{"application_doc": gin.H{"answers": gin.H{"q1": "Jane Doe", "q3": "Bachelor's Degree", "q4": "yes"}}},
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				limited := job
+				limited.MaxApplications = 1
+				store.EXPECT().
+					GetEmployerApplication(gomock.Any(), gomock.Eq(invitationParams)).
+					Times(1).
+					Return(invitation, nil)
+				esClient.EXPECT().
+					GetJob(gomock.Eq(job.ID)).
+					Times(1).
+					Return(&limited, nil)
+				application := db.CandidateApplication{
+					CandidateID:        candidate.ID,
+					EmployerID:         employerID,
+					ElasticsearchDocID: elasticsearch.CandidateApplicationDocID(candidate.ID, job.ID),
+					JobDocID:           job.ID,
+					ApplicationStatus:  db.ApplicationStatusPending,
+				}
+				store.EXPECT().
+					UpdateEmployerApplicationStatusTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.UpdateEmployerApplicationStatusTxParams) (db.TransitionApplicationStatusTxResult, error) {
+						require.NotNil(t, arg.Application)
+						require.Equal(t, db.CreateCandidateApplicationParams{
+							CandidateID:        application.CandidateID,
+							EmployerID:         application.EmployerID,
+							ElasticsearchDocID: application.ElasticsearchDocID,
+							JobDocID:           application.JobDocID,
+							ApplicationStatus:  application.ApplicationStatus,
+						}, arg.Application.CreateCandidateApplicationParams)
+						require.Equal(t, invitation.Message, arg.Application.AppDoc[invitationMessageKey])
+						require.NoError(t, arg.Application.AfterCreate(application.ElasticsearchDocID, arg.Application.AppDoc))
+
+						accepted := result
+						accepted.CandidateApplication = application
+						accepted.ApplicationCount = 1
+						require.NoError(t, arg.Application.AfterLimitReached())
+						return accepted, arg.AfterUpdate(accepted)
+					})
+				taskDistributor.EXPECT().
+					DistributeTaskCreateCandidateApplication(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+				taskDistributor.EXPECT().
+					DistributeTaskNotifyApplicationDecision(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+				taskDistributor.EXPECT().
+					DistributeTaskApplyJobSchedule(gomock.Any(), gomock.Eq(&worker.PayloadApplyJobSchedule{JobID: job.ID}), gomock.Any()).
+					Times(1).
+					Return(nil)
+				taskDistributor.EXPECT().
+					DistributeTaskIncrementJobStats(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+				store.EXPECT().
+					ListApplicationStatusHistory(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ApplicationStatusHistory{result.History}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res employerApplicationResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.NotNil(t, res.Application)
+				require.Equal(t, job.ID, res.Application.JobDocID)
+			},
+		},
+		{
+			name:      "InvitationExpired",
+			setupAuth: candidateAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				expired := invitation
+				expired.ExpiresAt = pgtype.Timestamptz{Time: time.Now().Add(-time.Hour), Valid: true}
+				store.EXPECT().
+					GetEmployerApplication(gomock.Any(), gomock.Eq(invitationParams)).
+					Times(1).
+					Return(expired, nil)
+				store.EXPECT().
+					UpdateEmployerApplicationStatusTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusGone, recorder.Code)
+			},
+		},
+		{
+			name: "EmployerCannotDecideOwn",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, "employer", db.RoleEmployer, time.Minute, employerID)
+			},
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					UpdateEmployerApplicationStatusTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			esClient := mockes.NewMockESClient(ctrl)
+			taskDistributor := mockwk.NewMockTaskDistributor(ctrl)
+			tc.buildStubs(store, esClient, taskDistributor)
+
+			server := newTestServer(t, store, esClient, taskDistributor)
+			recorder := httptest.NewRecorder()
+
+			var body []byte
+			if tc.body != nil {
+				var err error
+				body, err = json.Marshal(tc.body)
+				require.NoError(t, err)
+			}
+			url := fmt.Sprintf("/employer_applications/%d/%d/accept", employerID, candidate.ID)
+			request, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(body))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
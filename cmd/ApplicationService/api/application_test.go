@@ -2,12 +2,15 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"reflect"
 	"testing"
 	"time"
@@ -16,24 +19,36 @@ import (
 	"github.com/golang/mock/gomock"
 	mockdb "github.com/hankimmy/PtmrBackend/pkg/db/mock"
 	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	"github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
 	mockes "github.com/hankimmy/PtmrBackend/pkg/elasticsearch/mock"
 	"github.com/hankimmy/PtmrBackend/pkg/middleware"
 	"github.com/hankimmy/PtmrBackend/pkg/token"
 	"github.com/hankimmy/PtmrBackend/pkg/worker"
 	mockwk "github.com/hankimmy/PtmrBackend/pkg/worker/mock"
+	"github.com/hibiken/asynq"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/require"
 )
 
 func TestCreateCandidateApplicationAPI(t *testing.T) {
 	user, _ := db.RandomUser(db.RoleCandidate)
-	appDoc := gin.H{"key1": "value1", "key2": 2}
 	application := db.RandomCandidateApplication(1)
+	job := elasticsearch.RandomJob(application.EmployerID)
+	application.JobDocID = job.ID
+	application.ElasticsearchDocID = elasticsearch.CandidateApplicationDocID(application.CandidateID, job.ID)
+	pausedJob := job
+	pausedJob.Status = elasticsearch.JobStatusPaused
+	limitedJob := job
+	limitedJob.MaxApplications = 3
+	appDoc := gin.H{
+		"key1":    "value1",
+		"answers": gin.H{"q1": "Jane Doe", "q3": "Bachelor's Degree", "q4": "yes"},
+	}
 	testCases := []struct {
 		name          string
 		body          gin.H
 		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
-		buildStubs    func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor)
+		buildStubs    func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor)
 		checkResponse func(recorder *httptest.ResponseRecorder)
 	}{
 		{
@@ -48,7 +63,11 @@ func TestCreateCandidateApplicationAPI(t *testing.T) {
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleCandidate, time.Minute, application.CandidateID)
 			},
-			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().
+					GetJob(gomock.Eq(application.JobDocID)).
+					Times(1).
+					Return(&job, nil)
 				arg := db.CreateCandidateApplicationTxParams{
 					CreateCandidateApplicationParams: db.CreateCandidateApplicationParams{
 						CandidateID:        application.CandidateID,
@@ -71,6 +90,22 @@ func TestCreateCandidateApplicationAPI(t *testing.T) {
 					DistributeTaskCreateCandidateApplication(gomock.Any(), taskPayload, gomock.Any()).
 					Times(1).
 					Return(nil)
+				taskDistributor.EXPECT().
+					DistributeTaskIncrementJobStats(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, payload *worker.PayloadIncrementJobStats, _ ...interface{}) error {
+						require.Equal(t, worker.JobStatApplication, payload.Event)
+						require.Equal(t, []worker.JobStatTarget{{JobID: job.ID, EmployerID: job.EmployerID}}, payload.Jobs)
+						return nil
+					})
+				taskDistributor.EXPECT().
+					DistributeTaskNotifyApplicationReceived(gomock.Any(), gomock.Eq(&worker.PayloadNotifyApplicationReceived{
+						CandidateID: application.CandidateID,
+						EmployerID:  application.EmployerID,
+						JobID:       application.JobDocID,
+					}), gomock.Any()).
+					Times(1).
+					Return(nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
@@ -87,7 +122,7 @@ func TestCreateCandidateApplicationAPI(t *testing.T) {
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleCandidate, time.Minute, application.CandidateID)
 			},
-			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
@@ -104,7 +139,7 @@ func TestCreateCandidateApplicationAPI(t *testing.T) {
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleEmployer, time.Minute, application.EmployerID)
 			},
-			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, recorder.Code)
@@ -122,14 +157,14 @@ func TestCreateCandidateApplicationAPI(t *testing.T) {
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleCandidate, time.Minute, application.CandidateID)
 			},
-			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
 		{
-			name: "InternalServerError",
+			name: "MissingJobDocID",
 			body: gin.H{
 				"candidate_id":       application.CandidateID,
 				"employer_id":        application.EmployerID,
@@ -139,238 +174,858 @@ func TestCreateCandidateApplicationAPI(t *testing.T) {
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleCandidate, time.Minute, application.CandidateID)
 			},
-			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
 				store.EXPECT().
 					CreateCandidateApplicationTx(gomock.Any(), gomock.Any()).
-					Times(1).
-					Return(db.CandidateAppTxResult{}, sql.ErrConnDone)
-				taskDistributor.EXPECT().
-					DistributeTaskCreateCandidateApplication(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			storeCtrl := gomock.NewController(t)
-			defer storeCtrl.Finish()
-			store := mockdb.NewMockStore(storeCtrl)
-
-			taskCtrl := gomock.NewController(t)
-			defer taskCtrl.Finish()
-			taskDistributor := mockwk.NewMockTaskDistributor(taskCtrl)
-			tc.buildStubs(store, taskDistributor)
-
-			server := newTestServer(t, store, nil, taskDistributor)
-			recorder := httptest.NewRecorder()
-
-			// Marshal body data to JSON
-			data, err := json.Marshal(tc.body)
-			require.NoError(t, err)
-
-			url := "/candidate_applications"
-			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
-			require.NoError(t, err)
-
-			tc.setupAuth(t, request, server.tokenMaker)
-			server.router.ServeHTTP(recorder, request)
-			tc.checkResponse(recorder)
-		})
-	}
-}
-
-func TestCreateEmployerApplicationAPI(t *testing.T) {
-	user, _ := db.RandomUser(db.RoleEmployer)
-	application := db.RandomEmployerApplication(1)
-	appDoc := gin.H{"message": application.Message}
-
-	testCases := []struct {
-		name          string
-		body          gin.H
-		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
-		buildStubs    func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor)
-		checkResponse func(recorder *httptest.ResponseRecorder)
-	}{
 		{
-			name: "OK",
+			name: "DecidedOnCreate",
 			body: gin.H{
 				"candidate_id":       application.CandidateID,
 				"employer_id":        application.EmployerID,
-				"application_status": application.ApplicationStatus,
+				"application_status": db.ApplicationStatusAccepted,
+				"job_doc_id":         application.JobDocID,
 				"application_doc":    appDoc,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleEmployer, time.Minute, application.EmployerID)
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleCandidate, time.Minute, application.CandidateID)
 			},
-			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
-				arg := db.CreateEmployerApplicationParams{
-					EmployerID:        application.EmployerID,
-					CandidateID:       application.CandidateID,
-					Message:           application.Message,
-					ApplicationStatus: application.ApplicationStatus,
-				}
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().
+					GetJob(gomock.Any()).
+					Times(0)
 				store.EXPECT().
-					CreateEmployerApplication(gomock.Any(), gomock.Eq(arg)).
+					CreateCandidateApplicationTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "invalid application status transition")
+			},
+		},
+		{
+			name: "MissingRequiredAnswer",
+			body: gin.H{
+				"candidate_id":       application.CandidateID,
+				"employer_id":        application.EmployerID,
+				"application_status": application.ApplicationStatus,
+				"job_doc_id":         application.JobDocID,
+				"application_doc":    gin.H{"answers": gin.H{"q1": "Jane Doe"}},
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleCandidate, time.Minute, application.CandidateID)
+			},
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().
+					GetJob(gomock.Eq(application.JobDocID)).
 					Times(1).
-					Return(db.EmployerApplication{
-						EmployerID:        application.EmployerID,
-						CandidateID:       application.CandidateID,
-						Message:           application.Message,
-						ApplicationStatus: application.ApplicationStatus}, nil)
+					Return(&job, nil)
+				store.EXPECT().
+					CreateCandidateApplicationTx(gomock.Any(), gomock.Any()).
+					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusOK, recorder.Code)
-				requireBodyMatchEmployerApplication(t, recorder.Body, application)
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `question \"q3\" is required`)
 			},
 		},
 		{
-			name: "MissingApplicationStatus",
+			name: "InvalidOption",
 			body: gin.H{
-				"candidate_id":    application.CandidateID,
-				"employer_id":     application.EmployerID,
-				"application_doc": appDoc,
+				"candidate_id":       application.CandidateID,
+				"employer_id":        application.EmployerID,
+				"application_status": application.ApplicationStatus,
+				"job_doc_id":         application.JobDocID,
+				"application_doc":    gin.H{"answers": gin.H{"q1": "Jane Doe", "q3": "Bootcamp"}},
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleEmployer, time.Minute, application.EmployerID)
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleCandidate, time.Minute, application.CandidateID)
 			},
-			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().
+					GetJob(gomock.Eq(application.JobDocID)).
+					Times(1).
+					Return(&job, nil)
+				store.EXPECT().
+					CreateCandidateApplicationTx(gomock.Any(), gomock.Any()).
+					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "is not one of the options")
 			},
 		},
 		{
-			name: "UnauthorizedUserRole",
+			name: "JobNotFound",
 			body: gin.H{
 				"candidate_id":       application.CandidateID,
 				"employer_id":        application.EmployerID,
 				"application_status": application.ApplicationStatus,
+				"job_doc_id":         application.JobDocID,
 				"application_doc":    appDoc,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleCandidate, time.Minute, application.CandidateID)
 			},
-			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().
+					GetJob(gomock.Eq(application.JobDocID)).
+					Times(1).
+					Return(nil, nil)
+				store.EXPECT().
+					CreateCandidateApplicationTx(gomock.Any(), gomock.Any()).
+					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				require.Equal(t, http.StatusNotFound, recorder.Code)
 			},
 		},
 		{
-			name: "InvalidJSON",
+			name: "JobNotAcceptingApplications",
 			body: gin.H{
 				"candidate_id":       application.CandidateID,
 				"employer_id":        application.EmployerID,
 				"application_status": application.ApplicationStatus,
-				// application_doc is set to a string instead of a JSON object
-				"application_doc": "invalid_json",
+				"job_doc_id":         application.JobDocID,
+				"application_doc":    appDoc,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleEmployer, time.Minute, application.EmployerID)
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleCandidate, time.Minute, application.CandidateID)
 			},
-			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().
+					GetJob(gomock.Eq(application.JobDocID)).
+					Times(1).
+					Return(&pausedJob, nil)
+				store.EXPECT().
+					CreateCandidateApplicationTx(gomock.Any(), gomock.Any()).
+					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Equal(t, http.StatusConflict, recorder.Code)
 			},
 		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			storeCtrl := gomock.NewController(t)
-			defer storeCtrl.Finish()
-			store := mockdb.NewMockStore(storeCtrl)
-
-			taskCtrl := gomock.NewController(t)
-			defer taskCtrl.Finish()
-			taskDistributor := mockwk.NewMockTaskDistributor(taskCtrl)
-			tc.buildStubs(store, taskDistributor)
-
-			server := newTestServer(t, store, nil, taskDistributor)
-			recorder := httptest.NewRecorder()
-
-			// Marshal body data to JSON
-			data, err := json.Marshal(tc.body)
-			require.NoError(t, err)
-
-			url := "/employer_applications"
-			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
-			require.NoError(t, err)
-
-			tc.setupAuth(t, request, server.tokenMaker)
-			server.router.ServeHTTP(recorder, request)
-			tc.checkResponse(recorder)
-		})
-	}
-}
-
-func TestGetCandidateApplicationsByEmployerAPI(t *testing.T) {
-	user, _ := db.RandomUser(db.RoleCandidate)
-	employer := db.RandomEmployer(user.Username)
-	application := db.RandomCandidateApplication(employer.ID)
-	docID := fmt.Sprintf("%d_%d", application.CandidateID, employer.ID)
-
-	testCases := []struct {
-		name          string
-		employerID    int64
-		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
-		buildStubs    func(store *mockdb.MockStore, esClient *mockes.MockESClient)
-		checkResponse func(recorder *httptest.ResponseRecorder)
-	}{
 		{
-			name:       "OK",
-			employerID: employer.ID,
+			name: "ApplicationLimitReached",
+			body: gin.H{
+				"candidate_id":       application.CandidateID,
+				"employer_id":        application.EmployerID,
+				"application_status": application.ApplicationStatus,
+				"job_doc_id":         application.JobDocID,
+				"application_doc":    appDoc,
+			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleCandidate, time.Minute, employer.ID)
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleCandidate, time.Minute, application.CandidateID)
 			},
-			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().
+					GetJob(gomock.Eq(application.JobDocID)).
+					Times(1).
+					Return(&limitedJob, nil)
 				store.EXPECT().
-					GetCandidateApplicationsByEmployer(gomock.Any(), gomock.Eq(employer.ID)).
+					CreateCandidateApplicationTx(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return([]db.CandidateApplication{application}, nil)
-				var res map[string]interface{}
+					Return(db.CandidateAppTxResult{}, db.ErrApplicationLimitReached)
+				taskDistributor.EXPECT().
+					DistributeTaskApplyJobSchedule(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "LastApplicationClosesJob",
+			body: gin.H{
+				"candidate_id":       application.CandidateID,
+				"employer_id":        application.EmployerID,
+				"application_status": application.ApplicationStatus,
+				"job_doc_id":         application.JobDocID,
+				"application_doc":    appDoc,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleCandidate, time.Minute, application.CandidateID)
+			},
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
 				esClient.EXPECT().
-					GetCandidateApplication(gomock.Any(), gomock.Eq(docID)).
+					GetJob(gomock.Eq(application.JobDocID)).
+					Times(1).
+					Return(&limitedJob, nil)
+				store.EXPECT().
+					CreateCandidateApplicationTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateCandidateApplicationTxParams) (db.CandidateAppTxResult, error) {
+						require.Equal(t, limitedJob.MaxApplications, arg.MaxApplications)
+						return db.CandidateAppTxResult{CandidateApplication: application, ApplicationCount: 3}, arg.AfterLimitReached()
+					})
+				taskDistributor.EXPECT().
+					DistributeTaskApplyJobSchedule(gomock.Any(), gomock.Eq(&worker.PayloadApplyJobSchedule{JobID: limitedJob.ID}), gomock.Any()).
 					Times(1).
-					Return(res, nil)
+					Return(nil)
+				taskDistributor.EXPECT().
+					DistributeTaskIncrementJobStats(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+				taskDistributor.EXPECT().
+					DistributeTaskNotifyApplicationReceived(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
 			},
 		},
 		{
-			name:       "NoAuthorization",
-			employerID: employer.ID,
+			name: "CloseJobEnqueueFails",
+			body: gin.H{
+				"candidate_id":       application.CandidateID,
+				"employer_id":        application.EmployerID,
+				"application_status": application.ApplicationStatus,
+				"job_doc_id":         application.JobDocID,
+				"application_doc":    appDoc,
+			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleCandidate, time.Minute, application.CandidateID)
 			},
-			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().
+					GetJob(gomock.Eq(application.JobDocID)).
+					Times(1).
+					Return(&limitedJob, nil)
 				store.EXPECT().
-					GetCandidateApplicationsByEmployer(gomock.Any(), gomock.Any()).
+					CreateCandidateApplicationTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateCandidateApplicationTxParams) (db.CandidateAppTxResult, error) {
+						require.Equal(t, limitedJob.MaxApplications, arg.MaxApplications)
+						return db.CandidateAppTxResult{CandidateApplication: application, ApplicationCount: 3}, arg.AfterLimitReached()
+					})
+				taskDistributor.EXPECT().
+					DistributeTaskApplyJobSchedule(gomock.Any(), gomock.Eq(&worker.PayloadApplyJobSchedule{JobID: limitedJob.ID}), gomock.Any()).
+					Times(1).
+					Return(errors.New("redis unavailable"))
+				taskDistributor.EXPECT().
+					DistributeTaskNotifyApplicationReceived(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			ctrl := gomock.NewController(t)
-			defer ctrl.Finish()
-
-			store := mockdb.NewMockStore(ctrl)
-			mockEsClient := mockes.NewMockESClient(ctrl)
-			tc.buildStubs(store, mockEsClient)
-			server := newTestServer(t, store, mockEsClient, nil)
-			recorder := httptest.NewRecorder()
-
-			url := fmt.Sprintf("/candidate_applications/%d", tc.employerID)
+		{
+			name: "EmployerFromJob",
+			body: gin.H{
+				"candidate_id":       application.CandidateID,
+				"application_status": application.ApplicationStatus,
+				"job_doc_id":         application.JobDocID,
+				"application_doc":    appDoc,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleCandidate, time.Minute, application.CandidateID)
+			},
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().
+					GetJob(gomock.Eq(application.JobDocID)).
+					Times(1).
+					Return(&job, nil)
+				store.EXPECT().
+					CreateCandidateApplicationTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateCandidateApplicationTxParams) (db.CandidateAppTxResult, error) {
+						require.Equal(t, job.EmployerID, arg.EmployerID)
+						require.Equal(t, application.ElasticsearchDocID, arg.ElasticsearchDocID)
+						require.Equal(t, application.CandidateID, arg.AppDoc["candidate_id"])
+						require.Equal(t, job.EmployerID, arg.AppDoc["employer_id"])
+						require.Equal(t, job.ID, arg.AppDoc["job_id"])
+						return db.CandidateAppTxResult{CandidateApplication: application}, nil
+					})
+				taskDistributor.EXPECT().
+					DistributeTaskIncrementJobStats(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+				// The application is saved even if the employer can't be notified.
+				taskDistributor.EXPECT().
+					DistributeTaskNotifyApplicationReceived(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(errors.New("redis is down"))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "EmployerDoesNotMatchJob",
+			body: gin.H{
+				"candidate_id":       application.CandidateID,
+				"employer_id":        application.EmployerID + 1,
+				"application_status": application.ApplicationStatus,
+				"job_doc_id":         application.JobDocID,
+				"application_doc":    appDoc,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleCandidate, time.Minute, application.CandidateID)
+			},
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().
+					GetJob(gomock.Eq(application.JobDocID)).
+					Times(1).
+					Return(&job, nil)
+				store.EXPECT().
+					CreateCandidateApplicationTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "AlreadyApplied",
+			body: gin.H{
+				"candidate_id":       application.CandidateID,
+				"employer_id":        application.EmployerID,
+				"application_status": application.ApplicationStatus,
+				"job_doc_id":         application.JobDocID,
+				"application_doc":    appDoc,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleCandidate, time.Minute, application.CandidateID)
+			},
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().
+					GetJob(gomock.Eq(application.JobDocID)).
+					Times(1).
+					Return(&job, nil)
+				store.EXPECT().
+					CreateCandidateApplicationTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CandidateAppTxResult{}, db.ErrUniqueViolation)
+				taskDistributor.EXPECT().
+					DistributeTaskIncrementJobStats(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+				taskDistributor.EXPECT().
+					DistributeTaskNotifyApplicationReceived(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "ReappliedTooSoon",
+			body: gin.H{
+				"candidate_id":       application.CandidateID,
+				"employer_id":        application.EmployerID,
+				"application_status": application.ApplicationStatus,
+				"job_doc_id":         application.JobDocID,
+				"application_doc":    appDoc,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleCandidate, time.Minute, application.CandidateID)
+			},
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().
+					GetJob(gomock.Eq(application.JobDocID)).
+					Times(1).
+					Return(&job, nil)
+				store.EXPECT().
+					CreateCandidateApplicationTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateCandidateApplicationTxParams) (db.CandidateAppTxResult, error) {
+						require.Equal(t, defaultReapplyCooldown, arg.ReapplyCooldown)
+						return db.CandidateAppTxResult{}, db.ErrReapplyTooSoon
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "InternalServerError",
+			body: gin.H{
+				"candidate_id":       application.CandidateID,
+				"employer_id":        application.EmployerID,
+				"application_status": application.ApplicationStatus,
+				"job_doc_id":         application.JobDocID,
+				"application_doc":    appDoc,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleCandidate, time.Minute, application.CandidateID)
+			},
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().
+					GetJob(gomock.Eq(application.JobDocID)).
+					Times(1).
+					Return(&job, nil)
+				store.EXPECT().
+					CreateCandidateApplicationTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CandidateAppTxResult{}, sql.ErrConnDone)
+				taskDistributor.EXPECT().
+					DistributeTaskCreateCandidateApplication(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			taskCtrl := gomock.NewController(t)
+			defer taskCtrl.Finish()
+			taskDistributor := mockwk.NewMockTaskDistributor(taskCtrl)
+			esClient := mockes.NewMockESClient(storeCtrl)
+			tc.buildStubs(store, esClient, taskDistributor)
+
+			server := newTestServer(t, store, esClient, taskDistributor)
+			recorder := httptest.NewRecorder()
+
+			// Marshal body data to JSON
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := "/candidate_applications"
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestCreateEmployerApplicationAPI(t *testing.T) {
+	user, _ := db.RandomUser(db.RoleEmployer)
+	application := db.RandomEmployerApplication(1)
+	job := elasticsearch.RandomJob(application.EmployerID)
+	application.JobDocID = job.ID
+	otherJob := elasticsearch.RandomJob(application.EmployerID + 1)
+	appDoc := gin.H{"message": application.Message}
+	docID := elasticsearch.EmployerApplicationDocID(application.EmployerID, application.CandidateID)
+	employerAuth := func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+		middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleEmployer, time.Minute, application.EmployerID)
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"candidate_id":       application.CandidateID,
+				"employer_id":        application.EmployerID,
+				"application_status": application.ApplicationStatus,
+				"job_doc_id":         job.ID,
+				"application_doc":    appDoc,
+			},
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().
+					GetJob(gomock.Eq(job.ID)).
+					Times(1).
+					Return(&job, nil)
+				store.EXPECT().
+					CreateEmployerApplicationTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.CreateEmployerApplicationTxParams) (db.EmployerAppTxResult, error) {
+						require.Equal(t, application.EmployerID, arg.EmployerID)
+						require.Equal(t, application.CandidateID, arg.CandidateID)
+						require.Equal(t, application.Message, arg.Message)
+						require.Equal(t, job.ID, arg.JobDocID)
+						require.True(t, arg.ExpiresAt.Valid)
+						require.WithinDuration(t, time.Now().Add(defaultInvitationDuration), arg.ExpiresAt.Time, time.Minute)
+						require.Equal(t, docID, arg.DocID)
+						require.Equal(t, job.ID, arg.AppDoc["job_id"])
+						return db.EmployerAppTxResult{EmployerAppResult: application}, arg.AfterCreate(arg.DocID, arg.AppDoc)
+					})
+				taskDistributor.EXPECT().
+					DistributeTaskCreateEmployerApplication(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, payload *worker.PayloadCreateApplication, _ ...asynq.Option) error {
+						require.Equal(t, docID, payload.DocID)
+						return nil
+					})
+				taskDistributor.EXPECT().
+					DistributeTaskNotifyJobInvitation(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, payload *worker.PayloadNotifyJobInvitation, _ ...asynq.Option) error {
+						require.Equal(t, application.CandidateID, payload.CandidateID)
+						require.Equal(t, application.EmployerID, payload.EmployerID)
+						require.Equal(t, job.ID, payload.JobID)
+						require.Equal(t, application.Message, payload.Message)
+						return nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchEmployerApplication(t, recorder.Body, application)
+			},
+		},
+		{
+			name: "MissingJob",
+			body: gin.H{
+				"candidate_id":       application.CandidateID,
+				"employer_id":        application.EmployerID,
+				"application_status": application.ApplicationStatus,
+				"application_doc":    appDoc,
+			},
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					CreateEmployerApplicationTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "OtherEmployersJob",
+			body: gin.H{
+				"candidate_id":       application.CandidateID,
+				"employer_id":        application.EmployerID,
+				"application_status": application.ApplicationStatus,
+				"job_doc_id":         otherJob.ID,
+				"application_doc":    appDoc,
+			},
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().
+					GetJob(gomock.Eq(otherJob.ID)).
+					Times(1).
+					Return(&otherJob, nil)
+				store.EXPECT().
+					CreateEmployerApplicationTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "AlreadyInvited",
+			body: gin.H{
+				"candidate_id":       application.CandidateID,
+				"employer_id":        application.EmployerID,
+				"application_status": application.ApplicationStatus,
+				"job_doc_id":         job.ID,
+				"application_doc":    appDoc,
+			},
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().
+					GetJob(gomock.Eq(job.ID)).
+					Times(1).
+					Return(&job, nil)
+				store.EXPECT().
+					CreateEmployerApplicationTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.EmployerAppTxResult{}, db.ErrUniqueViolation)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "MissingApplicationStatus",
+			body: gin.H{
+				"candidate_id":    application.CandidateID,
+				"employer_id":     application.EmployerID,
+				"job_doc_id":      job.ID,
+				"application_doc": appDoc,
+			},
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UnauthorizedUserRole",
+			body: gin.H{
+				"candidate_id":       application.CandidateID,
+				"employer_id":        application.EmployerID,
+				"application_status": application.ApplicationStatus,
+				"job_doc_id":         job.ID,
+				"application_doc":    appDoc,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleCandidate, time.Minute, application.CandidateID)
+			},
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidJSON",
+			body: gin.H{
+				"candidate_id":       application.CandidateID,
+				"employer_id":        application.EmployerID,
+				"application_status": application.ApplicationStatus,
+				"job_doc_id":         job.ID,
+				// application_doc is set to a string instead of a JSON object
+				"application_doc": "invalid_json",
+			},
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+			esClient := mockes.NewMockESClient(ctrl)
+			taskDistributor := mockwk.NewMockTaskDistributor(ctrl)
+			tc.buildStubs(store, esClient, taskDistributor)
+
+			server := newTestServer(t, store, esClient, taskDistributor)
+			recorder := httptest.NewRecorder()
+
+			// Marshal body data to JSON
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := "/employer_applications"
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestGetCandidateApplicationsByEmployerAPI(t *testing.T) {
+	user, _ := db.RandomUser(db.RoleCandidate)
+	employer := db.RandomEmployer(user.Username)
+	application := db.RandomCandidateApplication(employer.ID)
+	docID := application.ElasticsearchDocID
+	cursorTime := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		employerID    int64
+		query         url.Values
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore, esClient *mockes.MockESClient)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:       "OK",
+			employerID: employer.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleEmployer, time.Minute, employer.ID)
+			},
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				store.EXPECT().
+					ListCandidateApplicationsNewestFirst(gomock.Any(), gomock.Eq(db.ListCandidateApplicationsNewestFirstParams{
+						EmployerID: employer.ID,
+						Statuses:   db.PipelineStatuses,
+						Limit:      defaultApplicationPageSize + 1,
+					})).
+					Times(1).
+					Return([]db.CandidateApplication{application}, nil)
+				store.EXPECT().
+					ListApplicationStatusHistoryByKeys(gomock.Any(), gomock.Eq(db.ListApplicationStatusHistoryByKeysParams{
+						ApplicationKind: string(db.ApplicationKindCandidate),
+						CandidateIds:    []int64{application.CandidateID},
+						EmployerIds:     []int64{application.EmployerID},
+						JobDocIds:       []string{application.JobDocID},
+					})).
+					Times(1).
+					Return([]db.ApplicationStatusHistory{}, nil)
+				esClient.EXPECT().
+					MGetCandidateApplications(gomock.Any(), gomock.Eq([]string{docID})).
+					Times(1).
+					Return(map[string]map[string]interface{}{docID: {}}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:       "FiltersAndNextPage",
+			employerID: employer.ID,
+			query: url.Values{
+				"status":         {"submitted", "accepted"},
+				"job_doc_id":     {application.JobDocID},
+				"created_after":  {"2024-01-01T00:00:00Z"},
+				"created_before": {"2024-02-01T00:00:00Z"},
+				"sort":           {"oldest"},
+				"page_size":      {"5"},
+				"cursor":         {encodeApplicationCursor(applicationCursor{CreatedAt: cursorTime, ID: 3, JobID: application.JobDocID})},
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleEmployer, time.Minute, employer.ID)
+			},
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				page := make([]db.CandidateApplication, 6)
+				for i := range page {
+					page[i] = application
+					page[i].CandidateID = int64(i + 10)
+					page[i].CreatedAt = cursorTime.Add(time.Duration(i) * time.Minute)
+				}
+				store.EXPECT().
+					ListCandidateApplicationsOldestFirst(gomock.Any(), gomock.Eq(db.ListCandidateApplicationsOldestFirstParams{
+						EmployerID:      employer.ID,
+						Statuses:        []string{"submitted", "accepted"},
+						JobDocIds:       []string{application.JobDocID},
+						CreatedAfter:    pgtype.Timestamptz{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+						CreatedBefore:   pgtype.Timestamptz{Time: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+						CursorCreatedAt: pgtype.Timestamptz{Time: cursorTime, Valid: true},
+						CursorID:        pgtype.Int8{Int64: 3, Valid: true},
+						CursorJobDocID:  pgtype.Text{String: application.JobDocID, Valid: true},
+						Limit:           6,
+					})).
+					Times(1).
+					Return(page, nil)
+				store.EXPECT().
+					ListApplicationStatusHistoryByKeys(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ApplicationStatusHistory{}, nil)
+				esClient.EXPECT().
+					MGetCandidateApplications(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, ids []string) (map[string]map[string]interface{}, error) {
+						require.Len(t, ids, 5)
+						return map[string]map[string]interface{}{}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res struct {
+					Applications []map[string]interface{} `json:"applications"`
+					NextCursor   string                   `json:"next_cursor"`
+				}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Len(t, res.Applications, 5)
+
+				cursor, err := decodeApplicationCursor(res.NextCursor, false)
+				require.NoError(t, err)
+				require.Equal(t, int64(14), cursor.ID)
+				require.Equal(t, application.JobDocID, cursor.JobID)
+				require.True(t, cursorTime.Add(4*time.Minute).Equal(cursor.CreatedAt))
+			},
+		},
+		{
+			name:       "InvalidStatus",
+			employerID: employer.ID,
+			query:      url.Values{"status": {"archived"}},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleEmployer, time.Minute, employer.ID)
+			},
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				store.EXPECT().
+					ListCandidateApplicationsNewestFirst(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:       "InvalidCursor",
+			employerID: employer.ID,
+			query:      url.Values{"cursor": {"not-a-cursor"}},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleEmployer, time.Minute, employer.ID)
+			},
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				store.EXPECT().
+					ListCandidateApplicationsNewestFirst(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:       "InvalidDateRange",
+			employerID: employer.ID,
+			query: url.Values{
+				"created_after":  {"2024-02-01T00:00:00Z"},
+				"created_before": {"2024-01-01T00:00:00Z"},
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleEmployer, time.Minute, employer.ID)
+			},
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				store.EXPECT().
+					ListCandidateApplicationsNewestFirst(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:       "OtherEmployer",
+			employerID: employer.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleEmployer, time.Minute, employer.ID+1)
+			},
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				store.EXPECT().
+					ListCandidateApplicationsNewestFirst(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:       "NoAuthorization",
+			employerID: employer.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				store.EXPECT().
+					ListCandidateApplicationsNewestFirst(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			mockEsClient := mockes.NewMockESClient(ctrl)
+			tc.buildStubs(store, mockEsClient)
+			server := newTestServer(t, store, mockEsClient, nil)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/candidate_applications/%d?%s", tc.employerID, tc.query.Encode())
 			request, err := http.NewRequest(http.MethodGet, url, nil)
 			require.NoError(t, err)
 
@@ -396,166 +1051,403 @@ func TestGetEmployerApplicationsByCandidateAPI(t *testing.T) {
 		{
 			name:        "OK",
 			candidateID: candidate.ID,
-			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleEmployer, time.Minute, candidate.ID)
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleCandidate, time.Minute, candidate.ID)
+			},
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				store.EXPECT().
+					ListEmployerApplications(gomock.Any(), gomock.Eq(db.ListEmployerApplicationsParams{
+						CandidateID: candidate.ID,
+						Limit:       defaultApplicationPageSize + 1,
+					})).
+					Times(1).
+					Return([]db.EmployerApplication{application}, nil)
+				store.EXPECT().
+					ListApplicationStatusHistoryByKeys(gomock.Any(), gomock.Eq(db.ListApplicationStatusHistoryByKeysParams{
+						ApplicationKind: string(db.ApplicationKindEmployer),
+						CandidateIds:    []int64{application.CandidateID},
+						EmployerIds:     []int64{application.EmployerID},
+						JobDocIds:       []string{""},
+					})).
+					Times(1).
+					Return([]db.ApplicationStatusHistory{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:        "NoAuthorization",
+			candidateID: candidate.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				store.EXPECT().
+					ListEmployerApplications(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			mockEsClient := mockes.NewMockESClient(ctrl)
+			tc.buildStubs(store, mockEsClient)
+
+			server := newTestServer(t, store, mockEsClient, nil)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/employer_applications/%d", tc.candidateID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUpdateCandidateApplicationAPI(t *testing.T) {
+	user, _ := db.RandomUser(db.RoleCandidate)
+	candidate := db.RandomCandidate(user.Username)
+	job := elasticsearch.RandomJob(1)
+	docID := elasticsearch.CandidateApplicationDocID(candidate.ID, job.ID)
+	current := db.CandidateApplication{
+		CandidateID:        candidate.ID,
+		EmployerID:         1,
+		ElasticsearchDocID: docID,
+		JobDocID:           job.ID,
+		ApplicationStatus:  db.ApplicationStatusPending,
+	}
+	withStatus := func(status db.ApplicationStatus) db.CandidateApplication {
+		application := current
+		application.ApplicationStatus = status
+		return application
+	}
+	historyArg := db.ListApplicationStatusHistoryParams{
+		ApplicationKind: string(db.ApplicationKindCandidate),
+		CandidateID:     pgtype.Int8{Int64: candidate.ID, Valid: true},
+		EmployerID:      pgtype.Int8{Int64: 1, Valid: true},
+	}
+	candidateAuth := func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+		middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleCandidate, time.Minute, candidate.ID)
+	}
+	employerAuth := func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+		middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, "employer", db.RoleEmployer, time.Minute, 1)
+	}
+	getApplication := func(store *mockdb.MockStore) {
+		store.EXPECT().
+			GetCandidateApplication(gomock.Any(), gomock.Eq(db.GetCandidateApplicationParams{
+				CandidateID: candidate.ID,
+				JobDocID:    job.ID,
+			})).
+			Times(1).
+			Return(current, nil)
+	}
+
+	testCases := []struct {
+		name          string
+		candidateID   int64
+		jobID         string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore, esClient *mockes.MockESClient)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "OK",
+			candidateID: candidate.ID,
+			jobID:       job.ID,
+			body: gin.H{
+				"application_status": db.ApplicationStatusRejected,
+				"reason":             "position filled",
+			},
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				getApplication(store)
+				arg := db.TransitionApplicationStatusTxParams{
+					Kind:        db.ApplicationKindCandidate,
+					CandidateID: candidate.ID,
+					EmployerID:  1,
+					JobDocID:    job.ID,
+					ActorRole:   db.RoleEmployer,
+					ActorID:     1,
+					Status:      db.ApplicationStatusRejected,
+					Reason:      "position filled",
+				}
+				history := db.ApplicationStatusHistory{
+					ID:              1,
+					ApplicationKind: string(db.ApplicationKindCandidate),
+					CandidateID:     candidate.ID,
+					EmployerID:      1,
+					JobDocID:        job.ID,
+					ActorRole:       db.RoleEmployer,
+					ActorID:         1,
+					FromStatus:      db.ApplicationStatusPending,
+					ToStatus:        db.ApplicationStatusRejected,
+					Reason:          "position filled",
+				}
+				store.EXPECT().
+					TransitionApplicationStatusTx(gomock.Any(), EqTransitionApplicationStatusTxParams(arg)).
+					Times(1).
+					Return(db.TransitionApplicationStatusTxResult{
+						CandidateApplication: withStatus(db.ApplicationStatusRejected),
+						Changed:              true,
+						History:              history,
+					}, nil)
+				store.EXPECT().
+					ListApplicationStatusHistory(gomock.Any(), gomock.Eq(historyArg)).
+					Times(1).
+					Return([]db.ApplicationStatusHistory{history}, nil)
+				esClient.EXPECT().
+					UpdateCandidateApplication(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchCandidateApplication(t, bytes.NewBuffer(recorder.Body.Bytes()), withStatus(db.ApplicationStatusRejected))
+
+				var res candidateApplicationResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Len(t, res.StatusHistory, 1)
+				require.Equal(t, db.ApplicationStatusPending, res.StatusHistory[0].FromStatus)
+				require.Equal(t, db.ApplicationStatusRejected, res.StatusHistory[0].ToStatus)
+				require.Equal(t, "position filled", res.StatusHistory[0].Reason)
+			},
+		},
+		{
+			name:        "Update With Application",
+			candidateID: candidate.ID,
+			jobID:       job.ID,
+			body: gin.H{
+				"application_status": db.ApplicationStatusSubmitted,
+				"application_doc": gin.H{
+					"key1":    "value1",
+					"answers": gin.H{"q1": "Jane Doe", "q3": "Doctorate"},
+				},
+			},
+			setupAuth: candidateAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				getApplication(store)
+				esClient.EXPECT().
+					GetJob(gomock.Eq(job.ID)).
+					Times(1).
+					Return(&job, nil)
+				arg := db.TransitionApplicationStatusTxParams{
+					Kind:        db.ApplicationKindCandidate,
+					CandidateID: candidate.ID,
+					EmployerID:  1,
+					JobDocID:    job.ID,
+					ActorRole:   db.RoleCandidate,
+					ActorID:     candidate.ID,
+					Status:      db.ApplicationStatusSubmitted,
+				}
+				store.EXPECT().
+					TransitionApplicationStatusTx(gomock.Any(), EqTransitionApplicationStatusTxParams(arg)).
+					Times(1).
+					Return(db.TransitionApplicationStatusTxResult{
+						CandidateApplication: withStatus(db.ApplicationStatusSubmitted),
+						Changed:              true,
+					}, nil)
+				esClient.EXPECT().
+					UpdateCandidateApplication(gomock.Any(), gomock.Eq(docID), gomock.Any()).
+					Times(1).
+					Return(nil)
+				store.EXPECT().
+					ListApplicationStatusHistory(gomock.Any(), gomock.Eq(historyArg)).
+					Times(1).
+					Return([]db.ApplicationStatusHistory{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchCandidateApplication(t, recorder.Body, withStatus(db.ApplicationStatusSubmitted))
+			},
+		},
+		{
+			name:        "DocumentOnly",
+			candidateID: candidate.ID,
+			jobID:       job.ID,
+			body: gin.H{
+				"application_doc": gin.H{
+					"answers": gin.H{"q1": "Jane Doe", "q3": "Doctorate"},
+				},
+			},
+			setupAuth: candidateAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				getApplication(store)
+				esClient.EXPECT().
+					GetJob(gomock.Eq(job.ID)).
+					Times(1).
+					Return(&job, nil)
+				store.EXPECT().
+					TransitionApplicationStatusTx(gomock.Any(), gomock.Any()).
+					Times(0)
+				esClient.EXPECT().
+					UpdateCandidateApplication(gomock.Any(), gomock.Eq(docID), gomock.Any()).
+					Times(1).
+					Return(nil)
+				store.EXPECT().
+					ListApplicationStatusHistory(gomock.Any(), gomock.Eq(historyArg)).
+					Times(1).
+					Return([]db.ApplicationStatusHistory{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchCandidateApplication(t, bytes.NewBuffer(recorder.Body.Bytes()), current)
+				require.Contains(t, recorder.Body.String(), `"status_history":[]`)
+			},
+		},
+		{
+			name:        "CandidateAcceptsOwnApplication",
+			candidateID: candidate.ID,
+			jobID:       job.ID,
+			body: gin.H{
+				"application_status": db.ApplicationStatusAccepted,
+			},
+			setupAuth: candidateAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				getApplication(store)
+				store.EXPECT().
+					TransitionApplicationStatusTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.TransitionApplicationStatusTxResult{}, fmt.Errorf("%w: candidate cannot accept", db.ErrStatusTransitionNotAllowed))
+				store.EXPECT().
+					ListApplicationStatusHistory(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:        "WithdrawWithoutReason",
+			candidateID: candidate.ID,
+			jobID:       job.ID,
+			body: gin.H{
+				"application_status": db.ApplicationStatusWithdrawn,
+			},
+			setupAuth: candidateAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				store.EXPECT().
+					TransitionApplicationStatusTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:        "InvalidTransition",
+			candidateID: candidate.ID,
+			jobID:       job.ID,
+			body: gin.H{
+				"application_status": db.ApplicationStatusAccepted,
 			},
+			setupAuth: employerAuth,
 			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				getApplication(store)
 				store.EXPECT().
-					GetEmployerApplicationsByCandidate(gomock.Any(), gomock.Eq(candidate.ID)).
+					TransitionApplicationStatusTx(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return([]db.EmployerApplication{application}, nil)
+					Return(db.TransitionApplicationStatusTxResult{}, fmt.Errorf("%w: rejected to accepted", db.ErrInvalidStatusTransition))
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, http.StatusConflict, recorder.Code)
 			},
 		},
 		{
-			name:        "NoAuthorization",
+			name:        "NotFoundLooksLikeOutsider",
 			candidateID: candidate.ID,
-			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			jobID:       job.ID,
+			body: gin.H{
+				"application_status": db.ApplicationStatusAccepted,
 			},
+			setupAuth: employerAuth,
 			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
 				store.EXPECT().
-					GetEmployerApplicationsByCandidate(gomock.Any(), gomock.Any()).
+					GetCandidateApplication(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CandidateApplication{}, db.ErrRecordNotFound)
+				store.EXPECT().
+					TransitionApplicationStatusTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, recorder.Code)
 			},
 		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			ctrl := gomock.NewController(t)
-			defer ctrl.Finish()
-
-			store := mockdb.NewMockStore(ctrl)
-			mockEsClient := mockes.NewMockESClient(ctrl)
-			tc.buildStubs(store, mockEsClient)
-
-			server := newTestServer(t, store, mockEsClient, nil)
-			recorder := httptest.NewRecorder()
-
-			url := fmt.Sprintf("/employer_applications/%d", tc.candidateID)
-			request, err := http.NewRequest(http.MethodGet, url, nil)
-			require.NoError(t, err)
-
-			tc.setupAuth(t, request, server.tokenMaker)
-			server.router.ServeHTTP(recorder, request)
-			tc.checkResponse(recorder)
-		})
-	}
-}
-
-func TestUpdateCandidateApplicationAPI(t *testing.T) {
-	user, _ := db.RandomUser(db.RoleCandidate)
-	candidate := db.RandomCandidate(user.Username)
-	docID := fmt.Sprintf("%d_%d", candidate.ID, 1)
-
-	testCases := []struct {
-		name          string
-		candidateID   int64
-		employerID    int64
-		body          gin.H
-		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
-		buildStubs    func(store *mockdb.MockStore, esClient *mockes.MockESClient)
-		checkResponse func(recorder *httptest.ResponseRecorder)
-	}{
 		{
-			name:        "OK",
+			name:        "EmployerEditsDocument",
 			candidateID: candidate.ID,
-			employerID:  1,
+			jobID:       job.ID,
 			body: gin.H{
-				"application_status": db.ApplicationStatusRejected,
-			},
-			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleCandidate, time.Minute, 1)
+				"application_doc": gin.H{"key1": "value1"},
 			},
+			setupAuth: employerAuth,
 			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
-				arg := db.UpdateCandidateApplicationParams{
-					CandidateID: candidate.ID,
-					EmployerID:  1,
-					ApplicationStatus: db.NullApplicationStatus{
-						ApplicationStatus: db.ApplicationStatusRejected,
-						Valid:             true,
-					},
-					ElasticsearchDocID: pgtype.Text{
-						String: docID,
-						Valid:  true,
-					},
-				}
-
-				store.EXPECT().
-					UpdateCandidateApplication(gomock.Any(), gomock.Eq(arg)).
-					Times(1).
-					Return(db.CandidateApplication{
-						CandidateID:        candidate.ID,
-						EmployerID:         1,
-						ElasticsearchDocID: docID,
-						ApplicationStatus:  db.ApplicationStatusRejected,
-					}, nil)
+				getApplication(store)
 				esClient.EXPECT().
 					UpdateCandidateApplication(gomock.Any(), gomock.Any(), gomock.Any()).
-					Times(0).
-					Return(nil)
+					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusOK, recorder.Code)
-				requireBodyMatchCandidateApplication(t, recorder.Body, db.CandidateApplication{
-					CandidateID:        candidate.ID,
-					EmployerID:         1,
-					ElasticsearchDocID: docID,
-					ApplicationStatus:  db.ApplicationStatusRejected,
-				})
+				require.Equal(t, http.StatusForbidden, recorder.Code)
 			},
 		},
 		{
-			name:        "Update With Application",
+			name:        "OtherEmployer",
 			candidateID: candidate.ID,
-			employerID:  1,
+			jobID:       job.ID,
 			body: gin.H{
-				"application_status": db.ApplicationStatusSubmitted,
-				"application_doc":    gin.H{"key1": "value1", "key2": 2},
+				"application_status": db.ApplicationStatusAccepted,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleCandidate, time.Minute, candidate.ID)
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, "employer", db.RoleEmployer, time.Minute, 2)
 			},
 			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
-				arg := db.UpdateCandidateApplicationParams{
-					CandidateID: candidate.ID,
-					EmployerID:  1,
-					ApplicationStatus: db.NullApplicationStatus{
-						ApplicationStatus: db.ApplicationStatusSubmitted,
-						Valid:             true,
-					},
-					ElasticsearchDocID: pgtype.Text{
-						String: docID,
-						Valid:  true,
-					},
-				}
+				getApplication(store)
 				store.EXPECT().
-					UpdateCandidateApplication(gomock.Any(), gomock.Eq(arg)).
-					Times(1).
-					Return(db.CandidateApplication{
-						CandidateID:        candidate.ID,
-						EmployerID:         1,
-						ElasticsearchDocID: docID,
-						ApplicationStatus:  db.ApplicationStatusSubmitted,
-					}, nil)
+					TransitionApplicationStatusTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:        "InvalidAnswers",
+			candidateID: candidate.ID,
+			jobID:       job.ID,
+			body: gin.H{
+				"application_doc": gin.H{
+					"answers": gin.H{"q1": "Jane Doe", "q3": "Doctorate", "q4": 42},
+				},
+			},
+			setupAuth: candidateAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				getApplication(store)
 				esClient.EXPECT().
-					UpdateCandidateApplication(gomock.Any(), gomock.Eq(docID), gomock.Any()).
+					GetJob(gomock.Eq(job.ID)).
 					Times(1).
-					Return(nil)
+					Return(&job, nil)
+				store.EXPECT().
+					TransitionApplicationStatusTx(gomock.Any(), gomock.Any()).
+					Times(0)
+				esClient.EXPECT().
+					UpdateCandidateApplication(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusOK, recorder.Code)
-				requireBodyMatchCandidateApplication(t, recorder.Body, db.CandidateApplication{
-					CandidateID:        candidate.ID,
-					EmployerID:         1,
-					ElasticsearchDocID: docID,
-					ApplicationStatus:  db.ApplicationStatusSubmitted,
-				})
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "answer must be yes or no")
 			},
 		},
 	}
@@ -576,7 +1468,7 @@ func TestUpdateCandidateApplicationAPI(t *testing.T) {
 			data, err := json.Marshal(tc.body)
 			require.NoError(t, err)
 
-			url := fmt.Sprintf("/candidate_applications/%d/%d", tc.candidateID, tc.employerID)
+			url := fmt.Sprintf("/candidate_applications/%d/%s", tc.candidateID, tc.jobID)
 			request, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(data))
 			require.NoError(t, err)
 
@@ -588,8 +1480,23 @@ func TestUpdateCandidateApplicationAPI(t *testing.T) {
 }
 
 func TestUpdateEmployerApplicationAPI(t *testing.T) {
-	user, _ := db.RandomUser(db.RoleEmployer)
 	app := db.RandomEmployerApplication(1)
+	withStatus := func(status db.ApplicationStatus) db.EmployerApplication {
+		application := app
+		application.ApplicationStatus = status
+		return application
+	}
+	historyArg := db.ListApplicationStatusHistoryParams{
+		ApplicationKind: string(db.ApplicationKindEmployer),
+		CandidateID:     pgtype.Int8{Int64: 1, Valid: true},
+		EmployerID:      pgtype.Int8{Int64: app.EmployerID, Valid: true},
+	}
+	candidateAuth := func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+		middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, "candidate", db.RoleCandidate, time.Minute, 1)
+	}
+	employerAuth := func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+		middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, "employer", db.RoleEmployer, time.Minute, app.EmployerID)
+	}
 
 	testCases := []struct {
 		name          string
@@ -601,87 +1508,138 @@ func TestUpdateEmployerApplicationAPI(t *testing.T) {
 		checkResponse func(recorder *httptest.ResponseRecorder)
 	}{
 		{
-			name:        "OK",
+			name:        "CandidateAccepts",
 			candidateID: 1,
 			employerID:  app.EmployerID,
 			body: gin.H{
-				"application_status": db.ApplicationStatusRejected,
-			},
-			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleEmployer, time.Minute, 1)
+				"application_status": db.ApplicationStatusAccepted,
 			},
+			setupAuth: candidateAuth,
 			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
-				arg := db.UpdateEmployerApplicationParams{
+				arg := db.TransitionApplicationStatusTxParams{
+					Kind:        db.ApplicationKindEmployer,
 					CandidateID: 1,
 					EmployerID:  app.EmployerID,
-					ApplicationStatus: db.NullApplicationStatus{
-						ApplicationStatus: db.ApplicationStatusRejected,
-						Valid:             true,
-					},
+					ActorRole:   db.RoleCandidate,
+					ActorID:     1,
+					Status:      db.ApplicationStatusAccepted,
 				}
-
 				store.EXPECT().
-					UpdateEmployerApplication(gomock.Any(), gomock.Eq(arg)).
+					TransitionApplicationStatusTx(gomock.Any(), EqTransitionApplicationStatusTxParams(arg)).
 					Times(1).
-					Return(db.EmployerApplication{
-						CandidateID:       1,
-						EmployerID:        app.EmployerID,
-						Message:           app.Message,
-						ApplicationStatus: db.ApplicationStatusRejected,
+					Return(db.TransitionApplicationStatusTxResult{
+						EmployerApplication: withStatus(db.ApplicationStatusAccepted),
+						Changed:             true,
 					}, nil)
+				store.EXPECT().
+					ListApplicationStatusHistory(gomock.Any(), gomock.Eq(historyArg)).
+					Times(1).
+					Return([]db.ApplicationStatusHistory{}, nil)
 				esClient.EXPECT().
 					UpdateEmployerApplication(gomock.Any(), gomock.Any(), gomock.Any()).
-					Times(0).
-					Return(nil)
+					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
-				requireBodyMatchEmployerApplication(t, recorder.Body, db.EmployerApplication{
-					CandidateID:       1,
-					EmployerID:        app.EmployerID,
-					Message:           app.Message,
-					ApplicationStatus: db.ApplicationStatusRejected,
-				})
+				requireBodyMatchEmployerApplication(t, recorder.Body, withStatus(db.ApplicationStatusAccepted))
 			},
 		},
 		{
-			name:        "Update With Application",
-			employerID:  app.EmployerID,
+			name:        "EmployerSubmits",
 			candidateID: 1,
+			employerID:  app.EmployerID,
 			body: gin.H{
 				"application_status": db.ApplicationStatusSubmitted,
-				"application_doc":    gin.H{"key1": "value1", "key2": 2},
-			},
-			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleCandidate, time.Minute, app.EmployerID)
 			},
+			setupAuth: employerAuth,
 			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
-				arg := db.UpdateEmployerApplicationParams{
+				arg := db.TransitionApplicationStatusTxParams{
+					Kind:        db.ApplicationKindEmployer,
 					CandidateID: 1,
 					EmployerID:  app.EmployerID,
-					ApplicationStatus: db.NullApplicationStatus{
-						ApplicationStatus: db.ApplicationStatusSubmitted,
-						Valid:             true,
-					},
+					ActorRole:   db.RoleEmployer,
+					ActorID:     app.EmployerID,
+					Status:      db.ApplicationStatusSubmitted,
 				}
 				store.EXPECT().
-					UpdateEmployerApplication(gomock.Any(), gomock.Eq(arg)).
+					TransitionApplicationStatusTx(gomock.Any(), EqTransitionApplicationStatusTxParams(arg)).
 					Times(1).
-					Return(db.EmployerApplication{
-						CandidateID:       1,
-						EmployerID:        app.EmployerID,
-						Message:           app.Message,
-						ApplicationStatus: db.ApplicationStatusSubmitted,
+					Return(db.TransitionApplicationStatusTxResult{
+						EmployerApplication: withStatus(db.ApplicationStatusSubmitted),
+						Changed:             true,
 					}, nil)
+				store.EXPECT().
+					ListApplicationStatusHistory(gomock.Any(), gomock.Eq(historyArg)).
+					Times(1).
+					Return([]db.ApplicationStatusHistory{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchEmployerApplication(t, recorder.Body, withStatus(db.ApplicationStatusSubmitted))
+			},
+		},
+		{
+			name:        "EmployerAcceptsOwnOffer",
+			candidateID: 1,
+			employerID:  app.EmployerID,
+			body: gin.H{
+				"application_status": db.ApplicationStatusAccepted,
+			},
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				store.EXPECT().
+					TransitionApplicationStatusTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.TransitionApplicationStatusTxResult{}, fmt.Errorf("%w: employer cannot accept", db.ErrStatusTransitionNotAllowed))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:        "NoStatusChange",
+			candidateID: 1,
+			employerID:  app.EmployerID,
+			body:        gin.H{},
+			setupAuth:   employerAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				store.EXPECT().
+					TransitionApplicationStatusTx(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					GetEmployerApplication(gomock.Any(), gomock.Eq(db.GetEmployerApplicationParams{
+						EmployerID:  app.EmployerID,
+						CandidateID: 1,
+					})).
+					Times(1).
+					Return(app, nil)
+				store.EXPECT().
+					ListApplicationStatusHistory(gomock.Any(), gomock.Eq(historyArg)).
+					Times(1).
+					Return([]db.ApplicationStatusHistory{}, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
-				requireBodyMatchEmployerApplication(t, recorder.Body, db.EmployerApplication{
-					CandidateID:       1,
-					EmployerID:        app.EmployerID,
-					Message:           app.Message,
-					ApplicationStatus: db.ApplicationStatusSubmitted,
-				})
+				requireBodyMatchEmployerApplication(t, recorder.Body, app)
+			},
+		},
+		{
+			name:        "RoleMismatch",
+			employerID:  app.EmployerID,
+			candidateID: 1,
+			body: gin.H{
+				"application_status": db.ApplicationStatusSubmitted,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, "candidate", db.RoleCandidate, time.Minute, app.EmployerID)
+			},
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				store.EXPECT().
+					TransitionApplicationStatusTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
 			},
 		},
 	}
@@ -716,83 +1674,143 @@ func TestUpdateEmployerApplicationAPI(t *testing.T) {
 func TestDeleteCandidateApplicationAPI(t *testing.T) {
 	user, _ := db.RandomUser(db.RoleCandidate)
 	candidate := db.RandomCandidate(user.Username)
-	docID := fmt.Sprintf("%d_%d", candidate.ID, 1)
+	job := elasticsearch.RandomJob(1)
+	current := db.CandidateApplication{
+		CandidateID:        candidate.ID,
+		EmployerID:         1,
+		ElasticsearchDocID: elasticsearch.CandidateApplicationDocID(candidate.ID, job.ID),
+		JobDocID:           job.ID,
+		ApplicationStatus:  db.ApplicationStatusSubmitted,
+	}
+	// Applications made before they were keyed by job keep their old
+	// document ID.
+	legacy := current
+	legacy.ElasticsearchDocID = fmt.Sprintf("%d_%d", candidate.ID, 1)
+	getApplication := func(store *mockdb.MockStore, application db.CandidateApplication) {
+		store.EXPECT().
+			GetCandidateApplication(gomock.Any(), gomock.Eq(db.GetCandidateApplicationParams{
+				CandidateID: candidate.ID,
+				JobDocID:    job.ID,
+			})).
+			Times(1).
+			Return(application, nil)
+	}
+	deleted := func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor, docID string) {
+		arg := db.DeleteApplicationTxParams{
+			DeleteCandidateApplicationParams: db.DeleteCandidateApplicationParams{
+				CandidateID: candidate.ID,
+				JobDocID:    job.ID,
+			},
+			IsEmployer: false,
+			DocID:      docID,
+		}
+		store.EXPECT().
+			DeleteApplicationTx(gomock.Any(), EqDeleteApplicationTxParams(arg)).
+			Times(1).
+			Return(nil)
+		taskPayload := &worker.PayloadDeleteApplication{DocID: docID}
+		taskDistributor.EXPECT().
+			DistributeTaskDeleteCandidateApplication(gomock.Any(), taskPayload, gomock.Any()).
+			Times(1).
+			Return(nil)
+	}
 
 	testCases := []struct {
 		name          string
-		body          gin.H
+		candidateID   int64
+		jobID         string
 		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
 		buildStubs    func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor)
 		checkResponse func(recorder *httptest.ResponseRecorder)
 	}{
 		{
-			name: "OK",
-			body: gin.H{
-				"candidate_id": candidate.ID,
-				"employer_id":  1,
+			name:        "OK",
+			candidateID: candidate.ID,
+			jobID:       job.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleCandidate, time.Minute, candidate.ID)
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				getApplication(store, current)
+				deleted(store, taskDistributor, current.ElasticsearchDocID)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
 			},
+		},
+		{
+			name:        "LegacyDocumentID",
+			candidateID: candidate.ID,
+			jobID:       job.ID,
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleCandidate, time.Minute, candidate.ID)
 			},
 			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
-				arg := db.DeleteApplicationTxParams{
-					DeleteCandidateApplicationParams: db.DeleteCandidateApplicationParams{
-						CandidateID: candidate.ID,
-						EmployerID:  1,
-					},
-					IsEmployer: false,
-					DocID:      docID,
-				}
-				store.EXPECT().
-					DeleteApplicationTx(gomock.Any(), EqDeleteApplicationTxParams(arg)).
-					Times(1).
-					Return(nil)
-				taskPayload := &worker.PayloadDeleteApplication{DocID: docID}
-				taskDistributor.EXPECT().
-					DistributeTaskDeleteCandidateApplication(gomock.Any(), taskPayload, gomock.Any()).
-					Times(1).
-					Return(nil)
+				getApplication(store, legacy)
+				deleted(store, taskDistributor, legacy.ElasticsearchDocID)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
 			},
 		},
 		{
-			name: "UnauthorizedUserRole",
-			body: gin.H{
-				"candidate_id": candidate.ID,
-				"employer_id":  1,
+			name:        "NotFound",
+			candidateID: candidate.ID,
+			jobID:       job.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleCandidate, time.Minute, candidate.ID)
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetCandidateApplication(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CandidateApplication{}, db.ErrRecordNotFound)
+				store.EXPECT().
+					DeleteApplicationTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
 			},
+		},
+		{
+			name:        "UnauthorizedUserRole",
+			candidateID: candidate.ID,
+			jobID:       job.ID,
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleEmployer, time.Minute, candidate.ID)
 			},
 			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				getApplication(store, current)
+				store.EXPECT().
+					DeleteApplicationTx(gomock.Any(), gomock.Any()).
+					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, recorder.Code)
 			},
 		},
 		{
-			name: "InvalidJSONBody",
-			body: gin.H{
-				"candidate_id": candidate.ID,
-				"employer_id":  "invalid_id", // Passing a string instead of an int64
-			},
+			name:        "EmployerCannotDelete",
+			candidateID: candidate.ID,
+			jobID:       job.ID,
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleCandidate, time.Minute, candidate.ID)
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, "employer", db.RoleEmployer, time.Minute, 1)
 			},
 			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				getApplication(store, current)
+				store.EXPECT().
+					DeleteApplicationTx(gomock.Any(), gomock.Any()).
+					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Equal(t, http.StatusForbidden, recorder.Code)
 			},
 		},
 		{
-			name: "InvalidIDs",
-			body: gin.H{
-				"candidate_id": 0,  // Invalid ID
-				"employer_id":  -1, // Invalid ID
-			},
+			name:        "InvalidIDs",
+			candidateID: 0,
+			jobID:       job.ID,
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleCandidate, time.Minute, candidate.ID)
 			},
@@ -803,15 +1821,14 @@ func TestDeleteCandidateApplicationAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "InternalServerError",
-			body: gin.H{
-				"candidate_id": candidate.ID,
-				"employer_id":  1,
-			},
+			name:        "InternalServerError",
+			candidateID: candidate.ID,
+			jobID:       job.ID,
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleCandidate, time.Minute, candidate.ID)
 			},
 			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				getApplication(store, current)
 				store.EXPECT().
 					DeleteApplicationTx(gomock.Any(), gomock.Any()).
 					Times(1).
@@ -839,9 +1856,8 @@ func TestDeleteCandidateApplicationAPI(t *testing.T) {
 
 			server := newTestServer(t, store, nil, taskDistributor)
 			recorder := httptest.NewRecorder()
-			data, _ := json.Marshal(tc.body)
-			url := fmt.Sprintf("/candidate_applications/%s", candidate.Username)
-			request, err := http.NewRequest(http.MethodDelete, url, bytes.NewReader(data))
+			url := fmt.Sprintf("/candidate_applications/%d/%s", tc.candidateID, tc.jobID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
 			require.NoError(t, err)
 
 			tc.setupAuth(t, request, server.tokenMaker)
@@ -857,20 +1873,13 @@ func TestDeleteEmployerApplicationAPI(t *testing.T) {
 	docID := fmt.Sprintf("%d_%d", employer.ID, 1)
 
 	testCases := []struct {
-		name             string
-		employerUsername string
-		body             gin.H
-		setupAuth        func(t *testing.T, request *http.Request, tokenMaker token.Maker)
-		buildStubs       func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor)
-		checkResponse    func(recorder *httptest.ResponseRecorder)
+		name          string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor)
+		checkResponse func(recorder *httptest.ResponseRecorder)
 	}{
 		{
-			name:             "OK",
-			employerUsername: user.Username,
-			body: gin.H{
-				"candidate_id": 1,
-				"employer_id":  employer.ID,
-			},
+			name: "OK",
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleEmployer, time.Minute, employer.ID)
 			},
@@ -897,6 +1906,20 @@ func TestDeleteEmployerApplicationAPI(t *testing.T) {
 				require.Equal(t, http.StatusOK, recorder.Code)
 			},
 		},
+		{
+			name: "CandidateCannotDelete",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, "candidate", db.RoleCandidate, time.Minute, 1)
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					DeleteApplicationTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -912,9 +1935,8 @@ func TestDeleteEmployerApplicationAPI(t *testing.T) {
 
 			server := newTestServer(t, store, nil, taskDistributor)
 			recorder := httptest.NewRecorder()
-			data, _ := json.Marshal(tc.body)
-			url := fmt.Sprintf("/employer_applications/%s", tc.employerUsername)
-			request, err := http.NewRequest(http.MethodDelete, url, bytes.NewReader(data))
+			url := fmt.Sprintf("/employer_applications/%d/%d", employer.ID, 1)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
 			require.NoError(t, err)
 
 			tc.setupAuth(t, request, server.tokenMaker)
@@ -961,7 +1983,8 @@ func (expected eqCreateCandidateApplicationTxParamsMatcher) Matches(x interface{
 	if !ok {
 		return false
 	}
-	if !reflect.DeepEqual(expected.arg.CreateCandidateApplicationParams, actualArg.CreateCandidateApplicationParams) {
+	if !reflect.DeepEqual(expected.arg.CreateCandidateApplicationParams, actualArg.CreateCandidateApplicationParams) ||
+		expected.arg.MaxApplications != actualArg.MaxApplications {
 		return false
 	}
 	err := actualArg.AfterCreate(expected.arg.CreateCandidateApplicationParams.ElasticsearchDocID, expected.arg.AppDoc)
@@ -1000,3 +2023,24 @@ func (expected eqDeleteApplicationTxParamsMatcher) String() string {
 func EqDeleteApplicationTxParams(arg db.DeleteApplicationTxParams) gomock.Matcher {
 	return eqDeleteApplicationTxParamsMatcher{arg}
 }
+
+type eqTransitionApplicationStatusTxParamsMatcher struct {
+	arg db.TransitionApplicationStatusTxParams
+}
+
+func (expected eqTransitionApplicationStatusTxParamsMatcher) Matches(x interface{}) bool {
+	actualArg, ok := x.(db.TransitionApplicationStatusTxParams)
+	if !ok || actualArg.AfterUpdate == nil {
+		return false
+	}
+	actualArg.AfterUpdate = nil
+	return reflect.DeepEqual(expected.arg, actualArg)
+}
+
+func (expected eqTransitionApplicationStatusTxParamsMatcher) String() string {
+	return fmt.Sprintf("matches arg %v", expected.arg)
+}
+
+func EqTransitionApplicationStatusTxParams(arg db.TransitionApplicationStatusTxParams) gomock.Matcher {
+	return eqTransitionApplicationStatusTxParamsMatcher{arg}
+}
@@ -0,0 +1,246 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	"github.com/hankimmy/PtmrBackend/pkg/middleware"
+	"github.com/hankimmy/PtmrBackend/pkg/service"
+	"github.com/hankimmy/PtmrBackend/pkg/token"
+)
+
+var (
+	// errNotParty means the caller has nothing to do with the resource.
+	errNotParty = errors.New("account doesn't belong to the authenticated user")
+	// errActionNotAllowed means the caller is a party to the application but
+	// the action belongs to the other party.
+	errActionNotAllowed = errors.New("action is not allowed for this account")
+)
+
+// applicationResource is what a request acts on. For a single application
+// both IDs are set. Listings only set the ID of the account whose inbox is
+// listed, and candidate actions such as swipes only set the candidate ID.
+type applicationResource struct {
+	Kind        db.ApplicationKind
+	CandidateID int64
+	EmployerID  int64
+}
+
+// partyID returns the resource's ID for the given role, or zero when the
+// role has no side in it.
+func (resource applicationResource) partyID(role db.Role) int64 {
+	switch role {
+	case db.RoleCandidate:
+		return resource.CandidateID
+	case db.RoleEmployer:
+		return resource.EmployerID
+	}
+	return 0
+}
+
+// isParty reports whether the caller is on either side of the resource.
+func (resource applicationResource) isParty(payload *token.Payload) bool {
+	id := resource.partyID(payload.Role)
+	return id != 0 && id == payload.RoleID
+}
+
+// policy decides whether the caller may perform one action on a resource.
+// It returns nil when the action is allowed.
+type policy func(payload *token.Payload, resource applicationResource) error
+
+// createApplicationPolicy lets the sender create an application in their own
+// name only.
+func createApplicationPolicy(payload *token.Payload, resource applicationResource) error {
+	if payload.Role != resource.Kind.Sender() || !resource.isParty(payload) {
+		return errNotParty
+	}
+	return nil
+}
+
+// listApplicationsPolicy lets an account list the applications it received.
+func listApplicationsPolicy(payload *token.Payload, resource applicationResource) error {
+	if payload.Role != resource.Kind.Recipient() || !resource.isParty(payload) {
+		return errNotParty
+	}
+	return nil
+}
+
+// updateApplicationPolicy lets either party update an application. The
+// status state machine decides which status changes each of them can make.
+func updateApplicationPolicy(payload *token.Payload, resource applicationResource) error {
+	if !resource.isParty(payload) {
+		return errNotParty
+	}
+	return nil
+}
+
+// editApplicationDocumentPolicy only lets the candidate change the answers in
+// their application.
+func editApplicationDocumentPolicy(payload *token.Payload, resource applicationResource) error {
+	if !resource.isParty(payload) {
+		return errNotParty
+	}
+	if resource.Kind != db.ApplicationKindCandidate || payload.Role != db.RoleCandidate {
+		return errActionNotAllowed
+	}
+	return nil
+}
+
+// decideApplicationPolicy lets the recipient accept or reject an application.
+func decideApplicationPolicy(payload *token.Payload, resource applicationResource) error {
+	if !resource.isParty(payload) {
+		return errNotParty
+	}
+	if payload.Role != resource.Kind.Recipient() {
+		return errActionNotAllowed
+	}
+	return nil
+}
+
+// deleteApplicationPolicy lets the sender take back an application.
+func deleteApplicationPolicy(payload *token.Payload, resource applicationResource) error {
+	if !resource.isParty(payload) {
+		return errNotParty
+	}
+	if payload.Role != resource.Kind.Sender() {
+		return errActionNotAllowed
+	}
+	return nil
+}
+
+// withdrawApplicationPolicy lets the candidate withdraw their application to
+// a job.
+func withdrawApplicationPolicy(payload *token.Payload, resource applicationResource) error {
+	if !resource.isParty(payload) {
+		return errNotParty
+	}
+	if resource.Kind != db.ApplicationKindCandidate || payload.Role != db.RoleCandidate {
+		return errActionNotAllowed
+	}
+	return nil
+}
+
+// candidateActionPolicy covers actions a candidate takes for themselves,
+// like swiping on a job or claiming a shift.
+func candidateActionPolicy(payload *token.Payload, resource applicationResource) error {
+	if payload.Role != db.RoleCandidate || !resource.isParty(payload) {
+		return errNotParty
+	}
+	return nil
+}
+
+// conversationPolicy lets either party of a conversation read it and write
+// to it. Whether they may message each other at all is checked when a
+// message is sent.
+func conversationPolicy(payload *token.Payload, resource applicationResource) error {
+	if !resource.isParty(payload) {
+		return errNotParty
+	}
+	return nil
+}
+
+// viewInterviewPolicy lets either party of an interview see it.
+func viewInterviewPolicy(payload *token.Payload, resource applicationResource) error {
+	if !resource.isParty(payload) {
+		return errNotParty
+	}
+	return nil
+}
+
+// organizeInterviewPolicy lets the employer propose, reschedule and cancel
+// interviews.
+func organizeInterviewPolicy(payload *token.Payload, resource applicationResource) error {
+	if !resource.isParty(payload) {
+		return errNotParty
+	}
+	if payload.Role != db.RoleEmployer {
+		return errActionNotAllowed
+	}
+	return nil
+}
+
+// pickInterviewSlotPolicy lets the candidate pick one of the proposed
+// interview slots.
+func pickInterviewSlotPolicy(payload *token.Payload, resource applicationResource) error {
+	if !resource.isParty(payload) {
+		return errNotParty
+	}
+	if payload.Role != db.RoleCandidate {
+		return errActionNotAllowed
+	}
+	return nil
+}
+
+// applicationURI identifies a single application in a route. Employer
+// applications are addressed by candidate and employer, candidate
+// applications by candidate and job.
+type applicationURI struct {
+	CandidateID int64  `uri:"candidate_id" json:"-" binding:"required,min=1"`
+	EmployerID  int64  `uri:"employer_id" json:"-" binding:"omitempty,min=1"`
+	JobID       string `uri:"job_id" json:"-"`
+}
+
+// resolveApplication builds the resource for the application in the route.
+// A candidate application's employer isn't part of its key, so the
+// application is loaded to find it and returned for the handler to use. A
+// missing application is only reported as such to the candidate in the
+// route; everyone else gets the response an outsider gets, so it can't be
+// used to find out whether the candidate applied to a job. On failure it
+// writes the response itself and reports false.
+func (server *Server) resolveApplication(ctx *gin.Context, kind db.ApplicationKind, uri applicationURI) (applicationResource, db.CandidateApplication, bool) {
+	resource := applicationResource{Kind: kind, CandidateID: uri.CandidateID, EmployerID: uri.EmployerID}
+	if kind == db.ApplicationKindEmployer {
+		if uri.EmployerID == 0 {
+			ctx.JSON(http.StatusBadRequest, service.ErrorResponse(errors.New("employer_id is required")))
+			return applicationResource{}, db.CandidateApplication{}, false
+		}
+		return resource, db.CandidateApplication{}, true
+	}
+
+	if uri.JobID == "" {
+		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(errors.New("job_id is required")))
+		return applicationResource{}, db.CandidateApplication{}, false
+	}
+	authPayload := ctx.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload)
+	isCandidate := authPayload.Role == db.RoleCandidate && authPayload.RoleID == uri.CandidateID
+	if authPayload.Role == db.RoleCandidate && !isCandidate {
+		ctx.JSON(http.StatusUnauthorized, service.ErrorResponse(errNotParty))
+		return applicationResource{}, db.CandidateApplication{}, false
+	}
+	application, err := server.store.GetCandidateApplication(ctx, db.GetCandidateApplicationParams{
+		CandidateID: uri.CandidateID,
+		JobDocID:    uri.JobID,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			if !isCandidate {
+				ctx.JSON(http.StatusUnauthorized, service.ErrorResponse(errNotParty))
+				return applicationResource{}, db.CandidateApplication{}, false
+			}
+			ctx.JSON(http.StatusNotFound, service.ErrorResponse(err))
+			return applicationResource{}, db.CandidateApplication{}, false
+		}
+		ctx.JSON(http.StatusInternalServerError, service.ErrorResponse(err))
+		return applicationResource{}, db.CandidateApplication{}, false
+	}
+	resource.EmployerID = application.EmployerID
+	return resource, application, true
+}
+
+// authorize applies a policy to the caller of the request. On failure it
+// writes the response itself, 401 for callers outside the resource and 403
+// for parties trying the other side's action, and reports false.
+func authorize(ctx *gin.Context, allowed policy, resource applicationResource) (*token.Payload, bool) {
+	authPayload := ctx.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload)
+	if err := allowed(authPayload, resource); err != nil {
+		status := http.StatusUnauthorized
+		if errors.Is(err, errActionNotAllowed) {
+			status = http.StatusForbidden
+		}
+		ctx.JSON(status, service.ErrorResponse(err))
+		return nil, false
+	}
+	return authPayload, true
+}
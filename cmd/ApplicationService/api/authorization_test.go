@@ -0,0 +1,234 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/hankimmy/PtmrBackend/pkg/db/mock"
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	"github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
+	mockes "github.com/hankimmy/PtmrBackend/pkg/elasticsearch/mock"
+	"github.com/hankimmy/PtmrBackend/pkg/middleware"
+	"github.com/hankimmy/PtmrBackend/pkg/token"
+	mockwk "github.com/hankimmy/PtmrBackend/pkg/worker/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplicationPolicies(t *testing.T) {
+	candidate := &token.Payload{Role: db.RoleCandidate, RoleID: 1}
+	employer := &token.Payload{Role: db.RoleEmployer, RoleID: 2}
+	otherCandidate := &token.Payload{Role: db.RoleCandidate, RoleID: 3}
+	otherEmployer := &token.Payload{Role: db.RoleEmployer, RoleID: 3}
+	// An employer whose ID happens to match the candidate's.
+	employerWithCandidateID := &token.Payload{Role: db.RoleEmployer, RoleID: 1}
+	admin := &token.Payload{Role: db.RoleAdmin, RoleID: 1}
+
+	candidateApp := applicationResource{Kind: db.ApplicationKindCandidate, CandidateID: 1, EmployerID: 2}
+	employerApp := applicationResource{Kind: db.ApplicationKindEmployer, CandidateID: 1, EmployerID: 2}
+	employerInbox := applicationResource{Kind: db.ApplicationKindCandidate, EmployerID: 2}
+	candidateInbox := applicationResource{Kind: db.ApplicationKindEmployer, CandidateID: 1}
+
+	testCases := []struct {
+		name     string
+		policy   policy
+		payload  *token.Payload
+		resource applicationResource
+		err      error
+	}{
+		{"CreateCandidateApplication", createApplicationPolicy, candidate, candidateApp, nil},
+		{"CreateCandidateApplicationAsOtherCandidate", createApplicationPolicy, otherCandidate, candidateApp, errNotParty},
+		{"CreateCandidateApplicationAsEmployer", createApplicationPolicy, employer, candidateApp, errNotParty},
+		{"CreateEmployerApplication", createApplicationPolicy, employer, employerApp, nil},
+		{"CreateEmployerApplicationAsOtherEmployer", createApplicationPolicy, otherEmployer, employerApp, errNotParty},
+		{"CreateEmployerApplicationAsCandidate", createApplicationPolicy, candidate, employerApp, errNotParty},
+
+		{"ListEmployerInbox", listApplicationsPolicy, employer, employerInbox, nil},
+		{"ListEmployerInboxAsOtherEmployer", listApplicationsPolicy, otherEmployer, employerInbox, errNotParty},
+		{"ListEmployerInboxAsCandidate", listApplicationsPolicy, candidate, employerInbox, errNotParty},
+		{"ListCandidateInbox", listApplicationsPolicy, candidate, candidateInbox, nil},
+		{"ListCandidateInboxAsOtherCandidate", listApplicationsPolicy, otherCandidate, candidateInbox, errNotParty},
+		{"ListCandidateInboxAsEmployerWithSameID", listApplicationsPolicy, employerWithCandidateID, candidateInbox, errNotParty},
+
+		{"UpdateAsCandidate", updateApplicationPolicy, candidate, candidateApp, nil},
+		{"UpdateAsEmployer", updateApplicationPolicy, employer, candidateApp, nil},
+		{"UpdateAsOtherCandidate", updateApplicationPolicy, otherCandidate, candidateApp, errNotParty},
+		{"UpdateAsOtherEmployer", updateApplicationPolicy, otherEmployer, employerApp, errNotParty},
+		{"UpdateAsEmployerWithCandidateID", updateApplicationPolicy, employerWithCandidateID, candidateApp, errNotParty},
+		{"UpdateAsAdmin", updateApplicationPolicy, admin, candidateApp, errNotParty},
+
+		{"EditDocumentAsCandidate", editApplicationDocumentPolicy, candidate, candidateApp, nil},
+		{"EditDocumentAsEmployer", editApplicationDocumentPolicy, employer, candidateApp, errActionNotAllowed},
+		{"EditDocumentAsOtherCandidate", editApplicationDocumentPolicy, otherCandidate, candidateApp, errNotParty},
+
+		{"DecideCandidateApplication", decideApplicationPolicy, employer, candidateApp, nil},
+		{"DecideOwnCandidateApplication", decideApplicationPolicy, candidate, candidateApp, errActionNotAllowed},
+		{"DecideCandidateApplicationAsOtherEmployer", decideApplicationPolicy, otherEmployer, candidateApp, errNotParty},
+		{"DecideEmployerApplication", decideApplicationPolicy, candidate, employerApp, nil},
+		{"DecideOwnEmployerApplication", decideApplicationPolicy, employer, employerApp, errActionNotAllowed},
+		{"DecideEmployerApplicationAsOtherCandidate", decideApplicationPolicy, otherCandidate, employerApp, errNotParty},
+
+		{"DeleteCandidateApplication", deleteApplicationPolicy, candidate, candidateApp, nil},
+		{"DeleteCandidateApplicationAsEmployer", deleteApplicationPolicy, employer, candidateApp, errActionNotAllowed},
+		{"DeleteCandidateApplicationAsOtherCandidate", deleteApplicationPolicy, otherCandidate, candidateApp, errNotParty},
+		{"DeleteEmployerApplication", deleteApplicationPolicy, employer, employerApp, nil},
+		{"DeleteEmployerApplicationAsCandidate", deleteApplicationPolicy, candidate, employerApp, errActionNotAllowed},
+		{"DeleteEmployerApplicationAsOtherEmployer", deleteApplicationPolicy, otherEmployer, employerApp, errNotParty},
+
+		{"WithdrawCandidateApplication", withdrawApplicationPolicy, candidate, candidateApp, nil},
+		{"WithdrawCandidateApplicationAsEmployer", withdrawApplicationPolicy, employer, candidateApp, errActionNotAllowed},
+		{"WithdrawCandidateApplicationAsOtherCandidate", withdrawApplicationPolicy, otherCandidate, candidateApp, errNotParty},
+		{"WithdrawEmployerApplication", withdrawApplicationPolicy, employer, employerApp, errActionNotAllowed},
+
+		{"CandidateAction", candidateActionPolicy, candidate, applicationResource{CandidateID: 1}, nil},
+		{"CandidateActionAsOtherCandidate", candidateActionPolicy, otherCandidate, applicationResource{CandidateID: 1}, errNotParty},
+		{"CandidateActionAsEmployerWithSameID", candidateActionPolicy, employerWithCandidateID, applicationResource{CandidateID: 1}, errNotParty},
+
+		{"ConversationAsCandidate", conversationPolicy, candidate, applicationResource{CandidateID: 1, EmployerID: 2}, nil},
+		{"ConversationAsEmployer", conversationPolicy, employer, applicationResource{CandidateID: 1, EmployerID: 2}, nil},
+		{"ConversationAsOtherCandidate", conversationPolicy, otherCandidate, applicationResource{CandidateID: 1, EmployerID: 2}, errNotParty},
+		{"ConversationAsOtherEmployer", conversationPolicy, otherEmployer, applicationResource{CandidateID: 1, EmployerID: 2}, errNotParty},
+		{"ConversationAsEmployerWithCandidateID", conversationPolicy, employerWithCandidateID, applicationResource{CandidateID: 1, EmployerID: 2}, errNotParty},
+		{"ConversationAsAdmin", conversationPolicy, admin, applicationResource{CandidateID: 1, EmployerID: 2}, errNotParty},
+
+		{"ViewInterviewAsCandidate", viewInterviewPolicy, candidate, candidateApp, nil},
+		{"ViewInterviewAsEmployer", viewInterviewPolicy, employer, candidateApp, nil},
+		{"ViewInterviewAsOtherEmployer", viewInterviewPolicy, otherEmployer, candidateApp, errNotParty},
+		{"OrganizeInterview", organizeInterviewPolicy, employer, candidateApp, nil},
+		{"OrganizeInterviewAsCandidate", organizeInterviewPolicy, candidate, candidateApp, errActionNotAllowed},
+		{"OrganizeInterviewAsOtherEmployer", organizeInterviewPolicy, otherEmployer, candidateApp, errNotParty},
+		{"PickInterviewSlot", pickInterviewSlotPolicy, candidate, candidateApp, nil},
+		{"PickInterviewSlotAsEmployer", pickInterviewSlotPolicy, employer, candidateApp, errActionNotAllowed},
+		{"PickInterviewSlotAsOtherCandidate", pickInterviewSlotPolicy, otherCandidate, candidateApp, errNotParty},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.policy(tc.payload, tc.resource)
+			if tc.err == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+// TestCrossTenantAccess calls every route as an account that has no access
+// to the resource. None of them may reach the store, Elasticsearch or the
+// task queue.
+func TestCrossTenantAccess(t *testing.T) {
+	candidateApplication := gin.H{
+		"candidate_id":       1,
+		"employer_id":        2,
+		"application_status": db.ApplicationStatusSubmitted,
+		"application_doc":    gin.H{},
+		"job_doc_id":         "job",
+	}
+	employerApplication := gin.H{
+		"candidate_id":       1,
+		"employer_id":        2,
+		"application_status": db.ApplicationStatusSubmitted,
+		"application_doc":    gin.H{"message": "hello"},
+	}
+
+	testCases := []struct {
+		name   string
+		method string
+		url    string
+		body   gin.H
+		role   db.Role
+		roleID int64
+		status int
+	}{
+		{"CreateCandidateApplicationForOtherCandidate", http.MethodPost, "/candidate_applications", candidateApplication, db.RoleCandidate, 3, http.StatusUnauthorized},
+		{"CreateCandidateApplicationAsEmployer", http.MethodPost, "/candidate_applications", candidateApplication, db.RoleEmployer, 2, http.StatusUnauthorized},
+		{"ListOtherEmployersApplications", http.MethodGet, "/candidate_applications/2", nil, db.RoleEmployer, 3, http.StatusUnauthorized},
+		{"ListEmployerApplicationsAsCandidateWithSameID", http.MethodGet, "/candidate_applications/2", nil, db.RoleCandidate, 2, http.StatusUnauthorized},
+		{"UpdateOtherCandidatesApplication", http.MethodPatch, "/candidate_applications/1/job", gin.H{}, db.RoleCandidate, 3, http.StatusUnauthorized},
+		{"UpdateCandidateApplicationAsOtherEmployer", http.MethodPatch, "/candidate_applications/1/job", gin.H{}, db.RoleEmployer, 3, http.StatusUnauthorized},
+		{"AcceptCandidateApplicationAsOtherEmployer", http.MethodPatch, "/candidate_applications/1/job/accept", nil, db.RoleEmployer, 3, http.StatusUnauthorized},
+		{"RejectCandidateApplicationAsOtherEmployer", http.MethodPatch, "/candidate_applications/1/job/reject", nil, db.RoleEmployer, 3, http.StatusUnauthorized},
+		{"DeleteOtherCandidatesApplication", http.MethodDelete, "/candidate_applications/1/job", nil, db.RoleCandidate, 3, http.StatusUnauthorized},
+		{"DeleteCandidateApplicationAsOtherEmployer", http.MethodDelete, "/candidate_applications/1/job", nil, db.RoleEmployer, 3, http.StatusUnauthorized},
+		{"WithdrawOtherCandidatesApplication", http.MethodPatch, "/candidate_applications/1/job/withdraw", gin.H{"reason": "other"}, db.RoleCandidate, 3, http.StatusUnauthorized},
+
+		{"CreateEmployerApplicationForOtherEmployer", http.MethodPost, "/employer_applications", employerApplication, db.RoleEmployer, 3, http.StatusUnauthorized},
+		{"CreateEmployerApplicationAsCandidate", http.MethodPost, "/employer_applications", employerApplication, db.RoleCandidate, 1, http.StatusUnauthorized},
+		{"ListOtherCandidatesApplications", http.MethodGet, "/employer_applications/1", nil, db.RoleCandidate, 3, http.StatusUnauthorized},
+		{"ListCandidateApplicationsAsEmployerWithSameID", http.MethodGet, "/employer_applications/1", nil, db.RoleEmployer, 1, http.StatusUnauthorized},
+		{"UpdateOtherEmployersApplication", http.MethodPatch, "/employer_applications/2/1", gin.H{}, db.RoleEmployer, 3, http.StatusUnauthorized},
+		{"UpdateEmployerApplicationAsOtherCandidate", http.MethodPatch, "/employer_applications/2/1", gin.H{}, db.RoleCandidate, 3, http.StatusUnauthorized},
+		{"AcceptEmployerApplicationAsOtherCandidate", http.MethodPatch, "/employer_applications/2/1/accept", nil, db.RoleCandidate, 3, http.StatusUnauthorized},
+		{"RejectEmployerApplicationAsOtherCandidate", http.MethodPatch, "/employer_applications/2/1/reject", nil, db.RoleCandidate, 3, http.StatusUnauthorized},
+		{"DeleteOtherEmployersApplication", http.MethodDelete, "/employer_applications/2/1", nil, db.RoleEmployer, 3, http.StatusUnauthorized},
+		{"DeleteEmployerApplicationAsOtherCandidate", http.MethodDelete, "/employer_applications/2/1", nil, db.RoleCandidate, 3, http.StatusUnauthorized},
+
+		{"SwipeForOtherCandidate", http.MethodPost, "/candidate_swipes", gin.H{"candidate_id": 1, "job_id": "job", "swipe": db.SwipeAccept}, db.RoleCandidate, 3, http.StatusUnauthorized},
+		{"SwipeAsEmployerWithSameID", http.MethodPost, "/candidate_swipes", gin.H{"candidate_id": 1, "job_id": "job", "swipe": db.SwipeAccept}, db.RoleEmployer, 1, http.StatusUnauthorized},
+		{"ClaimShiftForOtherCandidate", http.MethodPost, "/job_shifts/5/claims", gin.H{"candidate_id": 1}, db.RoleCandidate, 3, http.StatusUnauthorized},
+		{"ReleaseOtherCandidatesShift", http.MethodDelete, "/job_shifts/5/claims/1", nil, db.RoleCandidate, 3, http.StatusUnauthorized},
+		{"ReleaseShiftAsEmployer", http.MethodDelete, "/job_shifts/5/claims/1", nil, db.RoleEmployer, 1, http.StatusUnauthorized},
+		{"MessageOtherCandidate", http.MethodPost, "/messages", gin.H{"candidate_id": 1, "employer_id": 2, "body": "hi"}, db.RoleEmployer, 3, http.StatusUnauthorized},
+		{"MessageAsOtherCandidate", http.MethodPost, "/messages", gin.H{"candidate_id": 1, "employer_id": 2, "body": "hi"}, db.RoleCandidate, 3, http.StatusUnauthorized},
+		{"ReadOtherConversation", http.MethodGet, "/conversations/7/messages", nil, db.RoleCandidate, 3, http.StatusUnauthorized},
+		{"MarkOtherConversationRead", http.MethodPatch, "/conversations/7/read", nil, db.RoleEmployer, 3, http.StatusUnauthorized},
+		{"ProposeInterviewAsOtherEmployer", http.MethodPost, "/candidate_applications/1/job/interviews", gin.H{"slots": []time.Time{time.Now().Add(time.Hour)}, "duration_minutes": 30}, db.RoleEmployer, 3, http.StatusUnauthorized},
+		{"ViewOtherInterview", http.MethodGet, "/interviews/9", nil, db.RoleCandidate, 3, http.StatusUnauthorized},
+		{"PickSlotOfOtherInterview", http.MethodPatch, "/interviews/9/slot", gin.H{"slot_id": 1}, db.RoleCandidate, 3, http.StatusUnauthorized},
+		{"RescheduleOtherInterview", http.MethodPatch, "/interviews/9/reschedule", gin.H{"slots": []time.Time{time.Now().Add(time.Hour)}}, db.RoleEmployer, 3, http.StatusUnauthorized},
+		{"CancelOtherInterview", http.MethodPatch, "/interviews/9/cancel", nil, db.RoleEmployer, 3, http.StatusUnauthorized},
+		{"BulkRejectOtherEmployersApplications", http.MethodPatch, "/candidate_applications/bulk", gin.H{"job_id": "job", "status": db.ApplicationStatusRejected, "from_statuses": []db.ApplicationStatus{db.ApplicationStatusPending}}, db.RoleEmployer, 3, http.StatusUnauthorized},
+		{"BulkAcceptAsCandidate", http.MethodPatch, "/candidate_applications/bulk", gin.H{"job_id": "job", "status": db.ApplicationStatusAccepted, "candidate_ids": []int64{1}}, db.RoleCandidate, 1, http.StatusUnauthorized},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Candidate application, conversation, interview and bulk routes
+			// load the resource or job to find its parties. Beyond that the
+			// mocks have no expectations, so any other call fails the test.
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetCandidateApplication(gomock.Any(), gomock.Eq(db.GetCandidateApplicationParams{CandidateID: 1, JobDocID: "job"})).
+				AnyTimes().
+				Return(db.CandidateApplication{CandidateID: 1, EmployerID: 2, JobDocID: "job"}, nil)
+			store.EXPECT().
+				GetConversation(gomock.Any(), gomock.Eq(int64(7))).
+				AnyTimes().
+				Return(db.Conversation{ID: 7, CandidateID: 1, EmployerID: 2}, nil)
+			store.EXPECT().
+				GetInterview(gomock.Any(), gomock.Eq(int64(9))).
+				AnyTimes().
+				Return(db.Interview{ID: 9, CandidateID: 1, JobDocID: "job", EmployerID: 2}, nil)
+			esClient := mockes.NewMockESClient(ctrl)
+			esClient.EXPECT().
+				GetJob(gomock.Eq("job")).
+				AnyTimes().
+				Return(&elasticsearch.Job{ID: "job", EmployerID: 2}, nil)
+			taskDistributor := mockwk.NewMockTaskDistributor(ctrl)
+			server := newTestServer(t, store, esClient, taskDistributor)
+			recorder := httptest.NewRecorder()
+
+			var body []byte
+			if tc.body != nil {
+				data, err := json.Marshal(tc.body)
+				require.NoError(t, err)
+				body = data
+			}
+			request, err := http.NewRequest(tc.method, tc.url, bytes.NewReader(body))
+			require.NoError(t, err)
+
+			middleware.AddAuthorization(t, request, server.tokenMaker, middleware.AuthorizationTypeBearer, "user", tc.role, time.Minute, tc.roleID)
+			server.router.ServeHTTP(recorder, request)
+			require.Equal(t, tc.status, recorder.Code, recorder.Body.String())
+		})
+	}
+}
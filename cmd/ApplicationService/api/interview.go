@@ -0,0 +1,311 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	"github.com/hankimmy/PtmrBackend/pkg/service"
+	"github.com/hankimmy/PtmrBackend/pkg/token"
+	"github.com/hankimmy/PtmrBackend/pkg/worker"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// interviewReminderLeads are how long before an interview both parties are
+// reminded of it.
+var interviewReminderLeads = []time.Duration{24 * time.Hour, time.Hour}
+
+type interviewResponse struct {
+	db.Interview
+	Slots []db.InterviewSlot `json:"slots"`
+}
+
+type proposeInterviewRequest struct {
+	Slots           []time.Time `json:"slots" binding:"required,min=1,max=10"`
+	DurationMinutes int32       `json:"duration_minutes" binding:"required,min=5,max=480"`
+	Location        string      `json:"location" binding:"max=255"`
+	Notes           string      `json:"notes" binding:"max=2000"`
+}
+
+// proposeInterview lets the employer of an accepted application offer the
+// candidate a few interview slots to pick from.
+func (server *Server) proposeInterview(ctx *gin.Context) {
+	var uri applicationURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
+		return
+	}
+	var req proposeInterviewRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
+		return
+	}
+	resource, application, ok := server.resolveApplication(ctx, db.ApplicationKindCandidate, uri)
+	if !ok {
+		return
+	}
+	if _, ok := authorize(ctx, organizeInterviewPolicy, resource); !ok {
+		return
+	}
+	if err := validateInterviewSlots(req.Slots, time.Now()); err != nil {
+		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
+		return
+	}
+
+	result, err := server.store.CreateInterviewTx(ctx, db.CreateInterviewTxParams{
+		CreateInterviewParams: db.CreateInterviewParams{
+			CandidateID:     application.CandidateID,
+			JobDocID:        application.JobDocID,
+			EmployerID:      application.EmployerID,
+			DurationMinutes: req.DurationMinutes,
+			Location:        req.Location,
+			Notes:           req.Notes,
+		},
+		Slots:       req.Slots,
+		AfterCreate: server.afterInterviewChange(ctx, worker.InterviewEventProposed, ""),
+	})
+	if err != nil {
+		handleInterviewError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, interviewResponse{Interview: result.Interview, Slots: result.Slots})
+}
+
+type interviewURI struct {
+	InterviewID int64 `uri:"interview_id" binding:"required,min=1"`
+}
+
+// getInterview shows an interview and the slots proposed for it.
+func (server *Server) getInterview(ctx *gin.Context) {
+	var uri interviewURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
+		return
+	}
+	interview, _, ok := server.resolveInterview(ctx, uri.InterviewID, viewInterviewPolicy)
+	if !ok {
+		return
+	}
+	slots, err := server.store.ListInterviewSlots(ctx, interview.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, service.ErrorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, interviewResponse{Interview: interview, Slots: slots})
+}
+
+type pickInterviewSlotRequest struct {
+	SlotID int64 `json:"slot_id" binding:"required,min=1"`
+}
+
+// pickInterviewSlot schedules the interview at the slot the candidate picked.
+// Both parties get a calendar invite and are reminded before it starts.
+func (server *Server) pickInterviewSlot(ctx *gin.Context) {
+	var uri interviewURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
+		return
+	}
+	var req pickInterviewSlotRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
+		return
+	}
+	if _, _, ok := server.resolveInterview(ctx, uri.InterviewID, pickInterviewSlotPolicy); !ok {
+		return
+	}
+
+	result, err := server.store.ScheduleInterviewTx(ctx, db.ScheduleInterviewTxParams{
+		InterviewID: uri.InterviewID,
+		SlotID:      req.SlotID,
+		Now:         time.Now(),
+		AfterUpdate: server.afterInterviewChange(ctx, worker.InterviewEventScheduled, ""),
+	})
+	if err != nil {
+		handleInterviewError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, interviewResponse{Interview: result.Interview, Slots: result.Slots})
+}
+
+type rescheduleInterviewRequest struct {
+	Slots []time.Time `json:"slots" binding:"required,min=1,max=10"`
+	// DurationMinutes and Location keep their current values when left out.
+	DurationMinutes *int32  `json:"duration_minutes" binding:"omitempty,min=5,max=480"`
+	Location        *string `json:"location" binding:"omitempty,max=255"`
+}
+
+// rescheduleInterview replaces the proposed slots with new ones. A scheduled
+// interview is taken off the calendars and waits for the candidate to pick
+// again.
+func (server *Server) rescheduleInterview(ctx *gin.Context) {
+	var uri interviewURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
+		return
+	}
+	var req rescheduleInterviewRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
+		return
+	}
+	if _, _, ok := server.resolveInterview(ctx, uri.InterviewID, organizeInterviewPolicy); !ok {
+		return
+	}
+	if err := validateInterviewSlots(req.Slots, time.Now()); err != nil {
+		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
+		return
+	}
+
+	arg := db.RescheduleInterviewTxParams{
+		InterviewID: uri.InterviewID,
+		Slots:       req.Slots,
+		AfterUpdate: server.afterInterviewChange(ctx, worker.InterviewEventRescheduled, ""),
+	}
+	if req.DurationMinutes != nil {
+		arg.DurationMinutes = pgtype.Int4{Int32: *req.DurationMinutes, Valid: true}
+	}
+	if req.Location != nil {
+		arg.Location = pgtype.Text{String: *req.Location, Valid: true}
+	}
+	result, err := server.store.RescheduleInterviewTx(ctx, arg)
+	if err != nil {
+		handleInterviewError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, interviewResponse{Interview: result.Interview, Slots: result.Slots})
+}
+
+type cancelInterviewRequest struct {
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+// cancelInterview cancels an interview and tells both parties.
+func (server *Server) cancelInterview(ctx *gin.Context) {
+	var uri interviewURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
+		return
+	}
+	var req cancelInterviewRequest
+	// The reason is optional, so the body may be left out.
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
+			return
+		}
+	}
+	if _, _, ok := server.resolveInterview(ctx, uri.InterviewID, organizeInterviewPolicy); !ok {
+		return
+	}
+
+	result, err := server.store.CancelInterviewTx(ctx, db.CancelInterviewTxParams{
+		InterviewID: uri.InterviewID,
+		AfterUpdate: server.afterInterviewChange(ctx, worker.InterviewEventCancelled, req.Reason),
+	})
+	if err != nil {
+		handleInterviewError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, interviewResponse{Interview: result.Interview, Slots: result.Slots})
+}
+
+// resolveInterview loads an interview and applies the policy to the caller.
+// On failure it writes the response itself and reports false.
+func (server *Server) resolveInterview(ctx *gin.Context, id int64, allowed policy) (db.Interview, *token.Payload, bool) {
+	interview, err := server.store.GetInterview(ctx, id)
+	if err != nil {
+		handleInterviewError(ctx, err)
+		return db.Interview{}, nil, false
+	}
+	authPayload, ok := authorize(ctx, allowed, applicationResource{
+		Kind:        db.ApplicationKindCandidate,
+		CandidateID: interview.CandidateID,
+		EmployerID:  interview.EmployerID,
+	})
+	if !ok {
+		return db.Interview{}, nil, false
+	}
+	return interview, authPayload, true
+}
+
+// afterInterviewChange tells both parties about the change from inside the
+// transaction. Scheduling an interview also enqueues its reminders.
+func (server *Server) afterInterviewChange(ctx *gin.Context, event worker.InterviewEvent, reason string) func(result db.InterviewTxResult) error {
+	return func(result db.InterviewTxResult) error {
+		payload := &worker.PayloadNotifyInterview{
+			InterviewID: result.Interview.ID,
+			Event:       event,
+			Reason:      reason,
+		}
+		if event == worker.InterviewEventRescheduled && result.PreviousScheduledAt.Valid {
+			payload.PreviousScheduledAt = &result.PreviousScheduledAt.Time
+		}
+		opts := []asynq.Option{
+			asynq.MaxRetry(10),
+			asynq.ProcessIn(10 * time.Second),
+			asynq.Queue(worker.QueueMail),
+		}
+		if err := server.taskDistributor.DistributeTaskNotifyInterview(ctx, payload, opts...); err != nil {
+			return err
+		}
+		if event != worker.InterviewEventScheduled {
+			return nil
+		}
+
+		scheduledAt := result.Interview.ScheduledAt.Time
+		now := time.Now()
+		for _, lead := range interviewReminderLeads {
+			remindAt := scheduledAt.Add(-lead)
+			if !remindAt.After(now) {
+				continue
+			}
+			err := server.taskDistributor.DistributeTaskSendInterviewReminder(ctx, &worker.PayloadSendInterviewReminder{
+				InterviewID: result.Interview.ID,
+				ScheduledAt: scheduledAt,
+				Lead:        lead,
+			}, asynq.MaxRetry(3), asynq.ProcessAt(remindAt), asynq.Queue(worker.QueueMail))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// validateInterviewSlots checks that every slot is in the future and offered
+// once.
+func validateInterviewSlots(slots []time.Time, now time.Time) error {
+	seen := make(map[time.Time]bool, len(slots))
+	for _, slot := range slots {
+		if !slot.After(now) {
+			return fmt.Errorf("interview slot %s has already passed", slot.Format(time.RFC3339))
+		}
+		key := slot.UTC()
+		if seen[key] {
+			return fmt.Errorf("interview slot %s is offered twice", slot.Format(time.RFC3339))
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+func handleInterviewError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, db.ErrRecordNotFound):
+		ctx.JSON(http.StatusNotFound, service.ErrorResponse(err))
+	case errors.Is(err, db.ErrApplicationNotAccepted),
+		errors.Is(err, db.ErrInterviewNotProposed),
+		errors.Is(err, db.ErrInterviewCancelled),
+		errors.Is(err, db.ErrInterviewSlotPassed):
+		ctx.JSON(http.StatusConflict, service.ErrorResponse(err))
+	case db.ErrorCode(err) == db.UniqueViolation:
+		ctx.JSON(http.StatusConflict, service.ErrorResponse(errors.New("application already has an interview")))
+	default:
+		ctx.JSON(http.StatusInternalServerError, service.ErrorResponse(err))
+	}
+}
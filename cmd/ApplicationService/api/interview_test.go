@@ -0,0 +1,472 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/hankimmy/PtmrBackend/pkg/db/mock"
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	mockes "github.com/hankimmy/PtmrBackend/pkg/elasticsearch/mock"
+	"github.com/hankimmy/PtmrBackend/pkg/middleware"
+	"github.com/hankimmy/PtmrBackend/pkg/token"
+	"github.com/hankimmy/PtmrBackend/pkg/util"
+	"github.com/hankimmy/PtmrBackend/pkg/worker"
+	mockwk "github.com/hankimmy/PtmrBackend/pkg/worker/mock"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+// randomInterview returns an interview proposed for a random accepted
+// application.
+func randomInterview() (db.CandidateApplication, db.Interview) {
+	application := db.RandomCandidateApplication(util.RandomInt(1, 1000))
+	application.CandidateID = util.RandomInt(1, 1000)
+	application.ApplicationStatus = db.ApplicationStatusAccepted
+	return application, db.Interview{
+		ID:              util.RandomInt(1, 1000),
+		CandidateID:     application.CandidateID,
+		JobDocID:        application.JobDocID,
+		EmployerID:      application.EmployerID,
+		Status:          db.InterviewStatusProposed,
+		DurationMinutes: 30,
+		Location:        "1 Main St",
+	}
+}
+
+func TestProposeInterviewAPI(t *testing.T) {
+	application, interview := randomInterview()
+	slots := []time.Time{
+		time.Now().Add(48 * time.Hour).Truncate(time.Second),
+		time.Now().Add(72 * time.Hour).Truncate(time.Second),
+	}
+	body := gin.H{"slots": slots, "duration_minutes": 30, "location": "1 Main St"}
+
+	employerAuth := func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+		middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, "employer", db.RoleEmployer, time.Minute, application.EmployerID)
+	}
+	getApplication := func(store *mockdb.MockStore) {
+		store.EXPECT().
+			GetCandidateApplication(gomock.Any(), gomock.Eq(db.GetCandidateApplicationParams{CandidateID: application.CandidateID, JobDocID: application.JobDocID})).
+			Times(1).
+			Return(application, nil)
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "OK",
+			body:      body,
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				getApplication(store)
+				store.EXPECT().
+					CreateInterviewTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateInterviewTxParams) (db.InterviewTxResult, error) {
+						require.Equal(t, db.CreateInterviewParams{
+							CandidateID:     application.CandidateID,
+							JobDocID:        application.JobDocID,
+							EmployerID:      application.EmployerID,
+							DurationMinutes: 30,
+							Location:        "1 Main St",
+						}, arg.CreateInterviewParams)
+						require.Len(t, arg.Slots, 2)
+						result := db.InterviewTxResult{Interview: interview}
+						return result, arg.AfterCreate(result)
+					})
+				taskDistributor.EXPECT().
+					DistributeTaskNotifyInterview(gomock.Any(), gomock.Eq(&worker.PayloadNotifyInterview{
+						InterviewID: interview.ID,
+						Event:       worker.InterviewEventProposed,
+					}), gomock.Any()).
+					Times(1)
+				taskDistributor.EXPECT().DistributeTaskSendInterviewReminder(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var got interviewResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, interview.ID, got.ID)
+				require.Equal(t, db.InterviewStatusProposed, got.Status)
+			},
+		},
+		{
+			name:      "ApplicationNotAccepted",
+			body:      body,
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				getApplication(store)
+				store.EXPECT().
+					CreateInterviewTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.InterviewTxResult{}, db.ErrApplicationNotAccepted)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:      "AlreadyHasInterview",
+			body:      body,
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				getApplication(store)
+				store.EXPECT().
+					CreateInterviewTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.InterviewTxResult{}, db.ErrUniqueViolation)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:      "PastSlot",
+			body:      gin.H{"slots": []time.Time{time.Now().Add(-time.Hour)}, "duration_minutes": 30},
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				getApplication(store)
+				store.EXPECT().CreateInterviewTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:      "DuplicateSlot",
+			body:      gin.H{"slots": []time.Time{slots[0], slots[0]}, "duration_minutes": 30},
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				getApplication(store)
+				store.EXPECT().CreateInterviewTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Candidate",
+			body: body,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, "candidate", db.RoleCandidate, time.Minute, application.CandidateID)
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				getApplication(store)
+				store.EXPECT().CreateInterviewTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:      "MissingSlots",
+			body:      gin.H{"duration_minutes": 30},
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetCandidateApplication(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+			taskDistributor := mockwk.NewMockTaskDistributor(ctrl)
+			tc.buildStubs(store, taskDistributor)
+
+			server := newTestServer(t, store, mockes.NewMockESClient(ctrl), taskDistributor)
+			recorder := httptest.NewRecorder()
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+			url := fmt.Sprintf("/candidate_applications/%d/%s/interviews", application.CandidateID, application.JobDocID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestPickInterviewSlotAPI(t *testing.T) {
+	application, interview := randomInterview()
+	scheduled := interview
+	scheduled.Status = db.InterviewStatusScheduled
+	scheduled.ScheduledAt = pgtype.Timestamptz{Time: time.Now().Add(48 * time.Hour), Valid: true}
+	// Too soon for the day-before reminder, so only the hour-before one is
+	// enqueued.
+	soon := scheduled
+	soon.ScheduledAt = pgtype.Timestamptz{Time: time.Now().Add(3 * time.Hour), Valid: true}
+
+	candidateAuth := func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+		middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, "candidate", db.RoleCandidate, time.Minute, application.CandidateID)
+	}
+	scheduleAt := func(store *mockdb.MockStore, result db.Interview) {
+		store.EXPECT().
+			ScheduleInterviewTx(gomock.Any(), gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ interface{}, arg db.ScheduleInterviewTxParams) (db.InterviewTxResult, error) {
+				require.Equal(t, interview.ID, arg.InterviewID)
+				require.Equal(t, int64(3), arg.SlotID)
+				require.WithinDuration(t, time.Now(), arg.Now, time.Second)
+				txResult := db.InterviewTxResult{Interview: result}
+				return txResult, arg.AfterUpdate(txResult)
+			})
+	}
+
+	testCases := []struct {
+		name          string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "OK",
+			setupAuth: candidateAuth,
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetInterview(gomock.Any(), gomock.Eq(interview.ID)).Times(1).Return(interview, nil)
+				scheduleAt(store, scheduled)
+				taskDistributor.EXPECT().
+					DistributeTaskNotifyInterview(gomock.Any(), gomock.Eq(&worker.PayloadNotifyInterview{
+						InterviewID: interview.ID,
+						Event:       worker.InterviewEventScheduled,
+					}), gomock.Any()).
+					Times(1)
+				for _, lead := range interviewReminderLeads {
+					taskDistributor.EXPECT().
+						DistributeTaskSendInterviewReminder(gomock.Any(), gomock.Eq(&worker.PayloadSendInterviewReminder{
+							InterviewID: interview.ID,
+							ScheduledAt: scheduled.ScheduledAt.Time,
+							Lead:        lead,
+						}), gomock.Any()).
+						Times(1)
+				}
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var got interviewResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, db.InterviewStatusScheduled, got.Status)
+			},
+		},
+		{
+			name:      "SkipsPassedReminders",
+			setupAuth: candidateAuth,
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetInterview(gomock.Any(), gomock.Eq(interview.ID)).Times(1).Return(interview, nil)
+				scheduleAt(store, soon)
+				taskDistributor.EXPECT().DistributeTaskNotifyInterview(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+				taskDistributor.EXPECT().
+					DistributeTaskSendInterviewReminder(gomock.Any(), gomock.Eq(&worker.PayloadSendInterviewReminder{
+						InterviewID: interview.ID,
+						ScheduledAt: soon.ScheduledAt.Time,
+						Lead:        time.Hour,
+					}), gomock.Any()).
+					Times(1)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:      "SlotPassed",
+			setupAuth: candidateAuth,
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetInterview(gomock.Any(), gomock.Eq(interview.ID)).Times(1).Return(interview, nil)
+				store.EXPECT().
+					ScheduleInterviewTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.InterviewTxResult{}, db.ErrInterviewSlotPassed)
+				taskDistributor.EXPECT().DistributeTaskNotifyInterview(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "Employer",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, "employer", db.RoleEmployer, time.Minute, application.EmployerID)
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetInterview(gomock.Any(), gomock.Eq(interview.ID)).Times(1).Return(interview, nil)
+				store.EXPECT().ScheduleInterviewTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:      "NotFound",
+			setupAuth: candidateAuth,
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetInterview(gomock.Any(), gomock.Eq(interview.ID)).Times(1).Return(db.Interview{}, db.ErrRecordNotFound)
+				store.EXPECT().ScheduleInterviewTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+			taskDistributor := mockwk.NewMockTaskDistributor(ctrl)
+			tc.buildStubs(store, taskDistributor)
+
+			server := newTestServer(t, store, mockes.NewMockESClient(ctrl), taskDistributor)
+			recorder := httptest.NewRecorder()
+			data, err := json.Marshal(gin.H{"slot_id": 3})
+			require.NoError(t, err)
+			url := fmt.Sprintf("/interviews/%d/slot", interview.ID)
+			request, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestRescheduleInterviewAPI(t *testing.T) {
+	application, interview := randomInterview()
+	previous := time.Now().Add(24 * time.Hour)
+	slot := time.Now().Add(96 * time.Hour).Truncate(time.Second)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+	taskDistributor := mockwk.NewMockTaskDistributor(ctrl)
+	store.EXPECT().GetInterview(gomock.Any(), gomock.Eq(interview.ID)).Times(1).Return(interview, nil)
+	store.EXPECT().
+		RescheduleInterviewTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ interface{}, arg db.RescheduleInterviewTxParams) (db.InterviewTxResult, error) {
+			require.Equal(t, interview.ID, arg.InterviewID)
+			require.Len(t, arg.Slots, 1)
+			require.True(t, slot.Equal(arg.Slots[0]))
+			require.Equal(t, pgtype.Int4{Int32: 45, Valid: true}, arg.DurationMinutes)
+			require.False(t, arg.Location.Valid)
+			result := db.InterviewTxResult{
+				Interview:           interview,
+				PreviousScheduledAt: pgtype.Timestamptz{Time: previous, Valid: true},
+			}
+			return result, arg.AfterUpdate(result)
+		})
+	taskDistributor.EXPECT().
+		DistributeTaskNotifyInterview(gomock.Any(), gomock.Eq(&worker.PayloadNotifyInterview{
+			InterviewID:         interview.ID,
+			Event:               worker.InterviewEventRescheduled,
+			PreviousScheduledAt: &previous,
+		}), gomock.Any()).
+		Times(1)
+
+	server := newTestServer(t, store, mockes.NewMockESClient(ctrl), taskDistributor)
+	recorder := httptest.NewRecorder()
+	data, err := json.Marshal(gin.H{"slots": []time.Time{slot}, "duration_minutes": 45})
+	require.NoError(t, err)
+	url := fmt.Sprintf("/interviews/%d/reschedule", interview.ID)
+	request, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(data))
+	require.NoError(t, err)
+
+	middleware.AddAuthorization(t, request, server.tokenMaker, middleware.AuthorizationTypeBearer, "employer", db.RoleEmployer, time.Minute, application.EmployerID)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+}
+
+func TestCancelInterviewAPI(t *testing.T) {
+	application, interview := randomInterview()
+	cancelled := interview
+	cancelled.Status = db.InterviewStatusCancelled
+
+	testCases := []struct {
+		name       string
+		body       gin.H
+		buildStubs func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor)
+		status     int
+	}{
+		{
+			name: "OK",
+			body: gin.H{"reason": "The position was filled."},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetInterview(gomock.Any(), gomock.Eq(interview.ID)).Times(1).Return(interview, nil)
+				store.EXPECT().
+					CancelInterviewTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CancelInterviewTxParams) (db.InterviewTxResult, error) {
+						require.Equal(t, interview.ID, arg.InterviewID)
+						result := db.InterviewTxResult{Interview: cancelled}
+						return result, arg.AfterUpdate(result)
+					})
+				taskDistributor.EXPECT().
+					DistributeTaskNotifyInterview(gomock.Any(), gomock.Eq(&worker.PayloadNotifyInterview{
+						InterviewID: interview.ID,
+						Event:       worker.InterviewEventCancelled,
+						Reason:      "The position was filled.",
+					}), gomock.Any()).
+					Times(1)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name: "AlreadyCancelled",
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetInterview(gomock.Any(), gomock.Eq(interview.ID)).Times(1).Return(cancelled, nil)
+				store.EXPECT().
+					CancelInterviewTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.InterviewTxResult{}, db.ErrInterviewCancelled)
+				taskDistributor.EXPECT().DistributeTaskNotifyInterview(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusConflict,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+			taskDistributor := mockwk.NewMockTaskDistributor(ctrl)
+			tc.buildStubs(store, taskDistributor)
+
+			server := newTestServer(t, store, mockes.NewMockESClient(ctrl), taskDistributor)
+			recorder := httptest.NewRecorder()
+			var body []byte
+			if tc.body != nil {
+				data, err := json.Marshal(tc.body)
+				require.NoError(t, err)
+				body = data
+			}
+			url := fmt.Sprintf("/interviews/%d/cancel", interview.ID)
+			request, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(body))
+			require.NoError(t, err)
+
+			middleware.AddAuthorization(t, request, server.tokenMaker, middleware.AuthorizationTypeBearer, "employer", db.RoleEmployer, time.Minute, application.EmployerID)
+			server.router.ServeHTTP(recorder, request)
+			require.Equal(t, tc.status, recorder.Code, recorder.Body.String())
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	"github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
+	"github.com/hankimmy/PtmrBackend/pkg/service"
+	"github.com/hankimmy/PtmrBackend/pkg/worker"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// defaultInvitationDuration is how long a candidate has to answer an
+// invitation when INVITATION_DURATION isn't configured.
+const defaultInvitationDuration = 7 * 24 * time.Hour
+
+// invitationMessageKey is where the employer's message is kept in the
+// application an accepted invitation turns into.
+const invitationMessageKey = "invitation_message"
+
+func (server *Server) invitationDuration() time.Duration {
+	if server.config.InvitationDuration > 0 {
+		return server.config.InvitationDuration
+	}
+	return defaultInvitationDuration
+}
+
+// createInvitation stores an employer's invitation for a candidate to apply to
+// one of the employer's open jobs. The invitation is indexed and the
+// candidate notified from inside the transaction, so it is rolled back if
+// either task can't be enqueued.
+func (server *Server) createInvitation(ctx *gin.Context, req createApplicationRequest, employerID int64, appDoc map[string]interface{}) {
+	if req.JobDocID == "" {
+		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(errors.New("job_doc_id is required for employer applications")))
+		return
+	}
+	message, err := getMessageFromApplicationDoc(req.ApplicationDoc)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
+		return
+	}
+	job, status, err := server.getApplicationJob(req.JobDocID)
+	if err != nil {
+		ctx.JSON(status, service.ErrorResponse(err))
+		return
+	}
+	if job.EmployerID != employerID {
+		ctx.JSON(http.StatusForbidden, service.ErrorResponse(errors.New("job doesn't belong to the authenticated employer")))
+		return
+	}
+	now := time.Now()
+	if !job.AcceptsApplications(now) {
+		ctx.JSON(http.StatusConflict, service.ErrorResponse(errors.New("job is not accepting applications")))
+		return
+	}
+
+	expiresAt := now.Add(server.invitationDuration())
+	appDoc["candidate_id"] = req.CandidateID
+	appDoc["employer_id"] = employerID
+	appDoc["job_id"] = job.ID
+	appDoc["expires_at"] = expiresAt
+	arg := db.CreateEmployerApplicationTxParams{
+		CreateEmployerApplicationParams: db.CreateEmployerApplicationParams{
+			EmployerID:        employerID,
+			CandidateID:       req.CandidateID,
+			Message:           message,
+			ApplicationStatus: req.ApplicationStatus,
+			CreatedAt:         now,
+			JobDocID:          job.ID,
+			ExpiresAt:         pgtype.Timestamptz{Time: expiresAt, Valid: true},
+		},
+		DocID:  elasticsearch.EmployerApplicationDocID(employerID, req.CandidateID),
+		AppDoc: appDoc,
+		AfterCreate: server.afterEmployerCreateApp(ctx, &worker.PayloadNotifyJobInvitation{
+			CandidateID: req.CandidateID,
+			EmployerID:  employerID,
+			JobID:       job.ID,
+			Message:     message,
+			ExpiresAt:   expiresAt,
+		}),
+	}
+	result, err := server.store.CreateEmployerApplicationTx(ctx, arg)
+	if err != nil {
+		switch db.ErrorCode(err) {
+		case db.UniqueViolation:
+			ctx.JSON(http.StatusConflict, service.ErrorResponse(errors.New("candidate has already been invited")))
+		case db.ForeignKeyViolation:
+			ctx.JSON(http.StatusNotFound, service.ErrorResponse(errors.New("candidate not found")))
+		default:
+			ctx.JSON(http.StatusInternalServerError, service.ErrorResponse(err))
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, result.EmployerAppResult)
+}
+
+// afterEmployerCreateApp indexes a new invitation and tells the candidate
+// about it.
+func (server *Server) afterEmployerCreateApp(ctx *gin.Context, notification *worker.PayloadNotifyJobInvitation) func(docID string, appDoc map[string]interface{}) error {
+	indexApp := server.enqueueCreateAppTask(worker.TaskCreateEmployerApp, ctx)
+	return func(docID string, appDoc map[string]interface{}) error {
+		if err := indexApp(docID, appDoc); err != nil {
+			return err
+		}
+		opts := []asynq.Option{
+			asynq.MaxRetry(10),
+			asynq.ProcessIn(10 * time.Second),
+			asynq.Queue(worker.QueueMail),
+		}
+		return server.taskDistributor.DistributeTaskNotifyJobInvitation(ctx, notification, opts...)
+	}
+}
+
+// invitationApplication loads the invitation a candidate is accepting and,
+// when it is for a job, prepares the application it turns into, prefilled
+// with the invitation and any answers the candidate sent along. It returns a
+// nil application for invitations that aren't tied to a job. On failure it
+// writes the response itself and reports false.
+func (server *Server) invitationApplication(ctx *gin.Context, resource applicationResource, rawDoc json.RawMessage) (*db.CreateCandidateApplicationTxParams, *elasticsearch.Job, bool) {
+	invitation, err := server.store.GetEmployerApplication(ctx, db.GetEmployerApplicationParams{
+		EmployerID:  resource.EmployerID,
+		CandidateID: resource.CandidateID,
+	})
+	if err != nil {
+		handleTransitionError(ctx, err)
+		return nil, nil, false
+	}
+	now := time.Now()
+	if invitation.Expired(now) {
+		handleTransitionError(ctx, db.ErrInvitationExpired)
+		return nil, nil, false
+	}
+	if invitation.JobDocID == "" {
+		return nil, nil, true
+	}
+
+	job, status, err := server.getApplicationJob(invitation.JobDocID)
+	if err != nil {
+		ctx.JSON(status, service.ErrorResponse(err))
+		return nil, nil, false
+	}
+	if !job.AcceptsApplications(now) {
+		ctx.JSON(http.StatusConflict, service.ErrorResponse(errors.New("job is not accepting applications")))
+		return nil, nil, false
+	}
+	appDoc := map[string]interface{}{}
+	if rawDoc != nil {
+		if appDoc, err = convertApplicationDoc(rawDoc); err != nil {
+			ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
+			return nil, nil, false
+		}
+		if err := validateApplicationAnswers(job, appDoc); err != nil {
+			ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
+			return nil, nil, false
+		}
+	}
+	appDoc["candidate_id"] = invitation.CandidateID
+	appDoc["employer_id"] = job.EmployerID
+	appDoc["job_id"] = job.ID
+	appDoc[invitationMessageKey] = invitation.Message
+
+	// The application starts pending so that the candidate can complete it
+	// before submitting.
+	return &db.CreateCandidateApplicationTxParams{
+		CreateCandidateApplicationParams: db.CreateCandidateApplicationParams{
+			CandidateID:        invitation.CandidateID,
+			EmployerID:         job.EmployerID,
+			ElasticsearchDocID: elasticsearch.CandidateApplicationDocID(invitation.CandidateID, job.ID),
+			JobDocID:           job.ID,
+			ApplicationStatus:  db.ApplicationStatusPending,
+		},
+		MaxApplications:   job.MaxApplications,
+		AppDoc:            appDoc,
+		AfterCreate:       server.afterCandidateCreateApp(ctx),
+		AfterLimitReached: server.afterJobFilled(ctx, job.ID),
+	}, job, true
+}
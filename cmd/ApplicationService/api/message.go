@@ -0,0 +1,196 @@
+package api
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	"github.com/hankimmy/PtmrBackend/pkg/middleware"
+	"github.com/hankimmy/PtmrBackend/pkg/service"
+	"github.com/hankimmy/PtmrBackend/pkg/token"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const defaultMessagePageSize = 30
+
+type sendMessageRequest struct {
+	CandidateID int64  `json:"candidate_id" binding:"required,min=1"`
+	EmployerID  int64  `json:"employer_id" binding:"required,min=1"`
+	Body        string `json:"body" binding:"required,max=2000"`
+}
+
+// sendMessage sends a message to the other party, starting their
+// conversation if needed. Candidates and employers can only message each
+// other once an application between them was accepted or they matched.
+func (server *Server) sendMessage(ctx *gin.Context) {
+	var req sendMessageRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
+		return
+	}
+	authPayload, ok := authorize(ctx, conversationPolicy, applicationResource{
+		CandidateID: req.CandidateID,
+		EmployerID:  req.EmployerID,
+	})
+	if !ok {
+		return
+	}
+
+	result, err := server.store.SendMessageTx(ctx, db.SendMessageTxParams{
+		CandidateID: req.CandidateID,
+		EmployerID:  req.EmployerID,
+		SenderRole:  authPayload.Role,
+		SenderID:    authPayload.RoleID,
+		Body:        req.Body,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrMessagingNotAllowed) {
+			ctx.JSON(http.StatusForbidden, service.ErrorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, service.ErrorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, result.Message)
+}
+
+// listConversations lists the caller's conversations, most recently active
+// first, with the number of messages they haven't read in each.
+func (server *Server) listConversations(ctx *gin.Context) {
+	authPayload := ctx.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload)
+	arg := db.ListConversationsParams{ReaderRole: authPayload.Role}
+	switch authPayload.Role {
+	case db.RoleCandidate:
+		arg.CandidateID = pgtype.Int8{Int64: authPayload.RoleID, Valid: true}
+	case db.RoleEmployer:
+		arg.EmployerID = pgtype.Int8{Int64: authPayload.RoleID, Valid: true}
+	default:
+		ctx.JSON(http.StatusUnauthorized, service.ErrorResponse(errNotParty))
+		return
+	}
+
+	conversations, err := server.store.ListConversations(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, service.ErrorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, conversations)
+}
+
+type conversationURI struct {
+	ConversationID int64 `uri:"conversation_id" binding:"required,min=1"`
+}
+
+type listMessagesQuery struct {
+	PageSize int32 `form:"page_size" binding:"omitempty,min=1,max=100"`
+	// Cursor is the ID of the oldest message of the previous page.
+	Cursor int64 `form:"cursor" binding:"omitempty,min=1"`
+}
+
+type listMessagesResponse struct {
+	Messages   []db.Message `json:"messages"`
+	NextCursor string       `json:"next_cursor,omitempty"`
+}
+
+// listMessages pages through a conversation from the newest message back.
+func (server *Server) listMessages(ctx *gin.Context) {
+	var uri conversationURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
+		return
+	}
+	var query listMessagesQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
+		return
+	}
+	if _, _, ok := server.resolveConversation(ctx, uri.ConversationID); !ok {
+		return
+	}
+	if query.PageSize == 0 {
+		query.PageSize = defaultMessagePageSize
+	}
+
+	messages, err := server.store.ListMessages(ctx, db.ListMessagesParams{
+		ConversationID: uri.ConversationID,
+		BeforeID:       pgtype.Int8{Int64: query.Cursor, Valid: query.Cursor != 0},
+		Limit:          query.PageSize + 1,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, service.ErrorResponse(err))
+		return
+	}
+	var nextCursor string
+	if len(messages) > int(query.PageSize) {
+		messages = messages[:query.PageSize]
+		nextCursor = strconv.FormatInt(messages[len(messages)-1].ID, 10)
+	}
+	ctx.JSON(http.StatusOK, listMessagesResponse{Messages: messages, NextCursor: nextCursor})
+}
+
+type markConversationReadRequest struct {
+	// UpToID is the newest message the caller has seen. All messages are
+	// marked read when it is left out.
+	UpToID int64 `json:"up_to_id" binding:"omitempty,min=1"`
+}
+
+// markConversationRead records that the caller has read the other party's
+// messages, which the sender sees as read receipts.
+func (server *Server) markConversationRead(ctx *gin.Context) {
+	var uri conversationURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
+		return
+	}
+	var req markConversationReadRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
+			return
+		}
+	}
+	_, authPayload, ok := server.resolveConversation(ctx, uri.ConversationID)
+	if !ok {
+		return
+	}
+	if req.UpToID == 0 {
+		req.UpToID = math.MaxInt64
+	}
+
+	marked, err := server.store.MarkMessagesRead(ctx, db.MarkMessagesReadParams{
+		ConversationID: uri.ConversationID,
+		ReaderRole:     authPayload.Role,
+		UpToID:         req.UpToID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, service.ErrorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"marked_read": marked})
+}
+
+// resolveConversation loads a conversation and checks that the caller is
+// one of its parties. On failure it writes the response itself and reports
+// false.
+func (server *Server) resolveConversation(ctx *gin.Context, id int64) (db.Conversation, *token.Payload, bool) {
+	conversation, err := server.store.GetConversation(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, service.ErrorResponse(err))
+			return db.Conversation{}, nil, false
+		}
+		ctx.JSON(http.StatusInternalServerError, service.ErrorResponse(err))
+		return db.Conversation{}, nil, false
+	}
+	authPayload, ok := authorize(ctx, conversationPolicy, applicationResource{
+		CandidateID: conversation.CandidateID,
+		EmployerID:  conversation.EmployerID,
+	})
+	if !ok {
+		return db.Conversation{}, nil, false
+	}
+	return conversation, authPayload, true
+}
@@ -0,0 +1,356 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/hankimmy/PtmrBackend/pkg/db/mock"
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	mockes "github.com/hankimmy/PtmrBackend/pkg/elasticsearch/mock"
+	"github.com/hankimmy/PtmrBackend/pkg/middleware"
+	"github.com/hankimmy/PtmrBackend/pkg/token"
+	"github.com/hankimmy/PtmrBackend/pkg/util"
+	mockwk "github.com/hankimmy/PtmrBackend/pkg/worker/mock"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func randomConversation() db.Conversation {
+	return db.Conversation{
+		ID:          util.RandomInt(1, 1000),
+		CandidateID: util.RandomInt(1, 1000),
+		EmployerID:  util.RandomInt(1, 1000),
+		CreatedAt:   time.Now(),
+	}
+}
+
+func TestSendMessageAPI(t *testing.T) {
+	conversation := randomConversation()
+	body := gin.H{
+		"candidate_id": conversation.CandidateID,
+		"employer_id":  conversation.EmployerID,
+		"body":         "When can you start?",
+	}
+	message := db.Message{
+		ID:             1,
+		ConversationID: conversation.ID,
+		SenderRole:     db.RoleEmployer,
+		SenderID:       conversation.EmployerID,
+		Body:           "When can you start?",
+		CreatedAt:      time.Now(),
+	}
+
+	employerAuth := func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+		middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, "employer", db.RoleEmployer, time.Minute, conversation.EmployerID)
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "OK",
+			body:      body,
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.SendMessageTxParams{
+					CandidateID: conversation.CandidateID,
+					EmployerID:  conversation.EmployerID,
+					SenderRole:  db.RoleEmployer,
+					SenderID:    conversation.EmployerID,
+					Body:        "When can you start?",
+				}
+				store.EXPECT().
+					SendMessageTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.SendMessageTxResult{Conversation: conversation, Message: message}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var got db.Message
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, message.ID, got.ID)
+				require.Equal(t, message.Body, got.Body)
+			},
+		},
+		{
+			name:      "NotAllowed",
+			body:      body,
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SendMessageTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.SendMessageTxResult{}, db.ErrMessagingNotAllowed)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "OtherEmployer",
+			body: body,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, "employer", db.RoleEmployer, time.Minute, conversation.EmployerID+1)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SendMessageTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "EmptyBody",
+			body: gin.H{
+				"candidate_id": conversation.CandidateID,
+				"employer_id":  conversation.EmployerID,
+				"body":         "",
+			},
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SendMessageTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, mockes.NewMockESClient(ctrl), mockwk.NewMockTaskDistributor(ctrl))
+			recorder := httptest.NewRecorder()
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+			request, err := http.NewRequest(http.MethodPost, "/messages", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListConversationsAPI(t *testing.T) {
+	conversation := randomConversation()
+	row := db.ListConversationsRow{
+		ID:          conversation.ID,
+		CandidateID: conversation.CandidateID,
+		EmployerID:  conversation.EmployerID,
+		UnreadCount: 2,
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ListConversations(gomock.Any(), gomock.Eq(db.ListConversationsParams{
+			ReaderRole:  db.RoleCandidate,
+			CandidateID: pgtype.Int8{Int64: conversation.CandidateID, Valid: true},
+		})).
+		Times(1).
+		Return([]db.ListConversationsRow{row}, nil)
+
+	server := newTestServer(t, store, mockes.NewMockESClient(ctrl), mockwk.NewMockTaskDistributor(ctrl))
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/conversations", nil)
+	require.NoError(t, err)
+
+	middleware.AddAuthorization(t, request, server.tokenMaker, middleware.AuthorizationTypeBearer, "candidate", db.RoleCandidate, time.Minute, conversation.CandidateID)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var got []db.ListConversationsRow
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+	require.Len(t, got, 1)
+	require.Equal(t, int64(2), got[0].UnreadCount)
+}
+
+func TestListMessagesAPI(t *testing.T) {
+	conversation := randomConversation()
+	messages := []db.Message{
+		{ID: 9, ConversationID: conversation.ID},
+		{ID: 8, ConversationID: conversation.ID},
+		{ID: 7, ConversationID: conversation.ID},
+	}
+
+	candidateAuth := func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+		middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, "candidate", db.RoleCandidate, time.Minute, conversation.CandidateID)
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "FirstPage",
+			query:     "?page_size=2",
+			setupAuth: candidateAuth,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetConversation(gomock.Any(), gomock.Eq(conversation.ID)).Times(1).Return(conversation, nil)
+				store.EXPECT().
+					ListMessages(gomock.Any(), gomock.Eq(db.ListMessagesParams{ConversationID: conversation.ID, Limit: 3})).
+					Times(1).
+					Return(messages, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var got listMessagesResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Len(t, got.Messages, 2)
+				require.Equal(t, "8", got.NextCursor)
+			},
+		},
+		{
+			name:      "LastPage",
+			query:     "?page_size=2&cursor=8",
+			setupAuth: candidateAuth,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetConversation(gomock.Any(), gomock.Eq(conversation.ID)).Times(1).Return(conversation, nil)
+				arg := db.ListMessagesParams{
+					ConversationID: conversation.ID,
+					BeforeID:       pgtype.Int8{Int64: 8, Valid: true},
+					Limit:          3,
+				}
+				store.EXPECT().
+					ListMessages(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(messages[2:], nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var got listMessagesResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Len(t, got.Messages, 1)
+				require.Empty(t, got.NextCursor)
+			},
+		},
+		{
+			name:      "NotFound",
+			setupAuth: candidateAuth,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetConversation(gomock.Any(), gomock.Eq(conversation.ID)).Times(1).Return(db.Conversation{}, db.ErrRecordNotFound)
+				store.EXPECT().ListMessages(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "OtherCandidate",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, "candidate", db.RoleCandidate, time.Minute, conversation.CandidateID+1)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetConversation(gomock.Any(), gomock.Eq(conversation.ID)).Times(1).Return(conversation, nil)
+				store.EXPECT().ListMessages(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:      "InvalidPageSize",
+			query:     "?page_size=1000",
+			setupAuth: candidateAuth,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetConversation(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, mockes.NewMockESClient(ctrl), mockwk.NewMockTaskDistributor(ctrl))
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/conversations/%d/messages%s", conversation.ID, tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestMarkConversationReadAPI(t *testing.T) {
+	conversation := randomConversation()
+
+	testCases := []struct {
+		name   string
+		body   gin.H
+		upToID int64
+		role   db.Role
+		roleID int64
+	}{
+		{name: "AllMessages", upToID: math.MaxInt64, role: db.RoleEmployer, roleID: conversation.EmployerID},
+		{name: "UpToMessage", body: gin.H{"up_to_id": 5}, upToID: 5, role: db.RoleCandidate, roleID: conversation.CandidateID},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetConversation(gomock.Any(), gomock.Eq(conversation.ID)).Times(1).Return(conversation, nil)
+			store.EXPECT().
+				MarkMessagesRead(gomock.Any(), gomock.Eq(db.MarkMessagesReadParams{
+					ConversationID: conversation.ID,
+					ReaderRole:     tc.role,
+					UpToID:         tc.upToID,
+				})).
+				Times(1).
+				Return(int64(3), nil)
+
+			server := newTestServer(t, store, mockes.NewMockESClient(ctrl), mockwk.NewMockTaskDistributor(ctrl))
+			recorder := httptest.NewRecorder()
+			var body []byte
+			if tc.body != nil {
+				data, err := json.Marshal(tc.body)
+				require.NoError(t, err)
+				body = data
+			}
+			url := fmt.Sprintf("/conversations/%d/read", conversation.ID)
+			request, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(body))
+			require.NoError(t, err)
+
+			middleware.AddAuthorization(t, request, server.tokenMaker, middleware.AuthorizationTypeBearer, "user", tc.role, time.Minute, tc.roleID)
+			server.router.ServeHTTP(recorder, request)
+			require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+			var got map[string]int64
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+			require.Equal(t, int64(3), got["marked_read"])
+		})
+	}
+}
@@ -40,12 +40,26 @@ func (server *Server) SetupRouter() {
 	authRoutes.GET("/candidate_applications/:employer_id", func(ctx *gin.Context) {
 		server.getApplications(ctx, true)
 	})
-	authRoutes.PATCH("/candidate_applications/:candidate_id/:employer_id", func(ctx *gin.Context) {
+	authRoutes.GET("/candidate_applications/:employer_id/export", server.exportApplications)
+	authRoutes.PATCH("/candidate_applications/bulk", server.bulkUpdateApplications)
+	authRoutes.PATCH("/candidate_applications/:candidate_id/:job_id", func(ctx *gin.Context) {
 		server.updateApplication(ctx, false)
 	})
-	authRoutes.DELETE("/candidate_applications/:candidate_username", func(ctx *gin.Context) {
+	authRoutes.PATCH("/candidate_applications/:candidate_id/:job_id/accept", func(ctx *gin.Context) {
+		server.decideApplication(ctx, false, db.ApplicationStatusAccepted)
+	})
+	authRoutes.PATCH("/candidate_applications/:candidate_id/:job_id/reject", func(ctx *gin.Context) {
+		server.decideApplication(ctx, false, db.ApplicationStatusRejected)
+	})
+	authRoutes.PATCH("/candidate_applications/:candidate_id/:job_id/withdraw", server.withdrawApplication)
+	authRoutes.DELETE("/candidate_applications/:candidate_id/:job_id", func(ctx *gin.Context) {
 		server.deleteApplication(ctx, false)
 	})
+	authRoutes.POST("/candidate_applications/:candidate_id/:job_id/interviews", server.proposeInterview)
+
+	authRoutes.POST("/candidate_swipes", server.createCandidateSwipe)
+	authRoutes.POST("/job_shifts/:shift_id/claims", server.claimShift)
+	authRoutes.DELETE("/job_shifts/:shift_id/claims/:candidate_id", server.releaseShift)
 
 	// Employer Application Routes
 	authRoutes.POST("/employer_applications", func(ctx *gin.Context) {
@@ -57,10 +71,28 @@ func (server *Server) SetupRouter() {
 	authRoutes.PATCH("/employer_applications/:employer_id/:candidate_id", func(ctx *gin.Context) {
 		server.updateApplication(ctx, true)
 	})
-	authRoutes.DELETE("/employer_applications/:employer_username", func(ctx *gin.Context) {
+	authRoutes.PATCH("/employer_applications/:employer_id/:candidate_id/accept", func(ctx *gin.Context) {
+		server.decideApplication(ctx, true, db.ApplicationStatusAccepted)
+	})
+	authRoutes.PATCH("/employer_applications/:employer_id/:candidate_id/reject", func(ctx *gin.Context) {
+		server.decideApplication(ctx, true, db.ApplicationStatusRejected)
+	})
+	authRoutes.DELETE("/employer_applications/:employer_id/:candidate_id", func(ctx *gin.Context) {
 		server.deleteApplication(ctx, true)
 	})
 
+	// Interview Routes
+	authRoutes.GET("/interviews/:interview_id", server.getInterview)
+	authRoutes.PATCH("/interviews/:interview_id/slot", server.pickInterviewSlot)
+	authRoutes.PATCH("/interviews/:interview_id/reschedule", server.rescheduleInterview)
+	authRoutes.PATCH("/interviews/:interview_id/cancel", server.cancelInterview)
+
+	// Messaging Routes
+	authRoutes.POST("/messages", server.sendMessage)
+	authRoutes.GET("/conversations", server.listConversations)
+	authRoutes.GET("/conversations/:conversation_id/messages", server.listMessages)
+	authRoutes.PATCH("/conversations/:conversation_id/read", server.markConversationRead)
+
 	server.router = router
 }
 
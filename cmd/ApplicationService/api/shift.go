@@ -0,0 +1,146 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	"github.com/hankimmy/PtmrBackend/pkg/service"
+	"github.com/hankimmy/PtmrBackend/pkg/worker"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+type claimShiftURI struct {
+	ShiftID int64 `uri:"shift_id" binding:"required,min=1"`
+}
+
+type claimShiftRequest struct {
+	CandidateID int64 `json:"candidate_id" binding:"required,min=1"`
+}
+
+// claimShift gives the candidate one of the open spots on a shift. Spots are
+// counted under a lock on the shift so its headcount is never exceeded.
+func (server *Server) claimShift(ctx *gin.Context) {
+	var uri claimShiftURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
+		return
+	}
+	var req claimShiftRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
+		return
+	}
+	if _, ok := authorize(ctx, candidateActionPolicy, applicationResource{CandidateID: req.CandidateID}); !ok {
+		return
+	}
+
+	shift, ok := server.getShift(ctx, uri.ShiftID)
+	if !ok {
+		return
+	}
+	job, status, err := server.getApplicationJob(shift.JobID)
+	if err != nil {
+		ctx.JSON(status, service.ErrorResponse(err))
+		return
+	}
+	if !job.AcceptsApplications(time.Now()) {
+		ctx.JSON(http.StatusConflict, service.ErrorResponse(errors.New("job is not accepting applications")))
+		return
+	}
+
+	result, err := server.store.ClaimShiftTx(ctx, db.ClaimShiftTxParams{
+		CreateShiftClaimParams: db.CreateShiftClaimParams{
+			ShiftID:     shift.ID,
+			CandidateID: req.CandidateID,
+		},
+		Now: time.Now(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrRecordNotFound):
+			ctx.JSON(http.StatusNotFound, service.ErrorResponse(fmt.Errorf("shift %d not found", shift.ID)))
+		case errors.Is(err, db.ErrShiftFull), errors.Is(err, db.ErrShiftStarted):
+			ctx.JSON(http.StatusConflict, service.ErrorResponse(err))
+		case db.ErrorCode(err) == db.UniqueViolation:
+			ctx.JSON(http.StatusConflict, service.ErrorResponse(errors.New("shift has already been claimed")))
+		default:
+			ctx.JSON(http.StatusInternalServerError, service.ErrorResponse(err))
+		}
+		return
+	}
+
+	server.syncJobShifts(ctx, shift.JobID)
+	ctx.JSON(http.StatusOK, result.Claim)
+}
+
+type releaseShiftRequest struct {
+	ShiftID     int64 `uri:"shift_id" binding:"required,min=1"`
+	CandidateID int64 `uri:"candidate_id" binding:"required,min=1"`
+}
+
+// releaseShift gives up a candidate's claim on a shift that hasn't started.
+func (server *Server) releaseShift(ctx *gin.Context) {
+	var req releaseShiftRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
+		return
+	}
+	if _, ok := authorize(ctx, candidateActionPolicy, applicationResource{CandidateID: req.CandidateID}); !ok {
+		return
+	}
+
+	shift, ok := server.getShift(ctx, req.ShiftID)
+	if !ok {
+		return
+	}
+	if !shift.StartsAt.After(time.Now()) {
+		ctx.JSON(http.StatusConflict, service.ErrorResponse(db.ErrShiftStarted))
+		return
+	}
+
+	err := server.store.DeleteShiftClaim(ctx, db.DeleteShiftClaimParams{
+		ShiftID:     shift.ID,
+		CandidateID: req.CandidateID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, service.ErrorResponse(err))
+		return
+	}
+
+	server.syncJobShifts(ctx, shift.JobID)
+	ctx.JSON(http.StatusOK, gin.H{"message": "Shift released successfully"})
+}
+
+// getShift writes the error response itself and reports whether the handler
+// can continue.
+func (server *Server) getShift(ctx *gin.Context, shiftID int64) (db.JobShift, bool) {
+	shift, err := server.store.GetJobShift(ctx, shiftID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, service.ErrorResponse(fmt.Errorf("shift %d not found", shiftID)))
+			return db.JobShift{}, false
+		}
+		ctx.JSON(http.StatusInternalServerError, service.ErrorResponse(err))
+		return db.JobShift{}, false
+	}
+	return shift, true
+}
+
+// syncJobShifts refreshes the open spots on the job's document so the feed
+// stops showing full shifts. Failures are only logged: the claim itself has
+// already been stored.
+func (server *Server) syncJobShifts(ctx *gin.Context, jobID string) {
+	payload := &worker.PayloadSyncJobShifts{JobID: jobID}
+	opts := []asynq.Option{
+		asynq.MaxRetry(10),
+		asynq.Queue(worker.QueueDefault),
+	}
+	if err := server.taskDistributor.DistributeTaskSyncJobShifts(ctx, payload, opts...); err != nil {
+		log.Error().Err(err).Str("job_id", jobID).Msg("failed to enqueue job shift sync")
+	}
+}
@@ -0,0 +1,238 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/hankimmy/PtmrBackend/pkg/db/mock"
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	"github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
+	mockes "github.com/hankimmy/PtmrBackend/pkg/elasticsearch/mock"
+	"github.com/hankimmy/PtmrBackend/pkg/middleware"
+	"github.com/hankimmy/PtmrBackend/pkg/token"
+	"github.com/hankimmy/PtmrBackend/pkg/worker"
+	mockwk "github.com/hankimmy/PtmrBackend/pkg/worker/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimShiftAPI(t *testing.T) {
+	user, _ := db.RandomUser(db.RoleCandidate)
+	candidate := db.RandomCandidate(user.Username)
+	job := elasticsearch.RandomJob(1)
+	shift := db.RandomJobShift(job.ID, job.EmployerID)
+	pausedJob := job
+	pausedJob.Status = elasticsearch.JobStatusPaused
+	body := gin.H{"candidate_id": candidate.ID}
+
+	candidateAuth := func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+		middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleCandidate, time.Minute, candidate.ID)
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "OK",
+			body:      body,
+			setupAuth: candidateAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetJobShift(gomock.Any(), gomock.Eq(shift.ID)).Times(1).Return(shift, nil)
+				esClient.EXPECT().GetJob(gomock.Eq(job.ID)).Times(1).Return(&job, nil)
+				store.EXPECT().
+					ClaimShiftTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.ClaimShiftTxParams) (db.ClaimShiftTxResult, error) {
+						require.Equal(t, shift.ID, arg.ShiftID)
+						require.Equal(t, candidate.ID, arg.CandidateID)
+						require.WithinDuration(t, time.Now(), arg.Now, time.Second)
+						return db.ClaimShiftTxResult{
+							Shift:   shift,
+							Claim:   db.ShiftClaim{ShiftID: shift.ID, CandidateID: candidate.ID},
+							Claimed: 1,
+						}, nil
+					})
+				taskDistributor.EXPECT().
+					DistributeTaskSyncJobShifts(gomock.Any(), gomock.Eq(&worker.PayloadSyncJobShifts{JobID: job.ID}), gomock.Any()).
+					Times(1)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var claim db.ShiftClaim
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &claim))
+				require.Equal(t, shift.ID, claim.ShiftID)
+				require.Equal(t, candidate.ID, claim.CandidateID)
+			},
+		},
+		{
+			name: "OtherCandidate",
+			body: body,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleCandidate, time.Minute, candidate.ID+1)
+			},
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetJobShift(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:      "ShiftNotFound",
+			body:      body,
+			setupAuth: candidateAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetJobShift(gomock.Any(), gomock.Eq(shift.ID)).Times(1).Return(db.JobShift{}, db.ErrRecordNotFound)
+				store.EXPECT().ClaimShiftTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:      "JobNotAccepting",
+			body:      body,
+			setupAuth: candidateAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetJobShift(gomock.Any(), gomock.Eq(shift.ID)).Times(1).Return(shift, nil)
+				esClient.EXPECT().GetJob(gomock.Eq(job.ID)).Times(1).Return(&pausedJob, nil)
+				store.EXPECT().ClaimShiftTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:      "ShiftFull",
+			body:      body,
+			setupAuth: candidateAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetJobShift(gomock.Any(), gomock.Eq(shift.ID)).Times(1).Return(shift, nil)
+				esClient.EXPECT().GetJob(gomock.Eq(job.ID)).Times(1).Return(&job, nil)
+				store.EXPECT().ClaimShiftTx(gomock.Any(), gomock.Any()).Times(1).Return(db.ClaimShiftTxResult{}, db.ErrShiftFull)
+				taskDistributor.EXPECT().DistributeTaskSyncJobShifts(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:      "ShiftStarted",
+			body:      body,
+			setupAuth: candidateAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetJobShift(gomock.Any(), gomock.Eq(shift.ID)).Times(1).Return(shift, nil)
+				esClient.EXPECT().GetJob(gomock.Eq(job.ID)).Times(1).Return(&job, nil)
+				store.EXPECT().ClaimShiftTx(gomock.Any(), gomock.Any()).Times(1).Return(db.ClaimShiftTxResult{}, db.ErrShiftStarted)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:      "AlreadyClaimed",
+			body:      body,
+			setupAuth: candidateAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetJobShift(gomock.Any(), gomock.Eq(shift.ID)).Times(1).Return(shift, nil)
+				esClient.EXPECT().GetJob(gomock.Eq(job.ID)).Times(1).Return(&job, nil)
+				store.EXPECT().ClaimShiftTx(gomock.Any(), gomock.Any()).Times(1).Return(db.ClaimShiftTxResult{}, db.ErrUniqueViolation)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+			esClient := mockes.NewMockESClient(ctrl)
+			taskDistributor := mockwk.NewMockTaskDistributor(ctrl)
+			tc.buildStubs(store, esClient, taskDistributor)
+
+			server := newTestServer(t, store, esClient, taskDistributor)
+			recorder := httptest.NewRecorder()
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+			url := fmt.Sprintf("/job_shifts/%d/claims", shift.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestReleaseShiftAPI(t *testing.T) {
+	user, _ := db.RandomUser(db.RoleCandidate)
+	candidate := db.RandomCandidate(user.Username)
+	shift := db.RandomJobShift("1_shifts", 1)
+	startedShift := shift
+	startedShift.StartsAt = time.Now().Add(-time.Hour)
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetJobShift(gomock.Any(), gomock.Eq(shift.ID)).Times(1).Return(shift, nil)
+				arg := db.DeleteShiftClaimParams{ShiftID: shift.ID, CandidateID: candidate.ID}
+				store.EXPECT().DeleteShiftClaim(gomock.Any(), gomock.Eq(arg)).Times(1).Return(nil)
+				taskDistributor.EXPECT().
+					DistributeTaskSyncJobShifts(gomock.Any(), gomock.Eq(&worker.PayloadSyncJobShifts{JobID: shift.JobID}), gomock.Any()).
+					Times(1)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "ShiftStarted",
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetJobShift(gomock.Any(), gomock.Eq(shift.ID)).Times(1).Return(startedShift, nil)
+				store.EXPECT().DeleteShiftClaim(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+			taskDistributor := mockwk.NewMockTaskDistributor(ctrl)
+			tc.buildStubs(store, taskDistributor)
+
+			server := newTestServer(t, store, nil, taskDistributor)
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/job_shifts/%d/claims/%d", shift.ID, candidate.ID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			middleware.AddAuthorization(t, request, server.tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleCandidate, time.Minute, candidate.ID)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
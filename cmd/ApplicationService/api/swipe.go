@@ -0,0 +1,56 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	"github.com/hankimmy/PtmrBackend/pkg/service"
+	"github.com/hankimmy/PtmrBackend/pkg/worker"
+)
+
+type createCandidateSwipeRequest struct {
+	CandidateID int64    `json:"candidate_id" binding:"required,min=1"`
+	JobID       string   `json:"job_id" binding:"required"`
+	Swipe       db.Swipe `json:"swipe" binding:"required,oneof=accept reject"`
+}
+
+// createCandidateSwipe records a candidate swiping on a job in their feed.
+// A candidate can only swipe on a job once.
+func (server *Server) createCandidateSwipe(ctx *gin.Context) {
+	var req createCandidateSwipeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
+		return
+	}
+	if _, ok := authorize(ctx, candidateActionPolicy, applicationResource{CandidateID: req.CandidateID}); !ok {
+		return
+	}
+	job, status, err := server.getApplicationJob(req.JobID)
+	if err != nil {
+		ctx.JSON(status, service.ErrorResponse(err))
+		return
+	}
+
+	arg := db.CreateCandidateSwipeParams{
+		CandidateID: req.CandidateID,
+		JobID:       job.ID,
+		Swipe:       req.Swipe,
+	}
+	if err := server.store.CreateCandidateSwipe(ctx, arg); err != nil {
+		if db.ErrorCode(err) == db.UniqueViolation {
+			ctx.JSON(http.StatusConflict, service.ErrorResponse(errors.New("job has already been swiped")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, service.ErrorResponse(err))
+		return
+	}
+
+	event := worker.JobStatLeftSwipe
+	if req.Swipe == db.SwipeAccept {
+		event = worker.JobStatRightSwipe
+	}
+	worker.RecordJobStat(ctx, server.taskDistributor, event, *job)
+	ctx.JSON(http.StatusOK, arg)
+}
@@ -0,0 +1,167 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/hankimmy/PtmrBackend/pkg/db/mock"
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	"github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
+	mockes "github.com/hankimmy/PtmrBackend/pkg/elasticsearch/mock"
+	"github.com/hankimmy/PtmrBackend/pkg/middleware"
+	"github.com/hankimmy/PtmrBackend/pkg/token"
+	"github.com/hankimmy/PtmrBackend/pkg/worker"
+	mockwk "github.com/hankimmy/PtmrBackend/pkg/worker/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateCandidateSwipeAPI(t *testing.T) {
+	user, _ := db.RandomUser(db.RoleCandidate)
+	candidate := db.RandomCandidate(user.Username)
+	job := elasticsearch.RandomJob(1)
+
+	candidateAuth := func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+		middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleCandidate, time.Minute, candidate.ID)
+	}
+	swipeBody := func(swipe db.Swipe) gin.H {
+		return gin.H{
+			"candidate_id": candidate.ID,
+			"job_id":       job.ID,
+			"swipe":        swipe,
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "Accept",
+			body:      swipeBody(db.SwipeAccept),
+			setupAuth: candidateAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().GetJob(gomock.Eq(job.ID)).Times(1).Return(&job, nil)
+				arg := db.CreateCandidateSwipeParams{
+					CandidateID: candidate.ID,
+					JobID:       job.ID,
+					Swipe:       db.SwipeAccept,
+				}
+				store.EXPECT().CreateCandidateSwipe(gomock.Any(), gomock.Eq(arg)).Times(1).Return(nil)
+				payload := &worker.PayloadIncrementJobStats{
+					Event: worker.JobStatRightSwipe,
+					Jobs:  []worker.JobStatTarget{{JobID: job.ID, EmployerID: job.EmployerID}},
+				}
+				taskDistributor.EXPECT().
+					DistributeTaskIncrementJobStats(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, got *worker.PayloadIncrementJobStats, _ ...interface{}) error {
+						require.Equal(t, payload.Event, got.Event)
+						require.Equal(t, payload.Jobs, got.Jobs)
+						return nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:      "Reject",
+			body:      swipeBody(db.SwipeReject),
+			setupAuth: candidateAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().GetJob(gomock.Eq(job.ID)).Times(1).Return(&job, nil)
+				store.EXPECT().CreateCandidateSwipe(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				taskDistributor.EXPECT().
+					DistributeTaskIncrementJobStats(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, got *worker.PayloadIncrementJobStats, _ ...interface{}) error {
+						require.Equal(t, worker.JobStatLeftSwipe, got.Event)
+						return nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:      "InvalidSwipe",
+			body:      swipeBody("maybe"),
+			setupAuth: candidateAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().CreateCandidateSwipe(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "OtherCandidate",
+			body: swipeBody(db.SwipeAccept),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleCandidate, time.Minute, candidate.ID+1)
+			},
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().CreateCandidateSwipe(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:      "JobNotFound",
+			body:      swipeBody(db.SwipeAccept),
+			setupAuth: candidateAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().GetJob(gomock.Eq(job.ID)).Times(1).Return(nil, nil)
+				store.EXPECT().CreateCandidateSwipe(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:      "AlreadySwiped",
+			body:      swipeBody(db.SwipeAccept),
+			setupAuth: candidateAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().GetJob(gomock.Eq(job.ID)).Times(1).Return(&job, nil)
+				store.EXPECT().CreateCandidateSwipe(gomock.Any(), gomock.Any()).Times(1).Return(db.ErrUniqueViolation)
+				taskDistributor.EXPECT().DistributeTaskIncrementJobStats(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+			esClient := mockes.NewMockESClient(ctrl)
+			taskDistributor := mockwk.NewMockTaskDistributor(ctrl)
+			tc.buildStubs(store, esClient, taskDistributor)
+
+			server := newTestServer(t, store, esClient, taskDistributor)
+			recorder := httptest.NewRecorder()
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+			request, err := http.NewRequest(http.MethodPost, "/candidate_swipes", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	"github.com/hankimmy/PtmrBackend/pkg/service"
+	"github.com/hankimmy/PtmrBackend/pkg/worker"
+	"github.com/hibiken/asynq"
+)
+
+// defaultReapplyCooldown is how long a candidate waits to apply to a job
+// again after withdrawing from it when REAPPLY_COOLDOWN isn't configured.
+const defaultReapplyCooldown = 14 * 24 * time.Hour
+
+func (server *Server) reapplyCooldown() time.Duration {
+	if server.config.ReapplyCooldown > 0 {
+		return server.config.ReapplyCooldown
+	}
+	return defaultReapplyCooldown
+}
+
+type withdrawApplicationRequest struct {
+	Reason db.WithdrawalReason `json:"reason" binding:"required,oneof=accepted_other_offer schedule_conflict compensation no_longer_interested other"`
+	Note   string              `json:"note" binding:"max=500"`
+}
+
+// withdrawApplication lets a candidate take back an application that hasn't
+// been decided on. The application is kept with its reason so the employer
+// doesn't lose its context, and the employer is told about it.
+func (server *Server) withdrawApplication(ctx *gin.Context) {
+	var uri applicationURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
+		return
+	}
+	var req withdrawApplicationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, service.ErrorResponse(err))
+		return
+	}
+	resource, current, ok := server.resolveApplication(ctx, db.ApplicationKindCandidate, uri)
+	if !ok {
+		return
+	}
+	if _, ok := authorize(ctx, withdrawApplicationPolicy, resource); !ok {
+		return
+	}
+
+	result, err := server.store.WithdrawCandidateApplicationTx(ctx, db.WithdrawCandidateApplicationTxParams{
+		CandidateID: current.CandidateID,
+		JobDocID:    current.JobDocID,
+		Reason:      req.Reason,
+		Note:        req.Note,
+		AfterUpdate: server.afterApplicationWithdrawn(ctx, req),
+	})
+	if err != nil {
+		handleTransitionError(ctx, err)
+		return
+	}
+
+	history, err := server.listStatusHistory(ctx, db.ApplicationKindCandidate, resource.CandidateID, resource.EmployerID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, service.ErrorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, candidateApplicationResponse{
+		CandidateApplication: result.CandidateApplication,
+		StatusHistory:        statusHistoryOf(history, candidateApplicationKey(result.CandidateApplication)),
+	})
+}
+
+// afterApplicationWithdrawn enqueues an email telling the employer about the
+// withdrawal from inside the transaction.
+func (server *Server) afterApplicationWithdrawn(ctx *gin.Context, req withdrawApplicationRequest) func(result db.TransitionApplicationStatusTxResult) error {
+	return func(result db.TransitionApplicationStatusTxResult) error {
+		taskPayload := &worker.PayloadNotifyApplicationWithdrawn{
+			CandidateID: result.CandidateApplication.CandidateID,
+			EmployerID:  result.CandidateApplication.EmployerID,
+			JobID:       result.CandidateApplication.JobDocID,
+			Reason:      req.Reason,
+			Note:        req.Note,
+		}
+		opts := []asynq.Option{
+			asynq.MaxRetry(10),
+			asynq.ProcessIn(10 * time.Second),
+			asynq.Queue(worker.QueueMail),
+		}
+		return server.taskDistributor.DistributeTaskNotifyApplicationWithdrawn(ctx, taskPayload, opts...)
+	}
+}
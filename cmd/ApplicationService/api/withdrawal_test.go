@@ -0,0 +1,189 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/hankimmy/PtmrBackend/pkg/db/mock"
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	mockes "github.com/hankimmy/PtmrBackend/pkg/elasticsearch/mock"
+	"github.com/hankimmy/PtmrBackend/pkg/middleware"
+	"github.com/hankimmy/PtmrBackend/pkg/token"
+	"github.com/hankimmy/PtmrBackend/pkg/util"
+	"github.com/hankimmy/PtmrBackend/pkg/worker"
+	mockwk "github.com/hankimmy/PtmrBackend/pkg/worker/mock"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithdrawApplicationAPI(t *testing.T) {
+	application := db.RandomCandidateApplication(util.RandomInt(1, 1000))
+	application.CandidateID = util.RandomInt(1, 1000)
+	application.ApplicationStatus = db.ApplicationStatusSubmitted
+	withdrawn := application
+	withdrawn.ApplicationStatus = db.ApplicationStatusWithdrawn
+	withdrawn.WithdrawalReason = db.NullWithdrawalReason{WithdrawalReason: db.WithdrawalReasonScheduleConflict, Valid: true}
+	withdrawn.WithdrawalNote = "Found a job closer to home"
+	withdrawn.WithdrawnAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+	body := gin.H{"reason": "schedule_conflict", "note": "Found a job closer to home"}
+
+	candidateAuth := func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+		middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, "candidate", db.RoleCandidate, time.Minute, application.CandidateID)
+	}
+	getApplication := func(store *mockdb.MockStore) {
+		store.EXPECT().
+			GetCandidateApplication(gomock.Any(), gomock.Eq(db.GetCandidateApplicationParams{CandidateID: application.CandidateID, JobDocID: application.JobDocID})).
+			Times(1).
+			Return(application, nil)
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "OK",
+			body:      body,
+			setupAuth: candidateAuth,
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				getApplication(store)
+				store.EXPECT().
+					WithdrawCandidateApplicationTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.WithdrawCandidateApplicationTxParams) (db.TransitionApplicationStatusTxResult, error) {
+						require.Equal(t, application.CandidateID, arg.CandidateID)
+						require.Equal(t, application.JobDocID, arg.JobDocID)
+						require.Equal(t, db.WithdrawalReasonScheduleConflict, arg.Reason)
+						require.Equal(t, "Found a job closer to home", arg.Note)
+						result := db.TransitionApplicationStatusTxResult{CandidateApplication: withdrawn, Changed: true}
+						return result, arg.AfterUpdate(result)
+					})
+				taskDistributor.EXPECT().
+					DistributeTaskNotifyApplicationWithdrawn(gomock.Any(), gomock.Eq(&worker.PayloadNotifyApplicationWithdrawn{
+						CandidateID: application.CandidateID,
+						EmployerID:  application.EmployerID,
+						JobID:       application.JobDocID,
+						Reason:      db.WithdrawalReasonScheduleConflict,
+						Note:        "Found a job closer to home",
+					}), gomock.Any()).
+					Times(1)
+				store.EXPECT().
+					ListApplicationStatusHistory(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ApplicationStatusHistory{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var got candidateApplicationResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, db.ApplicationStatusWithdrawn, got.ApplicationStatus)
+				require.Equal(t, db.WithdrawalReasonScheduleConflict, got.WithdrawalReason.WithdrawalReason)
+				require.True(t, got.WithdrawnAt.Valid)
+			},
+		},
+		{
+			name:      "AlreadyDecided",
+			body:      body,
+			setupAuth: candidateAuth,
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				getApplication(store)
+				store.EXPECT().
+					WithdrawCandidateApplicationTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.TransitionApplicationStatusTxResult{}, db.ErrInvalidStatusTransition)
+				taskDistributor.EXPECT().DistributeTaskNotifyApplicationWithdrawn(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:      "UnknownReason",
+			body:      gin.H{"reason": "bored"},
+			setupAuth: candidateAuth,
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetCandidateApplication(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().WithdrawCandidateApplicationTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Employer",
+			body: body,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, "employer", db.RoleEmployer, time.Minute, application.EmployerID)
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				getApplication(store)
+				store.EXPECT().WithdrawCandidateApplicationTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:      "NotFound",
+			body:      body,
+			setupAuth: candidateAuth,
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetCandidateApplication(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CandidateApplication{}, db.ErrRecordNotFound)
+				store.EXPECT().WithdrawCandidateApplicationTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "OtherCandidate",
+			body: body,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, "candidate", db.RoleCandidate, time.Minute, application.CandidateID+1)
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				// The application isn't looked up for a caller who can't be a party.
+				store.EXPECT().GetCandidateApplication(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().WithdrawCandidateApplicationTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+			taskDistributor := mockwk.NewMockTaskDistributor(ctrl)
+			tc.buildStubs(store, taskDistributor)
+
+			server := newTestServer(t, store, mockes.NewMockESClient(ctrl), taskDistributor)
+			recorder := httptest.NewRecorder()
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+			url := fmt.Sprintf("/candidate_applications/%d/%s/withdraw", application.CandidateID, application.JobDocID)
+			request, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
 	"github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
+	"github.com/hankimmy/PtmrBackend/pkg/google"
 	"github.com/hankimmy/PtmrBackend/pkg/service"
 	"github.com/hankimmy/PtmrBackend/pkg/worker"
 	"github.com/hibiken/asynq"
@@ -42,7 +43,7 @@ func runTaskProcessor(
 	store db.Store,
 	esClient elasticsearch.ESClient,
 ) {
-	taskProcessor := worker.NewRedisTaskProcessor(redisOpt, store, esClient, nil)
+	taskProcessor := worker.NewRedisTaskProcessor(redisOpt, store, esClient, nil, google.NewGoogleService())
 
 	log.Info().Msg("start task processor")
 	err := taskProcessor.Start()
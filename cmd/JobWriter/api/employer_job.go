@@ -0,0 +1,108 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
+)
+
+// maxJobResultWindow is the deepest result Elasticsearch pages into by
+// default (index.max_result_window).
+const maxJobResultWindow = 10000
+
+type listEmployerJobsRequest struct {
+	Status   []elasticsearch.JobStatus `form:"status"`
+	Sort     elasticsearch.JobSort     `form:"sort" binding:"omitempty,oneof=newest oldest title wage"`
+	PageID   int32                     `form:"page_id" binding:"required,min=1"`
+	PageSize int32                     `form:"page_size" binding:"required,min=5,max=50"`
+}
+
+type employerJob struct {
+	elasticsearch.Job
+	Applicants     int64 `json:"applicants"`
+	OpenApplicants int64 `json:"open_applicants"`
+}
+
+type listEmployerJobsResponse struct {
+	Jobs     []employerJob `json:"jobs"`
+	Total    int64         `json:"total"`
+	PageID   int32         `json:"page_id"`
+	PageSize int32         `json:"page_size"`
+}
+
+// ListEmployerJobs returns a page of the employer's jobs, optionally filtered
+// by status, with the number of candidates that applied to each.
+func (server *Server) ListEmployerJobs(ctx *gin.Context) {
+	var uri employerURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if !authorizeEmployer(ctx, uri.EmployerID) {
+		return
+	}
+	var req listEmployerJobsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	for _, status := range req.Status {
+		if !status.Valid() {
+			ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid job status %q", status)))
+			return
+		}
+	}
+	from := int(req.PageID-1) * int(req.PageSize)
+	if from+int(req.PageSize) > maxJobResultWindow {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("cannot page past the first %d jobs", maxJobResultWindow)))
+		return
+	}
+
+	result, err := server.esClient.SearchEmployerJobs(ctx, elasticsearch.EmployerJobsParams{
+		EmployerID: uri.EmployerID,
+		Statuses:   req.Status,
+		Sort:       req.Sort,
+		From:       from,
+		Size:       int(req.PageSize),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := listEmployerJobsResponse{
+		Jobs:     make([]employerJob, 0, len(result.Jobs)),
+		Total:    result.Total,
+		PageID:   req.PageID,
+		PageSize: req.PageSize,
+	}
+	if len(result.Jobs) == 0 {
+		ctx.JSON(http.StatusOK, res)
+		return
+	}
+
+	ids := make([]string, 0, len(result.Jobs))
+	for _, job := range result.Jobs {
+		ids = append(ids, job.ID)
+	}
+	counts, err := server.store.CountApplicantsByJobs(ctx, ids)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	byJob := make(map[string]int, len(counts))
+	for i, row := range counts {
+		byJob[row.JobDocID] = i
+	}
+	for _, job := range result.Jobs {
+		item := employerJob{Job: job}
+		if i, ok := byJob[job.ID]; ok {
+			item.Applicants = counts[i].Applicants
+			item.OpenApplicants = counts[i].OpenApplicants
+		}
+		res.Jobs = append(res.Jobs, item)
+	}
+	ctx.JSON(http.StatusOK, res)
+}
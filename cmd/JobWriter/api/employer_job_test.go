@@ -0,0 +1,171 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/hankimmy/PtmrBackend/pkg/db/mock"
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	"github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
+	mockes "github.com/hankimmy/PtmrBackend/pkg/elasticsearch/mock"
+	"github.com/hankimmy/PtmrBackend/pkg/middleware"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListEmployerJobs(t *testing.T) {
+	user, _ := db.RandomUser(db.RoleEmployer)
+	employer := db.RandomEmployer(user.Username)
+	jobs := []elasticsearch.Job{
+		elasticsearch.RandomJob(employer.ID),
+		elasticsearch.RandomJob(employer.ID),
+	}
+
+	testCases := []struct {
+		name          string
+		employerID    int64
+		query         string
+		buildStubs    func(store *mockdb.MockStore, esClient *mockes.MockESClient)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:       "OK",
+			employerID: employer.ID,
+			query:      "?status=published&status=paused&sort=title&page_id=2&page_size=5",
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				arg := elasticsearch.EmployerJobsParams{
+					EmployerID: employer.ID,
+					Statuses:   []elasticsearch.JobStatus{elasticsearch.JobStatusPublished, elasticsearch.JobStatusPaused},
+					Sort:       elasticsearch.JobSortTitle,
+					From:       5,
+					Size:       5,
+				}
+				esClient.EXPECT().
+					SearchEmployerJobs(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(&elasticsearch.EmployerJobsResult{Jobs: jobs, Total: 7}, nil)
+				store.EXPECT().
+					CountApplicantsByJobs(gomock.Any(), gomock.Eq([]string{jobs[0].ID, jobs[1].ID})).
+					Times(1).
+					Return([]db.CountApplicantsByJobsRow{
+						{JobDocID: jobs[1].ID, Applicants: 3, OpenApplicants: 2},
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var res listEmployerJobsResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Equal(t, int64(7), res.Total)
+				require.Equal(t, int32(2), res.PageID)
+				require.Len(t, res.Jobs, 2)
+				require.Equal(t, jobs[0].ID, res.Jobs[0].ID)
+				require.Zero(t, res.Jobs[0].Applicants)
+				require.Equal(t, jobs[1].Title, res.Jobs[1].Title)
+				require.Equal(t, int64(3), res.Jobs[1].Applicants)
+				require.Equal(t, int64(2), res.Jobs[1].OpenApplicants)
+			},
+		},
+		{
+			name:       "NoJobs",
+			employerID: employer.ID,
+			query:      "?page_id=1&page_size=10",
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				esClient.EXPECT().
+					SearchEmployerJobs(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&elasticsearch.EmployerJobsResult{Jobs: []elasticsearch.Job{}}, nil)
+				store.EXPECT().CountApplicantsByJobs(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var res listEmployerJobsResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.NotNil(t, res.Jobs)
+				require.Empty(t, res.Jobs)
+			},
+		},
+		{
+			name:       "InvalidStatus",
+			employerID: employer.ID,
+			query:      "?status=archived&page_id=1&page_size=10",
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				esClient.EXPECT().SearchEmployerJobs(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:       "InvalidSort",
+			employerID: employer.ID,
+			query:      "?sort=random&page_id=1&page_size=10",
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				esClient.EXPECT().SearchEmployerJobs(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:       "PageTooDeep",
+			employerID: employer.ID,
+			query:      "?page_id=1000&page_size=50",
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				esClient.EXPECT().SearchEmployerJobs(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:       "OtherEmployer",
+			employerID: employer.ID + 1,
+			query:      "?page_id=1&page_size=10",
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				esClient.EXPECT().SearchEmployerJobs(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:       "SearchError",
+			employerID: employer.ID,
+			query:      "?page_id=1&page_size=10",
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				esClient.EXPECT().
+					SearchEmployerJobs(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, errors.New("es down"))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+			esClient := mockes.NewMockESClient(ctrl)
+			tc.buildStubs(store, esClient)
+
+			server := newTestServer(t, store, esClient, nil)
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/employers/%d/jobs%s", tc.employerID, tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			middleware.AddAuthorization(t, request, server.tokenMaker, middleware.AuthorizationTypeBearer, employer.Username, db.RoleEmployer, time.Minute, employer.ID)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -1,42 +1,36 @@
 package api
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
-	"regexp"
-	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
 	"github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
 	"github.com/hankimmy/PtmrBackend/pkg/middleware"
+	"github.com/hankimmy/PtmrBackend/pkg/moderation"
 	"github.com/hankimmy/PtmrBackend/pkg/token"
+	"github.com/hankimmy/PtmrBackend/pkg/worker"
+	"github.com/rs/zerolog/log"
 )
 
 type createJobRequest struct {
-	EmployerID     int64           `uri:"employer_id" binding:"required,min=1"`
-	BusinessName   string          `json:"business_name"`
-	Title          string          `json:"title"`
-	Description    string          `json:"description"`
-	Industry       string          `json:"industry"`
-	JobLocation    string          `json:"job_location"`
-	EmploymentType string          `json:"employment_type"`
-	Wage           float32         `json:"wage"`
-	Tips           float32         `json:"tips,omitempty"`
-	JobApplication json.RawMessage `json:"job_application,omitempty"`
-}
-
-func createGooglePlaceIDQuery(jobLocation, businessName string) string {
-	re := regexp.MustCompile(`,?\s*[A-Z]{2}\s*\d{5}(?:-\d{4})?$`)
-	cleanedAddress := re.ReplaceAllString(jobLocation, "")
-	cleanedAddress = strings.TrimSpace(cleanedAddress)
-	if strings.HasSuffix(cleanedAddress, ",") {
-		cleanedAddress = strings.TrimSuffix(cleanedAddress, ",")
-	}
-	return businessName + " " + cleanedAddress
+	EmployerID      int64                    `uri:"employer_id" binding:"required,min=1"`
+	BusinessName    string                   `json:"business_name"`
+	Title           string                   `json:"title"`
+	Description     string                   `json:"description"`
+	Industry        string                   `json:"industry"`
+	JobLocation     string                   `json:"job_location"`
+	EmploymentType  string                   `json:"employment_type"`
+	Wage            float32                  `json:"wage"`
+	Tips            float32                  `json:"tips,omitempty"`
+	JobApplication  []elasticsearch.Question `json:"job_application,omitempty"`
+	Status          string                   `json:"status" binding:"omitempty,oneof=draft published"`
+	PublishAt       *time.Time               `json:"publish_at,omitempty"`
+	CloseAt         *time.Time               `json:"close_at,omitempty"`
+	MaxApplications int32                    `json:"max_applications,omitempty" binding:"min=0"`
 }
 
 func (server *Server) CreateJob(ctx *gin.Context) {
@@ -54,61 +48,108 @@ func (server *Server) CreateJob(ctx *gin.Context) {
 		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("account is not an employer")))
 		return
 	}
+	server.postJob(ctx, authPayload.RoleID, &req)
+}
 
-	placeID, err := server.gapi.GetPlaceID(createGooglePlaceIDQuery(req.JobLocation, req.BusinessName))
-	if err != nil {
+// postJob validates and creates a new job for the employer, then schedules its
+// place enrichment and status transitions.
+func (server *Server) postJob(ctx *gin.Context, employerID int64, req *createJobRequest) {
+	if err := elasticsearch.ApplicationForm(req.JobApplication).Validate(); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
-
-	data, err := server.gapi.GetPlaceDetails(placeID)
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+	now := time.Now()
+	if err := elasticsearch.ValidateJobSchedule(req.PublishAt, req.CloseAt, req.MaxApplications, now); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
+	status := elasticsearch.JobStatusPublished
+	if req.Status != "" {
+		status = elasticsearch.JobStatus(req.Status)
+	}
+	// A job with a future publish_at stays a draft until it goes live.
+	if req.PublishAt != nil && req.PublishAt.After(now) {
+		status = elasticsearch.JobStatusDraft
+	}
+
 	arg := elasticsearch.Job{
-		ID:                 fmt.Sprintf("%d_%s", authPayload.RoleID, req.Title),
-		EmployerID:         authPayload.RoleID,
+		ID:                 fmt.Sprintf("%d_%s", employerID, req.Title),
+		EmployerID:         employerID,
 		HiringOrganization: req.BusinessName,
 		Title:              req.Title,
 		Industry:           req.Industry,
 		JobLocation:        req.JobLocation,
-		DatePosted:         time.Now().Format("2006-January-02"),
+		DatePosted:         now.Format(elasticsearch.DatePostedFormat),
 		Description:        req.Description,
 		EmploymentType:     req.EmploymentType,
 		Wage:               req.Wage,
 		Tips:               req.Tips,
 		JobApplication:     req.JobApplication,
 		IsUserCreated:      true,
-		PlaceID:            data.ID,
-		DisplayName:        data.DisplayName.Text,
-		PhoneNumber:        data.NationalPhoneNumber,
-		BusinessType:       data.Types,
-		FormattedAddress:   data.FormattedAddress,
-		PreciseLocation: elasticsearch.GeoPoint{
-			Lat: data.Location.Latitude,
-			Lon: data.Location.Longitude,
-		},
-		Photos:        data.Photos,
-		Rating:        data.Rating,
-		PriceLevel:    data.PriceLevel,
-		OpeningHours:  data.RegularOpeningHours,
-		WebsiteURI:    data.WebsiteURI,
-		GoogleMapsURI: data.GoogleMapsURI,
-	}
-
-	if err := server.esClient.IndexJob(&arg); err != nil {
+		Status:             status,
+		PublishAt:          req.PublishAt,
+		CloseAt:            req.CloseAt,
+		MaxApplications:    req.MaxApplications,
+		EnrichmentStatus:   elasticsearch.EnrichmentPending,
+	}
+
+	review := server.moderateJob(&arg)
+	if review.Decision == moderation.DecisionRejected {
+		ctx.JSON(http.StatusUnprocessableEntity, moderationRejectedResponse(review))
+		return
+	}
+	if duplicate := server.findDuplicateJob(ctx, &arg); duplicate != nil {
+		arg.DuplicateOf = duplicate.ID
+	}
+
+	// The ID comes from the title, so posting the same title again must not
+	// replace the live job and hand it the old job's applicants.
+	if err := server.esClient.CreateJob(&arg); err != nil {
+		if errors.Is(err, elasticsearch.ErrJobExists) {
+			ctx.JSON(http.StatusConflict, errorResponse(fmt.Errorf("job %s already exists", arg.ID)))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
-	ctx.JSON(http.StatusOK, gin.H{"message": "Job created successfully"})
+	// The review is keyed by the job ID, so it is only queued once the job
+	// was created. The job is kept if queueing fails.
+	if err := server.syncModerationReview(ctx, &arg, review); err != nil {
+		log.Error().Err(err).Str("job_id", arg.ID).Msg("failed to sync job moderation review")
+	}
+	server.enrichJobPlace(ctx, &arg)
+	server.scheduleJobTransitions(ctx, &arg, now)
+
+	res := gin.H{"message": "Job created successfully"}
+	if arg.DuplicateOf != "" {
+		res["duplicate_of"] = arg.DuplicateOf
+	}
+	if review.Decision == moderation.DecisionNeedsReview {
+		res["moderation_status"] = review.Decision
+		res["findings"] = review.Findings
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// findDuplicateJob returns the existing job the new job likely re-posts.
+// Detection is best effort: a failed search is logged and the job is posted.
+func (server *Server) findDuplicateJob(ctx *gin.Context, job *elasticsearch.Job) *elasticsearch.Job {
+	duplicate, err := server.esClient.FindDuplicateJob(ctx, job)
+	if err != nil {
+		log.Error().Err(err).Str("job_id", job.ID).Msg("failed to check for duplicate jobs")
+		return nil
+	}
+	return duplicate
 }
 
 type getJobRequest struct {
 	JobID string `uri:"job_id" binding:"required"`
 }
 
+// GetJob returns a job. Jobs that are not live, such as drafts and paused
+// jobs, are only shown to the employer that owns them and are reported as
+// not found to everyone else.
 func (server *Server) GetJob(ctx *gin.Context) {
 	var req getJobRequest
 	if err := ctx.ShouldBindUri(&req); err != nil {
@@ -122,20 +163,32 @@ func (server *Server) GetJob(ctx *gin.Context) {
 		return
 	}
 
+	authPayload := ctx.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload)
+	isOwner := job != nil && authPayload.Role == db.RoleEmployer && authPayload.RoleID == job.EmployerID
+	if job == nil || (!isOwner && !job.AcceptsApplications(time.Now())) {
+		ctx.JSON(http.StatusNotFound, errorResponse(fmt.Errorf("job %s not found", req.JobID)))
+		return
+	}
+	if authPayload.Role == db.RoleCandidate {
+		worker.RecordJobStat(ctx, server.taskDistributor, worker.JobStatView, *job)
+	}
 	ctx.JSON(http.StatusOK, job)
 }
 
 type updateJobRequest struct {
-	JobID          string          `uri:"job_id" binding:"required,min=1"`
-	EmployerID     int64           `json:"employer_id"`
-	Title          string          `json:"title"`
-	Description    string          `json:"description"`
-	Industry       string          `json:"industry"`
-	JobLocation    string          `json:"job_location"`
-	EmploymentType string          `json:"employment_type"`
-	Wage           float32         `json:"wage"`
-	Tips           float32         `json:"tips,omitempty"`
-	JobApplication json.RawMessage `json:"job_application"`
+	JobID           string                   `uri:"job_id" binding:"required,min=1"`
+	EmployerID      int64                    `json:"employer_id"`
+	Title           string                   `json:"title"`
+	Description     string                   `json:"description"`
+	Industry        string                   `json:"industry"`
+	JobLocation     string                   `json:"job_location"`
+	EmploymentType  string                   `json:"employment_type"`
+	Wage            float32                  `json:"wage"`
+	Tips            float32                  `json:"tips,omitempty"`
+	JobApplication  []elasticsearch.Question `json:"job_application"`
+	PublishAt       *time.Time               `json:"publish_at,omitempty"`
+	CloseAt         *time.Time               `json:"close_at,omitempty"`
+	MaxApplications int32                    `json:"max_applications,omitempty" binding:"min=0"`
 }
 
 func (server *Server) UpdateJob(ctx *gin.Context) {
@@ -154,24 +207,50 @@ func (server *Server) UpdateJob(ctx *gin.Context) {
 		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("account is not an employer")))
 		return
 	}
+	if err := elasticsearch.ApplicationForm(req.JobApplication).Validate(); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	now := time.Now()
+	if err := elasticsearch.ValidateJobSchedule(req.PublishAt, req.CloseAt, req.MaxApplications, now); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
 
 	arg := elasticsearch.Job{
-		ID:             req.JobID,
-		EmployerID:     req.EmployerID,
-		Title:          req.Title,
-		Description:    req.Description,
-		JobLocation:    req.JobLocation,
-		Industry:       req.Industry,
-		EmploymentType: req.EmploymentType,
-		Wage:           req.Wage,
-		Tips:           req.Tips,
-		JobApplication: req.JobApplication,
+		ID:              req.JobID,
+		EmployerID:      req.EmployerID,
+		Title:           req.Title,
+		Description:     req.Description,
+		JobLocation:     req.JobLocation,
+		Industry:        req.Industry,
+		EmploymentType:  req.EmploymentType,
+		Wage:            req.Wage,
+		Tips:            req.Tips,
+		JobApplication:  req.JobApplication,
+		PublishAt:       req.PublishAt,
+		CloseAt:         req.CloseAt,
+		MaxApplications: req.MaxApplications,
+	}
+	review := server.moderateJob(&arg)
+	if review.Decision == moderation.DecisionRejected {
+		ctx.JSON(http.StatusUnprocessableEntity, moderationRejectedResponse(review))
+		return
 	}
 	if err := server.esClient.UpdateJob(req.JobID, &arg); err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
+	if err := server.syncModerationReview(ctx, &arg, review); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	server.scheduleJobTransitions(ctx, &arg, now)
 
+	if review.Decision == moderation.DecisionNeedsReview {
+		ctx.JSON(http.StatusOK, gin.H{"message": "Job updated successfully", "moderation_status": review.Decision, "findings": review.Findings})
+		return
+	}
 	ctx.JSON(http.StatusOK, gin.H{"message": "Job updated successfully"})
 }
 
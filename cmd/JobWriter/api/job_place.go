@@ -0,0 +1,22 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
+	"github.com/hankimmy/PtmrBackend/pkg/worker"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+// enrichJobPlace enqueues the Google Places lookup for a new job. Failures are
+// only logged: the periodic place refresh re-enqueues jobs still pending.
+func (server *Server) enrichJobPlace(ctx *gin.Context, job *elasticsearch.Job) {
+	payload := &worker.PayloadEnrichJobPlace{JobID: job.ID}
+	opts := []asynq.Option{
+		asynq.MaxRetry(5),
+		asynq.Queue(worker.QueueDefault),
+	}
+	if err := server.taskDistributor.DistributeTaskEnrichJobPlace(ctx, payload, opts...); err != nil {
+		log.Error().Err(err).Str("job_id", job.ID).Msg("failed to enqueue job place enrichment")
+	}
+}
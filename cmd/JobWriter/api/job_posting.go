@@ -0,0 +1,205 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	"github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
+	"github.com/hankimmy/PtmrBackend/pkg/middleware"
+	"github.com/hankimmy/PtmrBackend/pkg/moderation"
+	"github.com/hankimmy/PtmrBackend/pkg/token"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	maxImportPostings = 100
+	jsonLDContentType = "application/ld+json; charset=utf-8"
+)
+
+// jobPostingBatch accepts either a single JobPosting document or an array of
+// them, since partners send both.
+type jobPostingBatch []elasticsearch.JobPosting
+
+func (b *jobPostingBatch) UnmarshalJSON(data []byte) error {
+	var list []elasticsearch.JobPosting
+	if err := json.Unmarshal(data, &list); err == nil {
+		*b = list
+		return nil
+	}
+	var single elasticsearch.JobPosting
+	if err := json.Unmarshal(data, &single); err != nil {
+		return err
+	}
+	*b = jobPostingBatch{single}
+	return nil
+}
+
+type importJobPostingsRequest struct {
+	EmployerID int64 `uri:"employer_id" binding:"required,min=1"`
+}
+
+type rejectedPosting struct {
+	Index    int                  `json:"index"`
+	Title    string               `json:"title,omitempty"`
+	Error    string               `json:"error"`
+	Findings []moderation.Finding `json:"findings,omitempty"`
+}
+
+// mergedPosting is a posting that was folded into an existing job, or into
+// an earlier posting of the same import, instead of being imported.
+type mergedPosting struct {
+	Index int    `json:"index"`
+	Title string `json:"title,omitempty"`
+	JobID string `json:"job_id"`
+}
+
+type importJobPostingsResponse struct {
+	Imported int                           `json:"imported"`
+	Rejected []rejectedPosting             `json:"rejected"`
+	Merged   []mergedPosting               `json:"merged"`
+	Failed   []elasticsearch.BulkItemError `json:"failed"`
+}
+
+func (server *Server) ImportJobPostings(ctx *gin.Context) {
+	var req importJobPostingsRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	authPayload := ctx.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload)
+	if authPayload.Role != db.RoleEmployer || authPayload.RoleID != req.EmployerID {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("account is not an employer")))
+		return
+	}
+
+	var postings jobPostingBatch
+	if err := ctx.ShouldBindJSON(&postings); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if len(postings) == 0 || len(postings) > maxImportPostings {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("import between 1 and %d job postings at a time", maxImportPostings)))
+		return
+	}
+
+	now := time.Now()
+	res := importJobPostingsResponse{
+		Rejected: []rejectedPosting{},
+		Merged:   []mergedPosting{},
+		Failed:   []elasticsearch.BulkItemError{},
+	}
+	jobs := make([]elasticsearch.Job, 0, len(postings))
+	seen := make(map[string]int, len(postings))
+	reviews := make(map[string]moderation.Result, len(postings))
+	for i := range postings {
+		job, err := postings[i].ToJob(req.EmployerID)
+		if err == nil {
+			err = elasticsearch.ValidateJobSchedule(nil, job.CloseAt, 0, now)
+		}
+		job.ID = fmt.Sprintf("%d_%s", req.EmployerID, job.Title)
+		if _, ok := seen[job.ID]; err == nil && ok {
+			err = errors.New("duplicate title in import")
+		}
+		if err != nil {
+			res.Rejected = append(res.Rejected, rejectedPosting{Index: i, Title: postings[i].Title, Error: err.Error()})
+			continue
+		}
+		original := elasticsearch.MostSimilarDuplicate(&job, jobs)
+		if original == nil {
+			original = server.findDuplicateJob(ctx, &job)
+		}
+		if original != nil {
+			res.Merged = append(res.Merged, mergedPosting{Index: i, Title: postings[i].Title, JobID: original.ID})
+			continue
+		}
+		review := server.moderateJob(&job)
+		if review.Decision == moderation.DecisionRejected {
+			res.Rejected = append(res.Rejected, rejectedPosting{Index: i, Title: postings[i].Title, Error: "rejected by moderation", Findings: review.Findings})
+			continue
+		}
+		seen[job.ID] = i
+		reviews[job.ID] = review
+
+		if job.DatePosted == "" {
+			job.DatePosted = now.Format(elasticsearch.DatePostedFormat)
+		}
+		job.IsUserCreated = true
+		job.Status = elasticsearch.JobStatusPublished
+		job.EnrichmentStatus = elasticsearch.EnrichmentPending
+		jobs = append(jobs, job)
+	}
+	if len(jobs) == 0 {
+		status := http.StatusBadRequest
+		if len(res.Merged) > 0 {
+			status = http.StatusOK
+		}
+		ctx.JSON(status, res)
+		return
+	}
+
+	result, err := server.esClient.BulkCreateJobs(ctx, jobs)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	res.Imported = result.Succeeded
+
+	failed := make(map[string]bool, len(result.Failed))
+	for _, item := range result.Failed {
+		failed[item.ID] = true
+		if item.Status == http.StatusConflict {
+			i := seen[item.ID]
+			res.Rejected = append(res.Rejected, rejectedPosting{Index: i, Title: postings[i].Title, Error: "job already exists"})
+			continue
+		}
+		res.Failed = append(res.Failed, item)
+	}
+	for i := range jobs {
+		if !failed[jobs[i].ID] {
+			if err := server.syncModerationReview(ctx, &jobs[i], reviews[jobs[i].ID]); err != nil {
+				log.Error().Err(err).Str("job_id", jobs[i].ID).Msg("failed to sync job moderation review")
+			}
+			server.enrichJobPlace(ctx, &jobs[i])
+			server.scheduleJobTransitions(ctx, &jobs[i], now)
+		}
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+type getJobPostingRequest struct {
+	JobID string `uri:"job_id" binding:"required"`
+}
+
+// GetJobPosting renders a live job as schema.org JSON-LD for embedding on
+// web pages. It is public, so jobs that are not accepting applications are
+// reported as not found.
+func (server *Server) GetJobPosting(ctx *gin.Context) {
+	var req getJobPostingRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	job, err := server.esClient.GetJob(req.JobID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if job == nil || !job.AcceptsApplications(time.Now()) {
+		ctx.JSON(http.StatusNotFound, errorResponse(fmt.Errorf("job %s not found", req.JobID)))
+		return
+	}
+
+	body, err := json.Marshal(job.ToJobPosting())
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.Data(http.StatusOK, jsonLDContentType, body)
+}
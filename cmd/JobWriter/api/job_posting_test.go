@@ -0,0 +1,389 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	"github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
+	mockes "github.com/hankimmy/PtmrBackend/pkg/elasticsearch/mock"
+	"github.com/hankimmy/PtmrBackend/pkg/middleware"
+	"github.com/hankimmy/PtmrBackend/pkg/token"
+	mockwk "github.com/hankimmy/PtmrBackend/pkg/worker/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportJobPostings(t *testing.T) {
+	user, _ := db.RandomUser(db.RoleEmployer)
+	employer := db.RandomEmployer(user.Username)
+	validThrough := time.Now().Add(30 * 24 * time.Hour).UTC().Truncate(time.Second)
+
+	posting := func(title string) map[string]interface{} {
+		return map[string]interface{}{
+			"@context":       "https://schema.org/",
+			"@type":          "JobPosting",
+			"title":          title,
+			"description":    "Serve customers",
+			"datePosted":     "2024-03-05",
+			"validThrough":   validThrough,
+			"employmentType": "PART_TIME",
+			"hiringOrganization": map[string]interface{}{
+				"@type": "Organization",
+				"name":  "Bean There",
+			},
+			"jobLocation": map[string]interface{}{
+				"@type": "Place",
+				"address": map[string]interface{}{
+					"streetAddress":   "1 Main St",
+					"addressLocality": "Brooklyn",
+					"addressRegion":   "NY",
+					"postalCode":      "11201",
+				},
+			},
+			"baseSalary": map[string]interface{}{
+				"currency": "USD",
+				"value":    map[string]interface{}{"value": 17, "unitText": "HOUR"},
+			},
+		}
+	}
+	existing := elasticsearch.RandomJob(employer.ID + 1)
+	employerAuth := func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+		middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, employer.Username, db.RoleEmployer, time.Minute, employer.ID)
+	}
+
+	testCases := []struct {
+		name          string
+		body          interface{}
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "OK",
+			body:      []interface{}{posting("Barista"), posting("Cashier"), map[string]interface{}{"@type": "JobPosting"}},
+			setupAuth: employerAuth,
+			buildStubs: func(esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().FindDuplicateJob(gomock.Any(), gomock.Any()).Times(2).Return(nil, nil)
+				esClient.EXPECT().
+					BulkCreateJobs(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, jobs []elasticsearch.Job) (*elasticsearch.BulkResult, error) {
+						require.Len(t, jobs, 2)
+						job := jobs[0]
+						require.Equal(t, fmt.Sprintf("%d_Barista", employer.ID), job.ID)
+						require.Equal(t, employer.ID, job.EmployerID)
+						require.Equal(t, "Bean There", job.HiringOrganization)
+						require.Equal(t, "part-time", job.EmploymentType)
+						require.Equal(t, "1 Main St, Brooklyn, NY 11201", job.JobLocation)
+						require.Equal(t, "2024-March-05", job.DatePosted)
+						require.Equal(t, float32(17), job.Wage)
+						require.Equal(t, elasticsearch.JobStatusPublished, job.Status)
+						require.Equal(t, elasticsearch.EnrichmentPending, job.EnrichmentStatus)
+						require.True(t, validThrough.Equal(*job.CloseAt))
+						return &elasticsearch.BulkResult{
+							Succeeded: 1,
+							Failed:    []elasticsearch.BulkItemError{{Action: "create", ID: jobs[1].ID, Status: 400, Reason: "mapper_parsing_exception"}},
+						}, nil
+					})
+				distributor.EXPECT().
+					DistributeTaskApplyJobSchedule(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+				distributor.EXPECT().
+					DistributeTaskEnrichJobPlace(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var res importJobPostingsResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Equal(t, 1, res.Imported)
+				require.Len(t, res.Failed, 1)
+				require.Len(t, res.Rejected, 1)
+				require.Equal(t, 2, res.Rejected[0].Index)
+				require.Equal(t, "title is required", res.Rejected[0].Error)
+			},
+		},
+		{
+			name:      "SingleDocument",
+			body:      posting("Barista"),
+			setupAuth: employerAuth,
+			buildStubs: func(esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().FindDuplicateJob(gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+				esClient.EXPECT().
+					BulkCreateJobs(gomock.Any(), gomock.Len(1)).
+					Times(1).
+					Return(&elasticsearch.BulkResult{Succeeded: 1}, nil)
+				distributor.EXPECT().
+					DistributeTaskApplyJobSchedule(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+				distributor.EXPECT().
+					DistributeTaskEnrichJobPlace(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:      "RejectsInvalidAndDuplicates",
+			body:      []interface{}{posting(""), posting("Barista"), posting("Barista")},
+			setupAuth: employerAuth,
+			buildStubs: func(esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().FindDuplicateJob(gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+				esClient.EXPECT().
+					BulkCreateJobs(gomock.Any(), gomock.Len(1)).
+					Times(1).
+					Return(&elasticsearch.BulkResult{Succeeded: 1}, nil)
+				distributor.EXPECT().
+					DistributeTaskApplyJobSchedule(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+				distributor.EXPECT().
+					DistributeTaskEnrichJobPlace(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "duplicate title in import")
+			},
+		},
+		{
+			name:      "RejectsExistingJobs",
+			body:      []interface{}{posting("Barista"), posting("Cashier")},
+			setupAuth: employerAuth,
+			buildStubs: func(esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().FindDuplicateJob(gomock.Any(), gomock.Any()).Times(2).Return(nil, nil)
+				esClient.EXPECT().
+					BulkCreateJobs(gomock.Any(), gomock.Len(2)).
+					Times(1).
+					DoAndReturn(func(_ interface{}, jobs []elasticsearch.Job) (*elasticsearch.BulkResult, error) {
+						return &elasticsearch.BulkResult{
+							Succeeded: 1,
+							Failed:    []elasticsearch.BulkItemError{{Action: "create", ID: jobs[0].ID, Status: http.StatusConflict, Reason: "version_conflict_engine_exception"}},
+						}, nil
+					})
+				distributor.EXPECT().
+					DistributeTaskApplyJobSchedule(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+				distributor.EXPECT().
+					DistributeTaskEnrichJobPlace(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var res importJobPostingsResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Equal(t, 1, res.Imported)
+				require.Empty(t, res.Failed)
+				require.Equal(t, []rejectedPosting{{Index: 0, Title: "Barista", Error: "job already exists"}}, res.Rejected)
+			},
+		},
+		{
+			name:      "MergesDuplicates",
+			body:      []interface{}{posting("Barista"), posting("barista!"), posting("Cashier")},
+			setupAuth: employerAuth,
+			buildStubs: func(esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().
+					FindDuplicateJob(gomock.Any(), gomock.Any()).
+					Times(2).
+					DoAndReturn(func(_ interface{}, job *elasticsearch.Job) (*elasticsearch.Job, error) {
+						if job.Title == "Cashier" {
+							return &existing, nil
+						}
+						return nil, nil
+					})
+				esClient.EXPECT().
+					BulkCreateJobs(gomock.Any(), gomock.Len(1)).
+					Times(1).
+					Return(&elasticsearch.BulkResult{Succeeded: 1}, nil)
+				distributor.EXPECT().
+					DistributeTaskApplyJobSchedule(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+				distributor.EXPECT().
+					DistributeTaskEnrichJobPlace(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var res importJobPostingsResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Equal(t, 1, res.Imported)
+				require.Equal(t, []mergedPosting{
+					{Index: 1, Title: "barista!", JobID: fmt.Sprintf("%d_Barista", employer.ID)},
+					{Index: 2, Title: "Cashier", JobID: existing.ID},
+				}, res.Merged)
+			},
+		},
+		{
+			name:      "AllMerged",
+			body:      posting("Cashier"),
+			setupAuth: employerAuth,
+			buildStubs: func(esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().FindDuplicateJob(gomock.Any(), gomock.Any()).Times(1).Return(&existing, nil)
+				esClient.EXPECT().BulkCreateJobs(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), existing.ID)
+			},
+		},
+		{
+			name:      "NothingValid",
+			body:      []interface{}{map[string]interface{}{"@type": "Event", "title": "Party"}},
+			setupAuth: employerAuth,
+			buildStubs: func(esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().BulkCreateJobs(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `unsupported @type \"Event\"`)
+			},
+		},
+		{
+			name:      "EmptyBatch",
+			body:      []interface{}{},
+			setupAuth: employerAuth,
+			buildStubs: func(esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().BulkCreateJobs(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "OtherEmployer",
+			body: posting("Barista"),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, employer.Username, db.RoleEmployer, time.Minute, employer.ID+1)
+			},
+			buildStubs: func(esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().BulkCreateJobs(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:      "BulkError",
+			body:      posting("Barista"),
+			setupAuth: employerAuth,
+			buildStubs: func(esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().FindDuplicateJob(gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+				esClient.EXPECT().
+					BulkCreateJobs(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, errors.New(elasticsearch.ErrBulkFailure))
+				distributor.EXPECT().DistributeTaskApplyJobSchedule(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			esClient := mockes.NewMockESClient(ctrl)
+			distributor := mockwk.NewMockTaskDistributor(ctrl)
+			tc.buildStubs(esClient, distributor)
+
+			server := newTestServer(t, newReviewStore(ctrl), esClient, distributor)
+			recorder := httptest.NewRecorder()
+			data, _ := json.Marshal(tc.body)
+			url := fmt.Sprintf("/jobs/%d/import", employer.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestGetJobPosting(t *testing.T) {
+	job := elasticsearch.RandomJob(1)
+	draftJob := elasticsearch.RandomJob(1)
+	draftJob.Status = elasticsearch.JobStatusDraft
+
+	testCases := []struct {
+		name          string
+		jobID         string
+		buildStubs    func(esClient *mockes.MockESClient)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			jobID: job.ID,
+			buildStubs: func(esClient *mockes.MockESClient) {
+				esClient.EXPECT().GetJob(gomock.Eq(job.ID)).Times(1).Return(&job, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, jsonLDContentType, recorder.Header().Get("Content-Type"))
+
+				var posting elasticsearch.JobPosting
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &posting))
+				require.Equal(t, "JobPosting", posting.Type)
+				require.Equal(t, job.Title, posting.Title)
+				require.Equal(t, job.HiringOrganization, posting.HiringOrganization.Name)
+			},
+		},
+		{
+			name:  "DraftIsHidden",
+			jobID: draftJob.ID,
+			buildStubs: func(esClient *mockes.MockESClient) {
+				esClient.EXPECT().GetJob(gomock.Eq(draftJob.ID)).Times(1).Return(&draftJob, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:  "NotFound",
+			jobID: "missing",
+			buildStubs: func(esClient *mockes.MockESClient) {
+				esClient.EXPECT().GetJob(gomock.Eq("missing")).Times(1).Return(nil, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			esClient := mockes.NewMockESClient(ctrl)
+			tc.buildStubs(esClient)
+
+			server := newTestServer(t, nil, esClient, nil)
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/jobs/%s/jsonld", tc.jobID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package api
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
+	"github.com/hankimmy/PtmrBackend/pkg/worker"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+// scheduleJobTransitions enqueues a task for the job's publish_at and close_at.
+// Failures are only logged: the periodic sweep picks up anything missed.
+func (server *Server) scheduleJobTransitions(ctx *gin.Context, job *elasticsearch.Job, now time.Time) {
+	payload := &worker.PayloadApplyJobSchedule{JobID: job.ID}
+	for _, at := range []*time.Time{job.PublishAt, job.CloseAt} {
+		if at == nil || !at.After(now) {
+			continue
+		}
+		opts := []asynq.Option{
+			asynq.MaxRetry(10),
+			asynq.ProcessAt(*at),
+			asynq.Queue(worker.QueueDefault),
+		}
+		if err := server.taskDistributor.DistributeTaskApplyJobSchedule(ctx, payload, opts...); err != nil {
+			log.Error().Err(err).Str("job_id", job.ID).Time("at", *at).Msg("failed to schedule job transition")
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	"github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
+	mockes "github.com/hankimmy/PtmrBackend/pkg/elasticsearch/mock"
+	"github.com/hankimmy/PtmrBackend/pkg/middleware"
+	"github.com/hankimmy/PtmrBackend/pkg/worker"
+	mockwk "github.com/hankimmy/PtmrBackend/pkg/worker/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateScheduledJob(t *testing.T) {
+	user, _ := db.RandomUser(db.RoleEmployer)
+	employer := db.RandomEmployer(user.Username)
+	job := elasticsearch.RandomJob(employer.ID)
+
+	publishAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	closeAt := publishAt.Add(7 * 24 * time.Hour)
+
+	body := func(publishAt, closeAt time.Time, maxApplications int) gin.H {
+		return gin.H{
+			"business_name":    job.HiringOrganization,
+			"title":            job.Title,
+			"job_location":     job.JobLocation,
+			"publish_at":       publishAt,
+			"close_at":         closeAt,
+			"max_applications": maxApplications,
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: body(publishAt, closeAt, 25),
+			buildStubs: func(esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().FindDuplicateJob(gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+				esClient.EXPECT().
+					CreateJob(gomock.Any()).
+					Times(1).
+					DoAndReturn(func(indexed *elasticsearch.Job) error {
+						require.Equal(t, elasticsearch.JobStatusDraft, indexed.Status)
+						require.True(t, publishAt.Equal(*indexed.PublishAt))
+						require.True(t, closeAt.Equal(*indexed.CloseAt))
+						require.Equal(t, int32(25), indexed.MaxApplications)
+						return nil
+					})
+				payload := &worker.PayloadApplyJobSchedule{JobID: fmt.Sprintf("%d_%s", employer.ID, job.Title)}
+				distributor.EXPECT().
+					DistributeTaskApplyJobSchedule(gomock.Any(), gomock.Eq(payload), gomock.Any()).
+					Times(2).
+					Return(nil)
+				distributor.EXPECT().
+					DistributeTaskEnrichJobPlace(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "CloseAtInPast",
+			body: body(publishAt, time.Now().Add(-time.Hour), 0),
+			buildStubs: func(esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().CreateJob(gomock.Any()).Times(0)
+				distributor.EXPECT().DistributeTaskApplyJobSchedule(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "close_at must be in the future")
+			},
+		},
+		{
+			name: "CloseAtBeforePublishAt",
+			body: body(closeAt, publishAt, 0),
+			buildStubs: func(esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().CreateJob(gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "close_at must be after publish_at")
+			},
+		},
+		{
+			name: "NegativeMaxApplications",
+			body: body(publishAt, closeAt, -1),
+			buildStubs: func(esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().CreateJob(gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			esClient := mockes.NewMockESClient(ctrl)
+			distributor := mockwk.NewMockTaskDistributor(ctrl)
+			tc.buildStubs(esClient, distributor)
+
+			server := newTestServer(t, newReviewStore(ctrl), esClient, distributor)
+			recorder := httptest.NewRecorder()
+			data, _ := json.Marshal(tc.body)
+			url := fmt.Sprintf("/jobs/%d", employer.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			middleware.AddAuthorization(t, request, server.tokenMaker, middleware.AuthorizationTypeBearer, employer.Username, db.RoleEmployer, time.Minute, employer.ID)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	"github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
+	"github.com/hankimmy/PtmrBackend/pkg/middleware"
+	"github.com/hankimmy/PtmrBackend/pkg/token"
+	"github.com/hankimmy/PtmrBackend/pkg/worker"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const maxShiftLength = 24 * time.Hour
+
+type createJobShiftRequest struct {
+	JobID     string    `json:"job_id" binding:"required"`
+	StartsAt  time.Time `json:"starts_at" binding:"required"`
+	EndsAt    time.Time `json:"ends_at" binding:"required"`
+	Timezone  string    `json:"timezone" binding:"required"`
+	Headcount int32     `json:"headcount" binding:"required,min=1"`
+}
+
+func (req *createJobShiftRequest) validate(now time.Time) error {
+	if _, err := time.LoadLocation(req.Timezone); err != nil {
+		return fmt.Errorf("unknown timezone %q", req.Timezone)
+	}
+	if !req.StartsAt.After(now) {
+		return errors.New("starts_at must be in the future")
+	}
+	if !req.EndsAt.After(req.StartsAt) {
+		return errors.New("ends_at must be after starts_at")
+	}
+	if req.EndsAt.Sub(req.StartsAt) > maxShiftLength {
+		return errors.New("a shift cannot be longer than 24 hours")
+	}
+	return nil
+}
+
+// CreateJobShift adds a shift with a number of open spots to one of the
+// employer's jobs.
+func (server *Server) CreateJobShift(ctx *gin.Context) {
+	var req createJobShiftRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := req.validate(time.Now()); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	job, err := server.esClient.GetJob(req.JobID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if job == nil {
+		ctx.JSON(http.StatusNotFound, errorResponse(fmt.Errorf("job %s not found", req.JobID)))
+		return
+	}
+	if !authorizeEmployer(ctx, job.EmployerID) {
+		return
+	}
+	if status := job.CurrentStatus(); status == elasticsearch.JobStatusFilled || status == elasticsearch.JobStatusClosed {
+		ctx.JSON(http.StatusConflict, errorResponse(fmt.Errorf("cannot add shifts to a %s job", status)))
+		return
+	}
+
+	shift, err := server.store.CreateJobShift(ctx, db.CreateJobShiftParams{
+		JobID:      job.ID,
+		EmployerID: job.EmployerID,
+		StartsAt:   req.StartsAt,
+		EndsAt:     req.EndsAt,
+		Timezone:   req.Timezone,
+		Headcount:  req.Headcount,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	server.syncJobShifts(ctx, job.ID)
+	ctx.JSON(http.StatusOK, shift)
+}
+
+type listJobShiftsRequest struct {
+	JobID string `uri:"job_id" binding:"required"`
+}
+
+// ListJobShifts returns a job's shifts in start order with how many of their
+// spots are claimed.
+func (server *Server) ListJobShifts(ctx *gin.Context) {
+	var req listJobShiftsRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	shifts, err := server.store.ListJobShifts(ctx, req.JobID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, shifts)
+}
+
+type jobShiftRequest struct {
+	ShiftID int64 `uri:"shift_id" binding:"required,min=1"`
+}
+
+// getEmployerJobShift loads a shift of one of the authenticated employer's
+// jobs. It writes the error response itself and reports whether the handler
+// can continue.
+func (server *Server) getEmployerJobShift(ctx *gin.Context, shiftID int64) (db.JobShift, bool) {
+	shift, err := server.store.GetJobShift(ctx, shiftID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(fmt.Errorf("shift %d not found", shiftID)))
+			return db.JobShift{}, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return db.JobShift{}, false
+	}
+	authPayload := ctx.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload)
+	if authPayload.Role != db.RoleEmployer || authPayload.RoleID != shift.EmployerID {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("shift doesn't belong to the authenticated employer")))
+		return db.JobShift{}, false
+	}
+	return shift, true
+}
+
+// DeleteJobShift removes a shift along with its claims.
+func (server *Server) DeleteJobShift(ctx *gin.Context) {
+	var req jobShiftRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	shift, ok := server.getEmployerJobShift(ctx, req.ShiftID)
+	if !ok {
+		return
+	}
+
+	if err := server.store.DeleteJobShift(ctx, shift.ID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	server.syncJobShifts(ctx, shift.JobID)
+	ctx.JSON(http.StatusOK, gin.H{"message": "Shift deleted successfully"})
+}
+
+// ListShiftClaims returns the candidates who claimed a spot on the shift.
+func (server *Server) ListShiftClaims(ctx *gin.Context) {
+	var req jobShiftRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	shift, ok := server.getEmployerJobShift(ctx, req.ShiftID)
+	if !ok {
+		return
+	}
+
+	claims, err := server.store.ListShiftClaims(ctx, shift.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, claims)
+}
+
+// syncJobShifts asks the worker to copy the job's shifts to its document so
+// the feed sees them. Failures are only logged: the next change to the job's
+// shifts syncs them again.
+func (server *Server) syncJobShifts(ctx *gin.Context, jobID string) {
+	payload := &worker.PayloadSyncJobShifts{JobID: jobID}
+	opts := []asynq.Option{
+		asynq.MaxRetry(10),
+		asynq.Queue(worker.QueueDefault),
+	}
+	if err := server.taskDistributor.DistributeTaskSyncJobShifts(ctx, payload, opts...); err != nil {
+		log.Error().Err(err).Str("job_id", jobID).Msg("failed to enqueue job shift sync")
+	}
+}
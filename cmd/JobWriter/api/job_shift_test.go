@@ -0,0 +1,322 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/hankimmy/PtmrBackend/pkg/db/mock"
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	"github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
+	mockes "github.com/hankimmy/PtmrBackend/pkg/elasticsearch/mock"
+	"github.com/hankimmy/PtmrBackend/pkg/middleware"
+	"github.com/hankimmy/PtmrBackend/pkg/token"
+	"github.com/hankimmy/PtmrBackend/pkg/worker"
+	mockwk "github.com/hankimmy/PtmrBackend/pkg/worker/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateJobShift(t *testing.T) {
+	user, _ := db.RandomUser(db.RoleEmployer)
+	employer := db.RandomEmployer(user.Username)
+	job := elasticsearch.RandomJob(employer.ID)
+	shift := db.RandomJobShift(job.ID, employer.ID)
+	body := gin.H{
+		"job_id":    job.ID,
+		"starts_at": shift.StartsAt,
+		"ends_at":   shift.EndsAt,
+		"timezone":  shift.Timezone,
+		"headcount": shift.Headcount,
+	}
+	withBody := func(overrides gin.H) gin.H {
+		b := gin.H{}
+		for k, v := range body {
+			b[k] = v
+		}
+		for k, v := range overrides {
+			b[k] = v
+		}
+		return b
+	}
+	closedJob := job
+	closedJob.Status = elasticsearch.JobStatusClosed
+
+	employerAuth := func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+		middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, employer.Username, db.RoleEmployer, time.Minute, employer.ID)
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore, esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "OK",
+			body:      body,
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().GetJob(gomock.Eq(job.ID)).Times(1).Return(&job, nil)
+				arg := db.CreateJobShiftParams{
+					JobID:      job.ID,
+					EmployerID: employer.ID,
+					StartsAt:   shift.StartsAt,
+					EndsAt:     shift.EndsAt,
+					Timezone:   shift.Timezone,
+					Headcount:  shift.Headcount,
+				}
+				store.EXPECT().CreateJobShift(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, got db.CreateJobShiftParams) (db.JobShift, error) {
+						require.True(t, arg.StartsAt.Equal(got.StartsAt))
+						require.True(t, arg.EndsAt.Equal(got.EndsAt))
+						got.StartsAt, got.EndsAt = arg.StartsAt, arg.EndsAt
+						require.Equal(t, arg, got)
+						return shift, nil
+					})
+				distributor.EXPECT().
+					DistributeTaskSyncJobShifts(gomock.Any(), gomock.Eq(&worker.PayloadSyncJobShifts{JobID: job.ID}), gomock.Any()).
+					Times(1)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var got db.JobShift
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, shift.ID, got.ID)
+				require.Equal(t, shift.Headcount, got.Headcount)
+			},
+		},
+		{
+			name:      "UnknownTimezone",
+			body:      withBody(gin.H{"timezone": "Mars/Olympus_Mons"}),
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().GetJob(gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:      "EndsBeforeStart",
+			body:      withBody(gin.H{"ends_at": shift.StartsAt.Add(-time.Hour)}),
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().GetJob(gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:      "StartsInPast",
+			body:      withBody(gin.H{"starts_at": time.Now().Add(-time.Hour), "ends_at": time.Now().Add(time.Hour)}),
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().GetJob(gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:      "ZeroHeadcount",
+			body:      withBody(gin.H{"headcount": 0}),
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().GetJob(gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:      "JobNotFound",
+			body:      body,
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().GetJob(gomock.Eq(job.ID)).Times(1).Return(nil, nil)
+				store.EXPECT().CreateJobShift(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "OtherEmployer",
+			body: body,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, employer.Username, db.RoleEmployer, time.Minute, employer.ID+1)
+			},
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().GetJob(gomock.Eq(job.ID)).Times(1).Return(&job, nil)
+				store.EXPECT().CreateJobShift(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:      "ClosedJob",
+			body:      body,
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().GetJob(gomock.Eq(job.ID)).Times(1).Return(&closedJob, nil)
+				store.EXPECT().CreateJobShift(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:      "SyncFailureIsIgnored",
+			body:      body,
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().GetJob(gomock.Eq(job.ID)).Times(1).Return(&job, nil)
+				store.EXPECT().CreateJobShift(gomock.Any(), gomock.Any()).Times(1).Return(shift, nil)
+				distributor.EXPECT().
+					DistributeTaskSyncJobShifts(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(errors.New("redis down"))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+			esClient := mockes.NewMockESClient(ctrl)
+			distributor := mockwk.NewMockTaskDistributor(ctrl)
+			tc.buildStubs(store, esClient, distributor)
+
+			server := newTestServer(t, store, esClient, distributor)
+			recorder := httptest.NewRecorder()
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+			request, err := http.NewRequest(http.MethodPost, "/job_shifts", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestDeleteJobShift(t *testing.T) {
+	user, _ := db.RandomUser(db.RoleEmployer)
+	employer := db.RandomEmployer(user.Username)
+	shift := db.RandomJobShift(fmt.Sprintf("%d_shifts", employer.ID), employer.ID)
+
+	testCases := []struct {
+		name          string
+		authID        int64
+		buildStubs    func(store *mockdb.MockStore, distributor *mockwk.MockTaskDistributor)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			authID: employer.ID,
+			buildStubs: func(store *mockdb.MockStore, distributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetJobShift(gomock.Any(), gomock.Eq(shift.ID)).Times(1).Return(shift, nil)
+				store.EXPECT().DeleteJobShift(gomock.Any(), gomock.Eq(shift.ID)).Times(1).Return(nil)
+				distributor.EXPECT().
+					DistributeTaskSyncJobShifts(gomock.Any(), gomock.Eq(&worker.PayloadSyncJobShifts{JobID: shift.JobID}), gomock.Any()).
+					Times(1)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "NotFound",
+			authID: employer.ID,
+			buildStubs: func(store *mockdb.MockStore, distributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetJobShift(gomock.Any(), gomock.Eq(shift.ID)).Times(1).Return(db.JobShift{}, db.ErrRecordNotFound)
+				store.EXPECT().DeleteJobShift(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "OtherEmployer",
+			authID: employer.ID + 1,
+			buildStubs: func(store *mockdb.MockStore, distributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetJobShift(gomock.Any(), gomock.Eq(shift.ID)).Times(1).Return(shift, nil)
+				store.EXPECT().DeleteJobShift(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+			distributor := mockwk.NewMockTaskDistributor(ctrl)
+			tc.buildStubs(store, distributor)
+
+			server := newTestServer(t, store, nil, distributor)
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/job_shifts/%d", shift.ID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			middleware.AddAuthorization(t, request, server.tokenMaker, middleware.AuthorizationTypeBearer, employer.Username, db.RoleEmployer, time.Minute, tc.authID)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListJobShifts(t *testing.T) {
+	user, _ := db.RandomUser(db.RoleCandidate)
+	candidate := db.RandomCandidate(user.Username)
+	shift := db.RandomJobShift("1_shifts", 1)
+	rows := []db.ListJobShiftsRow{{
+		ID:         shift.ID,
+		JobID:      shift.JobID,
+		EmployerID: shift.EmployerID,
+		StartsAt:   shift.StartsAt,
+		EndsAt:     shift.EndsAt,
+		Timezone:   shift.Timezone,
+		Headcount:  shift.Headcount,
+		Claimed:    1,
+	}}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().ListJobShifts(gomock.Any(), gomock.Eq(shift.JobID)).Times(1).Return(rows, nil)
+
+	server := newTestServer(t, store, nil, nil)
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/jobs/1_shifts/shifts", nil)
+	require.NoError(t, err)
+
+	middleware.AddAuthorization(t, request, server.tokenMaker, middleware.AuthorizationTypeBearer, candidate.Username, db.RoleCandidate, time.Minute, candidate.ID)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var got []db.ListJobShiftsRow
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+	require.Len(t, got, 1)
+	require.Equal(t, int32(1), got[0].Claimed)
+}
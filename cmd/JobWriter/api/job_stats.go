@@ -0,0 +1,214 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	"github.com/hankimmy/PtmrBackend/pkg/middleware"
+	"github.com/hankimmy/PtmrBackend/pkg/token"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	statsDateFormat  = "2006-01-02"
+	defaultStatsDays = 30
+	maxStatsDays     = 366
+)
+
+// statsRange is the inclusive range of days to report, defaulting to the last
+// 30 days.
+type statsRange struct {
+	From time.Time `form:"from" time_format:"2006-01-02" time_utc:"1"`
+	To   time.Time `form:"to" time_format:"2006-01-02" time_utc:"1"`
+}
+
+func (r *statsRange) resolve(now time.Time) error {
+	if r.To.IsZero() {
+		r.To = now.UTC().Truncate(24 * time.Hour)
+	}
+	if r.From.IsZero() {
+		r.From = r.To.AddDate(0, 0, -(defaultStatsDays - 1))
+	}
+	if r.From.After(r.To) {
+		return errors.New("from must not be after to")
+	}
+	if r.To.Sub(r.From) >= maxStatsDays*24*time.Hour {
+		return fmt.Errorf("range cannot be longer than %d days", maxStatsDays)
+	}
+	return nil
+}
+
+type jobStatCounts struct {
+	Views        int64 `json:"views"`
+	Impressions  int64 `json:"impressions"`
+	RightSwipes  int64 `json:"right_swipes"`
+	LeftSwipes   int64 `json:"left_swipes"`
+	Applications int64 `json:"applications"`
+}
+
+func (c *jobStatCounts) add(other jobStatCounts) {
+	c.Views += other.Views
+	c.Impressions += other.Impressions
+	c.RightSwipes += other.RightSwipes
+	c.LeftSwipes += other.LeftSwipes
+	c.Applications += other.Applications
+}
+
+type dailyJobStats struct {
+	Date string `json:"date"`
+	jobStatCounts
+}
+
+type jobStatsResponse struct {
+	JobID  string          `json:"job_id"`
+	From   string          `json:"from"`
+	To     string          `json:"to"`
+	Totals jobStatCounts   `json:"totals"`
+	Daily  []dailyJobStats `json:"daily"`
+}
+
+type getJobStatsRequest struct {
+	JobID string `uri:"job_id" binding:"required"`
+}
+
+// GetJobStats reports a job's daily statistics. Days without activity are
+// included with zero counts.
+func (server *Server) GetJobStats(ctx *gin.Context) {
+	var req getJobStatsRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var period statsRange
+	if err := ctx.ShouldBindQuery(&period); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := period.resolve(time.Now()); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	job, err := server.esClient.GetJob(req.JobID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if job == nil {
+		ctx.JSON(http.StatusNotFound, errorResponse(fmt.Errorf("job %s not found", req.JobID)))
+		return
+	}
+	authPayload := ctx.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload)
+	if authPayload.Role != db.RoleEmployer || authPayload.RoleID != job.EmployerID {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("job doesn't belong to the authenticated employer")))
+		return
+	}
+
+	rows, err := server.store.ListJobDailyStats(ctx, db.ListJobDailyStatsParams{
+		JobID:   job.ID,
+		FromDay: pgtype.Date{Time: period.From, Valid: true},
+		ToDay:   pgtype.Date{Time: period.To, Valid: true},
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	byDay := make(map[string]jobStatCounts, len(rows))
+	for _, row := range rows {
+		byDay[row.Day.Time.Format(statsDateFormat)] = jobStatCounts{
+			Views:        row.Views,
+			Impressions:  row.Impressions,
+			RightSwipes:  row.RightSwipes,
+			LeftSwipes:   row.LeftSwipes,
+			Applications: row.Applications,
+		}
+	}
+	res := jobStatsResponse{
+		JobID: job.ID,
+		From:  period.From.Format(statsDateFormat),
+		To:    period.To.Format(statsDateFormat),
+		Daily: []dailyJobStats{},
+	}
+	for day := period.From; !day.After(period.To); day = day.AddDate(0, 0, 1) {
+		date := day.Format(statsDateFormat)
+		counts := byDay[date]
+		res.Totals.add(counts)
+		res.Daily = append(res.Daily, dailyJobStats{Date: date, jobStatCounts: counts})
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+type jobStatsSummary struct {
+	JobID string `json:"job_id"`
+	jobStatCounts
+}
+
+type employerJobStatsResponse struct {
+	EmployerID int64             `json:"employer_id"`
+	From       string            `json:"from"`
+	To         string            `json:"to"`
+	Totals     jobStatCounts     `json:"totals"`
+	Jobs       []jobStatsSummary `json:"jobs"`
+}
+
+type getEmployerJobStatsRequest struct {
+	EmployerID int64 `uri:"employer_id" binding:"required,min=1"`
+}
+
+// GetEmployerJobStats sums the statistics of each of the employer's jobs
+// that had any activity in the range.
+func (server *Server) GetEmployerJobStats(ctx *gin.Context) {
+	var req getEmployerJobStatsRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	authPayload := ctx.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload)
+	if authPayload.Role != db.RoleEmployer || authPayload.RoleID != req.EmployerID {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("account is not an employer")))
+		return
+	}
+	var period statsRange
+	if err := ctx.ShouldBindQuery(&period); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := period.resolve(time.Now()); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rows, err := server.store.ListEmployerJobStats(ctx, db.ListEmployerJobStatsParams{
+		EmployerID: req.EmployerID,
+		FromDay:    pgtype.Date{Time: period.From, Valid: true},
+		ToDay:      pgtype.Date{Time: period.To, Valid: true},
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := employerJobStatsResponse{
+		EmployerID: req.EmployerID,
+		From:       period.From.Format(statsDateFormat),
+		To:         period.To.Format(statsDateFormat),
+		Jobs:       make([]jobStatsSummary, 0, len(rows)),
+	}
+	for _, row := range rows {
+		counts := jobStatCounts{
+			Views:        row.Views,
+			Impressions:  row.Impressions,
+			RightSwipes:  row.RightSwipes,
+			LeftSwipes:   row.LeftSwipes,
+			Applications: row.Applications,
+		}
+		res.Totals.add(counts)
+		res.Jobs = append(res.Jobs, jobStatsSummary{JobID: row.JobID, jobStatCounts: counts})
+	}
+	ctx.JSON(http.StatusOK, res)
+}
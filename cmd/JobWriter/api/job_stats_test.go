@@ -0,0 +1,221 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/hankimmy/PtmrBackend/pkg/db/mock"
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	"github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
+	mockes "github.com/hankimmy/PtmrBackend/pkg/elasticsearch/mock"
+	"github.com/hankimmy/PtmrBackend/pkg/middleware"
+	"github.com/hankimmy/PtmrBackend/pkg/token"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetJobStats(t *testing.T) {
+	user, _ := db.RandomUser(db.RoleEmployer)
+	employer := db.RandomEmployer(user.Username)
+	job := elasticsearch.RandomJob(employer.ID)
+	from := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 2)
+
+	employerAuth := func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+		middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, employer.Username, db.RoleEmployer, time.Minute, employer.ID)
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore, esClient *mockes.MockESClient)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "OK",
+			query:     "?from=2024-03-01&to=2024-03-03",
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				esClient.EXPECT().GetJob(gomock.Eq(job.ID)).Times(1).Return(&job, nil)
+				arg := db.ListJobDailyStatsParams{
+					JobID:   job.ID,
+					FromDay: pgtype.Date{Time: from, Valid: true},
+					ToDay:   pgtype.Date{Time: to, Valid: true},
+				}
+				store.EXPECT().
+					ListJobDailyStats(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return([]db.JobDailyStat{
+						{JobID: job.ID, EmployerID: employer.ID, Day: pgtype.Date{Time: from, Valid: true}, Impressions: 10, Views: 3},
+						{JobID: job.ID, EmployerID: employer.ID, Day: pgtype.Date{Time: to, Valid: true}, Impressions: 5, RightSwipes: 2, Applications: 1},
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var res jobStatsResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Equal(t, "2024-03-01", res.From)
+				require.Equal(t, "2024-03-03", res.To)
+				require.Equal(t, jobStatCounts{Views: 3, Impressions: 15, RightSwipes: 2, Applications: 1}, res.Totals)
+				require.Len(t, res.Daily, 3)
+				require.Equal(t, "2024-03-02", res.Daily[1].Date)
+				require.Zero(t, res.Daily[1].Impressions)
+				require.Equal(t, int64(5), res.Daily[2].Impressions)
+			},
+		},
+		{
+			name:      "DefaultRange",
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				esClient.EXPECT().GetJob(gomock.Eq(job.ID)).Times(1).Return(&job, nil)
+				store.EXPECT().ListJobDailyStats(gomock.Any(), gomock.Any()).Times(1).Return([]db.JobDailyStat{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var res jobStatsResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Len(t, res.Daily, defaultStatsDays)
+			},
+		},
+		{
+			name:      "InvalidRange",
+			query:     "?from=2024-03-03&to=2024-03-01",
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				esClient.EXPECT().GetJob(gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "OtherEmployer",
+			query: "?from=2024-03-01&to=2024-03-03",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, employer.Username, db.RoleEmployer, time.Minute, employer.ID+1)
+			},
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				esClient.EXPECT().GetJob(gomock.Eq(job.ID)).Times(1).Return(&job, nil)
+				store.EXPECT().ListJobDailyStats(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:      "JobNotFound",
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				esClient.EXPECT().GetJob(gomock.Eq(job.ID)).Times(1).Return(nil, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:      "InternalError",
+			setupAuth: employerAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				esClient.EXPECT().GetJob(gomock.Eq(job.ID)).Times(1).Return(&job, nil)
+				store.EXPECT().ListJobDailyStats(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("db down"))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+			esClient := mockes.NewMockESClient(ctrl)
+			tc.buildStubs(store, esClient)
+
+			server := newTestServer(t, store, esClient, nil)
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/jobs/%s/stats%s", job.ID, tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestGetEmployerJobStats(t *testing.T) {
+	user, _ := db.RandomUser(db.RoleEmployer)
+	employer := db.RandomEmployer(user.Username)
+
+	testCases := []struct {
+		name          string
+		employerID    int64
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:       "OK",
+			employerID: employer.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListEmployerJobStats(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.ListEmployerJobStatsParams) ([]db.ListEmployerJobStatsRow, error) {
+						require.Equal(t, employer.ID, arg.EmployerID)
+						require.Equal(t, time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), arg.FromDay.Time)
+						return []db.ListEmployerJobStatsRow{
+							{JobID: "a", Impressions: 10, Applications: 2},
+							{JobID: "b", Impressions: 4, LeftSwipes: 3},
+						}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var res employerJobStatsResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Len(t, res.Jobs, 2)
+				require.Equal(t, jobStatCounts{Impressions: 14, LeftSwipes: 3, Applications: 2}, res.Totals)
+				require.Equal(t, "b", res.Jobs[1].JobID)
+			},
+		},
+		{
+			name:       "OtherEmployer",
+			employerID: employer.ID + 1,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListEmployerJobStats(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil, nil)
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/employers/%d/stats?from=2024-03-01&to=2024-03-31", tc.employerID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			middleware.AddAuthorization(t, request, server.tokenMaker, middleware.AuthorizationTypeBearer, employer.Username, db.RoleEmployer, time.Minute, employer.ID)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	"github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
+	"github.com/hankimmy/PtmrBackend/pkg/middleware"
+	"github.com/hankimmy/PtmrBackend/pkg/token"
+	"github.com/hankimmy/PtmrBackend/pkg/worker"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+type transitionJobRequest struct {
+	JobID string `uri:"job_id" binding:"required"`
+}
+
+func (server *Server) TransitionJob(ctx *gin.Context, target elasticsearch.JobStatus) {
+	var req transitionJobRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload)
+	if authPayload.Role != db.RoleEmployer {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("account is not an employer")))
+		return
+	}
+
+	job, err := server.esClient.GetJob(req.JobID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if job == nil {
+		ctx.JSON(http.StatusNotFound, errorResponse(fmt.Errorf("job %s not found", req.JobID)))
+		return
+	}
+	if job.EmployerID != authPayload.RoleID {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("job does not belong to the authenticated employer")))
+		return
+	}
+
+	current := job.CurrentStatus()
+	if !current.CanTransitionTo(target) {
+		ctx.JSON(http.StatusConflict, errorResponse(fmt.Errorf("cannot move job from %s to %s", current, target)))
+		return
+	}
+
+	if err := server.esClient.UpdateJobStatus(req.JobID, target); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if target.NotifiesApplicants() {
+		server.enqueueNotifyJobClosed(ctx, job, target)
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"id": job.ID, "status": target})
+}
+
+// enqueueNotifyJobClosed only logs failures: the status change has already
+// been stored, so the request should not be reported as failed.
+func (server *Server) enqueueNotifyJobClosed(ctx *gin.Context, job *elasticsearch.Job, status elasticsearch.JobStatus) {
+	payload := &worker.PayloadNotifyJobClosed{
+		JobID:              job.ID,
+		Title:              job.Title,
+		HiringOrganization: job.HiringOrganization,
+		Status:             status,
+	}
+	opts := []asynq.Option{
+		asynq.MaxRetry(10),
+		asynq.ProcessIn(10 * time.Second),
+		asynq.Queue(worker.QueueMail),
+	}
+	if err := server.taskDistributor.DistributeTaskNotifyJobClosed(ctx, payload, opts...); err != nil {
+		log.Error().Err(err).Str("job_id", job.ID).Msg("failed to enqueue job closed notification")
+	}
+}
@@ -0,0 +1,180 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	"github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
+	mockes "github.com/hankimmy/PtmrBackend/pkg/elasticsearch/mock"
+	"github.com/hankimmy/PtmrBackend/pkg/middleware"
+	"github.com/hankimmy/PtmrBackend/pkg/token"
+	"github.com/hankimmy/PtmrBackend/pkg/worker"
+	mockwk "github.com/hankimmy/PtmrBackend/pkg/worker/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransitionJob(t *testing.T) {
+	user, _ := db.RandomUser(db.RoleEmployer)
+	employer := db.RandomEmployer(user.Username)
+
+	draftJob := elasticsearch.RandomJob(employer.ID)
+	draftJob.Status = elasticsearch.JobStatusDraft
+	publishedJob := elasticsearch.RandomJob(employer.ID)
+	closedJob := elasticsearch.RandomJob(employer.ID)
+	closedJob.Status = elasticsearch.JobStatusClosed
+	otherJob := elasticsearch.RandomJob(employer.ID + 1)
+
+	employerAuth := func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+		middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, employer.Username, db.RoleEmployer, time.Minute, employer.ID)
+	}
+
+	testCases := []struct {
+		name          string
+		job           elasticsearch.Job
+		action        string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "PublishDraft",
+			job:       draftJob,
+			action:    "publish",
+			setupAuth: employerAuth,
+			buildStubs: func(esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().GetJob(gomock.Eq(draftJob.ID)).Times(1).Return(&draftJob, nil)
+				esClient.EXPECT().
+					UpdateJobStatus(gomock.Eq(draftJob.ID), gomock.Eq(elasticsearch.JobStatusPublished)).
+					Times(1).
+					Return(nil)
+				distributor.EXPECT().DistributeTaskNotifyJobClosed(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), string(elasticsearch.JobStatusPublished))
+			},
+		},
+		{
+			name:      "FillNotifiesApplicants",
+			job:       publishedJob,
+			action:    "fill",
+			setupAuth: employerAuth,
+			buildStubs: func(esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().GetJob(gomock.Eq(publishedJob.ID)).Times(1).Return(&publishedJob, nil)
+				esClient.EXPECT().
+					UpdateJobStatus(gomock.Eq(publishedJob.ID), gomock.Eq(elasticsearch.JobStatusFilled)).
+					Times(1).
+					Return(nil)
+				payload := &worker.PayloadNotifyJobClosed{
+					JobID:              publishedJob.ID,
+					Title:              publishedJob.Title,
+					HiringOrganization: publishedJob.HiringOrganization,
+					Status:             elasticsearch.JobStatusFilled,
+				}
+				distributor.EXPECT().
+					DistributeTaskNotifyJobClosed(gomock.Any(), gomock.Eq(payload), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:      "InvalidTransition",
+			job:       closedJob,
+			action:    "publish",
+			setupAuth: employerAuth,
+			buildStubs: func(esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().GetJob(gomock.Eq(closedJob.ID)).Times(1).Return(&closedJob, nil)
+				esClient.EXPECT().UpdateJobStatus(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:      "NotFound",
+			job:       publishedJob,
+			action:    "pause",
+			setupAuth: employerAuth,
+			buildStubs: func(esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().GetJob(gomock.Eq(publishedJob.ID)).Times(1).Return(nil, nil)
+				esClient.EXPECT().UpdateJobStatus(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:      "NotJobOwner",
+			job:       otherJob,
+			action:    "close",
+			setupAuth: employerAuth,
+			buildStubs: func(esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().GetJob(gomock.Eq(otherJob.ID)).Times(1).Return(&otherJob, nil)
+				esClient.EXPECT().UpdateJobStatus(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:   "CandidateRole",
+			job:    publishedJob,
+			action: "pause",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleCandidate, time.Minute, employer.ID)
+			},
+			buildStubs: func(esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().GetJob(gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:      "UpdateError",
+			job:       publishedJob,
+			action:    "pause",
+			setupAuth: employerAuth,
+			buildStubs: func(esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().GetJob(gomock.Eq(publishedJob.ID)).Times(1).Return(&publishedJob, nil)
+				esClient.EXPECT().
+					UpdateJobStatus(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(errors.New(elasticsearch.ErrUpdateFailure))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			esClient := mockes.NewMockESClient(ctrl)
+			distributor := mockwk.NewMockTaskDistributor(ctrl)
+			tc.buildStubs(esClient, distributor)
+
+			server := newTestServer(t, nil, esClient, distributor)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/jobs/%s/%s", tc.job.ID, tc.action)
+			request, err := http.NewRequest(http.MethodPatch, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
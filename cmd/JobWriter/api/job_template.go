@@ -0,0 +1,422 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	"github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
+	"github.com/hankimmy/PtmrBackend/pkg/middleware"
+	"github.com/hankimmy/PtmrBackend/pkg/token"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type jobTemplateResponse struct {
+	ID             int64                         `json:"id"`
+	EmployerID     int64                         `json:"employer_id"`
+	Name           string                        `json:"name"`
+	Title          string                        `json:"title"`
+	Description    string                        `json:"description"`
+	Industry       string                        `json:"industry"`
+	EmploymentType string                        `json:"employment_type"`
+	Wage           float32                       `json:"wage"`
+	Tips           float32                       `json:"tips"`
+	JobApplication elasticsearch.ApplicationForm `json:"job_application"`
+	CreatedAt      time.Time                     `json:"created_at"`
+	UpdatedAt      time.Time                     `json:"updated_at"`
+}
+
+func newJobTemplateResponse(template db.JobTemplate) (jobTemplateResponse, error) {
+	form, err := decodeApplicationForm(template.JobApplication)
+	if err != nil {
+		return jobTemplateResponse{}, err
+	}
+	return jobTemplateResponse{
+		ID:             template.ID,
+		EmployerID:     template.EmployerID,
+		Name:           template.Name,
+		Title:          template.Title,
+		Description:    template.Description,
+		Industry:       template.Industry,
+		EmploymentType: template.EmploymentType,
+		Wage:           template.Wage,
+		Tips:           template.Tips,
+		JobApplication: form,
+		CreatedAt:      template.CreatedAt,
+		UpdatedAt:      template.UpdatedAt,
+	}, nil
+}
+
+func encodeApplicationForm(form elasticsearch.ApplicationForm) ([]byte, error) {
+	if form == nil {
+		form = elasticsearch.ApplicationForm{}
+	}
+	return json.Marshal(form)
+}
+
+func decodeApplicationForm(data []byte) (elasticsearch.ApplicationForm, error) {
+	form := elasticsearch.ApplicationForm{}
+	if len(data) == 0 {
+		return form, nil
+	}
+	if err := json.Unmarshal(data, &form); err != nil {
+		return nil, fmt.Errorf("failed to decode template application form: %w", err)
+	}
+	return form, nil
+}
+
+type employerURI struct {
+	EmployerID int64 `uri:"employer_id" binding:"required,min=1"`
+}
+
+type jobTemplateURI struct {
+	EmployerID int64 `uri:"employer_id" binding:"required,min=1"`
+	TemplateID int64 `uri:"template_id" binding:"required,min=1"`
+}
+
+// authorizeEmployer checks that the request is made by the employer in the
+// URI, responding with 401 when it is not.
+func authorizeEmployer(ctx *gin.Context, employerID int64) bool {
+	authPayload := ctx.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload)
+	if authPayload.Role != db.RoleEmployer || authPayload.RoleID != employerID {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("account is not an employer")))
+		return false
+	}
+	return true
+}
+
+// getJobTemplate loads a template owned by the employer in the URI. It writes
+// the error response itself and reports whether the handler can continue.
+func (server *Server) getJobTemplate(ctx *gin.Context, uri jobTemplateURI) (db.JobTemplate, bool) {
+	template, err := server.store.GetJobTemplate(ctx, uri.TemplateID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(fmt.Errorf("job template %d not found", uri.TemplateID)))
+			return db.JobTemplate{}, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return db.JobTemplate{}, false
+	}
+	if template.EmployerID != uri.EmployerID {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("job template doesn't belong to the authenticated employer")))
+		return db.JobTemplate{}, false
+	}
+	return template, true
+}
+
+type createJobTemplateRequest struct {
+	Name string `json:"name" binding:"required"`
+	// JobID saves an existing job of the employer as a template. The job's
+	// fields are used and the others in the request are ignored.
+	JobID          string                   `json:"job_id"`
+	Title          string                   `json:"title"`
+	Description    string                   `json:"description"`
+	Industry       string                   `json:"industry"`
+	EmploymentType string                   `json:"employment_type"`
+	Wage           float32                  `json:"wage" binding:"min=0"`
+	Tips           float32                  `json:"tips" binding:"min=0"`
+	JobApplication []elasticsearch.Question `json:"job_application"`
+}
+
+func (server *Server) CreateJobTemplate(ctx *gin.Context) {
+	var uri employerURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req createJobTemplateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if !authorizeEmployer(ctx, uri.EmployerID) {
+		return
+	}
+
+	if req.JobID != "" {
+		job, err := server.esClient.GetJob(req.JobID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		if job == nil {
+			ctx.JSON(http.StatusNotFound, errorResponse(fmt.Errorf("job %s not found", req.JobID)))
+			return
+		}
+		if job.EmployerID != uri.EmployerID {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("job doesn't belong to the authenticated employer")))
+			return
+		}
+		req.Title = job.Title
+		req.Description = job.Description
+		req.Industry = job.Industry
+		req.EmploymentType = job.EmploymentType
+		req.Wage = job.Wage
+		req.Tips = job.Tips
+		req.JobApplication = job.JobApplication
+	}
+	if req.Title == "" {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("title is required")))
+		return
+	}
+	if err := elasticsearch.ApplicationForm(req.JobApplication).Validate(); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	form, err := encodeApplicationForm(req.JobApplication)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	template, err := server.store.CreateJobTemplate(ctx, db.CreateJobTemplateParams{
+		EmployerID:     uri.EmployerID,
+		Name:           req.Name,
+		Title:          req.Title,
+		Description:    req.Description,
+		Industry:       req.Industry,
+		EmploymentType: req.EmploymentType,
+		Wage:           req.Wage,
+		Tips:           req.Tips,
+		JobApplication: form,
+	})
+	if err != nil {
+		if db.ErrorCode(err) == db.UniqueViolation {
+			ctx.JSON(http.StatusConflict, errorResponse(fmt.Errorf("a job template named %q already exists", req.Name)))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	server.respondJobTemplate(ctx, template)
+}
+
+func (server *Server) ListJobTemplates(ctx *gin.Context) {
+	var uri employerURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if !authorizeEmployer(ctx, uri.EmployerID) {
+		return
+	}
+
+	templates, err := server.store.ListJobTemplates(ctx, uri.EmployerID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	res := make([]jobTemplateResponse, 0, len(templates))
+	for _, template := range templates {
+		item, err := newJobTemplateResponse(template)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		res = append(res, item)
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (server *Server) GetJobTemplate(ctx *gin.Context) {
+	var uri jobTemplateURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if !authorizeEmployer(ctx, uri.EmployerID) {
+		return
+	}
+	template, ok := server.getJobTemplate(ctx, uri)
+	if !ok {
+		return
+	}
+	server.respondJobTemplate(ctx, template)
+}
+
+type updateJobTemplateRequest struct {
+	Name           string                   `json:"name"`
+	Title          string                   `json:"title"`
+	Description    string                   `json:"description"`
+	Industry       string                   `json:"industry"`
+	EmploymentType string                   `json:"employment_type"`
+	Wage           *float32                 `json:"wage" binding:"omitempty,min=0"`
+	Tips           *float32                 `json:"tips" binding:"omitempty,min=0"`
+	JobApplication []elasticsearch.Question `json:"job_application"`
+}
+
+// UpdateJobTemplate changes the fields present in the request and leaves the
+// others as they are.
+func (server *Server) UpdateJobTemplate(ctx *gin.Context) {
+	var uri jobTemplateURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req updateJobTemplateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if !authorizeEmployer(ctx, uri.EmployerID) {
+		return
+	}
+	if _, ok := server.getJobTemplate(ctx, uri); !ok {
+		return
+	}
+
+	arg := db.UpdateJobTemplateParams{
+		ID:             uri.TemplateID,
+		Name:           pgtype.Text{String: req.Name, Valid: req.Name != ""},
+		Title:          pgtype.Text{String: req.Title, Valid: req.Title != ""},
+		Description:    pgtype.Text{String: req.Description, Valid: req.Description != ""},
+		Industry:       pgtype.Text{String: req.Industry, Valid: req.Industry != ""},
+		EmploymentType: pgtype.Text{String: req.EmploymentType, Valid: req.EmploymentType != ""},
+	}
+	if req.Wage != nil {
+		arg.Wage = pgtype.Float4{Float32: *req.Wage, Valid: true}
+	}
+	if req.Tips != nil {
+		arg.Tips = pgtype.Float4{Float32: *req.Tips, Valid: true}
+	}
+	if req.JobApplication != nil {
+		if err := elasticsearch.ApplicationForm(req.JobApplication).Validate(); err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		form, err := encodeApplicationForm(req.JobApplication)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		arg.JobApplication = form
+	}
+
+	template, err := server.store.UpdateJobTemplate(ctx, arg)
+	if err != nil {
+		if db.ErrorCode(err) == db.UniqueViolation {
+			ctx.JSON(http.StatusConflict, errorResponse(fmt.Errorf("a job template named %q already exists", req.Name)))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	server.respondJobTemplate(ctx, template)
+}
+
+func (server *Server) DeleteJobTemplate(ctx *gin.Context) {
+	var uri jobTemplateURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if !authorizeEmployer(ctx, uri.EmployerID) {
+		return
+	}
+	if _, ok := server.getJobTemplate(ctx, uri); !ok {
+		return
+	}
+
+	if err := server.store.DeleteJobTemplate(ctx, uri.TemplateID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Job template deleted successfully"})
+}
+
+// createJobFromTemplateRequest holds the per-posting overrides. Fields left
+// out are taken from the template.
+type createJobFromTemplateRequest struct {
+	BusinessName    string                   `json:"business_name"`
+	JobLocation     string                   `json:"job_location"`
+	Title           string                   `json:"title"`
+	Description     string                   `json:"description"`
+	Industry        string                   `json:"industry"`
+	EmploymentType  string                   `json:"employment_type"`
+	Wage            *float32                 `json:"wage" binding:"omitempty,min=0"`
+	Tips            *float32                 `json:"tips" binding:"omitempty,min=0"`
+	JobApplication  []elasticsearch.Question `json:"job_application"`
+	Status          string                   `json:"status" binding:"omitempty,oneof=draft published"`
+	PublishAt       *time.Time               `json:"publish_at,omitempty"`
+	CloseAt         *time.Time               `json:"close_at,omitempty"`
+	MaxApplications int32                    `json:"max_applications,omitempty" binding:"min=0"`
+}
+
+// CreateJobFromTemplate posts a new job built from one of the employer's
+// templates.
+func (server *Server) CreateJobFromTemplate(ctx *gin.Context) {
+	var uri jobTemplateURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req createJobFromTemplateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if !authorizeEmployer(ctx, uri.EmployerID) {
+		return
+	}
+	template, ok := server.getJobTemplate(ctx, uri)
+	if !ok {
+		return
+	}
+	form, err := decodeApplicationForm(template.JobApplication)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	job := createJobRequest{
+		EmployerID:      uri.EmployerID,
+		BusinessName:    req.BusinessName,
+		Title:           template.Title,
+		Description:     template.Description,
+		Industry:        template.Industry,
+		JobLocation:     req.JobLocation,
+		EmploymentType:  template.EmploymentType,
+		Wage:            template.Wage,
+		Tips:            template.Tips,
+		JobApplication:  form,
+		Status:          req.Status,
+		PublishAt:       req.PublishAt,
+		CloseAt:         req.CloseAt,
+		MaxApplications: req.MaxApplications,
+	}
+	if req.Title != "" {
+		job.Title = req.Title
+	}
+	if req.Description != "" {
+		job.Description = req.Description
+	}
+	if req.Industry != "" {
+		job.Industry = req.Industry
+	}
+	if req.EmploymentType != "" {
+		job.EmploymentType = req.EmploymentType
+	}
+	if req.Wage != nil {
+		job.Wage = *req.Wage
+	}
+	if req.Tips != nil {
+		job.Tips = *req.Tips
+	}
+	if req.JobApplication != nil {
+		job.JobApplication = req.JobApplication
+	}
+	server.postJob(ctx, uri.EmployerID, &job)
+}
+
+func (server *Server) respondJobTemplate(ctx *gin.Context, template db.JobTemplate) {
+	res, err := newJobTemplateResponse(template)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, res)
+}
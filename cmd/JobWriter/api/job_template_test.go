@@ -0,0 +1,459 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/hankimmy/PtmrBackend/pkg/db/mock"
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	"github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
+	mockes "github.com/hankimmy/PtmrBackend/pkg/elasticsearch/mock"
+	"github.com/hankimmy/PtmrBackend/pkg/middleware"
+	"github.com/hankimmy/PtmrBackend/pkg/moderation"
+	"github.com/hankimmy/PtmrBackend/pkg/util"
+	"github.com/hankimmy/PtmrBackend/pkg/worker"
+	mockwk "github.com/hankimmy/PtmrBackend/pkg/worker/mock"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func randomJobTemplate(t *testing.T, employerID int64) db.JobTemplate {
+	job := elasticsearch.RandomJob(employerID)
+	form, err := json.Marshal(job.JobApplication)
+	require.NoError(t, err)
+	return db.JobTemplate{
+		ID:             util.RandomInt(1, 1000),
+		EmployerID:     employerID,
+		Name:           util.RandomString(8),
+		Title:          job.Title,
+		Description:    job.Description,
+		Industry:       job.Industry,
+		EmploymentType: job.EmploymentType,
+		Wage:           job.Wage,
+		Tips:           job.Tips,
+		JobApplication: form,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+}
+
+func serveJobTemplateRequest(t *testing.T, server *Server, method, url string, body gin.H, employerID int64) *httptest.ResponseRecorder {
+	var data []byte
+	if body != nil {
+		var err error
+		data, err = json.Marshal(body)
+		require.NoError(t, err)
+	}
+	request, err := http.NewRequest(method, url, bytes.NewReader(data))
+	require.NoError(t, err)
+	middleware.AddAuthorization(t, request, server.tokenMaker, middleware.AuthorizationTypeBearer, util.RandomString(6), db.RoleEmployer, time.Minute, employerID)
+
+	recorder := httptest.NewRecorder()
+	server.router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestCreateJobTemplate(t *testing.T) {
+	user, _ := db.RandomUser(db.RoleEmployer)
+	employer := db.RandomEmployer(user.Username)
+	template := randomJobTemplate(t, employer.ID)
+	job := elasticsearch.RandomJob(employer.ID)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		authID        int64
+		buildStubs    func(store *mockdb.MockStore, esClient *mockes.MockESClient)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"name":            template.Name,
+				"title":           template.Title,
+				"description":     template.Description,
+				"industry":        template.Industry,
+				"employment_type": template.EmploymentType,
+				"wage":            template.Wage,
+				"tips":            template.Tips,
+				"job_application": job.JobApplication,
+			},
+			authID: employer.ID,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				store.EXPECT().
+					CreateJobTemplate(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateJobTemplateParams) (db.JobTemplate, error) {
+						require.Equal(t, employer.ID, arg.EmployerID)
+						require.Equal(t, template.Name, arg.Name)
+						require.Equal(t, template.Title, arg.Title)
+						require.JSONEq(t, string(template.JobApplication), string(arg.JobApplication))
+						return template, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var res jobTemplateResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Equal(t, template.ID, res.ID)
+				require.Equal(t, job.JobApplication, res.JobApplication)
+			},
+		},
+		{
+			name:   "FromJob",
+			body:   gin.H{"name": template.Name, "job_id": job.ID, "title": "ignored"},
+			authID: employer.ID,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				esClient.EXPECT().GetJob(gomock.Eq(job.ID)).Times(1).Return(&job, nil)
+				store.EXPECT().
+					CreateJobTemplate(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateJobTemplateParams) (db.JobTemplate, error) {
+						require.Equal(t, job.Title, arg.Title)
+						require.Equal(t, job.Description, arg.Description)
+						require.Equal(t, job.Wage, arg.Wage)
+						return template, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "FromOtherEmployersJob",
+			body:   gin.H{"name": template.Name, "job_id": job.ID},
+			authID: employer.ID,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				otherJob := elasticsearch.RandomJob(employer.ID + 1)
+				esClient.EXPECT().GetJob(gomock.Eq(job.ID)).Times(1).Return(&otherJob, nil)
+				store.EXPECT().CreateJobTemplate(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:   "MissingTitle",
+			body:   gin.H{"name": template.Name},
+			authID: employer.ID,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				store.EXPECT().CreateJobTemplate(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "DuplicateName",
+			body:   gin.H{"name": template.Name, "title": template.Title},
+			authID: employer.ID,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				store.EXPECT().
+					CreateJobTemplate(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.JobTemplate{}, db.ErrUniqueViolation)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:   "OtherEmployer",
+			body:   gin.H{"name": template.Name, "title": template.Title},
+			authID: employer.ID + 1,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				store.EXPECT().CreateJobTemplate(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+			esClient := mockes.NewMockESClient(ctrl)
+			tc.buildStubs(store, esClient)
+
+			server := newTestServer(t, store, esClient, nil)
+			url := fmt.Sprintf("/employers/%d/job_templates", employer.ID)
+			tc.checkResponse(serveJobTemplateRequest(t, server, http.MethodPost, url, tc.body, tc.authID))
+		})
+	}
+}
+
+func TestListJobTemplates(t *testing.T) {
+	user, _ := db.RandomUser(db.RoleEmployer)
+	employer := db.RandomEmployer(user.Username)
+	templates := []db.JobTemplate{
+		randomJobTemplate(t, employer.ID),
+		randomJobTemplate(t, employer.ID),
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().ListJobTemplates(gomock.Any(), gomock.Eq(employer.ID)).Times(1).Return(templates, nil)
+
+	server := newTestServer(t, store, nil, nil)
+	url := fmt.Sprintf("/employers/%d/job_templates", employer.ID)
+	recorder := serveJobTemplateRequest(t, server, http.MethodGet, url, nil, employer.ID)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var res []jobTemplateResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+	require.Len(t, res, 2)
+	require.Equal(t, templates[1].Name, res[1].Name)
+	require.Len(t, res[1].JobApplication, 3)
+}
+
+func TestUpdateJobTemplate(t *testing.T) {
+	user, _ := db.RandomUser(db.RoleEmployer)
+	employer := db.RandomEmployer(user.Username)
+	template := randomJobTemplate(t, employer.ID)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"title": "Weekend Line Cook", "tips": 0},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetJobTemplate(gomock.Any(), gomock.Eq(template.ID)).Times(1).Return(template, nil)
+				arg := db.UpdateJobTemplateParams{
+					ID:    template.ID,
+					Title: pgtype.Text{String: "Weekend Line Cook", Valid: true},
+					Tips:  pgtype.Float4{Float32: 0, Valid: true},
+				}
+				updated := template
+				updated.Title = "Weekend Line Cook"
+				updated.Tips = 0
+				store.EXPECT().UpdateJobTemplate(gomock.Any(), gomock.Eq(arg)).Times(1).Return(updated, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var res jobTemplateResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Equal(t, "Weekend Line Cook", res.Title)
+			},
+		},
+		{
+			name: "InvalidApplicationForm",
+			body: gin.H{"job_application": []gin.H{{"id": "q1", "type": "signature", "question": "Sign here"}}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetJobTemplate(gomock.Any(), gomock.Eq(template.ID)).Times(1).Return(template, nil)
+				store.EXPECT().UpdateJobTemplate(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NotOwned",
+			body: gin.H{"title": "Weekend Line Cook"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetJobTemplate(gomock.Any(), gomock.Eq(template.ID)).
+					Times(1).
+					Return(randomJobTemplate(t, employer.ID+1), nil)
+				store.EXPECT().UpdateJobTemplate(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			body: gin.H{"title": "Weekend Line Cook"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetJobTemplate(gomock.Any(), gomock.Eq(template.ID)).
+					Times(1).
+					Return(db.JobTemplate{}, db.ErrRecordNotFound)
+				store.EXPECT().UpdateJobTemplate(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil, nil)
+			url := fmt.Sprintf("/employers/%d/job_templates/%d", employer.ID, template.ID)
+			tc.checkResponse(serveJobTemplateRequest(t, server, http.MethodPatch, url, tc.body, employer.ID))
+		})
+	}
+}
+
+func TestDeleteJobTemplate(t *testing.T) {
+	user, _ := db.RandomUser(db.RoleEmployer)
+	employer := db.RandomEmployer(user.Username)
+	template := randomJobTemplate(t, employer.ID)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetJobTemplate(gomock.Any(), gomock.Eq(template.ID)).Times(1).Return(template, nil)
+	store.EXPECT().DeleteJobTemplate(gomock.Any(), gomock.Eq(template.ID)).Times(1).Return(nil)
+
+	server := newTestServer(t, store, nil, nil)
+	url := fmt.Sprintf("/employers/%d/job_templates/%d", employer.ID, template.ID)
+	recorder := serveJobTemplateRequest(t, server, http.MethodDelete, url, nil, employer.ID)
+	require.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestCreateJobFromTemplate(t *testing.T) {
+	user, _ := db.RandomUser(db.RoleEmployer)
+	employer := db.RandomEmployer(user.Username)
+	template := randomJobTemplate(t, employer.ID)
+	location := util.RandomUSAddress()
+	closeAt := time.Now().Add(14 * 24 * time.Hour).UTC().Truncate(time.Second)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore, esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"job_location": location, "wage": 22, "close_at": closeAt},
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetJobTemplate(gomock.Any(), gomock.Eq(template.ID)).Times(1).Return(template, nil)
+				esClient.EXPECT().FindDuplicateJob(gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+				esClient.EXPECT().
+					CreateJob(gomock.Any()).
+					Times(1).
+					DoAndReturn(func(job *elasticsearch.Job) error {
+						require.Equal(t, fmt.Sprintf("%d_%s", employer.ID, template.Title), job.ID)
+						require.Equal(t, employer.ID, job.EmployerID)
+						require.Equal(t, template.Title, job.Title)
+						require.Equal(t, template.Description, job.Description)
+						require.Equal(t, location, job.JobLocation)
+						require.Equal(t, float32(22), job.Wage)
+						require.Equal(t, template.Tips, job.Tips)
+						require.Len(t, job.JobApplication, 3)
+						require.True(t, closeAt.Equal(*job.CloseAt))
+						require.Equal(t, elasticsearch.JobStatusPublished, job.Status)
+						return nil
+					})
+				store.EXPECT().
+					DeletePendingModerationReview(gomock.Any(), gomock.Eq(db.DeletePendingModerationReviewParams{
+						ItemType: moderation.ItemJob,
+						ItemID:   fmt.Sprintf("%d_%s", employer.ID, template.Title),
+					})).
+					Times(1).
+					Return(nil)
+				distributor.EXPECT().DistributeTaskEnrichJobPlace(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				distributor.EXPECT().
+					DistributeTaskApplyJobSchedule(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "TitleOverride",
+			body: gin.H{"title": "Sunday Line Cook"},
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetJobTemplate(gomock.Any(), gomock.Eq(template.ID)).Times(1).Return(template, nil)
+				esClient.EXPECT().FindDuplicateJob(gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+				esClient.EXPECT().
+					CreateJob(gomock.Any()).
+					Times(1).
+					DoAndReturn(func(job *elasticsearch.Job) error {
+						require.Equal(t, fmt.Sprintf("%d_Sunday Line Cook", employer.ID), job.ID)
+						return nil
+					})
+				store.EXPECT().DeletePendingModerationReview(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				distributor.EXPECT().
+					DistributeTaskEnrichJobPlace(gomock.Any(), gomock.Eq(&worker.PayloadEnrichJobPlace{JobID: fmt.Sprintf("%d_Sunday Line Cook", employer.ID)}), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "AlreadyPosted",
+			body: gin.H{},
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetJobTemplate(gomock.Any(), gomock.Eq(template.ID)).Times(1).Return(template, nil)
+				esClient.EXPECT().FindDuplicateJob(gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+				esClient.EXPECT().
+					CreateJob(gomock.Any()).
+					Times(1).
+					Return(elasticsearch.ErrJobExists)
+				store.EXPECT().DeletePendingModerationReview(gomock.Any(), gomock.Any()).Times(0)
+				distributor.EXPECT().DistributeTaskEnrichJobPlace(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				distributor.EXPECT().DistributeTaskApplyJobSchedule(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidSchedule",
+			body: gin.H{"close_at": time.Now().Add(-time.Hour)},
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetJobTemplate(gomock.Any(), gomock.Eq(template.ID)).Times(1).Return(template, nil)
+				esClient.EXPECT().CreateJob(gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "TemplateNotFound",
+			body: gin.H{},
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetJobTemplate(gomock.Any(), gomock.Eq(template.ID)).
+					Times(1).
+					Return(db.JobTemplate{}, db.ErrRecordNotFound)
+				esClient.EXPECT().CreateJob(gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+			esClient := mockes.NewMockESClient(ctrl)
+			distributor := mockwk.NewMockTaskDistributor(ctrl)
+			tc.buildStubs(store, esClient, distributor)
+
+			server := newTestServer(t, store, esClient, distributor)
+			url := fmt.Sprintf("/employers/%d/job_templates/%d/jobs", employer.ID, template.ID)
+			tc.checkResponse(serveJobTemplateRequest(t, server, http.MethodPost, url, tc.body, employer.ID))
+		})
+	}
+}
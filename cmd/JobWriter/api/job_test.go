@@ -15,10 +15,11 @@ import (
 	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
 	"github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
 	mockes "github.com/hankimmy/PtmrBackend/pkg/elasticsearch/mock"
-	"github.com/hankimmy/PtmrBackend/pkg/google"
-	mockgapi "github.com/hankimmy/PtmrBackend/pkg/google/mock"
 	"github.com/hankimmy/PtmrBackend/pkg/middleware"
+	"github.com/hankimmy/PtmrBackend/pkg/moderation"
 	"github.com/hankimmy/PtmrBackend/pkg/token"
+	"github.com/hankimmy/PtmrBackend/pkg/worker"
+	mockwk "github.com/hankimmy/PtmrBackend/pkg/worker/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -51,44 +52,15 @@ func TestCreateJob(t *testing.T) {
 		JobApplication:     job.JobApplication,
 		DatePosted:         job.DatePosted,
 		IsUserCreated:      job.IsUserCreated,
-		PlaceID:            job.PlaceID,
-		DisplayName:        job.DisplayName,
-		PhoneNumber:        job.PhoneNumber,
-		BusinessType:       job.BusinessType,
-		FormattedAddress:   job.FormattedAddress,
-		PreciseLocation:    job.PreciseLocation,
-		Photos:             job.Photos,
-		Rating:             job.Rating,
-		PriceLevel:         job.PriceLevel,
-		OpeningHours:       job.OpeningHours,
-		WebsiteURI:         job.WebsiteURI,
-		GoogleMapsURI:      job.GoogleMapsURI,
-	}
-	placeDetailsResponse := google.PlaceDetailsResponse{
-		Name:             "",
-		ID:               job.PlaceID,
-		Types:            job.BusinessType,
-		FormattedAddress: job.FormattedAddress,
-		Location: google.Location{
-			Latitude:  job.PreciseLocation.Lat,
-			Longitude: job.PreciseLocation.Lon,
-		},
-		Photos:        job.Photos,
-		GoogleMapsURI: job.GoogleMapsURI,
-		DisplayName: struct {
-			Text string `json:"text"`
-		}(struct{ Text string }{Text: job.DisplayName}),
-		NationalPhoneNumber: job.PhoneNumber,
-		PriceLevel:          job.PriceLevel,
-		Rating:              job.Rating,
-		RegularOpeningHours: job.OpeningHours,
-		WebsiteURI:          job.WebsiteURI,
+		Status:             elasticsearch.JobStatusPublished,
+		ModerationStatus:   moderation.DecisionApproved,
+		EnrichmentStatus:   elasticsearch.EnrichmentPending,
 	}
 	testCases := []struct {
 		name          string
 		body          gin.H
 		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
-		buildStubs    func(esClient *mockes.MockESClient, gapi *mockgapi.MockGAPI)
+		buildStubs    func(esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor)
 		checkResponse func(recorder *httptest.ResponseRecorder)
 	}{
 		{
@@ -97,22 +69,58 @@ func TestCreateJob(t *testing.T) {
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, employer.Username, db.RoleEmployer, time.Minute, employer.ID)
 			},
-			buildStubs: func(esClient *mockes.MockESClient, gapi *mockgapi.MockGAPI) {
-				gapi.EXPECT().
-					GetPlaceID(gomock.Any()).
+			buildStubs: func(esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().FindDuplicateJob(gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+				esClient.EXPECT().
+					CreateJob(gomock.Eq(&arg)).
 					Times(1).
-					Return("ChIJJS3mqONZwokR9KlP3H_7MNg", nil)
-				gapi.EXPECT().
-					GetPlaceDetails(gomock.Any()).
+					Return(nil)
+				distributor.EXPECT().
+					DistributeTaskEnrichJobPlace(gomock.Any(), gomock.Eq(&worker.PayloadEnrichJobPlace{JobID: job.ID}), gomock.Any()).
 					Times(1).
-					Return(&placeDetailsResponse, nil)
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "FlagsDuplicate",
+			body: jobBody,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, employer.Username, db.RoleEmployer, time.Minute, employer.ID)
+			},
+			buildStubs: func(esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				original := elasticsearch.RandomJob(employer.ID + 1)
+				esClient.EXPECT().FindDuplicateJob(gomock.Any(), gomock.Any()).Times(1).Return(&original, nil)
 				esClient.EXPECT().
-					IndexJob(gomock.Eq(&arg)).
+					CreateJob(gomock.Any()).
 					Times(1).
-					Return(nil)
+					DoAndReturn(func(indexed *elasticsearch.Job) error {
+						require.Equal(t, original.ID, indexed.DuplicateOf)
+						return nil
+					})
+				distributor.EXPECT().DistributeTaskEnrichJobPlace(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "duplicate_of")
+			},
+		},
+		{
+			name: "DuplicateCheckFails",
+			body: jobBody,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, employer.Username, db.RoleEmployer, time.Minute, employer.ID)
+			},
+			buildStubs: func(esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().FindDuplicateJob(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("es down"))
+				esClient.EXPECT().CreateJob(gomock.Eq(&arg)).Times(1).Return(nil)
+				distributor.EXPECT().DistributeTaskEnrichJobPlace(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.NotContains(t, recorder.Body.String(), "duplicate_of")
 			},
 		},
 		{
@@ -121,7 +129,7 @@ func TestCreateJob(t *testing.T) {
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleCandidate, time.Minute, employer.ID)
 			},
-			buildStubs: func(esClient *mockes.MockESClient, gapi *mockgapi.MockGAPI) {
+			buildStubs: func(esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, recorder.Code)
@@ -135,7 +143,46 @@ func TestCreateJob(t *testing.T) {
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, employer.Username, db.RoleEmployer, time.Minute, employer.ID)
 			},
-			buildStubs: func(esClient *mockes.MockESClient, gapi *mockgapi.MockGAPI) {
+			buildStubs: func(esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidApplicationForm",
+			body: gin.H{
+				"business_name": job.HiringOrganization,
+				"title":         job.Title,
+				"job_application": []gin.H{
+					{"id": "q1", "type": "multiple-choice", "question": "Pick one", "options": []string{"only"}},
+					{"id": "q1", "type": "signature", "question": "Sign here"},
+				},
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, employer.Username, db.RoleEmployer, time.Minute, employer.ID)
+			},
+			buildStubs: func(esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().CreateJob(gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "at least two options")
+				require.Contains(t, recorder.Body.String(), "unsupported type")
+			},
+		},
+		{
+			name: "MalformedApplicationForm",
+			body: gin.H{
+				"business_name":   job.HiringOrganization,
+				"title":           job.Title,
+				"job_application": gin.H{"questions": []string{"Why?"}},
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, employer.Username, db.RoleEmployer, time.Minute, employer.ID)
+			},
+			buildStubs: func(esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().CreateJob(gomock.Any()).Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
@@ -147,19 +194,13 @@ func TestCreateJob(t *testing.T) {
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, employer.Username, db.RoleEmployer, time.Minute, employer.ID)
 			},
-			buildStubs: func(esClient *mockes.MockESClient, gapi *mockgapi.MockGAPI) {
-				gapi.EXPECT().
-					GetPlaceID(gomock.Any()).
-					Times(1).
-					Return("ChIJJS3mqONZwokR9KlP3H_7MNg", nil)
-				gapi.EXPECT().
-					GetPlaceDetails(gomock.Any()).
-					Times(1).
-					Return(&placeDetailsResponse, nil)
+			buildStubs: func(esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().FindDuplicateJob(gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
 				esClient.EXPECT().
-					IndexJob(gomock.Any()).
+					CreateJob(gomock.Any()).
 					Times(1).
 					Return(errors.New(elasticsearch.ErrIndexFailure))
+				distributor.EXPECT().DistributeTaskEnrichJobPlace(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
@@ -174,12 +215,12 @@ func TestCreateJob(t *testing.T) {
 			defer esCtrl.Finish()
 			esClient := mockes.NewMockESClient(esCtrl)
 
-			gCtrl := gomock.NewController(t)
-			defer gCtrl.Finish()
-			gClient := mockgapi.NewMockGAPI(gCtrl)
-			tc.buildStubs(esClient, gClient)
+			wkCtrl := gomock.NewController(t)
+			defer wkCtrl.Finish()
+			distributor := mockwk.NewMockTaskDistributor(wkCtrl)
+			tc.buildStubs(esClient, distributor)
 
-			server := newTestServer(t, esClient, gClient)
+			server := newTestServer(t, newReviewStore(esCtrl), esClient, distributor)
 			recorder := httptest.NewRecorder()
 			data, _ := json.Marshal(tc.body)
 			url := fmt.Sprintf("/jobs/%d", employer.ID)
@@ -196,12 +237,14 @@ func TestCreateJob(t *testing.T) {
 func TestGetJob(t *testing.T) {
 	jobID := "job_123"
 	job := elasticsearch.RandomJob(0)
+	draft := elasticsearch.RandomJob(7)
+	draft.Status = elasticsearch.JobStatusDraft
 
 	testCases := []struct {
 		name          string
 		jobID         string
 		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
-		buildStubs    func(esClient *mockes.MockESClient)
+		buildStubs    func(esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor)
 		checkResponse func(recorder *httptest.ResponseRecorder)
 	}{
 		{
@@ -210,24 +253,99 @@ func TestGetJob(t *testing.T) {
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, "", db.RoleEmployer, time.Minute, 0)
 			},
-			buildStubs: func(esClient *mockes.MockESClient) {
+			buildStubs: func(esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
 				esClient.EXPECT().
 					GetJob(gomock.Eq(jobID)).
 					Times(1).
 					Return(&job, nil)
+				distributor.EXPECT().DistributeTaskIncrementJobStats(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
 				require.JSONEq(t, toJson(job), recorder.Body.String())
 			},
 		},
+		{
+			name:  "CandidateViewIsCounted",
+			jobID: jobID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, "", db.RoleCandidate, time.Minute, 1)
+			},
+			buildStubs: func(esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().
+					GetJob(gomock.Eq(jobID)).
+					Times(1).
+					Return(&job, nil)
+				distributor.EXPECT().
+					DistributeTaskIncrementJobStats(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, payload *worker.PayloadIncrementJobStats, _ ...interface{}) error {
+						require.Equal(t, worker.JobStatView, payload.Event)
+						require.Equal(t, []worker.JobStatTarget{{JobID: job.ID, EmployerID: job.EmployerID}}, payload.Jobs)
+						return nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "OwnerSeesDraft",
+			jobID: draft.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, "", db.RoleEmployer, time.Minute, draft.EmployerID)
+			},
+			buildStubs: func(esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().
+					GetJob(gomock.Eq(draft.ID)).
+					Times(1).
+					Return(&draft, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.JSONEq(t, toJson(draft), recorder.Body.String())
+			},
+		},
+		{
+			name:  "DraftHiddenFromOtherEmployer",
+			jobID: draft.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, "", db.RoleEmployer, time.Minute, draft.EmployerID+1)
+			},
+			buildStubs: func(esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().
+					GetJob(gomock.Eq(draft.ID)).
+					Times(1).
+					Return(&draft, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:  "DraftHiddenFromCandidate",
+			jobID: draft.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, "", db.RoleCandidate, time.Minute, draft.EmployerID)
+			},
+			buildStubs: func(esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().
+					GetJob(gomock.Eq(draft.ID)).
+					Times(1).
+					Return(&draft, nil)
+				distributor.EXPECT().DistributeTaskIncrementJobStats(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
 		{
 			name:  "NotFound",
 			jobID: "non_existent_job",
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, "", db.RoleEmployer, time.Minute, 0)
 			},
-			buildStubs: func(esClient *mockes.MockESClient) {
+			buildStubs: func(esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
 				esClient.EXPECT().
 					GetJob(gomock.Eq("non_existent_job")).
 					Times(1).
@@ -244,9 +362,10 @@ func TestGetJob(t *testing.T) {
 			esCtrl := gomock.NewController(t)
 			defer esCtrl.Finish()
 			esClient := mockes.NewMockESClient(esCtrl)
-			tc.buildStubs(esClient)
+			distributor := mockwk.NewMockTaskDistributor(esCtrl)
+			tc.buildStubs(esClient, distributor)
 
-			server := newTestServer(t, esClient, nil)
+			server := newTestServer(t, nil, esClient, distributor)
 			recorder := httptest.NewRecorder()
 
 			url := fmt.Sprintf("/jobs/%s", tc.jobID)
@@ -328,7 +447,7 @@ func TestUpdateJob(t *testing.T) {
 			defer esCtrl.Finish()
 			esClient := mockes.NewMockESClient(esCtrl)
 			tc.buildStubs(esClient)
-			server := newTestServer(t, esClient, nil)
+			server := newTestServer(t, newReviewStore(esCtrl), esClient, nil)
 			recorder := httptest.NewRecorder()
 
 			data, _ := json.Marshal(tc.body)
@@ -394,7 +513,7 @@ func TestDeleteJob(t *testing.T) {
 			defer esCtrl.Finish()
 			esClient := mockes.NewMockESClient(esCtrl)
 			tc.buildStubs(esClient)
-			server := newTestServer(t, esClient, nil)
+			server := newTestServer(t, nil, esClient, nil)
 			recorder := httptest.NewRecorder()
 
 			url := fmt.Sprintf("/jobs/%s", tc.jobID)
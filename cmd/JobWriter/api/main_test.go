@@ -6,25 +6,36 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/hankimmy/PtmrBackend/pkg/db/mock"
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
 	"github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
-	"github.com/hankimmy/PtmrBackend/pkg/google"
 	"github.com/hankimmy/PtmrBackend/pkg/token"
 	"github.com/hankimmy/PtmrBackend/pkg/util"
+	"github.com/hankimmy/PtmrBackend/pkg/worker"
 	"github.com/stretchr/testify/require"
 )
 
-func newTestServer(t *testing.T, esClient elasticsearch.ESClient, gapi google.GAPI) *Server {
+func newTestServer(t *testing.T, store db.Store, esClient elasticsearch.ESClient, taskDistributor worker.TaskDistributor) *Server {
 	config := util.Config{
 		TokenSymmetricKey:   util.RandomString(32),
 		AccessTokenDuration: time.Minute,
 	}
 	tokenMaker, err := token.NewPasetoMaker(config.TokenSymmetricKey)
 	require.NoError(t, err)
-	server := NewServer(config, esClient, tokenMaker, gapi)
+	server := NewServer(config, store, esClient, tokenMaker, taskDistributor)
 	server.SetupRouter()
 	return server
 }
 
+// newReviewStore returns a store for tests that save jobs moderation
+// approves, where each save closes the job's pending review.
+func newReviewStore(ctrl *gomock.Controller) *mockdb.MockStore {
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().DeletePendingModerationReview(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+	return store
+}
+
 func TestMain(m *testing.M) {
 	gin.SetMode(gin.TestMode)
 	os.Exit(m.Run())
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	"github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
+	"github.com/hankimmy/PtmrBackend/pkg/moderation"
+)
+
+// moderateJob runs the job's title and description through moderation and
+// records the decision on the job. Rejected jobs are turned away by the
+// caller; the others are saved and then passed to syncModerationReview.
+func (server *Server) moderateJob(job *elasticsearch.Job) moderation.Result {
+	result := server.moderator.Moderate(jobModerationFields(job)...)
+	job.ModerationStatus = result.Decision
+	return result
+}
+
+// syncModerationReview brings the review queue in line with a saved job.
+// Jobs that need review are queued for an employee and stay out of the feed
+// until approved. Any other decision closes a pending review, so a reviewer
+// can't act on text the job no longer has.
+func (server *Server) syncModerationReview(ctx context.Context, job *elasticsearch.Job, result moderation.Result) error {
+	if result.Decision != moderation.DecisionNeedsReview {
+		return server.store.DeletePendingModerationReview(ctx, db.DeletePendingModerationReviewParams{
+			ItemType: moderation.ItemJob,
+			ItemID:   job.ID,
+		})
+	}
+
+	content, err := json.Marshal(jobModerationFields(job))
+	if err != nil {
+		return err
+	}
+	findings, err := json.Marshal(result.Findings)
+	if err != nil {
+		return err
+	}
+	_, err = server.store.CreateModerationReview(ctx, db.CreateModerationReviewParams{
+		ItemType: moderation.ItemJob,
+		ItemID:   job.ID,
+		Content:  content,
+		Findings: findings,
+	})
+	return err
+}
+
+func jobModerationFields(job *elasticsearch.Job) []moderation.Field {
+	return []moderation.Field{
+		{Name: "title", Text: job.Title},
+		{Name: "description", Text: job.Description},
+	}
+}
+
+func moderationRejectedResponse(result moderation.Result) gin.H {
+	return gin.H{"error": "job was rejected by moderation", "findings": result.Findings}
+}
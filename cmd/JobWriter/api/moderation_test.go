@@ -0,0 +1,342 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/hankimmy/PtmrBackend/pkg/db/mock"
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	"github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
+	mockes "github.com/hankimmy/PtmrBackend/pkg/elasticsearch/mock"
+	"github.com/hankimmy/PtmrBackend/pkg/middleware"
+	"github.com/hankimmy/PtmrBackend/pkg/moderation"
+	mockwk "github.com/hankimmy/PtmrBackend/pkg/worker/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateJobModeration(t *testing.T) {
+	user, _ := db.RandomUser(db.RoleEmployer)
+	employer := db.RandomEmployer(user.Username)
+	job := elasticsearch.RandomJob(employer.ID)
+	jobBody := func(description string) gin.H {
+		return gin.H{
+			"business_name":   job.HiringOrganization,
+			"title":           job.Title,
+			"description":     description,
+			"industry":        job.Industry,
+			"job_location":    job.JobLocation,
+			"employment_type": job.EmploymentType,
+			"wage":            job.Wage,
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore, esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Rejected",
+			body: jobBody("Great pay! Just pay a fee of $40 for your starter kit."),
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().CreateModerationReview(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().DeletePendingModerationReview(gomock.Any(), gomock.Any()).Times(0)
+				esClient.EXPECT().CreateJob(gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "scam_patterns")
+			},
+		},
+		{
+			name: "NeedsReview",
+			body: jobBody("Text the manager at 917-555-0101 to set up a trial shift."),
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					CreateModerationReview(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateModerationReviewParams) (db.ModerationReview, error) {
+						require.Equal(t, moderation.ItemJob, arg.ItemType)
+						require.Equal(t, job.ID, arg.ItemID)
+						require.Contains(t, string(arg.Content), "917-555-0101")
+						require.Contains(t, string(arg.Findings), "contact_info")
+						return db.ModerationReview{ID: 1}, nil
+					})
+				esClient.EXPECT().FindDuplicateJob(gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+				esClient.EXPECT().
+					CreateJob(gomock.Any()).
+					Times(1).
+					DoAndReturn(func(indexed *elasticsearch.Job) error {
+						require.Equal(t, moderation.DecisionNeedsReview, indexed.ModerationStatus)
+						return nil
+					})
+				distributor.EXPECT().DistributeTaskEnrichJobPlace(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"moderation_status":"needs_review"`)
+			},
+		},
+		{
+			name: "ReviewQueueErrorKeepsJob",
+			body: jobBody("Text the manager at 917-555-0101 to set up a trial shift."),
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().FindDuplicateJob(gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+				createJob := esClient.EXPECT().CreateJob(gomock.Any()).Times(1).Return(nil)
+				store.EXPECT().
+					CreateModerationReview(gomock.Any(), gomock.Any()).
+					Times(1).
+					After(createJob).
+					Return(db.ModerationReview{}, errors.New("db down"))
+				distributor.EXPECT().DistributeTaskEnrichJobPlace(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"moderation_status":"needs_review"`)
+			},
+		},
+		{
+			name: "AlreadyExists",
+			body: jobBody("Text the manager at 917-555-0101 to set up a trial shift."),
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, distributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().FindDuplicateJob(gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+				esClient.EXPECT().CreateJob(gomock.Any()).Times(1).Return(elasticsearch.ErrJobExists)
+				store.EXPECT().CreateModerationReview(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+			esClient := mockes.NewMockESClient(ctrl)
+			distributor := mockwk.NewMockTaskDistributor(ctrl)
+			tc.buildStubs(store, esClient, distributor)
+
+			server := newTestServer(t, store, esClient, distributor)
+			recorder := httptest.NewRecorder()
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+			url := fmt.Sprintf("/jobs/%d", employer.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			middleware.AddAuthorization(t, request, server.tokenMaker, middleware.AuthorizationTypeBearer, employer.Username, db.RoleEmployer, time.Minute, employer.ID)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUpdateJobModeration(t *testing.T) {
+	user, _ := db.RandomUser(db.RoleEmployer)
+	employer := db.RandomEmployer(user.Username)
+	job := elasticsearch.RandomJob(employer.ID)
+
+	testCases := []struct {
+		name          string
+		description   string
+		buildStubs    func(store *mockdb.MockStore, esClient *mockes.MockESClient)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "Approved",
+			description: "Closing shifts, must be able to lift 30 lbs.",
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				store.EXPECT().CreateModerationReview(gomock.Any(), gomock.Any()).Times(0)
+				updateJob := esClient.EXPECT().
+					UpdateJob(gomock.Eq(job.ID), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ string, updated *elasticsearch.Job) error {
+						require.Equal(t, moderation.DecisionApproved, updated.ModerationStatus)
+						return nil
+					})
+				// Clean text closes the review the job was waiting on.
+				store.EXPECT().
+					DeletePendingModerationReview(gomock.Any(), gomock.Eq(db.DeletePendingModerationReviewParams{
+						ItemType: moderation.ItemJob,
+						ItemID:   job.ID,
+					})).
+					Times(1).
+					After(updateJob).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:        "ReviewSyncError",
+			description: "Closing shifts, must be able to lift 30 lbs.",
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				esClient.EXPECT().UpdateJob(gomock.Eq(job.ID), gomock.Any()).Times(1).Return(nil)
+				store.EXPECT().
+					DeletePendingModerationReview(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(errors.New("db down"))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:        "Rejected",
+			description: "Payment by Western Union only.",
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				store.EXPECT().CreateModerationReview(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().DeletePendingModerationReview(gomock.Any(), gomock.Any()).Times(0)
+				esClient.EXPECT().UpdateJob(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name:        "NeedsReview",
+			description: "Apply at https://a.example or https://b.example or https://c.example",
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				store.EXPECT().CreateModerationReview(gomock.Any(), gomock.Any()).Times(1).Return(db.ModerationReview{ID: 1}, nil)
+				esClient.EXPECT().
+					UpdateJob(gomock.Eq(job.ID), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ string, updated *elasticsearch.Job) error {
+						require.Equal(t, moderation.DecisionNeedsReview, updated.ModerationStatus)
+						return nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "excessive_links")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+			esClient := mockes.NewMockESClient(ctrl)
+			tc.buildStubs(store, esClient)
+
+			server := newTestServer(t, store, esClient, mockwk.NewMockTaskDistributor(ctrl))
+			recorder := httptest.NewRecorder()
+			data, err := json.Marshal(gin.H{
+				"employer_id": employer.ID,
+				"title":       job.Title,
+				"description": tc.description,
+			})
+			require.NoError(t, err)
+			request, err := http.NewRequest(http.MethodPatch, "/jobs/"+job.ID, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			middleware.AddAuthorization(t, request, server.tokenMaker, middleware.AuthorizationTypeBearer, employer.Username, db.RoleEmployer, time.Minute, employer.ID)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestImportJobPostingsModeration(t *testing.T) {
+	user, _ := db.RandomUser(db.RoleEmployer)
+	employer := db.RandomEmployer(user.Username)
+	posting := func(title, description string) map[string]interface{} {
+		return map[string]interface{}{
+			"@context":       "https://schema.org/",
+			"@type":          "JobPosting",
+			"title":          title,
+			"description":    description,
+			"employmentType": "PART_TIME",
+			"hiringOrganization": map[string]interface{}{
+				"@type": "Organization",
+				"name":  "Bean There",
+			},
+			"jobLocation": map[string]interface{}{
+				"@type": "Place",
+				"address": map[string]interface{}{
+					"streetAddress":   "1 Main St",
+					"addressLocality": "Brooklyn",
+					"addressRegion":   "NY",
+					"postalCode":      "11201",
+				},
+			},
+		}
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+	esClient := mockes.NewMockESClient(ctrl)
+	distributor := mockwk.NewMockTaskDistributor(ctrl)
+
+	esClient.EXPECT().FindDuplicateJob(gomock.Any(), gomock.Any()).Times(4).Return(nil, nil)
+	store.EXPECT().
+		DeletePendingModerationReview(gomock.Any(), gomock.Eq(db.DeletePendingModerationReviewParams{
+			ItemType: moderation.ItemJob,
+			ItemID:   fmt.Sprintf("%d_Barista", employer.ID),
+		})).
+		Times(1).
+		Return(nil)
+	store.EXPECT().
+		CreateModerationReview(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ interface{}, arg db.CreateModerationReviewParams) (db.ModerationReview, error) {
+			require.Equal(t, fmt.Sprintf("%d_Host", employer.ID), arg.ItemID)
+			return db.ModerationReview{ID: 1}, nil
+		})
+	esClient.EXPECT().
+		BulkCreateJobs(gomock.Any(), gomock.Len(3)).
+		Times(1).
+		DoAndReturn(func(_ interface{}, jobs []elasticsearch.Job) (*elasticsearch.BulkResult, error) {
+			require.Equal(t, moderation.DecisionApproved, jobs[0].ModerationStatus)
+			require.Equal(t, moderation.DecisionNeedsReview, jobs[1].ModerationStatus)
+			require.Equal(t, moderation.DecisionNeedsReview, jobs[2].ModerationStatus)
+			// The server already has a Cook job, so its review is not queued.
+			return &elasticsearch.BulkResult{
+				Succeeded: 2,
+				Failed:    []elasticsearch.BulkItemError{{ID: jobs[2].ID, Status: http.StatusConflict}},
+			}, nil
+		})
+	distributor.EXPECT().DistributeTaskEnrichJobPlace(gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Return(nil)
+
+	server := newTestServer(t, store, esClient, distributor)
+	recorder := httptest.NewRecorder()
+	data, err := json.Marshal([]interface{}{
+		posting("Barista", "Pull shots and steam milk."),
+		posting("Host", "Email resumes to host@example.com"),
+		posting("Cashier", "A registration fee is due on your first day."),
+		posting("Cook", "Call 917-555-0101 about the opening."),
+	})
+	require.NoError(t, err)
+	url := fmt.Sprintf("/jobs/%d/import", employer.ID)
+	request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+	require.NoError(t, err)
+
+	middleware.AddAuthorization(t, request, server.tokenMaker, middleware.AuthorizationTypeBearer, employer.Username, db.RoleEmployer, time.Minute, employer.ID)
+	server.router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var res importJobPostingsResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+	require.Equal(t, 2, res.Imported)
+	require.Len(t, res.Rejected, 2)
+	require.Equal(t, 2, res.Rejected[0].Index)
+	require.Equal(t, "rejected by moderation", res.Rejected[0].Error)
+	require.NotEmpty(t, res.Rejected[0].Findings)
+	require.Equal(t, 3, res.Rejected[1].Index)
+	require.Equal(t, "job already exists", res.Rejected[1].Error)
+}
@@ -2,37 +2,72 @@ package api
 
 import (
 	"github.com/gin-gonic/gin"
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
 	"github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
-	"github.com/hankimmy/PtmrBackend/pkg/google"
 	"github.com/hankimmy/PtmrBackend/pkg/middleware"
+	"github.com/hankimmy/PtmrBackend/pkg/moderation"
 	"github.com/hankimmy/PtmrBackend/pkg/token"
 	"github.com/hankimmy/PtmrBackend/pkg/util"
+	"github.com/hankimmy/PtmrBackend/pkg/worker"
 )
 
 type Server struct {
-	config     util.Config
-	esClient   elasticsearch.ESClient
-	router     *gin.Engine
-	tokenMaker token.Maker
-	gapi       google.GAPI
+	config          util.Config
+	store           db.Store
+	esClient        elasticsearch.ESClient
+	router          *gin.Engine
+	tokenMaker      token.Maker
+	taskDistributor worker.TaskDistributor
+	moderator       moderation.Moderator
 }
 
-func NewServer(config util.Config, esClient elasticsearch.ESClient, tokenMaker token.Maker, gapi google.GAPI) *Server {
+func NewServer(config util.Config, store db.Store, esClient elasticsearch.ESClient, tokenMaker token.Maker,
+	taskDistributor worker.TaskDistributor) *Server {
 	return &Server{
-		config:     config,
-		esClient:   esClient,
-		tokenMaker: tokenMaker,
-		gapi:       gapi,
+		config:          config,
+		store:           store,
+		esClient:        esClient,
+		tokenMaker:      tokenMaker,
+		taskDistributor: taskDistributor,
+		moderator:       moderation.NewDefaultModerator(config.BannedTerms),
 	}
 }
 
 func (server *Server) SetupRouter() {
 	router := gin.Default()
+	router.GET("/jobs/:job_id/jsonld", server.GetJobPosting)
+
 	authRoutes := router.Group("/").Use(middleware.AuthMiddleware(server.tokenMaker))
 	authRoutes.POST("/jobs/:employer_id", server.CreateJob)
+	authRoutes.POST("/jobs/:employer_id/import", server.ImportJobPostings)
 	authRoutes.GET("/jobs/:job_id", server.GetJob)
+	authRoutes.GET("/jobs/:job_id/stats", server.GetJobStats)
+	authRoutes.GET("/jobs/:job_id/shifts", server.ListJobShifts)
 	authRoutes.PATCH("/jobs/:job_id", server.UpdateJob)
 	authRoutes.DELETE("/jobs/:job_id", server.DeleteJob)
+	authRoutes.PATCH("/jobs/:job_id/publish", func(ctx *gin.Context) {
+		server.TransitionJob(ctx, elasticsearch.JobStatusPublished)
+	})
+	authRoutes.PATCH("/jobs/:job_id/pause", func(ctx *gin.Context) {
+		server.TransitionJob(ctx, elasticsearch.JobStatusPaused)
+	})
+	authRoutes.PATCH("/jobs/:job_id/fill", func(ctx *gin.Context) {
+		server.TransitionJob(ctx, elasticsearch.JobStatusFilled)
+	})
+	authRoutes.PATCH("/jobs/:job_id/close", func(ctx *gin.Context) {
+		server.TransitionJob(ctx, elasticsearch.JobStatusClosed)
+	})
+	authRoutes.POST("/job_shifts", server.CreateJobShift)
+	authRoutes.DELETE("/job_shifts/:shift_id", server.DeleteJobShift)
+	authRoutes.GET("/job_shifts/:shift_id/claims", server.ListShiftClaims)
+	authRoutes.GET("/employers/:employer_id/jobs", server.ListEmployerJobs)
+	authRoutes.GET("/employers/:employer_id/stats", server.GetEmployerJobStats)
+	authRoutes.POST("/employers/:employer_id/job_templates", server.CreateJobTemplate)
+	authRoutes.GET("/employers/:employer_id/job_templates", server.ListJobTemplates)
+	authRoutes.GET("/employers/:employer_id/job_templates/:template_id", server.GetJobTemplate)
+	authRoutes.PATCH("/employers/:employer_id/job_templates/:template_id", server.UpdateJobTemplate)
+	authRoutes.DELETE("/employers/:employer_id/job_templates/:template_id", server.DeleteJobTemplate)
+	authRoutes.POST("/employers/:employer_id/job_templates/:template_id/jobs", server.CreateJobFromTemplate)
 
 	server.router = router
 }
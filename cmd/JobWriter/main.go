@@ -1,8 +1,9 @@
 package main
 
 import (
-	"github.com/hankimmy/PtmrBackend/pkg/google"
 	"github.com/hankimmy/PtmrBackend/pkg/service"
+	"github.com/hankimmy/PtmrBackend/pkg/worker"
+	"github.com/hibiken/asynq"
 	"github.com/rs/zerolog/log"
 
 	"JobWriter/api"
@@ -14,8 +15,16 @@ func main() {
 		log.Fatal().Err(err).Msg("Failed to initialize service")
 	}
 	defer dependencies.StopFunc()
-	gapi := google.NewGoogleService()
-	server := api.NewServer(dependencies.Config, dependencies.ESClient, dependencies.TokenMaker, gapi)
+	redisOpt := asynq.RedisClientOpt{
+		Addr: dependencies.Config.RedisAddress,
+	}
+	taskDistributor := worker.NewRedisTaskDistributor(redisOpt)
+	taskScheduler := worker.NewRedisTaskScheduler(redisOpt)
+	if err := taskScheduler.Start(); err != nil {
+		log.Fatal().Err(err).Msg("failed to start task scheduler")
+	}
+	defer taskScheduler.Shutdown()
+	server := api.NewServer(dependencies.Config, dependencies.Store, dependencies.ESClient, dependencies.TokenMaker, taskDistributor)
 	server.SetupRouter()
 	err = server.Start(dependencies.Config.ServerAddress)
 	if err != nil {
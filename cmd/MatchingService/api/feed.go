@@ -9,6 +9,8 @@ import (
 	"github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
 	"github.com/hankimmy/PtmrBackend/pkg/middleware"
 	"github.com/hankimmy/PtmrBackend/pkg/token"
+	"github.com/hankimmy/PtmrBackend/pkg/util"
+	"github.com/hankimmy/PtmrBackend/pkg/worker"
 )
 
 type getCandidateBatchFeedRequest struct {
@@ -18,6 +20,9 @@ type getCandidateBatchFeedRequest struct {
 	Title          string `json:"title"`
 	Location       string `json:"location"`
 	Distance       string `json:"distance"`
+	// Availability is the candidate's weekly availability starting on Sunday.
+	// When set, only jobs with an open upcoming shift in it are returned.
+	Availability []util.Availability `json:"availability" binding:"max=7"`
 }
 
 func (server *Server) GetCandidateBatchFeed(ctx *gin.Context) {
@@ -49,7 +54,13 @@ func (server *Server) GetCandidateBatchFeed(ctx *gin.Context) {
 		Lon: lon,
 	}
 
-	jobs, err := server.esClient.SearchJobs(req.Industry, req.EmploymentType, req.Title, req.Distance, candidateLocation)
+	var jobs []elasticsearch.Job
+	if len(req.Availability) == 0 {
+		jobs, err = server.esClient.SearchJobs(req.Industry, req.EmploymentType, req.Title, req.Distance, candidateLocation)
+	} else if slots := elasticsearch.AvailabilitySlots(req.Availability); len(slots) > 0 {
+		jobs, err = server.esClient.SearchJobsWithShifts(ctx, req.Industry, req.EmploymentType, req.Title, req.Distance,
+			candidateLocation, slots)
+	}
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
@@ -58,6 +69,9 @@ func (server *Server) GetCandidateBatchFeed(ctx *gin.Context) {
 	if jobs == nil {
 		jobs = []elasticsearch.Job{}
 	}
+	if len(jobs) > 0 {
+		worker.RecordJobStat(ctx, server.taskDistributor, worker.JobStatImpression, jobs...)
+	}
 
 	ctx.JSON(http.StatusOK, jobs)
 }
@@ -18,6 +18,9 @@ import (
 	mockgapi "github.com/hankimmy/PtmrBackend/pkg/google/mock"
 	"github.com/hankimmy/PtmrBackend/pkg/middleware"
 	"github.com/hankimmy/PtmrBackend/pkg/token"
+	"github.com/hankimmy/PtmrBackend/pkg/util"
+	"github.com/hankimmy/PtmrBackend/pkg/worker"
+	mockwk "github.com/hankimmy/PtmrBackend/pkg/worker/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -31,6 +34,18 @@ func TestGetCandidateBatchFeed(t *testing.T) {
 		"location":        "13 E 37th St, New York, NY",
 		"distance":        "10mi",
 	}
+	withAvailability := func(availability []util.Availability) gin.H {
+		body := gin.H{"availability": availability}
+		for k, v := range candidateBody {
+			body[k] = v
+		}
+		return body
+	}
+	availability := make([]util.Availability, 7)
+	availability[time.Monday].Evening = true
+	availability[time.Saturday].Morning = true
+	availabilityBody := withAvailability(availability)
+	unavailableBody := withAvailability(make([]util.Availability, 7))
 	expectedJobs := []elasticsearch.Job{
 		elasticsearch.RandomJob(candidate.ID),
 		elasticsearch.RandomJob(candidate.ID),
@@ -41,7 +56,7 @@ func TestGetCandidateBatchFeed(t *testing.T) {
 		candidateID   int64
 		body          gin.H
 		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
-		buildStubs    func(esClient *mockes.MockESClient, gapi *mockgapi.MockGAPI)
+		buildStubs    func(esClient *mockes.MockESClient, gapi *mockgapi.MockGAPI, distributor *mockwk.MockTaskDistributor)
 		checkResponse func(recorder *httptest.ResponseRecorder)
 	}{
 		{
@@ -51,7 +66,7 @@ func TestGetCandidateBatchFeed(t *testing.T) {
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, candidate.Username, db.RoleCandidate, time.Minute, candidate.ID)
 			},
-			buildStubs: func(esClient *mockes.MockESClient, gapi *mockgapi.MockGAPI) {
+			buildStubs: func(esClient *mockes.MockESClient, gapi *mockgapi.MockGAPI, distributor *mockwk.MockTaskDistributor) {
 				gapi.EXPECT().
 					GetLatLon(gomock.Eq(candidateBody["location"].(string))).
 					Times(1).
@@ -67,12 +82,71 @@ func TestGetCandidateBatchFeed(t *testing.T) {
 						})).
 					Times(1).
 					Return(expectedJobs, nil)
+				distributor.EXPECT().
+					DistributeTaskIncrementJobStats(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, payload *worker.PayloadIncrementJobStats, _ ...interface{}) error {
+						require.Equal(t, worker.JobStatImpression, payload.Event)
+						require.Len(t, payload.Jobs, len(expectedJobs))
+						require.Equal(t, expectedJobs[1].ID, payload.Jobs[1].JobID)
+						return nil
+					})
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
 				requireBodyMatchJobs(t, recorder.Body, expectedJobs)
 			},
 		},
+		{
+			name:        "WithAvailability",
+			candidateID: candidate.ID,
+			body:        availabilityBody,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, candidate.Username, db.RoleCandidate, time.Minute, candidate.ID)
+			},
+			buildStubs: func(esClient *mockes.MockESClient, gapi *mockgapi.MockGAPI, distributor *mockwk.MockTaskDistributor) {
+				gapi.EXPECT().
+					GetLatLon(gomock.Eq(candidateBody["location"].(string))).
+					Times(1).
+					Return(40.7501259, -73.9820676, nil)
+				esClient.EXPECT().SearchJobs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				esClient.EXPECT().
+					SearchJobsWithShifts(gomock.Any(),
+						gomock.Eq(candidateBody["industry"]),
+						gomock.Eq(candidateBody["employment_type"]),
+						gomock.Eq(candidateBody["title"]),
+						gomock.Eq(candidateBody["distance"]),
+						gomock.Any(),
+						gomock.Eq([]string{"monday_evening", "saturday_morning"})).
+					Times(1).
+					Return(expectedJobs, nil)
+				distributor.EXPECT().DistributeTaskIncrementJobStats(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchJobs(t, recorder.Body, expectedJobs)
+			},
+		},
+		{
+			name:        "NoAvailableSlots",
+			candidateID: candidate.ID,
+			body:        unavailableBody,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, candidate.Username, db.RoleCandidate, time.Minute, candidate.ID)
+			},
+			buildStubs: func(esClient *mockes.MockESClient, gapi *mockgapi.MockGAPI, distributor *mockwk.MockTaskDistributor) {
+				gapi.EXPECT().GetLatLon(gomock.Any()).Times(1).Return(40.7501259, -73.9820676, nil)
+				esClient.EXPECT().SearchJobs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				esClient.EXPECT().
+					SearchJobsWithShifts(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+				distributor.EXPECT().DistributeTaskIncrementJobStats(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchJobs(t, recorder.Body, []elasticsearch.Job{})
+			},
+		},
 		{
 			name:        "UnauthorizedUserRole",
 			candidateID: candidate.ID,
@@ -80,7 +154,7 @@ func TestGetCandidateBatchFeed(t *testing.T) {
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleEmployer, time.Minute, candidate.ID)
 			},
-			buildStubs: func(esClient *mockes.MockESClient, gapi *mockgapi.MockGAPI) {
+			buildStubs: func(esClient *mockes.MockESClient, gapi *mockgapi.MockGAPI, distributor *mockwk.MockTaskDistributor) {
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, recorder.Code)
@@ -95,7 +169,7 @@ func TestGetCandidateBatchFeed(t *testing.T) {
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, candidate.Username, db.RoleCandidate, time.Minute, candidate.ID)
 			},
-			buildStubs: func(esClient *mockes.MockESClient, gapi *mockgapi.MockGAPI) {
+			buildStubs: func(esClient *mockes.MockESClient, gapi *mockgapi.MockGAPI, distributor *mockwk.MockTaskDistributor) {
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
@@ -108,7 +182,7 @@ func TestGetCandidateBatchFeed(t *testing.T) {
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, candidate.Username, db.RoleCandidate, time.Minute, candidate.ID)
 			},
-			buildStubs: func(esClient *mockes.MockESClient, gapi *mockgapi.MockGAPI) {
+			buildStubs: func(esClient *mockes.MockESClient, gapi *mockgapi.MockGAPI, distributor *mockwk.MockTaskDistributor) {
 				gapi.EXPECT().
 					GetLatLon(gomock.Eq(candidateBody["location"].(string))).
 					Times(1).
@@ -123,6 +197,7 @@ func TestGetCandidateBatchFeed(t *testing.T) {
 					).
 					Times(1).
 					Return(nil, errors.New("internal server error"))
+				distributor.EXPECT().DistributeTaskIncrementJobStats(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
@@ -138,9 +213,10 @@ func TestGetCandidateBatchFeed(t *testing.T) {
 			gCtrl := gomock.NewController(t)
 			defer gCtrl.Finish()
 			gClient := mockgapi.NewMockGAPI(gCtrl)
-			tc.buildStubs(esClient, gClient)
+			distributor := mockwk.NewMockTaskDistributor(gCtrl)
+			tc.buildStubs(esClient, gClient, distributor)
 
-			server := newTestServer(t, esClient, gClient)
+			server := newTestServer(t, esClient, gClient, distributor)
 			recorder := httptest.NewRecorder()
 			data, _ := json.Marshal(tc.body)
 			url := fmt.Sprintf("/feed/%d", tc.candidateID)
@@ -10,17 +10,18 @@ import (
 	"github.com/hankimmy/PtmrBackend/pkg/google"
 	"github.com/hankimmy/PtmrBackend/pkg/token"
 	"github.com/hankimmy/PtmrBackend/pkg/util"
+	"github.com/hankimmy/PtmrBackend/pkg/worker"
 	"github.com/stretchr/testify/require"
 )
 
-func newTestServer(t *testing.T, esClient elasticsearch.ESClient, gapi google.GAPI) *Server {
+func newTestServer(t *testing.T, esClient elasticsearch.ESClient, gapi google.GAPI, taskDistributor worker.TaskDistributor) *Server {
 	config := util.Config{
 		TokenSymmetricKey:   util.RandomString(32),
 		AccessTokenDuration: time.Minute,
 	}
 	tokenMaker, err := token.NewPasetoMaker(config.TokenSymmetricKey)
 	require.NoError(t, err)
-	server := NewServer(config, esClient, tokenMaker, gapi)
+	server := NewServer(config, esClient, tokenMaker, gapi, taskDistributor)
 	server.SetupRouter()
 	return server
 }
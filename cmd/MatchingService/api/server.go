@@ -7,22 +7,26 @@ import (
 	"github.com/hankimmy/PtmrBackend/pkg/middleware"
 	"github.com/hankimmy/PtmrBackend/pkg/token"
 	"github.com/hankimmy/PtmrBackend/pkg/util"
+	"github.com/hankimmy/PtmrBackend/pkg/worker"
 )
 
 type Server struct {
-	config     util.Config
-	esClient   elasticsearch.ESClient
-	router     *gin.Engine
-	tokenMaker token.Maker
-	gapi       google.GAPI
+	config          util.Config
+	esClient        elasticsearch.ESClient
+	router          *gin.Engine
+	tokenMaker      token.Maker
+	gapi            google.GAPI
+	taskDistributor worker.TaskDistributor
 }
 
-func NewServer(config util.Config, esClient elasticsearch.ESClient, tokenMaker token.Maker, gapi google.GAPI) *Server {
+func NewServer(config util.Config, esClient elasticsearch.ESClient, tokenMaker token.Maker, gapi google.GAPI,
+	taskDistributor worker.TaskDistributor) *Server {
 	return &Server{
-		config:     config,
-		esClient:   esClient,
-		tokenMaker: tokenMaker,
-		gapi:       gapi,
+		config:          config,
+		esClient:        esClient,
+		tokenMaker:      tokenMaker,
+		gapi:            gapi,
+		taskDistributor: taskDistributor,
 	}
 }
 
@@ -1,7 +1,10 @@
 package main
 
 import (
+	"github.com/hankimmy/PtmrBackend/pkg/google"
 	"github.com/hankimmy/PtmrBackend/pkg/service"
+	"github.com/hankimmy/PtmrBackend/pkg/worker"
+	"github.com/hibiken/asynq"
 	"github.com/rs/zerolog/log"
 
 	"MatchingService/api"
@@ -12,7 +15,12 @@ func main() {
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to initialize service")
 	}
-	server := api.NewServer(dependencies.Config, dependencies.ESClient, dependencies.TokenMaker, nil)
+	redisOpt := asynq.RedisClientOpt{
+		Addr: dependencies.Config.RedisAddress,
+	}
+	taskDistributor := worker.NewRedisTaskDistributor(redisOpt)
+	gapi := google.NewGoogleService()
+	server := api.NewServer(dependencies.Config, dependencies.ESClient, dependencies.TokenMaker, gapi, taskDistributor)
 	server.SetupRouter()
 	err = server.Start(dependencies.Config.ServerAddress)
 	if err != nil {
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -12,7 +13,11 @@ import (
 	es "github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
 	"github.com/hankimmy/PtmrBackend/pkg/firebase"
 	"github.com/hankimmy/PtmrBackend/pkg/middleware"
+	"github.com/hankimmy/PtmrBackend/pkg/moderation"
 	"github.com/hankimmy/PtmrBackend/pkg/token"
+	"github.com/hankimmy/PtmrBackend/pkg/worker"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
 )
 
 type createCandidateRequest struct {
@@ -67,14 +72,47 @@ func (server *Server) createCandidate(ctx *gin.Context) {
 		CreatedAt:          time.Time{},
 	}
 
+	fields := []moderation.Field{{Name: "description", Text: req.Description}}
+	review := server.moderator.Moderate(fields...)
+	if review.Decision == moderation.DecisionRejected {
+		ctx.JSON(http.StatusUnprocessableEntity, moderationRejectedResponse(review))
+		return
+	}
+	candidate.ModerationStatus = review.Decision
+
 	if err := server.esClient.IndexCandidateV2(ctx, candidate); err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
+	if err := server.syncModerationReview(ctx, moderation.ItemCandidate, uid, fields, review); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	server.geocodeLocation(ctx, req.Location)
 
 	ctx.JSON(http.StatusOK, statusResponse("candidate indexed successfully"))
 }
 
+// geocodeLocation queues the address to be geocoded so applicant ranking can
+// read its coordinates from the store. The profile is already saved, so a
+// failure is only logged and the candidate is ranked without a distance.
+func (server *Server) geocodeLocation(ctx *gin.Context, address string) {
+	address = strings.TrimSpace(address)
+	if address == "" {
+		return
+	}
+	payload := &worker.PayloadGeocodeLocation{Address: address}
+	opts := []asynq.Option{
+		asynq.MaxRetry(3),
+		asynq.Queue(worker.QueueDefault),
+		asynq.Unique(time.Hour),
+	}
+	err := server.taskDistributor.DistributeTaskGeocodeLocation(ctx, payload, opts...)
+	if err != nil && !errors.Is(err, asynq.ErrDuplicateTask) {
+		log.Error().Err(err).Str("address", address).Msg("failed to queue location geocoding")
+	}
+}
+
 type getCandidateRequest struct {
 	UID string `json:"uid" binding:"required,min=1"`
 }
@@ -172,10 +210,27 @@ func (server *Server) updateCandidate(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
+	fields := []moderation.Field{{Name: "description", Text: req.Description}}
+	var review moderation.Result
+	if req.Description != "" {
+		review = server.moderator.Moderate(fields...)
+		if review.Decision == moderation.DecisionRejected {
+			ctx.JSON(http.StatusUnprocessableEntity, moderationRejectedResponse(review))
+			return
+		}
+		updateFields["moderation_status"] = review.Decision
+	}
 	if err := server.esClient.UpdateCandidateV2(ctx, req.UserUID, updateFields); err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
+	if req.Description != "" {
+		if err := server.syncModerationReview(ctx, moderation.ItemCandidate, req.UserUID, fields, review); err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+	server.geocodeLocation(ctx, req.Location)
 	ctx.JSON(http.StatusOK, statusResponse("candidate updated successfully"))
 }
 
@@ -11,11 +11,15 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
+	mockdb "github.com/hankimmy/PtmrBackend/pkg/db/mock"
 	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
 	es "github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
 	mockes "github.com/hankimmy/PtmrBackend/pkg/elasticsearch/mock"
 	"github.com/hankimmy/PtmrBackend/pkg/firebase"
+	"github.com/hankimmy/PtmrBackend/pkg/moderation"
 	"github.com/hankimmy/PtmrBackend/pkg/util"
+	"github.com/hankimmy/PtmrBackend/pkg/worker"
+	mockwk "github.com/hankimmy/PtmrBackend/pkg/worker/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -47,7 +51,7 @@ func TestCreateCandidateAPI(t *testing.T) {
 		name          string
 		body          gin.H
 		setupAuth     func(t *testing.T, request *http.Request)
-		buildStubs    func(esClient *mockes.MockESClient)
+		buildStubs    func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor)
 		checkResponse func(recorder *httptest.ResponseRecorder)
 	}{
 		{
@@ -56,7 +60,7 @@ func TestCreateCandidateAPI(t *testing.T) {
 			setupAuth: func(t *testing.T, request *http.Request) {
 				firebase.AddAuthorization(t, request, firebase.AuthorizationTypeBearer, string(db.RoleCandidate))
 			},
-			buildStubs: func(esClient *mockes.MockESClient) {
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
 				arg := es.Candidate{
 					UserUid:            candidate.UserUid,
 					FullName:           candidate.FullName,
@@ -72,11 +76,23 @@ func TestCreateCandidateAPI(t *testing.T) {
 					ResumeFile:         candidate.ResumeFile,
 					ProfilePhoto:       candidate.ProfilePhoto,
 					Description:        candidate.Description,
+					ModerationStatus:   moderation.DecisionApproved,
 				}
 				esClient.EXPECT().
 					IndexCandidateV2(gomock.Any(), arg).
 					Times(1).
 					Return(nil)
+				store.EXPECT().
+					DeletePendingModerationReview(gomock.Any(), gomock.Eq(db.DeletePendingModerationReviewParams{
+						ItemType: moderation.ItemCandidate,
+						ItemID:   candidate.UserUid,
+					})).
+					Times(1).
+					Return(nil)
+				taskDistributor.EXPECT().
+					DistributeTaskGeocodeLocation(gomock.Any(), &worker.PayloadGeocodeLocation{Address: candidate.Location}, gomock.Any()).
+					Times(1).
+					Return(nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
@@ -87,10 +103,13 @@ func TestCreateCandidateAPI(t *testing.T) {
 			body: req,
 			setupAuth: func(t *testing.T, request *http.Request) {
 			},
-			buildStubs: func(esClient *mockes.MockESClient) {
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
 				esClient.EXPECT().
 					IndexCandidateV2(gomock.Any(), gomock.Any()).
 					Times(0)
+				taskDistributor.EXPECT().
+					DistributeTaskGeocodeLocation(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, recorder.Code)
@@ -102,11 +121,14 @@ func TestCreateCandidateAPI(t *testing.T) {
 			setupAuth: func(t *testing.T, request *http.Request) {
 				firebase.AddAuthorization(t, request, firebase.AuthorizationTypeBearer, string(db.RoleCandidate))
 			},
-			buildStubs: func(esClient *mockes.MockESClient) {
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
 				esClient.EXPECT().
 					IndexCandidateV2(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(errors.New("index failed"))
+				taskDistributor.EXPECT().
+					DistributeTaskGeocodeLocation(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
@@ -116,8 +138,12 @@ func TestCreateCandidateAPI(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			tc.buildStubs(esClient)
-			server := newTestServer(t, nil, nil, esClient, auth, nil)
+			taskCtrl := gomock.NewController(t)
+			defer taskCtrl.Finish()
+			taskDistributor := mockwk.NewMockTaskDistributor(taskCtrl)
+			store := mockdb.NewMockStore(taskCtrl)
+			tc.buildStubs(store, esClient, taskDistributor)
+			server := newTestServer(t, store, taskDistributor, esClient, auth, nil)
 			recorder := httptest.NewRecorder()
 
 			data := marshalRequestBody(t, tc.body)
@@ -146,11 +172,17 @@ func TestUpdateCandidateAPI(t *testing.T) {
 	var updateFields map[string]interface{}
 	data, _ := json.Marshal(req)
 	json.Unmarshal(data, &updateFields)
+
+	location := util.RandomString(20)
+	req.Location = location
+	var locationFields map[string]interface{}
+	data, _ = json.Marshal(req)
+	json.Unmarshal(data, &locationFields)
 	testCases := []struct {
 		name          string
 		body          gin.H
 		setupAuth     func(t *testing.T, request *http.Request)
-		buildStubs    func(esClient *mockes.MockESClient)
+		buildStubs    func(esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor)
 		checkResponse func(recorder *httptest.ResponseRecorder)
 	}{
 		{
@@ -162,11 +194,38 @@ func TestUpdateCandidateAPI(t *testing.T) {
 			setupAuth: func(t *testing.T, request *http.Request) {
 				firebase.AddAuthorization(t, request, firebase.AuthorizationTypeBearer, string(db.RoleCandidate))
 			},
-			buildStubs: func(esClient *mockes.MockESClient) {
+			buildStubs: func(esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
 				esClient.EXPECT().
 					UpdateCandidateV2(gomock.Any(), candidate.UserUid, updateFields).
 					Times(1).
 					Return(nil)
+				taskDistributor.EXPECT().
+					DistributeTaskGeocodeLocation(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "GeocodesNewLocation",
+			body: gin.H{
+				"uid":                  candidate.UserUid,
+				"industry_of_interest": industry,
+				"location":             location,
+			},
+			setupAuth: func(t *testing.T, request *http.Request) {
+				firebase.AddAuthorization(t, request, firebase.AuthorizationTypeBearer, string(db.RoleCandidate))
+			},
+			buildStubs: func(esClient *mockes.MockESClient, taskDistributor *mockwk.MockTaskDistributor) {
+				esClient.EXPECT().
+					UpdateCandidateV2(gomock.Any(), candidate.UserUid, locationFields).
+					Times(1).
+					Return(nil)
+				taskDistributor.EXPECT().
+					DistributeTaskGeocodeLocation(gomock.Any(), &worker.PayloadGeocodeLocation{Address: location}, gomock.Any()).
+					Times(1).
+					Return(nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
@@ -175,8 +234,11 @@ func TestUpdateCandidateAPI(t *testing.T) {
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			tc.buildStubs(esClient)
-			server := newTestServer(t, nil, nil, esClient, auth, nil)
+			taskCtrl := gomock.NewController(t)
+			defer taskCtrl.Finish()
+			taskDistributor := mockwk.NewMockTaskDistributor(taskCtrl)
+			tc.buildStubs(esClient, taskDistributor)
+			server := newTestServer(t, nil, taskDistributor, esClient, auth, nil)
 			recorder := httptest.NewRecorder()
 
 			data := marshalRequestBody(t, tc.body)
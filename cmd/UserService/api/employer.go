@@ -3,12 +3,15 @@ package api
 import (
 	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
 	"github.com/hankimmy/PtmrBackend/pkg/middleware"
+	"github.com/hankimmy/PtmrBackend/pkg/moderation"
 	"github.com/hankimmy/PtmrBackend/pkg/token"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
 )
 
 type createEmployerRequest struct {
@@ -41,11 +44,25 @@ func (server *Server) createEmployer(ctx *gin.Context) {
 		BusinessDescription: req.BusinessDescription,
 	}
 
+	fields := []moderation.Field{{Name: "business_description", Text: req.BusinessDescription}}
+	review := server.moderator.Moderate(fields...)
+	if review.Decision == moderation.DecisionRejected {
+		ctx.JSON(http.StatusUnprocessableEntity, moderationRejectedResponse(review))
+		return
+	}
+
 	employer, err := server.store.CreateEmployer(ctx, arg)
 	if err != nil {
 		server.handleDatabaseError(ctx, err)
 		return
 	}
+	// The review is keyed by the employer ID, so it can only be queued once
+	// the employer exists. The employer is kept if queueing fails.
+	if review.Decision == moderation.DecisionNeedsReview {
+		if err := server.queueModerationReview(ctx, moderation.ItemEmployer, strconv.FormatInt(employer.ID, 10), fields, review); err != nil {
+			log.Error().Err(err).Int64("employer_id", employer.ID).Msg("failed to queue employer for moderation review")
+		}
+	}
 
 	ctx.JSON(http.StatusOK, employer)
 }
@@ -135,11 +152,27 @@ func (server *Server) updateEmployer(ctx *gin.Context) {
 		},
 	}
 
+	fields := []moderation.Field{{Name: "business_description", Text: req.BusinessDescription}}
+	var review moderation.Result
+	if req.BusinessDescription != "" {
+		review = server.moderator.Moderate(fields...)
+		if review.Decision == moderation.DecisionRejected {
+			ctx.JSON(http.StatusUnprocessableEntity, moderationRejectedResponse(review))
+			return
+		}
+	}
+
 	employer, err := server.store.UpdateEmployer(ctx, arg)
 	if err != nil {
 		server.handleDatabaseError(ctx, err)
 		return
 	}
+	if req.BusinessDescription != "" {
+		if err := server.syncModerationReview(ctx, moderation.ItemEmployer, strconv.FormatInt(arg.ID, 10), fields, review); err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
 
 	ctx.JSON(http.StatusOK, employer)
 }
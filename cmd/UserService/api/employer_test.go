@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	mockdb "github.com/hankimmy/PtmrBackend/pkg/db/mock"
 	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
 	"github.com/hankimmy/PtmrBackend/pkg/middleware"
+	"github.com/hankimmy/PtmrBackend/pkg/moderation"
 	"github.com/hankimmy/PtmrBackend/pkg/token"
 	"github.com/hankimmy/PtmrBackend/pkg/util"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -504,6 +506,13 @@ func TestUpdateEmployersAPI(t *testing.T) {
 					UpdateEmployer(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
 					Return(employer, nil)
+				store.EXPECT().
+					DeletePendingModerationReview(gomock.Any(), gomock.Eq(db.DeletePendingModerationReviewParams{
+						ItemType: moderation.ItemEmployer,
+						ItemID:   strconv.FormatInt(employer.ID, 10),
+					})).
+					Times(1).
+					Return(nil)
 
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
@@ -0,0 +1,209 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	"github.com/hankimmy/PtmrBackend/pkg/middleware"
+	"github.com/hankimmy/PtmrBackend/pkg/moderation"
+	"github.com/hankimmy/PtmrBackend/pkg/token"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// queueModerationReview puts the item in the review queue. A pending review
+// for the same item is replaced with the new content.
+func (server *Server) queueModerationReview(ctx context.Context, itemType, itemID string, fields []moderation.Field, result moderation.Result) error {
+	content, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	findings, err := json.Marshal(result.Findings)
+	if err != nil {
+		return err
+	}
+	_, err = server.store.CreateModerationReview(ctx, db.CreateModerationReviewParams{
+		ItemType: itemType,
+		ItemID:   itemID,
+		Content:  content,
+		Findings: findings,
+	})
+	return err
+}
+
+// syncModerationReview brings the review queue in line with a saved item.
+// Items that need review are queued; any other decision closes a pending
+// review, so a reviewer can't act on content the item no longer has.
+func (server *Server) syncModerationReview(ctx context.Context, itemType, itemID string, fields []moderation.Field, result moderation.Result) error {
+	if result.Decision == moderation.DecisionNeedsReview {
+		return server.queueModerationReview(ctx, itemType, itemID, fields, result)
+	}
+	return server.store.DeletePendingModerationReview(ctx, db.DeletePendingModerationReviewParams{
+		ItemType: itemType,
+		ItemID:   itemID,
+	})
+}
+
+func moderationRejectedResponse(result moderation.Result) gin.H {
+	return gin.H{"error": "content was rejected by moderation", "findings": result.Findings}
+}
+
+type moderationReviewResponse struct {
+	ID         int64                 `json:"id"`
+	ItemType   string                `json:"item_type"`
+	ItemID     string                `json:"item_id"`
+	Content    json.RawMessage       `json:"content"`
+	Findings   json.RawMessage       `json:"findings"`
+	Decision   db.ModerationDecision `json:"decision"`
+	ReviewedBy string                `json:"reviewed_by,omitempty"`
+	Note       string                `json:"note,omitempty"`
+	CreatedAt  time.Time             `json:"created_at"`
+	ReviewedAt *time.Time            `json:"reviewed_at,omitempty"`
+}
+
+func newModerationReviewResponse(review db.ModerationReview) moderationReviewResponse {
+	res := moderationReviewResponse{
+		ID:         review.ID,
+		ItemType:   review.ItemType,
+		ItemID:     review.ItemID,
+		Content:    review.Content,
+		Findings:   review.Findings,
+		Decision:   review.Decision,
+		ReviewedBy: review.ReviewedBy.String,
+		Note:       review.Note,
+		CreatedAt:  review.CreatedAt,
+	}
+	if review.ReviewedAt.Valid {
+		res.ReviewedAt = &review.ReviewedAt.Time
+	}
+	return res
+}
+
+// authorizeAdmin reports whether the request comes from an employee. Other
+// accounts get a 401 response.
+func authorizeAdmin(ctx *gin.Context) (*token.Payload, bool) {
+	authPayload := ctx.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload)
+	if authPayload.Role != db.RoleAdmin {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("account is not an admin")))
+		return nil, false
+	}
+	return authPayload, true
+}
+
+type listModerationReviewsRequest struct {
+	ItemType string `form:"item_type" binding:"omitempty,oneof=job candidate employer"`
+	PageID   int32  `form:"page_id" binding:"required,min=1"`
+	PageSize int32  `form:"page_size" binding:"required,min=5,max=50"`
+}
+
+// listModerationReviews returns the items waiting on a reviewer, oldest
+// first.
+func (server *Server) listModerationReviews(ctx *gin.Context) {
+	var req listModerationReviewsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if _, ok := authorizeAdmin(ctx); !ok {
+		return
+	}
+
+	reviews, err := server.store.ListPendingModerationReviews(ctx, db.ListPendingModerationReviewsParams{
+		ItemType: pgtype.Text{String: req.ItemType, Valid: req.ItemType != ""},
+		Limit:    req.PageSize,
+		Offset:   (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := make([]moderationReviewResponse, 0, len(reviews))
+	for _, review := range reviews {
+		res = append(res, newModerationReviewResponse(review))
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+type resolveModerationReviewRequest struct {
+	ID   int64  `uri:"id" binding:"required,min=1"`
+	Note string `json:"note" binding:"max=1000"`
+}
+
+// resolveModerationReview applies a reviewer's decision to the reviewed item
+// and closes the review. Approved jobs and candidates become visible again;
+// rejected ones stay hidden, and a rejected business description is removed.
+func (server *Server) resolveModerationReview(ctx *gin.Context, decision db.ModerationDecision) {
+	var req resolveModerationReviewRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	authPayload, ok := authorizeAdmin(ctx)
+	if !ok {
+		return
+	}
+
+	review, err := server.store.GetModerationReview(ctx, req.ID)
+	if err != nil {
+		server.handleDatabaseError(ctx, err)
+		return
+	}
+	if review.Decision != db.ModerationDecisionNeedsReview {
+		ctx.JSON(http.StatusConflict, errorResponse(fmt.Errorf("review %d was already %s", review.ID, review.Decision)))
+		return
+	}
+
+	if err := server.applyModerationDecision(ctx, review, decision); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	review, err = server.store.ResolveModerationReview(ctx, db.ResolveModerationReviewParams{
+		ID:         review.ID,
+		Decision:   decision,
+		ReviewedBy: pgtype.Text{String: authPayload.Username, Valid: true},
+		Note:       req.Note,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			ctx.JSON(http.StatusConflict, errorResponse(fmt.Errorf("review %d was already resolved", req.ID)))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, newModerationReviewResponse(review))
+}
+
+func (server *Server) applyModerationDecision(ctx context.Context, review db.ModerationReview, decision db.ModerationDecision) error {
+	status := moderation.Decision(decision)
+	switch review.ItemType {
+	case moderation.ItemJob:
+		return server.esClient.UpdateJobModerationStatus(ctx, review.ItemID, status)
+	case moderation.ItemCandidate:
+		return server.esClient.UpdateCandidateV2(ctx, review.ItemID, map[string]interface{}{"moderation_status": status})
+	case moderation.ItemEmployer:
+		if decision != db.ModerationDecisionRejected {
+			return nil
+		}
+		employerID, err := strconv.ParseInt(review.ItemID, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid employer id %q: %w", review.ItemID, err)
+		}
+		return server.store.ClearEmployerDescription(ctx, employerID)
+	default:
+		return fmt.Errorf("unknown moderation item type %q", review.ItemType)
+	}
+}
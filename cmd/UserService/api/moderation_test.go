@@ -0,0 +1,501 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/hankimmy/PtmrBackend/pkg/db/mock"
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	es "github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
+	mockes "github.com/hankimmy/PtmrBackend/pkg/elasticsearch/mock"
+	"github.com/hankimmy/PtmrBackend/pkg/firebase"
+	"github.com/hankimmy/PtmrBackend/pkg/middleware"
+	"github.com/hankimmy/PtmrBackend/pkg/moderation"
+	"github.com/hankimmy/PtmrBackend/pkg/token"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateCandidateModeration(t *testing.T) {
+	candidate := randomCandidate()
+	auth := mockCandidateMiddleware(t, candidate.UserUid)
+	body := func(description string) gin.H {
+		return gin.H{
+			"full_name":    candidate.FullName,
+			"email":        candidate.Email,
+			"phone_number": candidate.PhoneNumber,
+			"description":  description,
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore, esClient *mockes.MockESClient)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Rejected",
+			body: body("Looking for a sugar daddy"),
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				store.EXPECT().CreateModerationReview(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().DeletePendingModerationReview(gomock.Any(), gomock.Any()).Times(0)
+				esClient.EXPECT().IndexCandidateV2(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "banned_terms")
+			},
+		},
+		{
+			name: "NeedsReview",
+			body: body("Reach me at me@example.com"),
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				store.EXPECT().
+					CreateModerationReview(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateModerationReviewParams) (db.ModerationReview, error) {
+						require.Equal(t, moderation.ItemCandidate, arg.ItemType)
+						require.Equal(t, candidate.UserUid, arg.ItemID)
+						return db.ModerationReview{ID: 1}, nil
+					})
+				esClient.EXPECT().
+					IndexCandidateV2(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, indexed es.Candidate) error {
+						require.Equal(t, moderation.DecisionNeedsReview, indexed.ModerationStatus)
+						return nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "CleanDescriptionClosesReview",
+			body: body("Friendly barista with three years of experience."),
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				store.EXPECT().CreateModerationReview(gomock.Any(), gomock.Any()).Times(0)
+				indexCandidate := esClient.EXPECT().IndexCandidateV2(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				store.EXPECT().
+					DeletePendingModerationReview(gomock.Any(), gomock.Eq(db.DeletePendingModerationReviewParams{
+						ItemType: moderation.ItemCandidate,
+						ItemID:   candidate.UserUid,
+					})).
+					Times(1).
+					After(indexCandidate).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "ReviewQueueError",
+			body: body("Reach me at me@example.com"),
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				esClient.EXPECT().IndexCandidateV2(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				store.EXPECT().
+					CreateModerationReview(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ModerationReview{}, errors.New("db down"))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+			esClient := mockes.NewMockESClient(ctrl)
+			tc.buildStubs(store, esClient)
+
+			server := newTestServer(t, store, nil, esClient, auth, nil)
+			recorder := httptest.NewRecorder()
+			request := createNewRequest(t, http.MethodPut, "/candidates/", marshalRequestBody(t, tc.body))
+			firebase.AddAuthorization(t, request, firebase.AuthorizationTypeBearer, string(db.RoleCandidate))
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestCreateEmployerModeration(t *testing.T) {
+	user, _ := randomUser(t)
+	employer := randomEmployer(user.Username)
+	body := func(description string) gin.H {
+		return gin.H{
+			"business_name":        employer.BusinessName,
+			"business_email":       employer.BusinessEmail,
+			"business_phone":       employer.BusinessPhone,
+			"business_description": description,
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Rejected",
+			body: body("All new hires pay a training fee."),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateEmployer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name: "NeedsReview",
+			body: body("FAMILY OWNED DINER SINCE 1982, BEST PANCAKES IN TOWN"),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateEmployer(gomock.Any(), gomock.Any()).Times(1).Return(employer, nil)
+				store.EXPECT().
+					CreateModerationReview(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateModerationReviewParams) (db.ModerationReview, error) {
+						require.Equal(t, moderation.ItemEmployer, arg.ItemType)
+						require.Equal(t, strconv.FormatInt(employer.ID, 10), arg.ItemID)
+						require.Contains(t, string(arg.Findings), "excessive_caps")
+						return db.ModerationReview{ID: 1}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "ReviewQueueErrorKeepsEmployer",
+			body: body("FAMILY OWNED DINER SINCE 1982, BEST PANCAKES IN TOWN"),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateEmployer(gomock.Any(), gomock.Any()).Times(1).Return(employer, nil)
+				store.EXPECT().
+					CreateModerationReview(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ModerationReview{}, errors.New("db down"))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil, nil, nil, nil)
+			recorder := httptest.NewRecorder()
+			request := createNewRequest(t, http.MethodPost, "/employers", marshalRequestBody(t, tc.body))
+			middleware.AddAuthorization(t, request, server.tokenMaker, middleware.AuthorizationTypeBearer, user.Username, db.RoleEmployer, time.Minute, 0)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func randomModerationReview(itemType, itemID string) db.ModerationReview {
+	return db.ModerationReview{
+		ID:        1,
+		ItemType:  itemType,
+		ItemID:    itemID,
+		Content:   []byte(`[{"name":"description","text":"call 212-555-0134"}]`),
+		Findings:  []byte(`[{"rule":"contact_info","field":"description","decision":"needs_review","reason":"phone number"}]`),
+		Decision:  db.ModerationDecisionNeedsReview,
+		CreatedAt: time.Now(),
+	}
+}
+
+func TestListModerationReviewsAPI(t *testing.T) {
+	reviews := []db.ModerationReview{
+		randomModerationReview(moderation.ItemJob, "1_Server"),
+		randomModerationReview(moderation.ItemJob, "2_Host"),
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		role          db.Role
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "?item_type=job&page_id=2&page_size=5",
+			role:  db.RoleAdmin,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListPendingModerationReviewsParams{
+					ItemType: pgtype.Text{String: moderation.ItemJob, Valid: true},
+					Limit:    5,
+					Offset:   5,
+				}
+				store.EXPECT().ListPendingModerationReviews(gomock.Any(), gomock.Eq(arg)).Times(1).Return(reviews, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var res []moderationReviewResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Len(t, res, 2)
+				require.Equal(t, "1_Server", res[0].ItemID)
+				require.JSONEq(t, string(reviews[0].Findings), string(res[0].Findings))
+			},
+		},
+		{
+			name:  "AllItemTypes",
+			query: "?page_id=1&page_size=5",
+			role:  db.RoleAdmin,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListPendingModerationReviewsParams{Limit: 5}
+				store.EXPECT().ListPendingModerationReviews(gomock.Any(), gomock.Eq(arg)).Times(1).Return([]db.ModerationReview{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.JSONEq(t, "[]", recorder.Body.String())
+			},
+		},
+		{
+			name:  "NotAdmin",
+			query: "?page_id=1&page_size=5",
+			role:  db.RoleEmployer,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListPendingModerationReviews(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidItemType",
+			query: "?item_type=shift&page_id=1&page_size=5",
+			role:  db.RoleAdmin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListPendingModerationReviews(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil, nil, nil, nil)
+			recorder := httptest.NewRecorder()
+			request := createNewRequest(t, http.MethodGet, "/moderation/reviews"+tc.query, nil)
+			middleware.AddAuthorization(t, request, server.tokenMaker, middleware.AuthorizationTypeBearer, "reviewer", tc.role, time.Minute, 0)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestResolveModerationReviewAPI(t *testing.T) {
+	jobReview := randomModerationReview(moderation.ItemJob, "1_Server")
+	candidateReview := randomModerationReview(moderation.ItemCandidate, "uid-1")
+	employerReview := randomModerationReview(moderation.ItemEmployer, "42")
+	resolved := func(review db.ModerationReview, decision db.ModerationDecision) db.ModerationReview {
+		review.Decision = decision
+		review.ReviewedBy = pgtype.Text{String: "reviewer", Valid: true}
+		review.ReviewedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+		return review
+	}
+	adminAuth := func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+		middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, "reviewer", db.RoleAdmin, time.Minute, 0)
+	}
+
+	testCases := []struct {
+		name          string
+		action        string
+		reviewID      int64
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore, esClient *mockes.MockESClient)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "ApproveJob",
+			action:    "approve",
+			reviewID:  jobReview.ID,
+			setupAuth: adminAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				store.EXPECT().GetModerationReview(gomock.Any(), gomock.Eq(jobReview.ID)).Times(1).Return(jobReview, nil)
+				esClient.EXPECT().
+					UpdateJobModerationStatus(gomock.Any(), gomock.Eq(jobReview.ItemID), gomock.Eq(moderation.DecisionApproved)).
+					Times(1).
+					Return(nil)
+				arg := db.ResolveModerationReviewParams{
+					ID:         jobReview.ID,
+					Decision:   db.ModerationDecisionApproved,
+					ReviewedBy: pgtype.Text{String: "reviewer", Valid: true},
+					Note:       "looks fine",
+				}
+				store.EXPECT().
+					ResolveModerationReview(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(resolved(jobReview, db.ModerationDecisionApproved), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var res moderationReviewResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Equal(t, db.ModerationDecisionApproved, res.Decision)
+				require.Equal(t, "reviewer", res.ReviewedBy)
+				require.NotNil(t, res.ReviewedAt)
+			},
+		},
+		{
+			name:      "RejectCandidate",
+			action:    "reject",
+			reviewID:  candidateReview.ID,
+			setupAuth: adminAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				store.EXPECT().GetModerationReview(gomock.Any(), gomock.Any()).Times(1).Return(candidateReview, nil)
+				esClient.EXPECT().
+					UpdateCandidateV2(gomock.Any(), gomock.Eq(candidateReview.ItemID), gomock.Eq(map[string]interface{}{"moderation_status": moderation.DecisionRejected})).
+					Times(1).
+					Return(nil)
+				store.EXPECT().
+					ResolveModerationReview(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(resolved(candidateReview, db.ModerationDecisionRejected), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:      "RejectEmployerClearsDescription",
+			action:    "reject",
+			reviewID:  employerReview.ID,
+			setupAuth: adminAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				store.EXPECT().GetModerationReview(gomock.Any(), gomock.Any()).Times(1).Return(employerReview, nil)
+				store.EXPECT().ClearEmployerDescription(gomock.Any(), gomock.Eq(int64(42))).Times(1).Return(nil)
+				store.EXPECT().
+					ResolveModerationReview(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(resolved(employerReview, db.ModerationDecisionRejected), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:      "ApproveEmployer",
+			action:    "approve",
+			reviewID:  employerReview.ID,
+			setupAuth: adminAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				store.EXPECT().GetModerationReview(gomock.Any(), gomock.Any()).Times(1).Return(employerReview, nil)
+				store.EXPECT().ClearEmployerDescription(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().
+					ResolveModerationReview(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(resolved(employerReview, db.ModerationDecisionApproved), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "NotAdmin",
+			action:   "approve",
+			reviewID: jobReview.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				middleware.AddAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, "employer", db.RoleEmployer, time.Minute, 1)
+			},
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				store.EXPECT().GetModerationReview(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:      "NotFound",
+			action:    "approve",
+			reviewID:  99,
+			setupAuth: adminAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				store.EXPECT().GetModerationReview(gomock.Any(), gomock.Eq(int64(99))).Times(1).Return(db.ModerationReview{}, db.ErrRecordNotFound)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:      "AlreadyResolved",
+			action:    "reject",
+			reviewID:  jobReview.ID,
+			setupAuth: adminAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				store.EXPECT().
+					GetModerationReview(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(resolved(jobReview, db.ModerationDecisionApproved), nil)
+				esClient.EXPECT().UpdateJobModerationStatus(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ResolveModerationReview(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:      "UpdateItemError",
+			action:    "approve",
+			reviewID:  jobReview.ID,
+			setupAuth: adminAuth,
+			buildStubs: func(store *mockdb.MockStore, esClient *mockes.MockESClient) {
+				store.EXPECT().GetModerationReview(gomock.Any(), gomock.Any()).Times(1).Return(jobReview, nil)
+				esClient.EXPECT().
+					UpdateJobModerationStatus(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(errors.New(es.ErrUpdateFailure))
+				store.EXPECT().ResolveModerationReview(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+			esClient := mockes.NewMockESClient(ctrl)
+			tc.buildStubs(store, esClient)
+
+			server := newTestServer(t, store, nil, esClient, nil, nil)
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/moderation/reviews/%d/%s", tc.reviewID, tc.action)
+			request := createNewRequest(t, http.MethodPatch, url, marshalRequestBody(t, gin.H{"note": "looks fine"}))
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	"github.com/hankimmy/PtmrBackend/pkg/middleware"
+	"github.com/hankimmy/PtmrBackend/pkg/token"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// getNotificationPreferences returns which emails the authenticated user
+// gets. Users who never changed them get every notification.
+func (server *Server) getNotificationPreferences(ctx *gin.Context) {
+	authPayload := ctx.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload)
+	preference, err := server.notificationPreference(ctx, authPayload.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, preference)
+}
+
+type updateNotificationPreferencesRequest struct {
+	ApplicationReceived  *bool `json:"application_received"`
+	ApplicationDecisions *bool `json:"application_decisions"`
+}
+
+// updateNotificationPreferences turns notifications on or off for the
+// authenticated user. Preferences left out of the request keep their value.
+func (server *Server) updateNotificationPreferences(ctx *gin.Context) {
+	var req updateNotificationPreferencesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.ApplicationReceived == nil && req.ApplicationDecisions == nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("no notification preferences to update")))
+		return
+	}
+
+	// Only the preferences in the request are written, so concurrent updates
+	// to different preferences don't overwrite each other.
+	authPayload := ctx.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload)
+	arg := db.UpsertNotificationPreferenceParams{
+		Username:             authPayload.Username,
+		ApplicationReceived:  optionalBool(req.ApplicationReceived),
+		ApplicationDecisions: optionalBool(req.ApplicationDecisions),
+	}
+	preference, err := server.store.UpsertNotificationPreference(ctx, arg)
+	if err != nil {
+		if db.ErrorCode(err) == db.ForeignKeyViolation {
+			ctx.JSON(http.StatusNotFound, errorResponse(errors.New("user not found")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, preference)
+}
+
+func optionalBool(value *bool) pgtype.Bool {
+	if value == nil {
+		return pgtype.Bool{}
+	}
+	return pgtype.Bool{Bool: *value, Valid: true}
+}
+
+func (server *Server) notificationPreference(ctx *gin.Context, username string) (db.NotificationPreference, error) {
+	preference, err := server.store.GetNotificationPreference(ctx, username)
+	if errors.Is(err, db.ErrRecordNotFound) {
+		return db.DefaultNotificationPreference(username), nil
+	}
+	return preference, err
+}
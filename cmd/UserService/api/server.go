@@ -9,6 +9,7 @@ import (
 	"github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
 	"github.com/hankimmy/PtmrBackend/pkg/firebase"
 	"github.com/hankimmy/PtmrBackend/pkg/middleware"
+	"github.com/hankimmy/PtmrBackend/pkg/moderation"
 	"github.com/hankimmy/PtmrBackend/pkg/token"
 	"github.com/hankimmy/PtmrBackend/pkg/util"
 	"github.com/hankimmy/PtmrBackend/pkg/worker"
@@ -23,6 +24,7 @@ type Server struct {
 	taskDistributor worker.TaskDistributor
 	auth            firebase.AuthClientFirebase
 	rateLimiter     *RateLimiter
+	moderator       moderation.Moderator
 }
 
 func NewServer(config util.Config, store db.Store, esClient elasticsearch.ESClient, taskDistributor worker.TaskDistributor, tokenMaker token.Maker, auth firebase.AuthClientFirebase, rateLimiter *RateLimiter) *Server {
@@ -34,6 +36,7 @@ func NewServer(config util.Config, store db.Store, esClient elasticsearch.ESClie
 		taskDistributor: taskDistributor,
 		auth:            auth,
 		rateLimiter:     rateLimiter,
+		moderator:       moderation.NewDefaultModerator(config.BannedTerms),
 	}
 }
 
@@ -68,6 +71,15 @@ func (server *Server) SetupRouter() {
 	authRoutes.GET("/employers/:id", server.getEmployer)
 	authRoutes.GET("/employers", server.listEmployer)
 	authRoutes.PATCH("/employers/:id", server.updateEmployer)
+	authRoutes.GET("/notification_preferences", server.getNotificationPreferences)
+	authRoutes.PATCH("/notification_preferences", server.updateNotificationPreferences)
+	authRoutes.GET("/moderation/reviews", server.listModerationReviews)
+	authRoutes.PATCH("/moderation/reviews/:id/approve", func(ctx *gin.Context) {
+		server.resolveModerationReview(ctx, db.ModerationDecisionApproved)
+	})
+	authRoutes.PATCH("/moderation/reviews/:id/reject", func(ctx *gin.Context) {
+		server.resolveModerationReview(ctx, db.ModerationDecisionRejected)
+	})
 
 	server.router = router
 }
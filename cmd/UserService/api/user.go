@@ -59,7 +59,7 @@ func (server *Server) sendEmailVerification(ctx *gin.Context) {
 	opts := []asynq.Option{
 		asynq.MaxRetry(10),
 		asynq.ProcessIn(10 * time.Second),
-		asynq.Queue(worker.QueueCritical),
+		asynq.Queue(worker.QueueMail),
 	}
 
 	if err := server.taskDistributor.DistributeTaskSendVerifyEmail(ctx, taskPayload, opts...); err != nil {
@@ -143,7 +143,7 @@ func (server *Server) resendEmailVerification(ctx *gin.Context) {
 	opts := []asynq.Option{
 		asynq.MaxRetry(10),
 		asynq.ProcessIn(10 * time.Second),
-		asynq.Queue(worker.QueueCritical),
+		asynq.Queue(worker.QueueMail),
 	}
 
 	// Distribute the email verification task
@@ -8,6 +8,7 @@ import (
 	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
 	"github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
 	"github.com/hankimmy/PtmrBackend/pkg/firebase"
+	"github.com/hankimmy/PtmrBackend/pkg/google"
 	"github.com/hankimmy/PtmrBackend/pkg/mail"
 	"github.com/hankimmy/PtmrBackend/pkg/service"
 	"github.com/hankimmy/PtmrBackend/pkg/util"
@@ -63,7 +64,7 @@ func runTaskProcessor(
 	esClient elasticsearch.ESClient,
 ) {
 	mailer := mail.NewGmailSender(config.EmailSenderName, config.EmailSenderAddress, config.EmailSenderPassword)
-	taskProcessor := worker.NewRedisTaskProcessor(redisOpt, store, esClient, mailer)
+	taskProcessor := worker.NewRedisTaskProcessor(redisOpt, store, esClient, mailer, google.NewGoogleService())
 
 	log.Info().Msg("start task processor")
 	err := taskProcessor.Start()
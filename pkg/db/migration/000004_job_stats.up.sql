@@ -0,0 +1,14 @@
+CREATE TABLE "job_daily_stats" (
+                                   "job_id" varchar NOT NULL,
+                                   "employer_id" bigint NOT NULL,
+                                   "day" date NOT NULL,
+                                   "views" bigint NOT NULL DEFAULT 0,
+                                   "impressions" bigint NOT NULL DEFAULT 0,
+                                   "right_swipes" bigint NOT NULL DEFAULT 0,
+                                   "left_swipes" bigint NOT NULL DEFAULT 0,
+                                   "applications" bigint NOT NULL DEFAULT 0,
+                                   PRIMARY KEY ("job_id", "day"),
+                                   FOREIGN KEY ("employer_id") REFERENCES "employers" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX ON "job_daily_stats" ("employer_id", "day");
@@ -0,0 +1,17 @@
+CREATE TABLE "job_templates" (
+                                 "id" bigserial PRIMARY KEY,
+                                 "employer_id" bigint NOT NULL,
+                                 "name" varchar NOT NULL,
+                                 "title" varchar NOT NULL,
+                                 "description" varchar NOT NULL DEFAULT '',
+                                 "industry" varchar NOT NULL DEFAULT '',
+                                 "employment_type" varchar NOT NULL DEFAULT '',
+                                 "wage" real NOT NULL DEFAULT 0,
+                                 "tips" real NOT NULL DEFAULT 0,
+                                 "job_application" jsonb NOT NULL DEFAULT '[]',
+                                 "created_at" timestamptz NOT NULL DEFAULT (now()),
+                                 "updated_at" timestamptz NOT NULL DEFAULT (now()),
+                                 FOREIGN KEY ("employer_id") REFERENCES "employers" ("id") ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX ON "job_templates" ("employer_id", "name");
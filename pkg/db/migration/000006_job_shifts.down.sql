@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "shift_claims";
+DROP TABLE IF EXISTS "job_shifts";
@@ -0,0 +1,24 @@
+CREATE TABLE "job_shifts" (
+                              "id" bigserial PRIMARY KEY,
+                              "job_id" varchar NOT NULL,
+                              "employer_id" bigint NOT NULL,
+                              "starts_at" timestamptz NOT NULL,
+                              "ends_at" timestamptz NOT NULL,
+                              "timezone" varchar NOT NULL,
+                              "headcount" integer NOT NULL,
+                              "created_at" timestamptz NOT NULL DEFAULT (now()),
+                              FOREIGN KEY ("employer_id") REFERENCES "employers" ("id") ON DELETE CASCADE,
+                              CHECK ("ends_at" > "starts_at"),
+                              CHECK ("headcount" > 0)
+);
+
+CREATE INDEX ON "job_shifts" ("job_id", "starts_at");
+
+CREATE TABLE "shift_claims" (
+                                "shift_id" bigint NOT NULL,
+                                "candidate_id" bigint NOT NULL,
+                                "created_at" timestamptz NOT NULL DEFAULT (now()),
+                                PRIMARY KEY ("shift_id", "candidate_id"),
+                                FOREIGN KEY ("shift_id") REFERENCES "job_shifts" ("id") ON DELETE CASCADE,
+                                FOREIGN KEY ("candidate_id") REFERENCES "candidates" ("id") ON DELETE CASCADE
+);
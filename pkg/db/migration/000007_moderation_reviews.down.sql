@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "moderation_reviews";
+DROP TYPE IF EXISTS moderation_decision;
+-- Postgres can't drop a value from an enum, so the admin role is left in place.
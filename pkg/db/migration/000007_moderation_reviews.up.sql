@@ -0,0 +1,19 @@
+ALTER TYPE role ADD VALUE IF NOT EXISTS 'admin';
+
+CREATE TYPE moderation_decision AS ENUM ('approved', 'needs_review', 'rejected');
+
+CREATE TABLE "moderation_reviews" (
+                                      "id" bigserial PRIMARY KEY,
+                                      "item_type" varchar NOT NULL,
+                                      "item_id" varchar NOT NULL,
+                                      "content" jsonb NOT NULL,
+                                      "findings" jsonb NOT NULL,
+                                      "decision" moderation_decision NOT NULL DEFAULT 'needs_review',
+                                      "reviewed_by" varchar,
+                                      "note" text NOT NULL DEFAULT '',
+                                      "created_at" timestamptz NOT NULL DEFAULT (now()),
+                                      "reviewed_at" timestamptz
+);
+
+CREATE UNIQUE INDEX ON "moderation_reviews" ("item_type", "item_id") WHERE "decision" = 'needs_review';
+CREATE INDEX ON "moderation_reviews" ("decision", "created_at");
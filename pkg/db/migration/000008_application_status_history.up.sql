@@ -0,0 +1,15 @@
+CREATE TABLE "application_status_history" (
+                                              "id" bigserial PRIMARY KEY,
+                                              "application_kind" varchar NOT NULL,
+                                              "candidate_id" bigint NOT NULL,
+                                              "employer_id" bigint NOT NULL,
+                                              "actor_role" role NOT NULL,
+                                              "actor_id" bigint NOT NULL,
+                                              "from_status" application_status NOT NULL,
+                                              "to_status" application_status NOT NULL,
+                                              "reason" text NOT NULL DEFAULT '',
+                                              "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "application_status_history" ("application_kind", "candidate_id", "employer_id", "created_at");
+CREATE INDEX ON "application_status_history" ("application_kind", "employer_id");
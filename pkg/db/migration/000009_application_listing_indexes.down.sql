@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "employer_applications_candidate_created_idx";
+DROP INDEX IF EXISTS "candidate_applications_employer_status_created_idx";
+DROP INDEX IF EXISTS "candidate_applications_employer_created_idx";
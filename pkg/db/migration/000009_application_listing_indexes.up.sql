@@ -0,0 +1,3 @@
+CREATE INDEX "candidate_applications_employer_created_idx" ON "candidate_applications" ("employer_id", "created_at", "candidate_id");
+CREATE INDEX "candidate_applications_employer_status_created_idx" ON "candidate_applications" ("employer_id", "application_status", "created_at");
+CREATE INDEX "employer_applications_candidate_created_idx" ON "employer_applications" ("candidate_id", "created_at", "employer_id");
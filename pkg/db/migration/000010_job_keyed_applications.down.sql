@@ -0,0 +1,11 @@
+-- This fails if a candidate has applied to more than one job at an employer
+-- or a job has more than one applicant, since the old keys can't hold them.
+ALTER TABLE "application_status_history" DROP COLUMN IF EXISTS "job_doc_id";
+
+DROP INDEX IF EXISTS "candidate_applications_job_doc_id_idx";
+DROP INDEX IF EXISTS "candidate_applications_employer_created_idx";
+CREATE INDEX "candidate_applications_employer_created_idx" ON "candidate_applications" ("employer_id", "created_at", "candidate_id");
+
+ALTER TABLE "candidate_applications" DROP CONSTRAINT "candidate_applications_pkey";
+ALTER TABLE "candidate_applications" ADD CONSTRAINT "candidate_applications_job_doc_id_key" UNIQUE ("job_doc_id");
+ALTER TABLE "candidate_applications" ADD PRIMARY KEY ("candidate_id", "employer_id");
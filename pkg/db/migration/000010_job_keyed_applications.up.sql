@@ -0,0 +1,23 @@
+-- Candidate applications are keyed by job, so a candidate can apply to several
+-- jobs at the same employer and a job can have many applicants. Existing rows
+-- keep their elasticsearch_doc_id, so their documents don't need reindexing.
+ALTER TABLE "candidate_applications" DROP CONSTRAINT "candidate_applications_pkey";
+ALTER TABLE "candidate_applications" DROP CONSTRAINT "candidate_applications_job_doc_id_key";
+ALTER TABLE "candidate_applications" ADD PRIMARY KEY ("candidate_id", "job_doc_id");
+
+DROP INDEX IF EXISTS "candidate_applications_employer_created_idx";
+CREATE INDEX "candidate_applications_employer_created_idx" ON "candidate_applications" ("employer_id", "created_at", "candidate_id", "job_doc_id");
+CREATE INDEX "candidate_applications_job_doc_id_idx" ON "candidate_applications" ("job_doc_id");
+
+-- Candidate application history follows the application's new key. Employer
+-- applications aren't tied to a job and keep an empty job_doc_id.
+ALTER TABLE "application_status_history" ADD COLUMN "job_doc_id" varchar NOT NULL DEFAULT '';
+
+UPDATE "application_status_history" h
+SET "job_doc_id" = ca."job_doc_id"
+FROM "candidate_applications" ca
+WHERE h."application_kind" = 'candidate'
+  AND ca."candidate_id" = h."candidate_id"
+  AND ca."employer_id" = h."employer_id";
+
+CREATE INDEX "application_status_history_candidate_job_idx" ON "application_status_history" ("application_kind", "candidate_id", "job_doc_id", "created_at");
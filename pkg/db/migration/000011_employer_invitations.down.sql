@@ -0,0 +1,2 @@
+ALTER TABLE "employer_applications" DROP COLUMN IF EXISTS "expires_at";
+ALTER TABLE "employer_applications" DROP COLUMN IF EXISTS "job_doc_id";
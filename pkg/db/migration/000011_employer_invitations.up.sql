@@ -0,0 +1,5 @@
+-- Employer applications are invitations to apply to one of the employer's
+-- jobs. Invitations sent before they were tied to a job keep an empty
+-- job_doc_id and never expire.
+ALTER TABLE "employer_applications" ADD COLUMN "job_doc_id" varchar NOT NULL DEFAULT '';
+ALTER TABLE "employer_applications" ADD COLUMN "expires_at" timestamptz;
@@ -0,0 +1,28 @@
+-- A conversation is the one thread between a candidate and an employer.
+CREATE TABLE "conversations" (
+                                 "id" bigserial PRIMARY KEY,
+                                 "candidate_id" bigint NOT NULL,
+                                 "employer_id" bigint NOT NULL,
+                                 "last_message_at" timestamptz,
+                                 "created_at" timestamptz NOT NULL DEFAULT (now()),
+                                 FOREIGN KEY ("candidate_id") REFERENCES "candidates" ("id") ON DELETE CASCADE,
+                                 FOREIGN KEY ("employer_id") REFERENCES "employers" ("id") ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX ON "conversations" ("candidate_id", "employer_id");
+CREATE INDEX ON "conversations" ("employer_id", "last_message_at");
+
+-- read_at is set once the other party has read the message.
+CREATE TABLE "messages" (
+                            "id" bigserial PRIMARY KEY,
+                            "conversation_id" bigint NOT NULL,
+                            "sender_role" role NOT NULL,
+                            "sender_id" bigint NOT NULL,
+                            "body" text NOT NULL,
+                            "read_at" timestamptz,
+                            "created_at" timestamptz NOT NULL DEFAULT (now()),
+                            FOREIGN KEY ("conversation_id") REFERENCES "conversations" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX ON "messages" ("conversation_id", "id");
+CREATE INDEX ON "messages" ("conversation_id", "sender_role") WHERE "read_at" IS NULL;
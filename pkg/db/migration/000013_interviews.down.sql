@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "interview_slots";
+DROP TABLE IF EXISTS "interviews";
+DROP TYPE IF EXISTS interview_status;
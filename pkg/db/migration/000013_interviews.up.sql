@@ -0,0 +1,36 @@
+CREATE TYPE interview_status AS ENUM ('proposed', 'scheduled', 'cancelled');
+
+-- An interview for an accepted candidate application. The employer proposes
+-- slots and the interview is scheduled once the candidate picks one.
+-- sequence counts the changes to the interview so that calendar clients
+-- replace the event they were sent before.
+CREATE TABLE "interviews" (
+                              "id" bigserial PRIMARY KEY,
+                              "candidate_id" bigint NOT NULL,
+                              "job_doc_id" varchar NOT NULL,
+                              "employer_id" bigint NOT NULL,
+                              "status" interview_status NOT NULL DEFAULT 'proposed',
+                              "scheduled_at" timestamptz,
+                              "duration_minutes" integer NOT NULL,
+                              "location" varchar NOT NULL DEFAULT '',
+                              "notes" text NOT NULL DEFAULT '',
+                              "sequence" integer NOT NULL DEFAULT 0,
+                              "created_at" timestamptz NOT NULL DEFAULT (now()),
+                              "updated_at" timestamptz NOT NULL DEFAULT (now()),
+                              FOREIGN KEY ("candidate_id", "job_doc_id") REFERENCES "candidate_applications" ("candidate_id", "job_doc_id") ON DELETE CASCADE,
+                              FOREIGN KEY ("employer_id") REFERENCES "employers" ("id") ON DELETE CASCADE,
+                              CHECK ("duration_minutes" > 0),
+                              CHECK ("status" <> 'scheduled' OR "scheduled_at" IS NOT NULL)
+);
+
+-- An application has at most one interview that hasn't been cancelled.
+CREATE UNIQUE INDEX ON "interviews" ("candidate_id", "job_doc_id") WHERE "status" <> 'cancelled';
+CREATE INDEX ON "interviews" ("employer_id", "scheduled_at");
+
+CREATE TABLE "interview_slots" (
+                                   "id" bigserial PRIMARY KEY,
+                                   "interview_id" bigint NOT NULL,
+                                   "starts_at" timestamptz NOT NULL,
+                                   FOREIGN KEY ("interview_id") REFERENCES "interviews" ("id") ON DELETE CASCADE,
+                                   UNIQUE ("interview_id", "starts_at")
+);
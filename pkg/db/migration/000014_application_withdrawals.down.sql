@@ -0,0 +1,6 @@
+ALTER TABLE "candidate_applications"
+    DROP COLUMN IF EXISTS "withdrawn_at",
+    DROP COLUMN IF EXISTS "withdrawal_note",
+    DROP COLUMN IF EXISTS "withdrawal_reason";
+DROP TYPE IF EXISTS withdrawal_reason;
+-- Postgres can't drop a value from an enum, so the withdrawn status is left in place.
@@ -0,0 +1,14 @@
+ALTER TYPE application_status ADD VALUE IF NOT EXISTS 'withdrawn';
+
+CREATE TYPE withdrawal_reason AS ENUM (
+    'accepted_other_offer',
+    'schedule_conflict',
+    'compensation',
+    'no_longer_interested',
+    'other'
+);
+
+ALTER TABLE "candidate_applications"
+    ADD COLUMN "withdrawal_reason" withdrawal_reason,
+    ADD COLUMN "withdrawal_note" text NOT NULL DEFAULT '',
+    ADD COLUMN "withdrawn_at" timestamptz;
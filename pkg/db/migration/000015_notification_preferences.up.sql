@@ -0,0 +1,8 @@
+-- A user without a row gets every notification.
+CREATE TABLE "notification_preferences" (
+                                            "username" varchar PRIMARY KEY,
+                                            "application_received" boolean NOT NULL DEFAULT true,
+                                            "application_decisions" boolean NOT NULL DEFAULT true,
+                                            "updated_at" timestamptz NOT NULL DEFAULT (now()),
+                                            FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE
+);
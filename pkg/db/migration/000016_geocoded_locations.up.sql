@@ -0,0 +1,7 @@
+-- Coordinates for addresses, filled in when profiles are written so reads never call the geocoder.
+CREATE TABLE "geocoded_locations" (
+                                      "address" varchar PRIMARY KEY,
+                                      "latitude" double precision NOT NULL,
+                                      "longitude" double precision NOT NULL,
+                                      "geocoded_at" timestamptz NOT NULL DEFAULT (now())
+);
@@ -50,6 +50,155 @@ func (mr *MockStoreMockRecorder) AddJobListing(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddJobListing", reflect.TypeOf((*MockStore)(nil).AddJobListing), arg0, arg1)
 }
 
+// BulkTransitionApplicationsTx mocks base method.
+func (m *MockStore) BulkTransitionApplicationsTx(arg0 context.Context, arg1 db.BulkTransitionApplicationsTxParams) ([]db.BulkApplicationResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkTransitionApplicationsTx", arg0, arg1)
+	ret0, _ := ret[0].([]db.BulkApplicationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkTransitionApplicationsTx indicates an expected call of BulkTransitionApplicationsTx.
+func (mr *MockStoreMockRecorder) BulkTransitionApplicationsTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkTransitionApplicationsTx", reflect.TypeOf((*MockStore)(nil).BulkTransitionApplicationsTx), arg0, arg1)
+}
+
+// CanMessage mocks base method.
+func (m *MockStore) CanMessage(arg0 context.Context, arg1 db.CanMessageParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CanMessage", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CanMessage indicates an expected call of CanMessage.
+func (mr *MockStoreMockRecorder) CanMessage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanMessage", reflect.TypeOf((*MockStore)(nil).CanMessage), arg0, arg1)
+}
+
+// CancelInterview mocks base method.
+func (m *MockStore) CancelInterview(arg0 context.Context, arg1 int64) (db.Interview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelInterview", arg0, arg1)
+	ret0, _ := ret[0].(db.Interview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelInterview indicates an expected call of CancelInterview.
+func (mr *MockStoreMockRecorder) CancelInterview(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelInterview", reflect.TypeOf((*MockStore)(nil).CancelInterview), arg0, arg1)
+}
+
+// CancelInterviewTx mocks base method.
+func (m *MockStore) CancelInterviewTx(arg0 context.Context, arg1 db.CancelInterviewTxParams) (db.InterviewTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelInterviewTx", arg0, arg1)
+	ret0, _ := ret[0].(db.InterviewTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelInterviewTx indicates an expected call of CancelInterviewTx.
+func (mr *MockStoreMockRecorder) CancelInterviewTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelInterviewTx", reflect.TypeOf((*MockStore)(nil).CancelInterviewTx), arg0, arg1)
+}
+
+// ClaimShiftTx mocks base method.
+func (m *MockStore) ClaimShiftTx(arg0 context.Context, arg1 db.ClaimShiftTxParams) (db.ClaimShiftTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimShiftTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ClaimShiftTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimShiftTx indicates an expected call of ClaimShiftTx.
+func (mr *MockStoreMockRecorder) ClaimShiftTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimShiftTx", reflect.TypeOf((*MockStore)(nil).ClaimShiftTx), arg0, arg1)
+}
+
+// ClearEmployerDescription mocks base method.
+func (m *MockStore) ClearEmployerDescription(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearEmployerDescription", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearEmployerDescription indicates an expected call of ClearEmployerDescription.
+func (mr *MockStoreMockRecorder) ClearEmployerDescription(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearEmployerDescription", reflect.TypeOf((*MockStore)(nil).ClearEmployerDescription), arg0, arg1)
+}
+
+// CountApplicantsByJobs mocks base method.
+func (m *MockStore) CountApplicantsByJobs(arg0 context.Context, arg1 []string) ([]db.CountApplicantsByJobsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountApplicantsByJobs", arg0, arg1)
+	ret0, _ := ret[0].([]db.CountApplicantsByJobsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountApplicantsByJobs indicates an expected call of CountApplicantsByJobs.
+func (mr *MockStoreMockRecorder) CountApplicantsByJobs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountApplicantsByJobs", reflect.TypeOf((*MockStore)(nil).CountApplicantsByJobs), arg0, arg1)
+}
+
+// CountCandidateApplicationsByJob mocks base method.
+func (m *MockStore) CountCandidateApplicationsByJob(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCandidateApplicationsByJob", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCandidateApplicationsByJob indicates an expected call of CountCandidateApplicationsByJob.
+func (mr *MockStoreMockRecorder) CountCandidateApplicationsByJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCandidateApplicationsByJob", reflect.TypeOf((*MockStore)(nil).CountCandidateApplicationsByJob), arg0, arg1)
+}
+
+// CountShiftClaims mocks base method.
+func (m *MockStore) CountShiftClaims(arg0 context.Context, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountShiftClaims", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountShiftClaims indicates an expected call of CountShiftClaims.
+func (mr *MockStoreMockRecorder) CountShiftClaims(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountShiftClaims", reflect.TypeOf((*MockStore)(nil).CountShiftClaims), arg0, arg1)
+}
+
+// CreateApplicationStatusHistory mocks base method.
+func (m *MockStore) CreateApplicationStatusHistory(arg0 context.Context, arg1 db.CreateApplicationStatusHistoryParams) (db.ApplicationStatusHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateApplicationStatusHistory", arg0, arg1)
+	ret0, _ := ret[0].(db.ApplicationStatusHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateApplicationStatusHistory indicates an expected call of CreateApplicationStatusHistory.
+func (mr *MockStoreMockRecorder) CreateApplicationStatusHistory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateApplicationStatusHistory", reflect.TypeOf((*MockStore)(nil).CreateApplicationStatusHistory), arg0, arg1)
+}
+
 // CreateCandidate mocks base method.
 func (m *MockStore) CreateCandidate(arg0 context.Context, arg1 db.CreateCandidateParams) (db.Candidate, error) {
 	m.ctrl.T.Helper()
@@ -154,6 +303,21 @@ func (mr *MockStoreMockRecorder) CreateEmployerApplication(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEmployerApplication", reflect.TypeOf((*MockStore)(nil).CreateEmployerApplication), arg0, arg1)
 }
 
+// CreateEmployerApplicationTx mocks base method.
+func (m *MockStore) CreateEmployerApplicationTx(arg0 context.Context, arg1 db.CreateEmployerApplicationTxParams) (db.EmployerAppTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEmployerApplicationTx", arg0, arg1)
+	ret0, _ := ret[0].(db.EmployerAppTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEmployerApplicationTx indicates an expected call of CreateEmployerApplicationTx.
+func (mr *MockStoreMockRecorder) CreateEmployerApplicationTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEmployerApplicationTx", reflect.TypeOf((*MockStore)(nil).CreateEmployerApplicationTx), arg0, arg1)
+}
+
 // CreateEmployerSwipes mocks base method.
 func (m *MockStore) CreateEmployerSwipes(arg0 context.Context, arg1 db.CreateEmployerSwipesParams) error {
 	m.ctrl.T.Helper()
@@ -168,6 +332,111 @@ func (mr *MockStoreMockRecorder) CreateEmployerSwipes(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEmployerSwipes", reflect.TypeOf((*MockStore)(nil).CreateEmployerSwipes), arg0, arg1)
 }
 
+// CreateInterview mocks base method.
+func (m *MockStore) CreateInterview(arg0 context.Context, arg1 db.CreateInterviewParams) (db.Interview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInterview", arg0, arg1)
+	ret0, _ := ret[0].(db.Interview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInterview indicates an expected call of CreateInterview.
+func (mr *MockStoreMockRecorder) CreateInterview(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInterview", reflect.TypeOf((*MockStore)(nil).CreateInterview), arg0, arg1)
+}
+
+// CreateInterviewSlot mocks base method.
+func (m *MockStore) CreateInterviewSlot(arg0 context.Context, arg1 db.CreateInterviewSlotParams) (db.InterviewSlot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInterviewSlot", arg0, arg1)
+	ret0, _ := ret[0].(db.InterviewSlot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInterviewSlot indicates an expected call of CreateInterviewSlot.
+func (mr *MockStoreMockRecorder) CreateInterviewSlot(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInterviewSlot", reflect.TypeOf((*MockStore)(nil).CreateInterviewSlot), arg0, arg1)
+}
+
+// CreateInterviewTx mocks base method.
+func (m *MockStore) CreateInterviewTx(arg0 context.Context, arg1 db.CreateInterviewTxParams) (db.InterviewTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInterviewTx", arg0, arg1)
+	ret0, _ := ret[0].(db.InterviewTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInterviewTx indicates an expected call of CreateInterviewTx.
+func (mr *MockStoreMockRecorder) CreateInterviewTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInterviewTx", reflect.TypeOf((*MockStore)(nil).CreateInterviewTx), arg0, arg1)
+}
+
+// CreateJobShift mocks base method.
+func (m *MockStore) CreateJobShift(arg0 context.Context, arg1 db.CreateJobShiftParams) (db.JobShift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateJobShift", arg0, arg1)
+	ret0, _ := ret[0].(db.JobShift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateJobShift indicates an expected call of CreateJobShift.
+func (mr *MockStoreMockRecorder) CreateJobShift(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJobShift", reflect.TypeOf((*MockStore)(nil).CreateJobShift), arg0, arg1)
+}
+
+// CreateJobTemplate mocks base method.
+func (m *MockStore) CreateJobTemplate(arg0 context.Context, arg1 db.CreateJobTemplateParams) (db.JobTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateJobTemplate", arg0, arg1)
+	ret0, _ := ret[0].(db.JobTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateJobTemplate indicates an expected call of CreateJobTemplate.
+func (mr *MockStoreMockRecorder) CreateJobTemplate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJobTemplate", reflect.TypeOf((*MockStore)(nil).CreateJobTemplate), arg0, arg1)
+}
+
+// CreateMessage mocks base method.
+func (m *MockStore) CreateMessage(arg0 context.Context, arg1 db.CreateMessageParams) (db.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMessage", arg0, arg1)
+	ret0, _ := ret[0].(db.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMessage indicates an expected call of CreateMessage.
+func (mr *MockStoreMockRecorder) CreateMessage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMessage", reflect.TypeOf((*MockStore)(nil).CreateMessage), arg0, arg1)
+}
+
+// CreateModerationReview mocks base method.
+func (m *MockStore) CreateModerationReview(arg0 context.Context, arg1 db.CreateModerationReviewParams) (db.ModerationReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateModerationReview", arg0, arg1)
+	ret0, _ := ret[0].(db.ModerationReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateModerationReview indicates an expected call of CreateModerationReview.
+func (mr *MockStoreMockRecorder) CreateModerationReview(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateModerationReview", reflect.TypeOf((*MockStore)(nil).CreateModerationReview), arg0, arg1)
+}
+
 // CreatePastExperience mocks base method.
 func (m *MockStore) CreatePastExperience(arg0 context.Context, arg1 db.CreatePastExperienceParams) (db.PastExperience, error) {
 	m.ctrl.T.Helper()
@@ -213,6 +482,21 @@ func (mr *MockStoreMockRecorder) CreateSession(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockStore)(nil).CreateSession), arg0, arg1)
 }
 
+// CreateShiftClaim mocks base method.
+func (m *MockStore) CreateShiftClaim(arg0 context.Context, arg1 db.CreateShiftClaimParams) (db.ShiftClaim, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShiftClaim", arg0, arg1)
+	ret0, _ := ret[0].(db.ShiftClaim)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateShiftClaim indicates an expected call of CreateShiftClaim.
+func (mr *MockStoreMockRecorder) CreateShiftClaim(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShiftClaim", reflect.TypeOf((*MockStore)(nil).CreateShiftClaim), arg0, arg1)
+}
+
 // CreateUser mocks base method.
 func (m *MockStore) CreateUser(arg0 context.Context, arg1 db.CreateUserParams) (db.User, error) {
 	m.ctrl.T.Helper()
@@ -258,6 +542,20 @@ func (mr *MockStoreMockRecorder) CreateVerifyEmail(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVerifyEmail", reflect.TypeOf((*MockStore)(nil).CreateVerifyEmail), arg0, arg1)
 }
 
+// DeleteApplicationStatusHistory mocks base method.
+func (m *MockStore) DeleteApplicationStatusHistory(arg0 context.Context, arg1 db.DeleteApplicationStatusHistoryParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteApplicationStatusHistory", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteApplicationStatusHistory indicates an expected call of DeleteApplicationStatusHistory.
+func (mr *MockStoreMockRecorder) DeleteApplicationStatusHistory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteApplicationStatusHistory", reflect.TypeOf((*MockStore)(nil).DeleteApplicationStatusHistory), arg0, arg1)
+}
+
 // DeleteApplicationTx mocks base method.
 func (m *MockStore) DeleteApplicationTx(arg0 context.Context, arg1 db.DeleteApplicationTxParams) error {
 	m.ctrl.T.Helper()
@@ -287,11 +585,12 @@ func (mr *MockStoreMockRecorder) DeleteCandidate(arg0, arg1 interface{}) *gomock
 }
 
 // DeleteCandidateApplication mocks base method.
-func (m *MockStore) DeleteCandidateApplication(arg0 context.Context, arg1 db.DeleteCandidateApplicationParams) error {
+func (m *MockStore) DeleteCandidateApplication(arg0 context.Context, arg1 db.DeleteCandidateApplicationParams) (db.CandidateApplication, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "DeleteCandidateApplication", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(db.CandidateApplication)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // DeleteCandidateApplication indicates an expected call of DeleteCandidateApplication.
@@ -356,6 +655,48 @@ func (mr *MockStoreMockRecorder) DeleteEmployerSwipe(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEmployerSwipe", reflect.TypeOf((*MockStore)(nil).DeleteEmployerSwipe), arg0, arg1)
 }
 
+// DeleteInterviewSlots mocks base method.
+func (m *MockStore) DeleteInterviewSlots(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteInterviewSlots", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteInterviewSlots indicates an expected call of DeleteInterviewSlots.
+func (mr *MockStoreMockRecorder) DeleteInterviewSlots(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInterviewSlots", reflect.TypeOf((*MockStore)(nil).DeleteInterviewSlots), arg0, arg1)
+}
+
+// DeleteJobShift mocks base method.
+func (m *MockStore) DeleteJobShift(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteJobShift", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteJobShift indicates an expected call of DeleteJobShift.
+func (mr *MockStoreMockRecorder) DeleteJobShift(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteJobShift", reflect.TypeOf((*MockStore)(nil).DeleteJobShift), arg0, arg1)
+}
+
+// DeleteJobTemplate mocks base method.
+func (m *MockStore) DeleteJobTemplate(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteJobTemplate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteJobTemplate indicates an expected call of DeleteJobTemplate.
+func (mr *MockStoreMockRecorder) DeleteJobTemplate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteJobTemplate", reflect.TypeOf((*MockStore)(nil).DeleteJobTemplate), arg0, arg1)
+}
+
 // DeletePastExperience mocks base method.
 func (m *MockStore) DeletePastExperience(arg0 context.Context, arg1 db.DeletePastExperienceParams) error {
 	m.ctrl.T.Helper()
@@ -384,94 +725,92 @@ func (mr *MockStoreMockRecorder) DeletePastExperienceTx(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePastExperienceTx", reflect.TypeOf((*MockStore)(nil).DeletePastExperienceTx), arg0, arg1)
 }
 
-// GetCandidate mocks base method.
-func (m *MockStore) GetCandidate(arg0 context.Context, arg1 int64) (db.Candidate, error) {
+// DeletePendingModerationReview mocks base method.
+func (m *MockStore) DeletePendingModerationReview(arg0 context.Context, arg1 db.DeletePendingModerationReviewParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCandidate", arg0, arg1)
-	ret0, _ := ret[0].(db.Candidate)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "DeletePendingModerationReview", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetCandidate indicates an expected call of GetCandidate.
-func (mr *MockStoreMockRecorder) GetCandidate(arg0, arg1 interface{}) *gomock.Call {
+// DeletePendingModerationReview indicates an expected call of DeletePendingModerationReview.
+func (mr *MockStoreMockRecorder) DeletePendingModerationReview(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCandidate", reflect.TypeOf((*MockStore)(nil).GetCandidate), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePendingModerationReview", reflect.TypeOf((*MockStore)(nil).DeletePendingModerationReview), arg0, arg1)
 }
 
-// GetCandidateApplicationsByEmployer mocks base method.
-func (m *MockStore) GetCandidateApplicationsByEmployer(arg0 context.Context, arg1 int64) ([]db.CandidateApplication, error) {
+// DeleteShiftClaim mocks base method.
+func (m *MockStore) DeleteShiftClaim(arg0 context.Context, arg1 db.DeleteShiftClaimParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCandidateApplicationsByEmployer", arg0, arg1)
-	ret0, _ := ret[0].([]db.CandidateApplication)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "DeleteShiftClaim", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetCandidateApplicationsByEmployer indicates an expected call of GetCandidateApplicationsByEmployer.
-func (mr *MockStoreMockRecorder) GetCandidateApplicationsByEmployer(arg0, arg1 interface{}) *gomock.Call {
+// DeleteShiftClaim indicates an expected call of DeleteShiftClaim.
+func (mr *MockStoreMockRecorder) DeleteShiftClaim(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCandidateApplicationsByEmployer", reflect.TypeOf((*MockStore)(nil).GetCandidateApplicationsByEmployer), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteShiftClaim", reflect.TypeOf((*MockStore)(nil).DeleteShiftClaim), arg0, arg1)
 }
 
-// GetCandidateApplicationsByStatusAccepted mocks base method.
-func (m *MockStore) GetCandidateApplicationsByStatusAccepted(arg0 context.Context, arg1 int64) ([]db.CandidateApplication, error) {
+// GetCandidate mocks base method.
+func (m *MockStore) GetCandidate(arg0 context.Context, arg1 int64) (db.Candidate, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCandidateApplicationsByStatusAccepted", arg0, arg1)
-	ret0, _ := ret[0].([]db.CandidateApplication)
+	ret := m.ctrl.Call(m, "GetCandidate", arg0, arg1)
+	ret0, _ := ret[0].(db.Candidate)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCandidateApplicationsByStatusAccepted indicates an expected call of GetCandidateApplicationsByStatusAccepted.
-func (mr *MockStoreMockRecorder) GetCandidateApplicationsByStatusAccepted(arg0, arg1 interface{}) *gomock.Call {
+// GetCandidate indicates an expected call of GetCandidate.
+func (mr *MockStoreMockRecorder) GetCandidate(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCandidateApplicationsByStatusAccepted", reflect.TypeOf((*MockStore)(nil).GetCandidateApplicationsByStatusAccepted), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCandidate", reflect.TypeOf((*MockStore)(nil).GetCandidate), arg0, arg1)
 }
 
-// GetCandidateApplicationsByStatusPending mocks base method.
-func (m *MockStore) GetCandidateApplicationsByStatusPending(arg0 context.Context, arg1 int64) ([]db.CandidateApplication, error) {
+// GetCandidateApplication mocks base method.
+func (m *MockStore) GetCandidateApplication(arg0 context.Context, arg1 db.GetCandidateApplicationParams) (db.CandidateApplication, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCandidateApplicationsByStatusPending", arg0, arg1)
-	ret0, _ := ret[0].([]db.CandidateApplication)
+	ret := m.ctrl.Call(m, "GetCandidateApplication", arg0, arg1)
+	ret0, _ := ret[0].(db.CandidateApplication)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCandidateApplicationsByStatusPending indicates an expected call of GetCandidateApplicationsByStatusPending.
-func (mr *MockStoreMockRecorder) GetCandidateApplicationsByStatusPending(arg0, arg1 interface{}) *gomock.Call {
+// GetCandidateApplication indicates an expected call of GetCandidateApplication.
+func (mr *MockStoreMockRecorder) GetCandidateApplication(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCandidateApplicationsByStatusPending", reflect.TypeOf((*MockStore)(nil).GetCandidateApplicationsByStatusPending), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCandidateApplication", reflect.TypeOf((*MockStore)(nil).GetCandidateApplication), arg0, arg1)
 }
 
-// GetCandidateApplicationsByStatusRejected mocks base method.
-func (m *MockStore) GetCandidateApplicationsByStatusRejected(arg0 context.Context, arg1 int64) ([]db.CandidateApplication, error) {
+// GetCandidateApplicationForUpdate mocks base method.
+func (m *MockStore) GetCandidateApplicationForUpdate(arg0 context.Context, arg1 db.GetCandidateApplicationForUpdateParams) (db.CandidateApplication, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCandidateApplicationsByStatusRejected", arg0, arg1)
-	ret0, _ := ret[0].([]db.CandidateApplication)
+	ret := m.ctrl.Call(m, "GetCandidateApplicationForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.CandidateApplication)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCandidateApplicationsByStatusRejected indicates an expected call of GetCandidateApplicationsByStatusRejected.
-func (mr *MockStoreMockRecorder) GetCandidateApplicationsByStatusRejected(arg0, arg1 interface{}) *gomock.Call {
+// GetCandidateApplicationForUpdate indicates an expected call of GetCandidateApplicationForUpdate.
+func (mr *MockStoreMockRecorder) GetCandidateApplicationForUpdate(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCandidateApplicationsByStatusRejected", reflect.TypeOf((*MockStore)(nil).GetCandidateApplicationsByStatusRejected), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCandidateApplicationForUpdate", reflect.TypeOf((*MockStore)(nil).GetCandidateApplicationForUpdate), arg0, arg1)
 }
 
-// GetCandidateApplicationsByStatusSubmitted mocks base method.
-func (m *MockStore) GetCandidateApplicationsByStatusSubmitted(arg0 context.Context, arg1 int64) ([]db.CandidateApplication, error) {
+// GetCandidateApplicationsByEmployer mocks base method.
+func (m *MockStore) GetCandidateApplicationsByEmployer(arg0 context.Context, arg1 int64) ([]db.CandidateApplication, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCandidateApplicationsByStatusSubmitted", arg0, arg1)
+	ret := m.ctrl.Call(m, "GetCandidateApplicationsByEmployer", arg0, arg1)
 	ret0, _ := ret[0].([]db.CandidateApplication)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCandidateApplicationsByStatusSubmitted indicates an expected call of GetCandidateApplicationsByStatusSubmitted.
-func (mr *MockStoreMockRecorder) GetCandidateApplicationsByStatusSubmitted(arg0, arg1 interface{}) *gomock.Call {
+// GetCandidateApplicationsByEmployer indicates an expected call of GetCandidateApplicationsByEmployer.
+func (mr *MockStoreMockRecorder) GetCandidateApplicationsByEmployer(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCandidateApplicationsByStatusSubmitted", reflect.TypeOf((*MockStore)(nil).GetCandidateApplicationsByStatusSubmitted), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCandidateApplicationsByEmployer", reflect.TypeOf((*MockStore)(nil).GetCandidateApplicationsByEmployer), arg0, arg1)
 }
 
 // GetCandidateIDsByEmployer mocks base method.
@@ -519,6 +858,21 @@ func (mr *MockStoreMockRecorder) GetCandidateSwipe(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCandidateSwipe", reflect.TypeOf((*MockStore)(nil).GetCandidateSwipe), arg0, arg1)
 }
 
+// GetConversation mocks base method.
+func (m *MockStore) GetConversation(arg0 context.Context, arg1 int64) (db.Conversation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConversation", arg0, arg1)
+	ret0, _ := ret[0].(db.Conversation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConversation indicates an expected call of GetConversation.
+func (mr *MockStoreMockRecorder) GetConversation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConversation", reflect.TypeOf((*MockStore)(nil).GetConversation), arg0, arg1)
+}
+
 // GetEmployer mocks base method.
 func (m *MockStore) GetEmployer(arg0 context.Context, arg1 int64) (db.Employer, error) {
 	m.ctrl.T.Helper()
@@ -534,109 +888,139 @@ func (mr *MockStoreMockRecorder) GetEmployer(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmployer", reflect.TypeOf((*MockStore)(nil).GetEmployer), arg0, arg1)
 }
 
-// GetEmployerApplicationsByCandidate mocks base method.
-func (m *MockStore) GetEmployerApplicationsByCandidate(arg0 context.Context, arg1 int64) ([]db.EmployerApplication, error) {
+// GetEmployerApplication mocks base method.
+func (m *MockStore) GetEmployerApplication(arg0 context.Context, arg1 db.GetEmployerApplicationParams) (db.EmployerApplication, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetEmployerApplicationsByCandidate", arg0, arg1)
-	ret0, _ := ret[0].([]db.EmployerApplication)
+	ret := m.ctrl.Call(m, "GetEmployerApplication", arg0, arg1)
+	ret0, _ := ret[0].(db.EmployerApplication)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetEmployerApplicationsByCandidate indicates an expected call of GetEmployerApplicationsByCandidate.
-func (mr *MockStoreMockRecorder) GetEmployerApplicationsByCandidate(arg0, arg1 interface{}) *gomock.Call {
+// GetEmployerApplication indicates an expected call of GetEmployerApplication.
+func (mr *MockStoreMockRecorder) GetEmployerApplication(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmployerApplicationsByCandidate", reflect.TypeOf((*MockStore)(nil).GetEmployerApplicationsByCandidate), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmployerApplication", reflect.TypeOf((*MockStore)(nil).GetEmployerApplication), arg0, arg1)
 }
 
-// GetEmployerApplicationsByStatusAccepted mocks base method.
-func (m *MockStore) GetEmployerApplicationsByStatusAccepted(arg0 context.Context, arg1 int64) ([]db.EmployerApplication, error) {
+// GetEmployerApplicationForUpdate mocks base method.
+func (m *MockStore) GetEmployerApplicationForUpdate(arg0 context.Context, arg1 db.GetEmployerApplicationForUpdateParams) (db.EmployerApplication, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetEmployerApplicationsByStatusAccepted", arg0, arg1)
-	ret0, _ := ret[0].([]db.EmployerApplication)
+	ret := m.ctrl.Call(m, "GetEmployerApplicationForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.EmployerApplication)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetEmployerApplicationsByStatusAccepted indicates an expected call of GetEmployerApplicationsByStatusAccepted.
-func (mr *MockStoreMockRecorder) GetEmployerApplicationsByStatusAccepted(arg0, arg1 interface{}) *gomock.Call {
+// GetEmployerApplicationForUpdate indicates an expected call of GetEmployerApplicationForUpdate.
+func (mr *MockStoreMockRecorder) GetEmployerApplicationForUpdate(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmployerApplicationsByStatusAccepted", reflect.TypeOf((*MockStore)(nil).GetEmployerApplicationsByStatusAccepted), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmployerApplicationForUpdate", reflect.TypeOf((*MockStore)(nil).GetEmployerApplicationForUpdate), arg0, arg1)
 }
 
-// GetEmployerApplicationsByStatusPending mocks base method.
-func (m *MockStore) GetEmployerApplicationsByStatusPending(arg0 context.Context, arg1 int64) ([]db.EmployerApplication, error) {
+// GetEmployerApplicationsByCandidate mocks base method.
+func (m *MockStore) GetEmployerApplicationsByCandidate(arg0 context.Context, arg1 int64) ([]db.EmployerApplication, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetEmployerApplicationsByStatusPending", arg0, arg1)
+	ret := m.ctrl.Call(m, "GetEmployerApplicationsByCandidate", arg0, arg1)
 	ret0, _ := ret[0].([]db.EmployerApplication)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetEmployerApplicationsByStatusPending indicates an expected call of GetEmployerApplicationsByStatusPending.
-func (mr *MockStoreMockRecorder) GetEmployerApplicationsByStatusPending(arg0, arg1 interface{}) *gomock.Call {
+// GetEmployerApplicationsByCandidate indicates an expected call of GetEmployerApplicationsByCandidate.
+func (mr *MockStoreMockRecorder) GetEmployerApplicationsByCandidate(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmployerApplicationsByStatusPending", reflect.TypeOf((*MockStore)(nil).GetEmployerApplicationsByStatusPending), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmployerApplicationsByCandidate", reflect.TypeOf((*MockStore)(nil).GetEmployerApplicationsByCandidate), arg0, arg1)
 }
 
-// GetEmployerApplicationsByStatusRejected mocks base method.
-func (m *MockStore) GetEmployerApplicationsByStatusRejected(arg0 context.Context, arg1 int64) ([]db.EmployerApplication, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetEmployerApplicationsByStatusRejected", arg0, arg1)
-	ret0, _ := ret[0].([]db.EmployerApplication)
+// GetEmployerIdByUsername mocks base method.
+func (m *MockStore) GetEmployerIdByUsername(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmployerIdByUsername", arg0, arg1)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetEmployerApplicationsByStatusRejected indicates an expected call of GetEmployerApplicationsByStatusRejected.
-func (mr *MockStoreMockRecorder) GetEmployerApplicationsByStatusRejected(arg0, arg1 interface{}) *gomock.Call {
+// GetEmployerIdByUsername indicates an expected call of GetEmployerIdByUsername.
+func (mr *MockStoreMockRecorder) GetEmployerIdByUsername(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmployerApplicationsByStatusRejected", reflect.TypeOf((*MockStore)(nil).GetEmployerApplicationsByStatusRejected), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmployerIdByUsername", reflect.TypeOf((*MockStore)(nil).GetEmployerIdByUsername), arg0, arg1)
 }
 
-// GetEmployerApplicationsByStatusSubmitted mocks base method.
-func (m *MockStore) GetEmployerApplicationsByStatusSubmitted(arg0 context.Context, arg1 int64) ([]db.EmployerApplication, error) {
+// GetEmployerSwipe mocks base method.
+func (m *MockStore) GetEmployerSwipe(arg0 context.Context, arg1 db.GetEmployerSwipeParams) (db.EmployerSwipe, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetEmployerApplicationsByStatusSubmitted", arg0, arg1)
-	ret0, _ := ret[0].([]db.EmployerApplication)
+	ret := m.ctrl.Call(m, "GetEmployerSwipe", arg0, arg1)
+	ret0, _ := ret[0].(db.EmployerSwipe)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetEmployerApplicationsByStatusSubmitted indicates an expected call of GetEmployerApplicationsByStatusSubmitted.
-func (mr *MockStoreMockRecorder) GetEmployerApplicationsByStatusSubmitted(arg0, arg1 interface{}) *gomock.Call {
+// GetEmployerSwipe indicates an expected call of GetEmployerSwipe.
+func (mr *MockStoreMockRecorder) GetEmployerSwipe(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmployerApplicationsByStatusSubmitted", reflect.TypeOf((*MockStore)(nil).GetEmployerApplicationsByStatusSubmitted), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmployerSwipe", reflect.TypeOf((*MockStore)(nil).GetEmployerSwipe), arg0, arg1)
 }
 
-// GetEmployerIdByUsername mocks base method.
-func (m *MockStore) GetEmployerIdByUsername(arg0 context.Context, arg1 string) (int64, error) {
+// GetGeocodedLocation mocks base method.
+func (m *MockStore) GetGeocodedLocation(arg0 context.Context, arg1 string) (db.GeocodedLocation, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetEmployerIdByUsername", arg0, arg1)
-	ret0, _ := ret[0].(int64)
+	ret := m.ctrl.Call(m, "GetGeocodedLocation", arg0, arg1)
+	ret0, _ := ret[0].(db.GeocodedLocation)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetEmployerIdByUsername indicates an expected call of GetEmployerIdByUsername.
-func (mr *MockStoreMockRecorder) GetEmployerIdByUsername(arg0, arg1 interface{}) *gomock.Call {
+// GetGeocodedLocation indicates an expected call of GetGeocodedLocation.
+func (mr *MockStoreMockRecorder) GetGeocodedLocation(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmployerIdByUsername", reflect.TypeOf((*MockStore)(nil).GetEmployerIdByUsername), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGeocodedLocation", reflect.TypeOf((*MockStore)(nil).GetGeocodedLocation), arg0, arg1)
 }
 
-// GetEmployerSwipe mocks base method.
-func (m *MockStore) GetEmployerSwipe(arg0 context.Context, arg1 db.GetEmployerSwipeParams) (db.EmployerSwipe, error) {
+// GetInterview mocks base method.
+func (m *MockStore) GetInterview(arg0 context.Context, arg1 int64) (db.Interview, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetEmployerSwipe", arg0, arg1)
-	ret0, _ := ret[0].(db.EmployerSwipe)
+	ret := m.ctrl.Call(m, "GetInterview", arg0, arg1)
+	ret0, _ := ret[0].(db.Interview)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetEmployerSwipe indicates an expected call of GetEmployerSwipe.
-func (mr *MockStoreMockRecorder) GetEmployerSwipe(arg0, arg1 interface{}) *gomock.Call {
+// GetInterview indicates an expected call of GetInterview.
+func (mr *MockStoreMockRecorder) GetInterview(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmployerSwipe", reflect.TypeOf((*MockStore)(nil).GetEmployerSwipe), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterview", reflect.TypeOf((*MockStore)(nil).GetInterview), arg0, arg1)
+}
+
+// GetInterviewForUpdate mocks base method.
+func (m *MockStore) GetInterviewForUpdate(arg0 context.Context, arg1 int64) (db.Interview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInterviewForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.Interview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInterviewForUpdate indicates an expected call of GetInterviewForUpdate.
+func (mr *MockStoreMockRecorder) GetInterviewForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterviewForUpdate", reflect.TypeOf((*MockStore)(nil).GetInterviewForUpdate), arg0, arg1)
+}
+
+// GetInterviewSlot mocks base method.
+func (m *MockStore) GetInterviewSlot(arg0 context.Context, arg1 db.GetInterviewSlotParams) (db.InterviewSlot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInterviewSlot", arg0, arg1)
+	ret0, _ := ret[0].(db.InterviewSlot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInterviewSlot indicates an expected call of GetInterviewSlot.
+func (mr *MockStoreMockRecorder) GetInterviewSlot(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterviewSlot", reflect.TypeOf((*MockStore)(nil).GetInterviewSlot), arg0, arg1)
 }
 
 // GetJobIDsByCandidate mocks base method.
@@ -654,6 +1038,81 @@ func (mr *MockStoreMockRecorder) GetJobIDsByCandidate(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobIDsByCandidate", reflect.TypeOf((*MockStore)(nil).GetJobIDsByCandidate), arg0, arg1)
 }
 
+// GetJobShift mocks base method.
+func (m *MockStore) GetJobShift(arg0 context.Context, arg1 int64) (db.JobShift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJobShift", arg0, arg1)
+	ret0, _ := ret[0].(db.JobShift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJobShift indicates an expected call of GetJobShift.
+func (mr *MockStoreMockRecorder) GetJobShift(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobShift", reflect.TypeOf((*MockStore)(nil).GetJobShift), arg0, arg1)
+}
+
+// GetJobShiftForUpdate mocks base method.
+func (m *MockStore) GetJobShiftForUpdate(arg0 context.Context, arg1 int64) (db.JobShift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJobShiftForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.JobShift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJobShiftForUpdate indicates an expected call of GetJobShiftForUpdate.
+func (mr *MockStoreMockRecorder) GetJobShiftForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobShiftForUpdate", reflect.TypeOf((*MockStore)(nil).GetJobShiftForUpdate), arg0, arg1)
+}
+
+// GetJobTemplate mocks base method.
+func (m *MockStore) GetJobTemplate(arg0 context.Context, arg1 int64) (db.JobTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJobTemplate", arg0, arg1)
+	ret0, _ := ret[0].(db.JobTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJobTemplate indicates an expected call of GetJobTemplate.
+func (mr *MockStoreMockRecorder) GetJobTemplate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobTemplate", reflect.TypeOf((*MockStore)(nil).GetJobTemplate), arg0, arg1)
+}
+
+// GetModerationReview mocks base method.
+func (m *MockStore) GetModerationReview(arg0 context.Context, arg1 int64) (db.ModerationReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetModerationReview", arg0, arg1)
+	ret0, _ := ret[0].(db.ModerationReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetModerationReview indicates an expected call of GetModerationReview.
+func (mr *MockStoreMockRecorder) GetModerationReview(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModerationReview", reflect.TypeOf((*MockStore)(nil).GetModerationReview), arg0, arg1)
+}
+
+// GetNotificationPreference mocks base method.
+func (m *MockStore) GetNotificationPreference(arg0 context.Context, arg1 string) (db.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationPreference", arg0, arg1)
+	ret0, _ := ret[0].(db.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationPreference indicates an expected call of GetNotificationPreference.
+func (mr *MockStoreMockRecorder) GetNotificationPreference(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationPreference", reflect.TypeOf((*MockStore)(nil).GetNotificationPreference), arg0, arg1)
+}
+
 // GetPastExperience mocks base method.
 func (m *MockStore) GetPastExperience(arg0 context.Context, arg1 int64) (db.PastExperience, error) {
 	m.ctrl.T.Helper()
@@ -729,6 +1188,140 @@ func (mr *MockStoreMockRecorder) GetUser(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), arg0, arg1)
 }
 
+// IncrementJobDailyStats mocks base method.
+func (m *MockStore) IncrementJobDailyStats(arg0 context.Context, arg1 db.IncrementJobDailyStatsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementJobDailyStats", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrementJobDailyStats indicates an expected call of IncrementJobDailyStats.
+func (mr *MockStoreMockRecorder) IncrementJobDailyStats(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementJobDailyStats", reflect.TypeOf((*MockStore)(nil).IncrementJobDailyStats), arg0, arg1)
+}
+
+// ListApplicationStatusHistory mocks base method.
+func (m *MockStore) ListApplicationStatusHistory(arg0 context.Context, arg1 db.ListApplicationStatusHistoryParams) ([]db.ApplicationStatusHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListApplicationStatusHistory", arg0, arg1)
+	ret0, _ := ret[0].([]db.ApplicationStatusHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListApplicationStatusHistory indicates an expected call of ListApplicationStatusHistory.
+func (mr *MockStoreMockRecorder) ListApplicationStatusHistory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListApplicationStatusHistory", reflect.TypeOf((*MockStore)(nil).ListApplicationStatusHistory), arg0, arg1)
+}
+
+// ListApplicationStatusHistoryByKeys mocks base method.
+func (m *MockStore) ListApplicationStatusHistoryByKeys(arg0 context.Context, arg1 db.ListApplicationStatusHistoryByKeysParams) ([]db.ApplicationStatusHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListApplicationStatusHistoryByKeys", arg0, arg1)
+	ret0, _ := ret[0].([]db.ApplicationStatusHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListApplicationStatusHistoryByKeys indicates an expected call of ListApplicationStatusHistoryByKeys.
+func (mr *MockStoreMockRecorder) ListApplicationStatusHistoryByKeys(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListApplicationStatusHistoryByKeys", reflect.TypeOf((*MockStore)(nil).ListApplicationStatusHistoryByKeys), arg0, arg1)
+}
+
+// ListCandidateApplicationJobIDs mocks base method.
+func (m *MockStore) ListCandidateApplicationJobIDs(arg0 context.Context, arg1 db.ListCandidateApplicationJobIDsParams) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCandidateApplicationJobIDs", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCandidateApplicationJobIDs indicates an expected call of ListCandidateApplicationJobIDs.
+func (mr *MockStoreMockRecorder) ListCandidateApplicationJobIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCandidateApplicationJobIDs", reflect.TypeOf((*MockStore)(nil).ListCandidateApplicationJobIDs), arg0, arg1)
+}
+
+// ListCandidateApplicationsNewestFirst mocks base method.
+func (m *MockStore) ListCandidateApplicationsNewestFirst(arg0 context.Context, arg1 db.ListCandidateApplicationsNewestFirstParams) ([]db.CandidateApplication, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCandidateApplicationsNewestFirst", arg0, arg1)
+	ret0, _ := ret[0].([]db.CandidateApplication)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCandidateApplicationsNewestFirst indicates an expected call of ListCandidateApplicationsNewestFirst.
+func (mr *MockStoreMockRecorder) ListCandidateApplicationsNewestFirst(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCandidateApplicationsNewestFirst", reflect.TypeOf((*MockStore)(nil).ListCandidateApplicationsNewestFirst), arg0, arg1)
+}
+
+// ListCandidateApplicationsOldestFirst mocks base method.
+func (m *MockStore) ListCandidateApplicationsOldestFirst(arg0 context.Context, arg1 db.ListCandidateApplicationsOldestFirstParams) ([]db.CandidateApplication, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCandidateApplicationsOldestFirst", arg0, arg1)
+	ret0, _ := ret[0].([]db.CandidateApplication)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCandidateApplicationsOldestFirst indicates an expected call of ListCandidateApplicationsOldestFirst.
+func (mr *MockStoreMockRecorder) ListCandidateApplicationsOldestFirst(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCandidateApplicationsOldestFirst", reflect.TypeOf((*MockStore)(nil).ListCandidateApplicationsOldestFirst), arg0, arg1)
+}
+
+// ListCandidateContacts mocks base method.
+func (m *MockStore) ListCandidateContacts(arg0 context.Context, arg1 []int64) ([]db.ListCandidateContactsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCandidateContacts", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListCandidateContactsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCandidateContacts indicates an expected call of ListCandidateContacts.
+func (mr *MockStoreMockRecorder) ListCandidateContacts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCandidateContacts", reflect.TypeOf((*MockStore)(nil).ListCandidateContacts), arg0, arg1)
+}
+
+// ListCandidateIDsByJob mocks base method.
+func (m *MockStore) ListCandidateIDsByJob(arg0 context.Context, arg1 db.ListCandidateIDsByJobParams) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCandidateIDsByJob", arg0, arg1)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCandidateIDsByJob indicates an expected call of ListCandidateIDsByJob.
+func (mr *MockStoreMockRecorder) ListCandidateIDsByJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCandidateIDsByJob", reflect.TypeOf((*MockStore)(nil).ListCandidateIDsByJob), arg0, arg1)
+}
+
+// ListCandidateProfiles mocks base method.
+func (m *MockStore) ListCandidateProfiles(arg0 context.Context, arg1 []int64) ([]db.ListCandidateProfilesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCandidateProfiles", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListCandidateProfilesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCandidateProfiles indicates an expected call of ListCandidateProfiles.
+func (mr *MockStoreMockRecorder) ListCandidateProfiles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCandidateProfiles", reflect.TypeOf((*MockStore)(nil).ListCandidateProfiles), arg0, arg1)
+}
+
 // ListCandidates mocks base method.
 func (m *MockStore) ListCandidates(arg0 context.Context, arg1 db.ListCandidatesParams) ([]db.Candidate, error) {
 	m.ctrl.T.Helper()
@@ -744,6 +1337,66 @@ func (mr *MockStoreMockRecorder) ListCandidates(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCandidates", reflect.TypeOf((*MockStore)(nil).ListCandidates), arg0, arg1)
 }
 
+// ListCandidatesByIDs mocks base method.
+func (m *MockStore) ListCandidatesByIDs(arg0 context.Context, arg1 []int64) ([]db.Candidate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCandidatesByIDs", arg0, arg1)
+	ret0, _ := ret[0].([]db.Candidate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCandidatesByIDs indicates an expected call of ListCandidatesByIDs.
+func (mr *MockStoreMockRecorder) ListCandidatesByIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCandidatesByIDs", reflect.TypeOf((*MockStore)(nil).ListCandidatesByIDs), arg0, arg1)
+}
+
+// ListConversations mocks base method.
+func (m *MockStore) ListConversations(arg0 context.Context, arg1 db.ListConversationsParams) ([]db.ListConversationsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListConversations", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListConversationsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListConversations indicates an expected call of ListConversations.
+func (mr *MockStoreMockRecorder) ListConversations(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListConversations", reflect.TypeOf((*MockStore)(nil).ListConversations), arg0, arg1)
+}
+
+// ListEmployerApplications mocks base method.
+func (m *MockStore) ListEmployerApplications(arg0 context.Context, arg1 db.ListEmployerApplicationsParams) ([]db.EmployerApplication, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEmployerApplications", arg0, arg1)
+	ret0, _ := ret[0].([]db.EmployerApplication)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEmployerApplications indicates an expected call of ListEmployerApplications.
+func (mr *MockStoreMockRecorder) ListEmployerApplications(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEmployerApplications", reflect.TypeOf((*MockStore)(nil).ListEmployerApplications), arg0, arg1)
+}
+
+// ListEmployerJobStats mocks base method.
+func (m *MockStore) ListEmployerJobStats(arg0 context.Context, arg1 db.ListEmployerJobStatsParams) ([]db.ListEmployerJobStatsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEmployerJobStats", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListEmployerJobStatsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEmployerJobStats indicates an expected call of ListEmployerJobStats.
+func (mr *MockStoreMockRecorder) ListEmployerJobStats(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEmployerJobStats", reflect.TypeOf((*MockStore)(nil).ListEmployerJobStats), arg0, arg1)
+}
+
 // ListEmployers mocks base method.
 func (m *MockStore) ListEmployers(arg0 context.Context, arg1 db.ListEmployersParams) ([]db.Employer, error) {
 	m.ctrl.T.Helper()
@@ -759,6 +1412,111 @@ func (mr *MockStoreMockRecorder) ListEmployers(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEmployers", reflect.TypeOf((*MockStore)(nil).ListEmployers), arg0, arg1)
 }
 
+// ListGeocodedLocations mocks base method.
+func (m *MockStore) ListGeocodedLocations(arg0 context.Context, arg1 []string) ([]db.GeocodedLocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGeocodedLocations", arg0, arg1)
+	ret0, _ := ret[0].([]db.GeocodedLocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGeocodedLocations indicates an expected call of ListGeocodedLocations.
+func (mr *MockStoreMockRecorder) ListGeocodedLocations(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGeocodedLocations", reflect.TypeOf((*MockStore)(nil).ListGeocodedLocations), arg0, arg1)
+}
+
+// ListInterviewSlots mocks base method.
+func (m *MockStore) ListInterviewSlots(arg0 context.Context, arg1 int64) ([]db.InterviewSlot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInterviewSlots", arg0, arg1)
+	ret0, _ := ret[0].([]db.InterviewSlot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInterviewSlots indicates an expected call of ListInterviewSlots.
+func (mr *MockStoreMockRecorder) ListInterviewSlots(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInterviewSlots", reflect.TypeOf((*MockStore)(nil).ListInterviewSlots), arg0, arg1)
+}
+
+// ListJobDailyStats mocks base method.
+func (m *MockStore) ListJobDailyStats(arg0 context.Context, arg1 db.ListJobDailyStatsParams) ([]db.JobDailyStat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListJobDailyStats", arg0, arg1)
+	ret0, _ := ret[0].([]db.JobDailyStat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListJobDailyStats indicates an expected call of ListJobDailyStats.
+func (mr *MockStoreMockRecorder) ListJobDailyStats(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJobDailyStats", reflect.TypeOf((*MockStore)(nil).ListJobDailyStats), arg0, arg1)
+}
+
+// ListJobShifts mocks base method.
+func (m *MockStore) ListJobShifts(arg0 context.Context, arg1 string) ([]db.ListJobShiftsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListJobShifts", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListJobShiftsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListJobShifts indicates an expected call of ListJobShifts.
+func (mr *MockStoreMockRecorder) ListJobShifts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJobShifts", reflect.TypeOf((*MockStore)(nil).ListJobShifts), arg0, arg1)
+}
+
+// ListJobTemplates mocks base method.
+func (m *MockStore) ListJobTemplates(arg0 context.Context, arg1 int64) ([]db.JobTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListJobTemplates", arg0, arg1)
+	ret0, _ := ret[0].([]db.JobTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListJobTemplates indicates an expected call of ListJobTemplates.
+func (mr *MockStoreMockRecorder) ListJobTemplates(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJobTemplates", reflect.TypeOf((*MockStore)(nil).ListJobTemplates), arg0, arg1)
+}
+
+// ListMessages mocks base method.
+func (m *MockStore) ListMessages(arg0 context.Context, arg1 db.ListMessagesParams) ([]db.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMessages", arg0, arg1)
+	ret0, _ := ret[0].([]db.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMessages indicates an expected call of ListMessages.
+func (mr *MockStoreMockRecorder) ListMessages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMessages", reflect.TypeOf((*MockStore)(nil).ListMessages), arg0, arg1)
+}
+
+// ListOpenApplicantsByJob mocks base method.
+func (m *MockStore) ListOpenApplicantsByJob(arg0 context.Context, arg1 string) ([]db.ListOpenApplicantsByJobRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOpenApplicantsByJob", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListOpenApplicantsByJobRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOpenApplicantsByJob indicates an expected call of ListOpenApplicantsByJob.
+func (mr *MockStoreMockRecorder) ListOpenApplicantsByJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOpenApplicantsByJob", reflect.TypeOf((*MockStore)(nil).ListOpenApplicantsByJob), arg0, arg1)
+}
+
 // ListPastExperiences mocks base method.
 func (m *MockStore) ListPastExperiences(arg0 context.Context, arg1 db.ListPastExperiencesParams) ([]db.PastExperience, error) {
 	m.ctrl.T.Helper()
@@ -774,6 +1532,200 @@ func (mr *MockStoreMockRecorder) ListPastExperiences(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPastExperiences", reflect.TypeOf((*MockStore)(nil).ListPastExperiences), arg0, arg1)
 }
 
+// ListPastExperiencesByCandidates mocks base method.
+func (m *MockStore) ListPastExperiencesByCandidates(arg0 context.Context, arg1 []int64) ([]db.PastExperience, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPastExperiencesByCandidates", arg0, arg1)
+	ret0, _ := ret[0].([]db.PastExperience)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPastExperiencesByCandidates indicates an expected call of ListPastExperiencesByCandidates.
+func (mr *MockStoreMockRecorder) ListPastExperiencesByCandidates(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPastExperiencesByCandidates", reflect.TypeOf((*MockStore)(nil).ListPastExperiencesByCandidates), arg0, arg1)
+}
+
+// ListPendingModerationReviews mocks base method.
+func (m *MockStore) ListPendingModerationReviews(arg0 context.Context, arg1 db.ListPendingModerationReviewsParams) ([]db.ModerationReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingModerationReviews", arg0, arg1)
+	ret0, _ := ret[0].([]db.ModerationReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingModerationReviews indicates an expected call of ListPendingModerationReviews.
+func (mr *MockStoreMockRecorder) ListPendingModerationReviews(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingModerationReviews", reflect.TypeOf((*MockStore)(nil).ListPendingModerationReviews), arg0, arg1)
+}
+
+// ListShiftClaims mocks base method.
+func (m *MockStore) ListShiftClaims(arg0 context.Context, arg1 int64) ([]db.ShiftClaim, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListShiftClaims", arg0, arg1)
+	ret0, _ := ret[0].([]db.ShiftClaim)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListShiftClaims indicates an expected call of ListShiftClaims.
+func (mr *MockStoreMockRecorder) ListShiftClaims(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListShiftClaims", reflect.TypeOf((*MockStore)(nil).ListShiftClaims), arg0, arg1)
+}
+
+// LockJobApplications mocks base method.
+func (m *MockStore) LockJobApplications(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockJobApplications", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockJobApplications indicates an expected call of LockJobApplications.
+func (mr *MockStoreMockRecorder) LockJobApplications(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockJobApplications", reflect.TypeOf((*MockStore)(nil).LockJobApplications), arg0, arg1)
+}
+
+// MarkMessagesRead mocks base method.
+func (m *MockStore) MarkMessagesRead(arg0 context.Context, arg1 db.MarkMessagesReadParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkMessagesRead", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkMessagesRead indicates an expected call of MarkMessagesRead.
+func (mr *MockStoreMockRecorder) MarkMessagesRead(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkMessagesRead", reflect.TypeOf((*MockStore)(nil).MarkMessagesRead), arg0, arg1)
+}
+
+// ReopenCandidateApplication mocks base method.
+func (m *MockStore) ReopenCandidateApplication(arg0 context.Context, arg1 db.ReopenCandidateApplicationParams) (db.CandidateApplication, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReopenCandidateApplication", arg0, arg1)
+	ret0, _ := ret[0].(db.CandidateApplication)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReopenCandidateApplication indicates an expected call of ReopenCandidateApplication.
+func (mr *MockStoreMockRecorder) ReopenCandidateApplication(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReopenCandidateApplication", reflect.TypeOf((*MockStore)(nil).ReopenCandidateApplication), arg0, arg1)
+}
+
+// RescheduleInterview mocks base method.
+func (m *MockStore) RescheduleInterview(arg0 context.Context, arg1 db.RescheduleInterviewParams) (db.Interview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RescheduleInterview", arg0, arg1)
+	ret0, _ := ret[0].(db.Interview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RescheduleInterview indicates an expected call of RescheduleInterview.
+func (mr *MockStoreMockRecorder) RescheduleInterview(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RescheduleInterview", reflect.TypeOf((*MockStore)(nil).RescheduleInterview), arg0, arg1)
+}
+
+// RescheduleInterviewTx mocks base method.
+func (m *MockStore) RescheduleInterviewTx(arg0 context.Context, arg1 db.RescheduleInterviewTxParams) (db.InterviewTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RescheduleInterviewTx", arg0, arg1)
+	ret0, _ := ret[0].(db.InterviewTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RescheduleInterviewTx indicates an expected call of RescheduleInterviewTx.
+func (mr *MockStoreMockRecorder) RescheduleInterviewTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RescheduleInterviewTx", reflect.TypeOf((*MockStore)(nil).RescheduleInterviewTx), arg0, arg1)
+}
+
+// ResolveModerationReview mocks base method.
+func (m *MockStore) ResolveModerationReview(arg0 context.Context, arg1 db.ResolveModerationReviewParams) (db.ModerationReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveModerationReview", arg0, arg1)
+	ret0, _ := ret[0].(db.ModerationReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveModerationReview indicates an expected call of ResolveModerationReview.
+func (mr *MockStoreMockRecorder) ResolveModerationReview(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveModerationReview", reflect.TypeOf((*MockStore)(nil).ResolveModerationReview), arg0, arg1)
+}
+
+// ScheduleInterview mocks base method.
+func (m *MockStore) ScheduleInterview(arg0 context.Context, arg1 db.ScheduleInterviewParams) (db.Interview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleInterview", arg0, arg1)
+	ret0, _ := ret[0].(db.Interview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScheduleInterview indicates an expected call of ScheduleInterview.
+func (mr *MockStoreMockRecorder) ScheduleInterview(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleInterview", reflect.TypeOf((*MockStore)(nil).ScheduleInterview), arg0, arg1)
+}
+
+// ScheduleInterviewTx mocks base method.
+func (m *MockStore) ScheduleInterviewTx(arg0 context.Context, arg1 db.ScheduleInterviewTxParams) (db.InterviewTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleInterviewTx", arg0, arg1)
+	ret0, _ := ret[0].(db.InterviewTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScheduleInterviewTx indicates an expected call of ScheduleInterviewTx.
+func (mr *MockStoreMockRecorder) ScheduleInterviewTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleInterviewTx", reflect.TypeOf((*MockStore)(nil).ScheduleInterviewTx), arg0, arg1)
+}
+
+// SendMessageTx mocks base method.
+func (m *MockStore) SendMessageTx(arg0 context.Context, arg1 db.SendMessageTxParams) (db.SendMessageTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMessageTx", arg0, arg1)
+	ret0, _ := ret[0].(db.SendMessageTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendMessageTx indicates an expected call of SendMessageTx.
+func (mr *MockStoreMockRecorder) SendMessageTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMessageTx", reflect.TypeOf((*MockStore)(nil).SendMessageTx), arg0, arg1)
+}
+
+// TransitionApplicationStatusTx mocks base method.
+func (m *MockStore) TransitionApplicationStatusTx(arg0 context.Context, arg1 db.TransitionApplicationStatusTxParams) (db.TransitionApplicationStatusTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransitionApplicationStatusTx", arg0, arg1)
+	ret0, _ := ret[0].(db.TransitionApplicationStatusTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransitionApplicationStatusTx indicates an expected call of TransitionApplicationStatusTx.
+func (mr *MockStoreMockRecorder) TransitionApplicationStatusTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransitionApplicationStatusTx", reflect.TypeOf((*MockStore)(nil).TransitionApplicationStatusTx), arg0, arg1)
+}
+
 // UpdateCandidate mocks base method.
 func (m *MockStore) UpdateCandidate(arg0 context.Context, arg1 db.UpdateCandidateParams) (db.Candidate, error) {
 	m.ctrl.T.Helper()
@@ -819,11 +1771,12 @@ func (mr *MockStoreMockRecorder) UpdateCandidateApplicationStatus(arg0, arg1 int
 }
 
 // UpdateCandidateApplicationStatusTx mocks base method.
-func (m *MockStore) UpdateCandidateApplicationStatusTx(arg0 context.Context, arg1 db.UpdateCandidateApplicationStatusTxParams) error {
+func (m *MockStore) UpdateCandidateApplicationStatusTx(arg0 context.Context, arg1 db.UpdateCandidateApplicationStatusTxParams) (db.TransitionApplicationStatusTxResult, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UpdateCandidateApplicationStatusTx", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(db.TransitionApplicationStatusTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // UpdateCandidateApplicationStatusTx indicates an expected call of UpdateCandidateApplicationStatusTx.
@@ -847,6 +1800,20 @@ func (mr *MockStoreMockRecorder) UpdateCandidateTx(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCandidateTx", reflect.TypeOf((*MockStore)(nil).UpdateCandidateTx), arg0, arg1)
 }
 
+// UpdateConversationLastMessage mocks base method.
+func (m *MockStore) UpdateConversationLastMessage(arg0 context.Context, arg1 db.UpdateConversationLastMessageParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateConversationLastMessage", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateConversationLastMessage indicates an expected call of UpdateConversationLastMessage.
+func (mr *MockStoreMockRecorder) UpdateConversationLastMessage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateConversationLastMessage", reflect.TypeOf((*MockStore)(nil).UpdateConversationLastMessage), arg0, arg1)
+}
+
 // UpdateEmployer mocks base method.
 func (m *MockStore) UpdateEmployer(arg0 context.Context, arg1 db.UpdateEmployerParams) (db.Employer, error) {
 	m.ctrl.T.Helper()
@@ -892,11 +1859,12 @@ func (mr *MockStoreMockRecorder) UpdateEmployerApplicationStatus(arg0, arg1 inte
 }
 
 // UpdateEmployerApplicationStatusTx mocks base method.
-func (m *MockStore) UpdateEmployerApplicationStatusTx(arg0 context.Context, arg1 db.UpdateEmployerApplicationStatusTxParams) error {
+func (m *MockStore) UpdateEmployerApplicationStatusTx(arg0 context.Context, arg1 db.UpdateEmployerApplicationStatusTxParams) (db.TransitionApplicationStatusTxResult, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UpdateEmployerApplicationStatusTx", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(db.TransitionApplicationStatusTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // UpdateEmployerApplicationStatusTx indicates an expected call of UpdateEmployerApplicationStatusTx.
@@ -905,6 +1873,21 @@ func (mr *MockStoreMockRecorder) UpdateEmployerApplicationStatusTx(arg0, arg1 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEmployerApplicationStatusTx", reflect.TypeOf((*MockStore)(nil).UpdateEmployerApplicationStatusTx), arg0, arg1)
 }
 
+// UpdateJobTemplate mocks base method.
+func (m *MockStore) UpdateJobTemplate(arg0 context.Context, arg1 db.UpdateJobTemplateParams) (db.JobTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateJobTemplate", arg0, arg1)
+	ret0, _ := ret[0].(db.JobTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateJobTemplate indicates an expected call of UpdateJobTemplate.
+func (mr *MockStoreMockRecorder) UpdateJobTemplate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateJobTemplate", reflect.TypeOf((*MockStore)(nil).UpdateJobTemplate), arg0, arg1)
+}
+
 // UpdatePastExperience mocks base method.
 func (m *MockStore) UpdatePastExperience(arg0 context.Context, arg1 db.UpdatePastExperienceParams) (db.PastExperience, error) {
 	m.ctrl.T.Helper()
@@ -964,3 +1947,106 @@ func (mr *MockStoreMockRecorder) UpdateVerifyEmail(arg0, arg1 interface{}) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVerifyEmail", reflect.TypeOf((*MockStore)(nil).UpdateVerifyEmail), arg0, arg1)
 }
+
+// UpsertCandidateSwipe mocks base method.
+func (m *MockStore) UpsertCandidateSwipe(arg0 context.Context, arg1 db.UpsertCandidateSwipeParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertCandidateSwipe", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertCandidateSwipe indicates an expected call of UpsertCandidateSwipe.
+func (mr *MockStoreMockRecorder) UpsertCandidateSwipe(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertCandidateSwipe", reflect.TypeOf((*MockStore)(nil).UpsertCandidateSwipe), arg0, arg1)
+}
+
+// UpsertConversation mocks base method.
+func (m *MockStore) UpsertConversation(arg0 context.Context, arg1 db.UpsertConversationParams) (db.Conversation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertConversation", arg0, arg1)
+	ret0, _ := ret[0].(db.Conversation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertConversation indicates an expected call of UpsertConversation.
+func (mr *MockStoreMockRecorder) UpsertConversation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertConversation", reflect.TypeOf((*MockStore)(nil).UpsertConversation), arg0, arg1)
+}
+
+// UpsertEmployerSwipe mocks base method.
+func (m *MockStore) UpsertEmployerSwipe(arg0 context.Context, arg1 db.UpsertEmployerSwipeParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertEmployerSwipe", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertEmployerSwipe indicates an expected call of UpsertEmployerSwipe.
+func (mr *MockStoreMockRecorder) UpsertEmployerSwipe(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertEmployerSwipe", reflect.TypeOf((*MockStore)(nil).UpsertEmployerSwipe), arg0, arg1)
+}
+
+// UpsertGeocodedLocation mocks base method.
+func (m *MockStore) UpsertGeocodedLocation(arg0 context.Context, arg1 db.UpsertGeocodedLocationParams) (db.GeocodedLocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertGeocodedLocation", arg0, arg1)
+	ret0, _ := ret[0].(db.GeocodedLocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertGeocodedLocation indicates an expected call of UpsertGeocodedLocation.
+func (mr *MockStoreMockRecorder) UpsertGeocodedLocation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertGeocodedLocation", reflect.TypeOf((*MockStore)(nil).UpsertGeocodedLocation), arg0, arg1)
+}
+
+// UpsertNotificationPreference mocks base method.
+func (m *MockStore) UpsertNotificationPreference(arg0 context.Context, arg1 db.UpsertNotificationPreferenceParams) (db.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertNotificationPreference", arg0, arg1)
+	ret0, _ := ret[0].(db.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertNotificationPreference indicates an expected call of UpsertNotificationPreference.
+func (mr *MockStoreMockRecorder) UpsertNotificationPreference(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertNotificationPreference", reflect.TypeOf((*MockStore)(nil).UpsertNotificationPreference), arg0, arg1)
+}
+
+// WithdrawCandidateApplication mocks base method.
+func (m *MockStore) WithdrawCandidateApplication(arg0 context.Context, arg1 db.WithdrawCandidateApplicationParams) (db.CandidateApplication, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithdrawCandidateApplication", arg0, arg1)
+	ret0, _ := ret[0].(db.CandidateApplication)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WithdrawCandidateApplication indicates an expected call of WithdrawCandidateApplication.
+func (mr *MockStoreMockRecorder) WithdrawCandidateApplication(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithdrawCandidateApplication", reflect.TypeOf((*MockStore)(nil).WithdrawCandidateApplication), arg0, arg1)
+}
+
+// WithdrawCandidateApplicationTx mocks base method.
+func (m *MockStore) WithdrawCandidateApplicationTx(arg0 context.Context, arg1 db.WithdrawCandidateApplicationTxParams) (db.TransitionApplicationStatusTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithdrawCandidateApplicationTx", arg0, arg1)
+	ret0, _ := ret[0].(db.TransitionApplicationStatusTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WithdrawCandidateApplicationTx indicates an expected call of WithdrawCandidateApplicationTx.
+func (mr *MockStoreMockRecorder) WithdrawCandidateApplicationTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithdrawCandidateApplicationTx", reflect.TypeOf((*MockStore)(nil).WithdrawCandidateApplicationTx), arg0, arg1)
+}
@@ -0,0 +1,34 @@
+-- name: CreateApplicationStatusHistory :one
+INSERT INTO application_status_history (
+    application_kind,
+    candidate_id,
+    employer_id,
+    job_doc_id,
+    actor_role,
+    actor_id,
+    from_status,
+    to_status,
+    reason
+) VALUES (
+             $1, $2, $3, $4, $5, $6, $7, $8, $9
+         ) RETURNING *;
+
+-- name: ListApplicationStatusHistory :many
+SELECT * FROM application_status_history
+WHERE application_kind = sqlc.arg(application_kind)
+  AND (sqlc.narg(candidate_id)::bigint IS NULL OR candidate_id = sqlc.narg(candidate_id))
+  AND (sqlc.narg(employer_id)::bigint IS NULL OR employer_id = sqlc.narg(employer_id))
+  AND (sqlc.narg(job_doc_id)::varchar IS NULL OR job_doc_id = sqlc.narg(job_doc_id))
+ORDER BY created_at, id;
+
+-- name: ListApplicationStatusHistoryByKeys :many
+SELECT * FROM application_status_history
+WHERE application_kind = sqlc.arg(application_kind)
+  AND (candidate_id, employer_id, job_doc_id) IN (
+    SELECT t.candidate_id, t.employer_id, t.job_doc_id
+    FROM unnest(sqlc.arg(candidate_ids)::bigint[], sqlc.arg(employer_ids)::bigint[], sqlc.arg(job_doc_ids)::varchar[]) AS t (candidate_id, employer_id, job_doc_id))
+ORDER BY created_at, id;
+
+-- name: DeleteApplicationStatusHistory :exec
+DELETE FROM application_status_history
+WHERE application_kind = $1 AND candidate_id = $2 AND employer_id = $3 AND job_doc_id = $4;
@@ -29,6 +29,11 @@ ORDER BY id
 LIMIT $2
     OFFSET $3;
 
+-- name: ListCandidatesByIDs :many
+SELECT * FROM candidates
+WHERE id = ANY(sqlc.arg(ids)::bigint[])
+ORDER BY id;
+
 -- name: UpdateCandidate :one
 UPDATE candidates
 SET full_name = COALESCE(sqlc.narg(full_name), full_name),
@@ -55,3 +60,19 @@ WHERE id = $1;
 -- name: GetCandidateIdByUsername :one
 SELECT id FROM candidates
 WHERE username = $1 LIMIT 1;
+
+-- name: ListCandidateContacts :many
+SELECT c.id, c.full_name, u.email,
+       COALESCE(np.application_decisions, true)::bool AS application_decisions
+FROM candidates c
+JOIN users u ON u.username = c.username
+LEFT JOIN notification_preferences np ON np.username = c.username
+WHERE c.id = ANY(sqlc.arg(ids)::bigint[])
+ORDER BY c.id;
+
+-- name: ListCandidateProfiles :many
+SELECT c.id, c.full_name, u.email, c.phone_number, c.location, c.education, c.skill_set, c.certificates
+FROM candidates c
+JOIN users u ON u.username = c.username
+WHERE c.id = ANY(sqlc.arg(ids)::bigint[])
+ORDER BY c.id;
@@ -13,41 +13,117 @@ INSERT INTO candidate_applications (
 UPDATE candidate_applications
 SET application_status = COALESCE(sqlc.narg(application_status), application_status),
     elasticsearch_doc_id = COALESCE(sqlc.narg(elasticsearch_doc_id), elasticsearch_doc_id)
-WHERE candidate_id = $1 AND employer_id = $2
+WHERE candidate_id = $1 AND job_doc_id = $2
 RETURNING *;
 
 -- name: UpdateCandidateApplicationStatus :exec
 UPDATE candidate_applications
 SET application_status = $3
-WHERE candidate_id = $1 AND employer_id = $2;
+WHERE candidate_id = $1 AND job_doc_id = $2;
 
--- name: DeleteCandidateApplication :exec
+-- name: WithdrawCandidateApplication :one
+UPDATE candidate_applications
+SET withdrawal_reason = $3,
+    withdrawal_note = $4,
+    withdrawn_at = now()
+WHERE candidate_id = $1 AND job_doc_id = $2
+RETURNING *;
+
+-- name: ReopenCandidateApplication :one
+UPDATE candidate_applications
+SET employer_id = $3,
+    elasticsearch_doc_id = $4,
+    application_status = $5,
+    withdrawal_reason = NULL,
+    withdrawal_note = '',
+    withdrawn_at = NULL,
+    created_at = now()
+WHERE candidate_id = $1 AND job_doc_id = $2
+RETURNING *;
+
+-- name: DeleteCandidateApplication :one
 DELETE FROM candidate_applications
-WHERE candidate_id = $1 AND employer_id = $2;
+WHERE candidate_id = $1 AND job_doc_id = $2
+RETURNING *;
+
+-- name: GetCandidateApplication :one
+SELECT * FROM candidate_applications
+WHERE candidate_id = $1 AND job_doc_id = $2 LIMIT 1;
 
 -- name: GetCandidateApplicationsByEmployer :many
 SELECT * FROM candidate_applications
 WHERE employer_id = $1
 ORDER BY created_at DESC;
 
--- name: GetCandidateApplicationsByStatusPending :many
+-- name: ListCandidateApplicationsNewestFirst :many
 SELECT * FROM candidate_applications
-WHERE application_status = 'pending' AND candidate_id = $1
-ORDER BY created_at DESC;
+WHERE employer_id = sqlc.arg(employer_id)
+  AND application_status = ANY(sqlc.arg(statuses)::text[]::application_status[])
+  AND (sqlc.narg(job_doc_ids)::varchar[] IS NULL OR job_doc_id = ANY(sqlc.narg(job_doc_ids)::varchar[]))
+  AND (sqlc.narg(created_after)::timestamptz IS NULL OR created_at >= sqlc.narg(created_after))
+  AND (sqlc.narg(created_before)::timestamptz IS NULL OR created_at < sqlc.narg(created_before))
+  AND (created_at, candidate_id, job_doc_id) < (
+    COALESCE(sqlc.narg(cursor_created_at)::timestamptz, 'infinity'),
+    COALESCE(sqlc.narg(cursor_id)::bigint, 0),
+    COALESCE(sqlc.narg(cursor_job_doc_id)::varchar, ''))
+ORDER BY created_at DESC, candidate_id DESC, job_doc_id DESC
+LIMIT sqlc.arg('limit');
 
--- name: GetCandidateApplicationsByStatusSubmitted :many
+-- name: ListCandidateApplicationsOldestFirst :many
 SELECT * FROM candidate_applications
-WHERE application_status = 'submitted' AND candidate_id = $1
-ORDER BY created_at DESC;
+WHERE employer_id = sqlc.arg(employer_id)
+  AND application_status = ANY(sqlc.arg(statuses)::text[]::application_status[])
+  AND (sqlc.narg(job_doc_ids)::varchar[] IS NULL OR job_doc_id = ANY(sqlc.narg(job_doc_ids)::varchar[]))
+  AND (sqlc.narg(created_after)::timestamptz IS NULL OR created_at >= sqlc.narg(created_after))
+  AND (sqlc.narg(created_before)::timestamptz IS NULL OR created_at < sqlc.narg(created_before))
+  AND (created_at, candidate_id, job_doc_id) > (
+    COALESCE(sqlc.narg(cursor_created_at)::timestamptz, '-infinity'),
+    COALESCE(sqlc.narg(cursor_id)::bigint, 0),
+    COALESCE(sqlc.narg(cursor_job_doc_id)::varchar, ''))
+ORDER BY created_at, candidate_id, job_doc_id
+LIMIT sqlc.arg('limit');
 
+-- name: ListOpenApplicantsByJob :many
+SELECT ca.candidate_id, c.full_name, u.email
+FROM candidate_applications ca
+JOIN candidates c ON c.id = ca.candidate_id
+JOIN users u ON u.username = c.username
+WHERE ca.job_doc_id = $1
+  AND ca.application_status IN ('pending', 'submitted')
+ORDER BY ca.created_at DESC;
 
--- name: GetCandidateApplicationsByStatusAccepted :many
-SELECT * FROM candidate_applications
-WHERE application_status = 'accepted' AND candidate_id = $1
-ORDER BY created_at DESC;
+-- name: CountCandidateApplicationsByJob :one
+SELECT COUNT(*) FROM candidate_applications
+WHERE job_doc_id = $1 AND application_status <> 'withdrawn';
 
+-- name: CountApplicantsByJobs :many
+SELECT job_doc_id,
+       COUNT(*) FILTER (WHERE application_status <> 'withdrawn') AS applicants,
+       COUNT(*) FILTER (WHERE application_status IN ('pending', 'submitted')) AS open_applicants
+FROM candidate_applications
+WHERE job_doc_id = ANY(sqlc.arg(job_doc_ids)::varchar[])
+GROUP BY job_doc_id;
 
--- name: GetCandidateApplicationsByStatusRejected :many
+-- name: LockJobApplications :exec
+SELECT pg_advisory_xact_lock(hashtext(sqlc.arg(job_doc_id)::text));
+
+-- name: GetCandidateApplicationForUpdate :one
 SELECT * FROM candidate_applications
-WHERE application_status = 'rejected' AND candidate_id = $1
-ORDER BY created_at DESC;
+WHERE candidate_id = $1 AND job_doc_id = $2 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListCandidateApplicationJobIDs :many
+SELECT DISTINCT job_doc_id FROM candidate_applications
+WHERE employer_id = sqlc.arg(employer_id)
+  AND (CASE WHEN sqlc.narg(statuses)::text[] IS NULL THEN application_status <> 'withdrawn'
+    ELSE application_status = ANY(sqlc.narg(statuses)::text[]::application_status[]) END)
+  AND (sqlc.narg(created_after)::timestamptz IS NULL OR created_at >= sqlc.narg(created_after))
+  AND (sqlc.narg(created_before)::timestamptz IS NULL OR created_at < sqlc.narg(created_before))
+ORDER BY job_doc_id;
+
+-- name: ListCandidateIDsByJob :many
+SELECT candidate_id FROM candidate_applications
+WHERE job_doc_id = sqlc.arg(job_doc_id)
+  AND employer_id = sqlc.arg(employer_id)
+  AND application_status = ANY(sqlc.arg(statuses)::text[]::application_status[])
+ORDER BY candidate_id;
@@ -0,0 +1,51 @@
+-- name: UpsertConversation :one
+INSERT INTO conversations (
+    candidate_id,
+    employer_id
+) VALUES (
+             $1, $2
+         )
+ON CONFLICT (candidate_id, employer_id)
+DO UPDATE SET candidate_id = EXCLUDED.candidate_id
+RETURNING *;
+
+-- name: GetConversation :one
+SELECT * FROM conversations
+WHERE id = $1 LIMIT 1;
+
+-- name: UpdateConversationLastMessage :exec
+UPDATE conversations
+SET last_message_at = $2
+WHERE id = $1;
+
+-- name: ListConversations :many
+SELECT c.id, c.candidate_id, c.employer_id, c.last_message_at, c.created_at,
+       (SELECT COUNT(*) FROM messages m
+        WHERE m.conversation_id = c.id
+          AND m.sender_role <> sqlc.arg(reader_role)::role
+          AND m.read_at IS NULL)::bigint AS unread_count
+FROM conversations c
+WHERE (sqlc.narg(candidate_id)::bigint IS NULL OR c.candidate_id = sqlc.narg(candidate_id))
+  AND (sqlc.narg(employer_id)::bigint IS NULL OR c.employer_id = sqlc.narg(employer_id))
+ORDER BY c.last_message_at DESC NULLS LAST, c.id DESC;
+
+-- name: CanMessage :one
+-- Candidates and employers can message each other once an application
+-- between them has been accepted, or once they have matched: the employer
+-- swiped right on the candidate and the candidate applied to one of their
+-- jobs.
+SELECT (
+    EXISTS (
+        SELECT 1 FROM candidate_applications ca
+        WHERE ca.candidate_id = $1 AND ca.employer_id = $2 AND ca.application_status = 'accepted'
+    ) OR EXISTS (
+        SELECT 1 FROM employer_applications ea
+        WHERE ea.candidate_id = $1 AND ea.employer_id = $2 AND ea.application_status = 'accepted'
+    ) OR EXISTS (
+        SELECT 1 FROM employer_swipes es
+        JOIN candidate_applications ca ON ca.candidate_id = es.candidate_id AND ca.employer_id = es.employer_id
+        WHERE es.candidate_id = $1 AND es.employer_id = $2
+          AND es.swipe = 'accept'
+          AND ca.application_status NOT IN ('rejected', 'withdrawn')
+    )
+)::boolean AS allowed;
@@ -48,3 +48,8 @@ WHERE username = $1 LIMIT 1;
 UPDATE employers
 SET job_listings = array_append(job_listings, sqlc.narg(job_id))
 WHERE id = $1;
+
+-- name: ClearEmployerDescription :exec
+UPDATE employers
+SET business_description = ''
+WHERE id = $1;
@@ -4,9 +4,11 @@ INSERT INTO employer_applications (
     candidate_id,
     message,
     application_status,
-    created_at
+    created_at,
+    job_doc_id,
+    expires_at
 ) VALUES (
-             $1, $2, $3, $4, $5
+             $1, $2, $3, $4, $5, $6, $7
          ) RETURNING *;
 
 -- name: UpdateEmployerApplication :one
@@ -29,22 +31,27 @@ SELECT * FROM employer_applications
 WHERE candidate_id = $1
 ORDER BY created_at DESC;
 
--- name: GetEmployerApplicationsByStatusPending :many
+-- name: GetEmployerApplication :one
 SELECT * FROM employer_applications
-WHERE application_status = 'pending' AND candidate_id = $1
-ORDER BY created_at DESC;
-
--- name: GetEmployerApplicationsByStatusSubmitted :many
-SELECT * FROM employer_applications
-WHERE application_status = 'submitted' AND candidate_id = $1
-ORDER BY created_at DESC;
+WHERE employer_id = $1 AND candidate_id = $2 LIMIT 1;
 
--- name: GetEmployerApplicationsByStatusAccepted :many
+-- name: GetEmployerApplicationForUpdate :one
 SELECT * FROM employer_applications
-WHERE application_status = 'accepted' AND candidate_id = $1
-ORDER BY created_at DESC;
+WHERE employer_id = $1 AND candidate_id = $2 LIMIT 1
+FOR NO KEY UPDATE;
 
--- name: GetEmployerApplicationsByStatusRejected :many
+-- name: ListEmployerApplications :many
 SELECT * FROM employer_applications
-WHERE application_status = 'rejected' AND candidate_id = $1
-ORDER BY created_at DESC;
+WHERE candidate_id = sqlc.arg(candidate_id)
+  AND (sqlc.narg(statuses)::text[] IS NULL OR application_status = ANY(sqlc.narg(statuses)::text[]::application_status[]))
+  AND (sqlc.narg(created_after)::timestamptz IS NULL OR created_at >= sqlc.narg(created_after))
+  AND (sqlc.narg(created_before)::timestamptz IS NULL OR created_at < sqlc.narg(created_before))
+  AND (sqlc.narg(cursor_created_at)::timestamptz IS NULL
+    OR (sqlc.arg(oldest_first)::boolean AND (created_at, employer_id) > (sqlc.narg(cursor_created_at), sqlc.narg(cursor_id)::bigint))
+    OR (NOT sqlc.arg(oldest_first)::boolean AND (created_at, employer_id) < (sqlc.narg(cursor_created_at), sqlc.narg(cursor_id)::bigint)))
+ORDER BY
+    CASE WHEN sqlc.arg(oldest_first)::boolean THEN created_at END,
+    CASE WHEN sqlc.arg(oldest_first)::boolean THEN employer_id END,
+    created_at DESC,
+    employer_id DESC
+LIMIT sqlc.arg('limit');
@@ -0,0 +1,21 @@
+-- name: GetGeocodedLocation :one
+SELECT * FROM geocoded_locations
+WHERE address = $1 LIMIT 1;
+
+-- name: ListGeocodedLocations :many
+SELECT * FROM geocoded_locations
+WHERE address = ANY(sqlc.arg(addresses)::varchar[]);
+
+-- name: UpsertGeocodedLocation :one
+INSERT INTO geocoded_locations (
+    address,
+    latitude,
+    longitude
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (address) DO UPDATE SET
+    latitude = EXCLUDED.latitude,
+    longitude = EXCLUDED.longitude,
+    geocoded_at = now()
+RETURNING *;
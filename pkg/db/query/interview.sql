@@ -0,0 +1,69 @@
+-- name: CreateInterview :one
+INSERT INTO interviews (
+    candidate_id,
+    job_doc_id,
+    employer_id,
+    duration_minutes,
+    location,
+    notes
+) VALUES (
+             $1, $2, $3, $4, $5, $6
+         ) RETURNING *;
+
+-- name: GetInterview :one
+SELECT * FROM interviews
+WHERE id = $1 LIMIT 1;
+
+-- name: GetInterviewForUpdate :one
+SELECT * FROM interviews
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ScheduleInterview :one
+UPDATE interviews
+SET status = 'scheduled',
+    scheduled_at = $2,
+    sequence = sequence + 1,
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: RescheduleInterview :one
+UPDATE interviews
+SET status = 'proposed',
+    scheduled_at = NULL,
+    duration_minutes = COALESCE(sqlc.narg(duration_minutes), duration_minutes),
+    location = COALESCE(sqlc.narg(location), location),
+    sequence = sequence + 1,
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: CancelInterview :one
+UPDATE interviews
+SET status = 'cancelled',
+    sequence = sequence + 1,
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateInterviewSlot :one
+INSERT INTO interview_slots (
+    interview_id,
+    starts_at
+) VALUES (
+             $1, $2
+         ) RETURNING *;
+
+-- name: GetInterviewSlot :one
+SELECT * FROM interview_slots
+WHERE id = $1 AND interview_id = $2 LIMIT 1;
+
+-- name: ListInterviewSlots :many
+SELECT * FROM interview_slots
+WHERE interview_id = $1
+ORDER BY starts_at;
+
+-- name: DeleteInterviewSlots :exec
+DELETE FROM interview_slots
+WHERE interview_id = $1;
@@ -0,0 +1,52 @@
+-- name: CreateJobShift :one
+INSERT INTO job_shifts (
+    job_id,
+    employer_id,
+    starts_at,
+    ends_at,
+    timezone,
+    headcount
+) VALUES (
+             $1, $2, $3, $4, $5, $6
+         ) RETURNING *;
+
+-- name: GetJobShift :one
+SELECT * FROM job_shifts
+WHERE id = $1 LIMIT 1;
+
+-- name: GetJobShiftForUpdate :one
+SELECT * FROM job_shifts
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListJobShifts :many
+SELECT s.*,
+       (SELECT COUNT(*) FROM shift_claims c WHERE c.shift_id = s.id)::int AS claimed
+FROM job_shifts s
+WHERE s.job_id = $1
+ORDER BY s.starts_at;
+
+-- name: DeleteJobShift :exec
+DELETE FROM job_shifts
+WHERE id = $1;
+
+-- name: CountShiftClaims :one
+SELECT COUNT(*) FROM shift_claims
+WHERE shift_id = $1;
+
+-- name: CreateShiftClaim :one
+INSERT INTO shift_claims (
+    shift_id,
+    candidate_id
+) VALUES (
+             $1, $2
+         ) RETURNING *;
+
+-- name: DeleteShiftClaim :exec
+DELETE FROM shift_claims
+WHERE shift_id = $1 AND candidate_id = $2;
+
+-- name: ListShiftClaims :many
+SELECT * FROM shift_claims
+WHERE shift_id = $1
+ORDER BY created_at;
@@ -0,0 +1,46 @@
+-- name: IncrementJobDailyStats :exec
+INSERT INTO job_daily_stats (
+    job_id,
+    employer_id,
+    day,
+    views,
+    impressions,
+    right_swipes,
+    left_swipes,
+    applications
+)
+SELECT t.job_id,
+       t.employer_id,
+       sqlc.arg(day)::date,
+       sqlc.arg(views)::bigint,
+       sqlc.arg(impressions)::bigint,
+       sqlc.arg(right_swipes)::bigint,
+       sqlc.arg(left_swipes)::bigint,
+       sqlc.arg(applications)::bigint
+FROM unnest(sqlc.arg(job_ids)::varchar[], sqlc.arg(employer_ids)::bigint[]) AS t (job_id, employer_id)
+WHERE EXISTS (SELECT 1 FROM employers WHERE employers.id = t.employer_id)
+ON CONFLICT (job_id, day) DO UPDATE SET
+    views = job_daily_stats.views + EXCLUDED.views,
+    impressions = job_daily_stats.impressions + EXCLUDED.impressions,
+    right_swipes = job_daily_stats.right_swipes + EXCLUDED.right_swipes,
+    left_swipes = job_daily_stats.left_swipes + EXCLUDED.left_swipes,
+    applications = job_daily_stats.applications + EXCLUDED.applications;
+
+-- name: ListEmployerJobStats :many
+SELECT job_id,
+       SUM(views)::bigint AS views,
+       SUM(impressions)::bigint AS impressions,
+       SUM(right_swipes)::bigint AS right_swipes,
+       SUM(left_swipes)::bigint AS left_swipes,
+       SUM(applications)::bigint AS applications
+FROM job_daily_stats
+WHERE employer_id = sqlc.arg(employer_id)
+  AND day BETWEEN sqlc.arg(from_day) AND sqlc.arg(to_day)
+GROUP BY job_id
+ORDER BY job_id;
+
+-- name: ListJobDailyStats :many
+SELECT * FROM job_daily_stats
+WHERE job_id = sqlc.arg(job_id)
+  AND day BETWEEN sqlc.arg(from_day) AND sqlc.arg(to_day)
+ORDER BY day;
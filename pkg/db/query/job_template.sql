@@ -0,0 +1,42 @@
+-- name: CreateJobTemplate :one
+INSERT INTO job_templates (
+    employer_id,
+    name,
+    title,
+    description,
+    industry,
+    employment_type,
+    wage,
+    tips,
+    job_application
+) VALUES (
+             $1, $2, $3, $4, $5, $6, $7, $8, $9
+         ) RETURNING *;
+
+-- name: GetJobTemplate :one
+SELECT * FROM job_templates
+WHERE id = $1 LIMIT 1;
+
+-- name: ListJobTemplates :many
+SELECT * FROM job_templates
+WHERE employer_id = $1
+ORDER BY name;
+
+-- name: UpdateJobTemplate :one
+UPDATE job_templates
+SET name = COALESCE(sqlc.narg(name), name),
+    title = COALESCE(sqlc.narg(title), title),
+    description = COALESCE(sqlc.narg(description), description),
+    industry = COALESCE(sqlc.narg(industry), industry),
+    employment_type = COALESCE(sqlc.narg(employment_type), employment_type),
+    wage = COALESCE(sqlc.narg(wage), wage),
+    tips = COALESCE(sqlc.narg(tips), tips),
+    job_application = COALESCE(sqlc.narg(job_application), job_application),
+    updated_at = now()
+WHERE
+    id = $1
+RETURNING *;
+
+-- name: DeleteJobTemplate :exec
+DELETE FROM job_templates
+WHERE id = $1;
@@ -0,0 +1,24 @@
+-- name: CreateMessage :one
+INSERT INTO messages (
+    conversation_id,
+    sender_role,
+    sender_id,
+    body
+) VALUES (
+             $1, $2, $3, $4
+         ) RETURNING *;
+
+-- name: ListMessages :many
+SELECT * FROM messages
+WHERE conversation_id = sqlc.arg(conversation_id)
+  AND (sqlc.narg(before_id)::bigint IS NULL OR id < sqlc.narg(before_id))
+ORDER BY id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: MarkMessagesRead :execrows
+UPDATE messages
+SET read_at = now()
+WHERE conversation_id = sqlc.arg(conversation_id)
+  AND sender_role <> sqlc.arg(reader_role)::role
+  AND read_at IS NULL
+  AND id <= sqlc.arg(up_to_id);
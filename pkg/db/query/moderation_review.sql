@@ -0,0 +1,39 @@
+-- name: CreateModerationReview :one
+INSERT INTO moderation_reviews (
+    item_type,
+    item_id,
+    content,
+    findings
+) VALUES (
+             $1, $2, $3, $4
+         )
+ON CONFLICT (item_type, item_id) WHERE decision = 'needs_review'
+DO UPDATE SET content = EXCLUDED.content,
+              findings = EXCLUDED.findings,
+              created_at = now()
+RETURNING *;
+
+-- name: DeletePendingModerationReview :exec
+DELETE FROM moderation_reviews
+WHERE item_type = $1 AND item_id = $2 AND decision = 'needs_review';
+
+-- name: GetModerationReview :one
+SELECT * FROM moderation_reviews
+WHERE id = $1 LIMIT 1;
+
+-- name: ListPendingModerationReviews :many
+SELECT * FROM moderation_reviews
+WHERE decision = 'needs_review'
+  AND (sqlc.narg(item_type)::varchar IS NULL OR item_type = sqlc.narg(item_type))
+ORDER BY created_at, id
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: ResolveModerationReview :one
+UPDATE moderation_reviews
+SET decision = $2,
+    reviewed_by = $3,
+    note = $4,
+    reviewed_at = now()
+WHERE id = $1 AND decision = 'needs_review'
+RETURNING *;
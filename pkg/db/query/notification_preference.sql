@@ -0,0 +1,19 @@
+-- name: GetNotificationPreference :one
+SELECT * FROM notification_preferences
+WHERE username = $1 LIMIT 1;
+
+-- name: UpsertNotificationPreference :one
+INSERT INTO notification_preferences (
+    username,
+    application_received,
+    application_decisions
+) VALUES (
+    $1,
+    COALESCE(sqlc.narg(application_received), true),
+    COALESCE(sqlc.narg(application_decisions), true)
+)
+ON CONFLICT (username) DO UPDATE SET
+    application_received = COALESCE(sqlc.narg(application_received), notification_preferences.application_received),
+    application_decisions = COALESCE(sqlc.narg(application_decisions), notification_preferences.application_decisions),
+    updated_at = now()
+RETURNING *;
@@ -23,6 +23,11 @@ ORDER BY id
 LIMIT $2
     OFFSET $3;
 
+-- name: ListPastExperiencesByCandidates :many
+SELECT * FROM past_experiences
+WHERE candidate_id = ANY(sqlc.arg(candidate_ids)::bigint[])
+ORDER BY candidate_id, id;
+
 -- name: UpdatePastExperience :one
 UPDATE past_experiences
 SET industry = COALESCE(sqlc.narg(industry), industry),
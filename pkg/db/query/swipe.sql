@@ -54,4 +54,27 @@ ORDER BY created_at DESC;
 -- name: GetRejectedCandidateIdsByEmployer :many
 SELECT candidate_id
 FROM employer_swipes
-WHERE employer_id = $1 AND swipe = 'reject';
\ No newline at end of file
+WHERE employer_id = $1 AND swipe = 'reject';
+-- name: UpsertCandidateSwipe :exec
+INSERT INTO candidate_swipes (
+    candidate_id,
+    job_id,
+    swipe
+) VALUES (
+             $1, $2, $3
+         )
+ON CONFLICT (candidate_id, job_id)
+DO UPDATE SET swipe = EXCLUDED.swipe,
+              created_at = now();
+
+-- name: UpsertEmployerSwipe :exec
+INSERT INTO employer_swipes (
+    employer_id,
+    candidate_id,
+    swipe
+) VALUES (
+             $1, $2, $3
+         )
+ON CONFLICT (employer_id, candidate_id)
+DO UPDATE SET swipe = EXCLUDED.swipe,
+              created_at = now();
@@ -0,0 +1,98 @@
+package db
+
+import (
+	"fmt"
+	"time"
+)
+
+// ApplicationKind tells which side of the platform sent an application.
+type ApplicationKind string
+
+const (
+	// ApplicationKindCandidate is a candidate applying to an employer's job.
+	ApplicationKindCandidate ApplicationKind = "candidate"
+	// ApplicationKindEmployer is an employer reaching out to a candidate.
+	ApplicationKindEmployer ApplicationKind = "employer"
+)
+
+// Sender is the role that creates applications of this kind. The other role
+// receives them and decides on them.
+func (k ApplicationKind) Sender() Role {
+	if k == ApplicationKindEmployer {
+		return RoleEmployer
+	}
+	return RoleCandidate
+}
+
+// Recipient is the role that receives applications of this kind.
+func (k ApplicationKind) Recipient() Role {
+	if k == ApplicationKindEmployer {
+		return RoleCandidate
+	}
+	return RoleEmployer
+}
+
+// PipelineStatuses are the statuses an employer's pipeline lists when no
+// status is asked for. Withdrawn applications are left out.
+var PipelineStatuses = []string{
+	string(ApplicationStatusPending),
+	string(ApplicationStatusSubmitted),
+	string(ApplicationStatusAccepted),
+	string(ApplicationStatusRejected),
+}
+
+type applicationParty int
+
+const (
+	partySender applicationParty = iota
+	partyReceiver
+)
+
+// applicationTransitions lists every status change an application can go
+// through and which party may make it. The sender can withdraw an application
+// until it has been decided on. Accepted, rejected and withdrawn are final,
+// though a withdrawn candidate application can be reopened by applying again.
+var applicationTransitions = map[ApplicationStatus]map[ApplicationStatus]applicationParty{
+	ApplicationStatusPending: {
+		ApplicationStatusSubmitted: partySender,
+		ApplicationStatusAccepted:  partyReceiver,
+		ApplicationStatusRejected:  partyReceiver,
+		ApplicationStatusWithdrawn: partySender,
+	},
+	ApplicationStatusSubmitted: {
+		ApplicationStatusAccepted:  partyReceiver,
+		ApplicationStatusRejected:  partyReceiver,
+		ApplicationStatusWithdrawn: partySender,
+	},
+}
+
+// ValidateInitialApplicationStatus checks the status an application is
+// created with. Decisions can only be made on an existing application.
+func ValidateInitialApplicationStatus(status ApplicationStatus) error {
+	if status != ApplicationStatusPending && status != ApplicationStatusSubmitted {
+		return fmt.Errorf("%w: applications start as %s or %s, not %s",
+			ErrInvalidStatusTransition, ApplicationStatusPending, ApplicationStatusSubmitted, status)
+	}
+	return nil
+}
+
+// ValidateApplicationTransition checks that the actor may move an
+// application of the given kind from one status to another.
+func ValidateApplicationTransition(kind ApplicationKind, actor Role, from, to ApplicationStatus) error {
+	party, ok := applicationTransitions[from][to]
+	if !ok {
+		return fmt.Errorf("%w: %s to %s", ErrInvalidStatusTransition, from, to)
+	}
+	sender := kind.Sender()
+	if (party == partySender) != (actor == sender) {
+		return fmt.Errorf("%w: %s cannot move a %s application from %s to %s",
+			ErrStatusTransitionNotAllowed, actor, kind, from, to)
+	}
+	return nil
+}
+
+// Expired reports whether an employer's invitation can no longer be answered.
+// Invitations sent before they expired never do.
+func (application EmployerApplication) Expired(now time.Time) bool {
+	return application.ExpiresAt.Valid && !application.ExpiresAt.Time.After(now)
+}
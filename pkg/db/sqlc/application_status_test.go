@@ -0,0 +1,420 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hankimmy/PtmrBackend/pkg/util"
+)
+
+func TestValidateApplicationTransition(t *testing.T) {
+	testCases := []struct {
+		name  string
+		kind  ApplicationKind
+		actor Role
+		from  ApplicationStatus
+		to    ApplicationStatus
+		err   error
+	}{
+		{"CandidateSubmits", ApplicationKindCandidate, RoleCandidate, ApplicationStatusPending, ApplicationStatusSubmitted, nil},
+		{"EmployerAccepts", ApplicationKindCandidate, RoleEmployer, ApplicationStatusSubmitted, ApplicationStatusAccepted, nil},
+		{"EmployerRejectsPending", ApplicationKindCandidate, RoleEmployer, ApplicationStatusPending, ApplicationStatusRejected, nil},
+		{"CandidateAcceptsOwn", ApplicationKindCandidate, RoleCandidate, ApplicationStatusSubmitted, ApplicationStatusAccepted, ErrStatusTransitionNotAllowed},
+		{"EmployerSubmitsForCandidate", ApplicationKindCandidate, RoleEmployer, ApplicationStatusPending, ApplicationStatusSubmitted, ErrStatusTransitionNotAllowed},
+		{"EmployerSendsOffer", ApplicationKindEmployer, RoleEmployer, ApplicationStatusPending, ApplicationStatusSubmitted, nil},
+		{"CandidateAcceptsOffer", ApplicationKindEmployer, RoleCandidate, ApplicationStatusSubmitted, ApplicationStatusAccepted, nil},
+		{"EmployerAcceptsOwnOffer", ApplicationKindEmployer, RoleEmployer, ApplicationStatusSubmitted, ApplicationStatusAccepted, ErrStatusTransitionNotAllowed},
+		{"ReopenRejected", ApplicationKindCandidate, RoleEmployer, ApplicationStatusRejected, ApplicationStatusSubmitted, ErrInvalidStatusTransition},
+		{"UndoAccepted", ApplicationKindEmployer, RoleCandidate, ApplicationStatusAccepted, ApplicationStatusRejected, ErrInvalidStatusTransition},
+		{"BackToPending", ApplicationKindCandidate, RoleCandidate, ApplicationStatusSubmitted, ApplicationStatusPending, ErrInvalidStatusTransition},
+		{"CandidateWithdraws", ApplicationKindCandidate, RoleCandidate, ApplicationStatusSubmitted, ApplicationStatusWithdrawn, nil},
+		{"EmployerWithdrawsCandidates", ApplicationKindCandidate, RoleEmployer, ApplicationStatusPending, ApplicationStatusWithdrawn, ErrStatusTransitionNotAllowed},
+		{"WithdrawAccepted", ApplicationKindCandidate, RoleCandidate, ApplicationStatusAccepted, ApplicationStatusWithdrawn, ErrInvalidStatusTransition},
+		{"ReopenWithdrawn", ApplicationKindCandidate, RoleCandidate, ApplicationStatusWithdrawn, ApplicationStatusSubmitted, ErrInvalidStatusTransition},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateApplicationTransition(tc.kind, tc.actor, tc.from, tc.to)
+			if tc.err == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestValidateInitialApplicationStatus(t *testing.T) {
+	require.NoError(t, ValidateInitialApplicationStatus(ApplicationStatusPending))
+	require.NoError(t, ValidateInitialApplicationStatus(ApplicationStatusSubmitted))
+	require.ErrorIs(t, ValidateInitialApplicationStatus(ApplicationStatusAccepted), ErrInvalidStatusTransition)
+	require.ErrorIs(t, ValidateInitialApplicationStatus(ApplicationStatusRejected), ErrInvalidStatusTransition)
+}
+
+func TestTransitionCandidateApplicationStatusTx(t *testing.T) {
+	application := createRandomCandidateApplication(t, ApplicationStatusPending)
+	arg := TransitionApplicationStatusTxParams{
+		Kind:        ApplicationKindCandidate,
+		CandidateID: application.CandidateID,
+		JobDocID:    application.JobDocID,
+		ActorRole:   RoleCandidate,
+		ActorID:     application.CandidateID,
+		Status:      ApplicationStatusSubmitted,
+	}
+	result, err := testStore.TransitionApplicationStatusTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.True(t, result.Changed)
+	require.Equal(t, ApplicationStatusSubmitted, result.CandidateApplication.ApplicationStatus)
+	require.Equal(t, ApplicationStatusPending, result.History.FromStatus)
+	require.Equal(t, ApplicationStatusSubmitted, result.History.ToStatus)
+	require.Equal(t, RoleCandidate, result.History.ActorRole)
+	require.Equal(t, application.EmployerID, result.History.EmployerID)
+	require.Equal(t, application.JobDocID, result.History.JobDocID)
+
+	// Submitting again is a no-op.
+	result, err = testStore.TransitionApplicationStatusTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.False(t, result.Changed)
+
+	// The candidate cannot decide on their own application.
+	arg.Status = ApplicationStatusAccepted
+	_, err = testStore.TransitionApplicationStatusTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrStatusTransitionNotAllowed)
+
+	arg.ActorRole = RoleEmployer
+	arg.ActorID = application.EmployerID
+	arg.Reason = "great fit"
+	result, err = testStore.TransitionApplicationStatusTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, ApplicationStatusAccepted, result.CandidateApplication.ApplicationStatus)
+	require.Equal(t, "great fit", result.History.Reason)
+
+	history, err := testStore.ListApplicationStatusHistory(context.Background(), ListApplicationStatusHistoryParams{
+		ApplicationKind: string(ApplicationKindCandidate),
+		CandidateID:     pgtype.Int8{Int64: application.CandidateID, Valid: true},
+		JobDocID:        pgtype.Text{String: application.JobDocID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	require.Equal(t, ApplicationStatusSubmitted, history[0].ToStatus)
+	require.Equal(t, ApplicationStatusAccepted, history[1].ToStatus)
+
+	byKeys, err := testStore.ListApplicationStatusHistoryByKeys(context.Background(), ListApplicationStatusHistoryByKeysParams{
+		ApplicationKind: string(ApplicationKindCandidate),
+		CandidateIds:    []int64{application.CandidateID, application.CandidateID},
+		EmployerIds:     []int64{application.EmployerID, application.EmployerID},
+		JobDocIds:       []string{application.JobDocID, "missing"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, history, byKeys)
+
+	arg.Status = ApplicationStatusRejected
+	_, err = testStore.TransitionApplicationStatusTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrInvalidStatusTransition)
+}
+
+func TestTransitionEmployerApplicationStatusTx(t *testing.T) {
+	application := createRandomEmployerApplication(t, ApplicationStatusSubmitted)
+	result, err := testStore.TransitionApplicationStatusTx(context.Background(), TransitionApplicationStatusTxParams{
+		Kind:        ApplicationKindEmployer,
+		CandidateID: application.CandidateID,
+		EmployerID:  application.EmployerID,
+		ActorRole:   RoleCandidate,
+		ActorID:     application.CandidateID,
+		Status:      ApplicationStatusRejected,
+		Reason:      "found another job",
+	})
+	require.NoError(t, err)
+	require.True(t, result.Changed)
+	require.Equal(t, ApplicationStatusRejected, result.EmployerApplication.ApplicationStatus)
+
+	history, err := testStore.ListApplicationStatusHistory(context.Background(), ListApplicationStatusHistoryParams{
+		ApplicationKind: string(ApplicationKindEmployer),
+		EmployerID:      pgtype.Int8{Int64: application.EmployerID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	require.Equal(t, result.History.ID, history[0].ID)
+}
+
+func TestTransitionApplicationStatusTxNotFound(t *testing.T) {
+	_, err := testStore.TransitionApplicationStatusTx(context.Background(), TransitionApplicationStatusTxParams{
+		Kind:        ApplicationKindCandidate,
+		CandidateID: -1,
+		JobDocID:    util.RandomString(6),
+		ActorRole:   RoleEmployer,
+		Status:      ApplicationStatusAccepted,
+	})
+	require.ErrorIs(t, err, ErrRecordNotFound)
+
+	// A candidate application is only found through its own employer.
+	application := createRandomCandidateApplication(t, ApplicationStatusSubmitted)
+	_, err = testStore.TransitionApplicationStatusTx(context.Background(), TransitionApplicationStatusTxParams{
+		Kind:        ApplicationKindCandidate,
+		CandidateID: application.CandidateID,
+		EmployerID:  application.EmployerID + 1,
+		JobDocID:    application.JobDocID,
+		ActorRole:   RoleEmployer,
+		ActorID:     application.EmployerID + 1,
+		Status:      ApplicationStatusAccepted,
+	})
+	require.ErrorIs(t, err, ErrRecordNotFound)
+}
+
+func TestUpdateCandidateApplicationStatusTx(t *testing.T) {
+	application := createRandomCandidateApplication(t, ApplicationStatusSubmitted)
+	// The candidate swiped right on the job before applying.
+	err := testStore.CreateCandidateSwipe(context.Background(), CreateCandidateSwipeParams{
+		CandidateID: application.CandidateID,
+		JobID:       application.JobDocID,
+		Swipe:       SwipeAccept,
+	})
+	require.NoError(t, err)
+
+	var hookResult TransitionApplicationStatusTxResult
+	result, err := testStore.UpdateCandidateApplicationStatusTx(context.Background(), UpdateCandidateApplicationStatusTxParams{
+		UpdateCandidateApplicationStatusParams: UpdateCandidateApplicationStatusParams{
+			CandidateID:       application.CandidateID,
+			JobDocID:          application.JobDocID,
+			ApplicationStatus: ApplicationStatusRejected,
+		},
+		EmployerID: application.EmployerID,
+		Reason:     "position filled",
+		AfterUpdate: func(result TransitionApplicationStatusTxResult) error {
+			hookResult = result
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, ApplicationStatusRejected, result.CandidateApplication.ApplicationStatus)
+	require.Equal(t, RoleEmployer, result.History.ActorRole)
+	require.Equal(t, application.EmployerID, result.History.ActorID)
+	require.Equal(t, result.History, hookResult.History)
+
+	swipes, err := testStore.GetCandidateSwipe(context.Background(), GetCandidateSwipeParams{
+		JobID:       application.JobDocID,
+		CandidateID: application.CandidateID,
+	})
+	require.NoError(t, err)
+	require.Len(t, swipes, 1)
+	require.Equal(t, SwipeReject, swipes[0].Swipe)
+}
+
+func TestUpdateCandidateApplicationStatusTxRollsBack(t *testing.T) {
+	application := createRandomCandidateApplication(t, ApplicationStatusSubmitted)
+	_, err := testStore.UpdateCandidateApplicationStatusTx(context.Background(), UpdateCandidateApplicationStatusTxParams{
+		UpdateCandidateApplicationStatusParams: UpdateCandidateApplicationStatusParams{
+			CandidateID:       application.CandidateID,
+			JobDocID:          application.JobDocID,
+			ApplicationStatus: ApplicationStatusAccepted,
+		},
+		EmployerID: application.EmployerID,
+		AfterUpdate: func(result TransitionApplicationStatusTxResult) error {
+			return errors.New("enqueue failed")
+		},
+	})
+	require.Error(t, err)
+
+	current, err := testStore.GetCandidateApplication(context.Background(), GetCandidateApplicationParams{
+		CandidateID: application.CandidateID,
+		JobDocID:    application.JobDocID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, ApplicationStatusSubmitted, current.ApplicationStatus)
+
+	swipes, err := testStore.GetCandidateSwipe(context.Background(), GetCandidateSwipeParams{
+		JobID:       application.JobDocID,
+		CandidateID: application.CandidateID,
+	})
+	require.NoError(t, err)
+	require.Empty(t, swipes)
+}
+
+func TestUpdateCandidateApplicationStatusTxInvalidStatus(t *testing.T) {
+	_, err := testStore.UpdateCandidateApplicationStatusTx(context.Background(), UpdateCandidateApplicationStatusTxParams{
+		UpdateCandidateApplicationStatusParams: UpdateCandidateApplicationStatusParams{
+			CandidateID:       1,
+			JobDocID:          util.RandomString(6),
+			ApplicationStatus: ApplicationStatusSubmitted,
+		},
+		EmployerID: 1,
+	})
+	require.ErrorIs(t, err, ErrInvalidStatusTransition)
+}
+
+func TestUpdateEmployerApplicationStatusTx(t *testing.T) {
+	application := createRandomEmployerApplication(t, ApplicationStatusSubmitted)
+	result, err := testStore.UpdateEmployerApplicationStatusTx(context.Background(), UpdateEmployerApplicationStatusTxParams{
+		UpdateEmployerApplicationStatusParams: UpdateEmployerApplicationStatusParams{
+			CandidateID:       application.CandidateID,
+			EmployerID:        application.EmployerID,
+			ApplicationStatus: ApplicationStatusAccepted,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, ApplicationStatusAccepted, result.EmployerApplication.ApplicationStatus)
+	require.Equal(t, RoleCandidate, result.History.ActorRole)
+
+	swipe, err := testStore.GetEmployerSwipe(context.Background(), GetEmployerSwipeParams{
+		CandidateID: application.CandidateID,
+		EmployerID:  application.EmployerID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, SwipeAccept, swipe.Swipe)
+}
+
+func TestUpdateEmployerApplicationStatusTxExpired(t *testing.T) {
+	application, err := testStore.CreateEmployerApplication(context.Background(), CreateEmployerApplicationParams{
+		EmployerID:        createRandomEmployer(t).ID,
+		CandidateID:       createRandomCandidate(t).ID,
+		Message:           util.RandomString(10),
+		ApplicationStatus: ApplicationStatusSubmitted,
+		CreatedAt:         time.Now().Add(-2 * time.Hour),
+		JobDocID:          util.RandomString(12),
+		ExpiresAt:         pgtype.Timestamptz{Time: time.Now().Add(-time.Hour), Valid: true},
+	})
+	require.NoError(t, err)
+	require.True(t, application.Expired(time.Now()))
+
+	_, err = testStore.UpdateEmployerApplicationStatusTx(context.Background(), UpdateEmployerApplicationStatusTxParams{
+		UpdateEmployerApplicationStatusParams: UpdateEmployerApplicationStatusParams{
+			CandidateID:       application.CandidateID,
+			EmployerID:        application.EmployerID,
+			ApplicationStatus: ApplicationStatusAccepted,
+		},
+	})
+	require.ErrorIs(t, err, ErrInvitationExpired)
+
+	got, err := testStore.GetEmployerApplication(context.Background(), GetEmployerApplicationParams{
+		EmployerID:  application.EmployerID,
+		CandidateID: application.CandidateID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, ApplicationStatusSubmitted, got.ApplicationStatus)
+}
+
+func TestUpdateEmployerApplicationStatusTxCreatesApplication(t *testing.T) {
+	application := createRandomEmployerApplication(t, ApplicationStatusSubmitted)
+	jobID := util.RandomString(12)
+	result, err := testStore.UpdateEmployerApplicationStatusTx(context.Background(), UpdateEmployerApplicationStatusTxParams{
+		UpdateEmployerApplicationStatusParams: UpdateEmployerApplicationStatusParams{
+			CandidateID:       application.CandidateID,
+			EmployerID:        application.EmployerID,
+			ApplicationStatus: ApplicationStatusAccepted,
+		},
+		Application: &CreateCandidateApplicationTxParams{
+			CreateCandidateApplicationParams: CreateCandidateApplicationParams{
+				CandidateID:        application.CandidateID,
+				EmployerID:         application.EmployerID,
+				ElasticsearchDocID: util.RandomString(12),
+				JobDocID:           jobID,
+				ApplicationStatus:  ApplicationStatusPending,
+			},
+			MaxApplications: 1,
+			AfterCreate: func(docID string, appDoc map[string]interface{}) error {
+				return nil
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, ApplicationStatusAccepted, result.EmployerApplication.ApplicationStatus)
+	require.Equal(t, jobID, result.CandidateApplication.JobDocID)
+	require.Equal(t, int64(1), result.ApplicationCount)
+
+	_, err = testStore.GetCandidateApplication(context.Background(), GetCandidateApplicationParams{
+		CandidateID: application.CandidateID,
+		JobDocID:    jobID,
+	})
+	require.NoError(t, err)
+}
+
+func TestBulkTransitionApplicationsTx(t *testing.T) {
+	ctx := context.Background()
+	employer := createRandomEmployer(t)
+	jobID := util.RandomString(8)
+	apply := func(status ApplicationStatus) CandidateApplication {
+		application, err := testStore.CreateCandidateApplication(ctx, CreateCandidateApplicationParams{
+			CandidateID:        createRandomCandidate(t).ID,
+			EmployerID:         employer.ID,
+			ElasticsearchDocID: util.RandomString(10),
+			JobDocID:           jobID,
+			ApplicationStatus:  status,
+		})
+		require.NoError(t, err)
+		return application
+	}
+	pending := []CandidateApplication{apply(ApplicationStatusPending), apply(ApplicationStatusPending)}
+	accepted := apply(ApplicationStatusAccepted)
+
+	var changed []TransitionApplicationStatusTxResult
+	results, err := testStore.BulkTransitionApplicationsTx(ctx, BulkTransitionApplicationsTxParams{
+		EmployerID:   employer.ID,
+		JobDocID:     jobID,
+		FromStatuses: []ApplicationStatus{ApplicationStatusPending},
+		Status:       ApplicationStatusRejected,
+		Reason:       "Position filled",
+		AfterUpdate: func(results []TransitionApplicationStatusTxResult) error {
+			changed = results
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.Len(t, changed, 2)
+	for _, result := range results {
+		require.NoError(t, result.Err)
+		require.True(t, result.Changed)
+		require.Equal(t, ApplicationStatusRejected, result.CandidateApplication.ApplicationStatus)
+		require.Equal(t, "Position filled", result.History.Reason)
+	}
+
+	// Listed applications are checked one by one, and the ones that can't
+	// change don't hold back the rest.
+	results, err = testStore.BulkTransitionApplicationsTx(ctx, BulkTransitionApplicationsTxParams{
+		EmployerID:   employer.ID,
+		JobDocID:     jobID,
+		CandidateIDs: []int64{accepted.CandidateID, pending[0].CandidateID, accepted.CandidateID, -1},
+		Status:       ApplicationStatusAccepted,
+		AfterUpdate: func(results []TransitionApplicationStatusTxResult) error {
+			return errors.New("nothing changed, so the hook shouldn't run")
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	require.ErrorIs(t, results[0].Err, ErrRecordNotFound)
+	byCandidate := map[int64]BulkApplicationResult{}
+	for _, result := range results {
+		byCandidate[result.CandidateID] = result
+	}
+	require.NoError(t, byCandidate[accepted.CandidateID].Err)
+	require.False(t, byCandidate[accepted.CandidateID].Changed)
+	require.ErrorIs(t, byCandidate[pending[0].CandidateID].Err, ErrInvalidStatusTransition)
+}
+
+func TestBulkTransitionApplicationsTxRollsBack(t *testing.T) {
+	ctx := context.Background()
+	application := createRandomCandidateApplication(t, ApplicationStatusSubmitted)
+	_, err := testStore.BulkTransitionApplicationsTx(ctx, BulkTransitionApplicationsTxParams{
+		EmployerID:   application.EmployerID,
+		JobDocID:     application.JobDocID,
+		CandidateIDs: []int64{application.CandidateID},
+		Status:       ApplicationStatusRejected,
+		AfterUpdate: func(results []TransitionApplicationStatusTxResult) error {
+			return errors.New("redis unavailable")
+		},
+	})
+	require.Error(t, err)
+
+	got, err := testStore.GetCandidateApplication(ctx, GetCandidateApplicationParams{
+		CandidateID: application.CandidateID,
+		JobDocID:    application.JobDocID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, ApplicationStatusSubmitted, got.ApplicationStatus)
+}
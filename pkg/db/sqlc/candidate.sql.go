@@ -139,6 +139,96 @@ func (q *Queries) GetCandidateIdByUsername(ctx context.Context, username string)
 	return id, err
 }
 
+const listCandidateContacts = `-- name: ListCandidateContacts :many
+SELECT c.id, c.full_name, u.email,
+       COALESCE(np.application_decisions, true)::bool AS application_decisions
+FROM candidates c
+JOIN users u ON u.username = c.username
+LEFT JOIN notification_preferences np ON np.username = c.username
+WHERE c.id = ANY($1::bigint[])
+ORDER BY c.id
+`
+
+type ListCandidateContactsRow struct {
+	ID                   int64  `json:"id"`
+	FullName             string `json:"full_name"`
+	Email                string `json:"email"`
+	ApplicationDecisions bool   `json:"application_decisions"`
+}
+
+func (q *Queries) ListCandidateContacts(ctx context.Context, ids []int64) ([]ListCandidateContactsRow, error) {
+	rows, err := q.db.Query(ctx, listCandidateContacts, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListCandidateContactsRow{}
+	for rows.Next() {
+		var i ListCandidateContactsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FullName,
+			&i.Email,
+			&i.ApplicationDecisions,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCandidateProfiles = `-- name: ListCandidateProfiles :many
+SELECT c.id, c.full_name, u.email, c.phone_number, c.location, c.education, c.skill_set, c.certificates
+FROM candidates c
+JOIN users u ON u.username = c.username
+WHERE c.id = ANY($1::bigint[])
+ORDER BY c.id
+`
+
+type ListCandidateProfilesRow struct {
+	ID           int64     `json:"id"`
+	FullName     string    `json:"full_name"`
+	Email        string    `json:"email"`
+	PhoneNumber  string    `json:"phone_number"`
+	Location     string    `json:"location"`
+	Education    Education `json:"education"`
+	SkillSet     []string  `json:"skill_set"`
+	Certificates []string  `json:"certificates"`
+}
+
+func (q *Queries) ListCandidateProfiles(ctx context.Context, ids []int64) ([]ListCandidateProfilesRow, error) {
+	rows, err := q.db.Query(ctx, listCandidateProfiles, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListCandidateProfilesRow{}
+	for rows.Next() {
+		var i ListCandidateProfilesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FullName,
+			&i.Email,
+			&i.PhoneNumber,
+			&i.Location,
+			&i.Education,
+			&i.SkillSet,
+			&i.Certificates,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listCandidates = `-- name: ListCandidates :many
 SELECT id, username, full_name, phone_number, education, location, skill_set, certificates, industry_of_interest, job_preference, time_availability, account_verified, resume_file, profile_photo, description, created_at FROM candidates
 WHERE username = $1
@@ -190,6 +280,49 @@ func (q *Queries) ListCandidates(ctx context.Context, arg ListCandidatesParams)
 	return items, nil
 }
 
+const listCandidatesByIDs = `-- name: ListCandidatesByIDs :many
+SELECT id, username, full_name, phone_number, education, location, skill_set, certificates, industry_of_interest, job_preference, time_availability, account_verified, resume_file, profile_photo, description, created_at FROM candidates
+WHERE id = ANY($1::bigint[])
+ORDER BY id
+`
+
+func (q *Queries) ListCandidatesByIDs(ctx context.Context, ids []int64) ([]Candidate, error) {
+	rows, err := q.db.Query(ctx, listCandidatesByIDs, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Candidate{}
+	for rows.Next() {
+		var i Candidate
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.FullName,
+			&i.PhoneNumber,
+			&i.Education,
+			&i.Location,
+			&i.SkillSet,
+			&i.Certificates,
+			&i.IndustryOfInterest,
+			&i.JobPreference,
+			&i.TimeAvailability,
+			&i.AccountVerified,
+			&i.ResumeFile,
+			&i.ProfilePhoto,
+			&i.Description,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateCandidate = `-- name: UpdateCandidate :one
 UPDATE candidates
 SET full_name = COALESCE($2, full_name),
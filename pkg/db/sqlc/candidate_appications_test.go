@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -41,6 +42,18 @@ func TestCreateCandidateApplication(t *testing.T) {
 	createRandomCandidateApplication(t, ApplicationStatusPending)
 }
 
+func TestGetCandidateApplication(t *testing.T) {
+	application := createRandomCandidateApplication(t, ApplicationStatusPending)
+	gotApplication, err := testStore.GetCandidateApplication(context.Background(), GetCandidateApplicationParams{
+		CandidateID: application.CandidateID,
+		JobDocID:    application.JobDocID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, application.ElasticsearchDocID, gotApplication.ElasticsearchDocID)
+	require.Equal(t, application.JobDocID, gotApplication.JobDocID)
+	require.WithinDuration(t, application.CreatedAt, gotApplication.CreatedAt, time.Second)
+}
+
 func TestGetCandidateApplicationsByEmployer(t *testing.T) {
 	application := createRandomCandidateApplication(t, ApplicationStatusPending)
 	applications, err := testStore.GetCandidateApplicationsByEmployer(context.Background(), application.EmployerID)
@@ -59,7 +72,7 @@ func TestUpdateCandidateApplication(t *testing.T) {
 	newDocID := util.RandomString(10)
 	arg := UpdateCandidateApplicationParams{
 		CandidateID:        application.CandidateID,
-		EmployerID:         application.EmployerID,
+		JobDocID:           application.JobDocID,
 		ApplicationStatus:  NullApplicationStatus{ApplicationStatus: newStatus, Valid: true},
 		ElasticsearchDocID: pgtype.Text{String: newDocID, Valid: true},
 	}
@@ -78,7 +91,7 @@ func TestUpdateCandidateApplicationStatus(t *testing.T) {
 	application := createRandomCandidateApplication(t, ApplicationStatusPending)
 	err := testStore.UpdateCandidateApplicationStatus(context.Background(), UpdateCandidateApplicationStatusParams{
 		CandidateID:       application.CandidateID,
-		EmployerID:        application.EmployerID,
+		JobDocID:          application.JobDocID,
 		ApplicationStatus: ApplicationStatusRejected,
 	})
 	require.NoError(t, err)
@@ -92,61 +105,401 @@ func TestUpdateCandidateApplicationStatus(t *testing.T) {
 
 func TestDeleteCandidateApplication(t *testing.T) {
 	application := createRandomCandidateApplication(t, ApplicationStatusPending)
-	err := testStore.DeleteCandidateApplication(context.Background(), DeleteCandidateApplicationParams{
+	deleted, err := testStore.DeleteCandidateApplication(context.Background(), DeleteCandidateApplicationParams{
 		CandidateID: application.CandidateID,
-		EmployerID:  application.EmployerID,
+		JobDocID:    application.JobDocID,
 	})
 	require.NoError(t, err)
+	require.Equal(t, application.EmployerID, deleted.EmployerID)
 
 	applications, err := testStore.GetCandidateApplicationsByEmployer(context.Background(), application.EmployerID)
 	require.NoError(t, err)
 	require.Empty(t, applications)
 }
 
-func TestGetCandidateApplicationsByStatusAccepted(t *testing.T) {
-	application := createRandomCandidateApplication(t, ApplicationStatusAccepted)
-	applications, err := testStore.GetCandidateApplicationsByStatusAccepted(context.Background(), application.CandidateID)
+func TestCandidateApplicationsPerJob(t *testing.T) {
+	application := createRandomCandidateApplication(t, ApplicationStatusPending)
+
+	// The same candidate can apply to another job at the same employer.
+	other, err := testStore.CreateCandidateApplication(context.Background(), CreateCandidateApplicationParams{
+		CandidateID:        application.CandidateID,
+		EmployerID:         application.EmployerID,
+		ElasticsearchDocID: util.RandomString(10),
+		JobDocID:           util.RandomString(6),
+		ApplicationStatus:  ApplicationStatusSubmitted,
+	})
 	require.NoError(t, err)
-	require.NotEmpty(t, applications)
 
-	for _, app := range applications {
-		require.Equal(t, application.CandidateID, app.CandidateID)
-		require.Equal(t, ApplicationStatusAccepted, app.ApplicationStatus)
+	// But not twice to the same job.
+	_, err = testStore.CreateCandidateApplication(context.Background(), CreateCandidateApplicationParams{
+		CandidateID:        application.CandidateID,
+		EmployerID:         application.EmployerID,
+		ElasticsearchDocID: util.RandomString(10),
+		JobDocID:           application.JobDocID,
+		ApplicationStatus:  ApplicationStatusPending,
+	})
+	require.Equal(t, UniqueViolation, ErrorCode(err))
+
+	applications, err := testStore.GetCandidateApplicationsByEmployer(context.Background(), application.EmployerID)
+	require.NoError(t, err)
+	require.Len(t, applications, 2)
+
+	gotApplication, err := testStore.GetCandidateApplication(context.Background(), GetCandidateApplicationParams{
+		CandidateID: application.CandidateID,
+		JobDocID:    other.JobDocID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, ApplicationStatusSubmitted, gotApplication.ApplicationStatus)
+}
+
+func TestListCandidateApplications(t *testing.T) {
+	employer := createRandomEmployer(t)
+	statuses := []ApplicationStatus{
+		ApplicationStatusPending,
+		ApplicationStatusSubmitted,
+		ApplicationStatusSubmitted,
+		ApplicationStatusAccepted,
+		ApplicationStatusSubmitted,
 	}
+	var submitted []CandidateApplication
+	for _, status := range statuses {
+		application, err := testStore.CreateCandidateApplication(context.Background(), CreateCandidateApplicationParams{
+			CandidateID:        createRandomCandidate(t).ID,
+			EmployerID:         employer.ID,
+			ElasticsearchDocID: util.RandomString(10),
+			JobDocID:           util.RandomString(5),
+			ApplicationStatus:  status,
+		})
+		require.NoError(t, err)
+		if status == ApplicationStatusSubmitted {
+			submitted = append(submitted, application)
+		}
+	}
+
+	arg := ListCandidateApplicationsNewestFirstParams{
+		EmployerID: employer.ID,
+		Statuses:   []string{string(ApplicationStatusSubmitted)},
+		Limit:      2,
+	}
+	firstPage, err := testStore.ListCandidateApplicationsNewestFirst(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, firstPage, 2)
+
+	last := firstPage[len(firstPage)-1]
+	arg.CursorCreatedAt = pgtype.Timestamptz{Time: last.CreatedAt, Valid: true}
+	arg.CursorID = pgtype.Int8{Int64: last.CandidateID, Valid: true}
+	arg.CursorJobDocID = pgtype.Text{String: last.JobDocID, Valid: true}
+	secondPage, err := testStore.ListCandidateApplicationsNewestFirst(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, secondPage, 1)
+
+	// Pages don't overlap and come newest first.
+	seen := make(map[int64]bool)
+	all := append(firstPage, secondPage...)
+	for i, application := range all {
+		require.Equal(t, ApplicationStatusSubmitted, application.ApplicationStatus)
+		require.False(t, seen[application.CandidateID])
+		seen[application.CandidateID] = true
+		if i > 0 {
+			require.False(t, application.CreatedAt.After(all[i-1].CreatedAt))
+		}
+	}
+	require.Len(t, seen, len(submitted))
+
+	oldestArg := ListCandidateApplicationsOldestFirstParams{
+		EmployerID: employer.ID,
+		Statuses:   []string{string(ApplicationStatusSubmitted)},
+		Limit:      2,
+	}
+	oldest, err := testStore.ListCandidateApplicationsOldestFirst(context.Background(), oldestArg)
+	require.NoError(t, err)
+	require.Len(t, oldest, 2)
+	require.Equal(t, submitted[0].CandidateID, oldest[0].CandidateID)
+	last = oldest[len(oldest)-1]
+	oldestArg.CursorCreatedAt = pgtype.Timestamptz{Time: last.CreatedAt, Valid: true}
+	oldestArg.CursorID = pgtype.Int8{Int64: last.CandidateID, Valid: true}
+	oldestArg.CursorJobDocID = pgtype.Text{String: last.JobDocID, Valid: true}
+	newest, err := testStore.ListCandidateApplicationsOldestFirst(context.Background(), oldestArg)
+	require.NoError(t, err)
+	require.Len(t, newest, 1)
+	require.Equal(t, submitted[2].CandidateID, newest[0].CandidateID)
+
+	byJob, err := testStore.ListCandidateApplicationsOldestFirst(context.Background(), ListCandidateApplicationsOldestFirstParams{
+		EmployerID: employer.ID,
+		Statuses:   PipelineStatuses,
+		JobDocIds:  []string{submitted[0].JobDocID},
+		Limit:      10,
+	})
+	require.NoError(t, err)
+	require.Len(t, byJob, 1)
+	require.Equal(t, submitted[0].CandidateID, byJob[0].CandidateID)
+
+	none, err := testStore.ListCandidateApplicationsNewestFirst(context.Background(), ListCandidateApplicationsNewestFirstParams{
+		EmployerID:   employer.ID,
+		Statuses:     PipelineStatuses,
+		CreatedAfter: pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true},
+		Limit:        10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, none)
+}
+
+func TestListCandidateApplicationJobIDs(t *testing.T) {
+	employer := createRandomEmployer(t)
+	jobIDs := []string{"b_" + util.RandomString(5), "a_" + util.RandomString(5)}
+	for _, jobID := range append(jobIDs, jobIDs[0]) {
+		_, err := testStore.CreateCandidateApplication(context.Background(), CreateCandidateApplicationParams{
+			CandidateID:        createRandomCandidate(t).ID,
+			EmployerID:         employer.ID,
+			ElasticsearchDocID: util.RandomString(10),
+			JobDocID:           jobID,
+			ApplicationStatus:  ApplicationStatusSubmitted,
+		})
+		require.NoError(t, err)
+	}
+
+	got, err := testStore.ListCandidateApplicationJobIDs(context.Background(), ListCandidateApplicationJobIDsParams{
+		EmployerID:   employer.ID,
+		CreatedAfter: pgtype.Timestamptz{Time: time.Now().Add(-time.Hour), Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{jobIDs[1], jobIDs[0]}, got)
+
+	got, err = testStore.ListCandidateApplicationJobIDs(context.Background(), ListCandidateApplicationJobIDsParams{
+		EmployerID: employer.ID,
+		Statuses:   []string{string(ApplicationStatusAccepted)},
+	})
+	require.NoError(t, err)
+	require.Empty(t, got)
 }
 
-func TestGetCandidateApplicationsByStatusPending(t *testing.T) {
+func TestListOpenApplicantsByJob(t *testing.T) {
+	pending := createRandomCandidateApplication(t, ApplicationStatusPending)
+	applicants, err := testStore.ListOpenApplicantsByJob(context.Background(), pending.JobDocID)
+	require.NoError(t, err)
+	require.Len(t, applicants, 1)
+	require.Equal(t, pending.CandidateID, applicants[0].CandidateID)
+	require.NotEmpty(t, applicants[0].Email)
+
+	rejected := createRandomCandidateApplication(t, ApplicationStatusRejected)
+	applicants, err = testStore.ListOpenApplicantsByJob(context.Background(), rejected.JobDocID)
+	require.NoError(t, err)
+	require.Empty(t, applicants)
+}
+
+func TestCountCandidateApplicationsByJob(t *testing.T) {
 	application := createRandomCandidateApplication(t, ApplicationStatusPending)
-	applications, err := testStore.GetCandidateApplicationsByStatusPending(context.Background(), application.CandidateID)
+	count, err := testStore.CountCandidateApplicationsByJob(context.Background(), application.JobDocID)
 	require.NoError(t, err)
-	require.NotEmpty(t, applications)
+	require.Equal(t, int64(1), count)
 
-	for _, app := range applications {
-		require.Equal(t, application.CandidateID, app.CandidateID)
-		require.Equal(t, ApplicationStatusPending, app.ApplicationStatus)
+	count, err = testStore.CountCandidateApplicationsByJob(context.Background(), util.RandomString(6))
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
+
+func TestCreateCandidateApplicationTxMaxApplications(t *testing.T) {
+	existing := createRandomCandidateApplication(t, ApplicationStatusPending)
+	candidate := createRandomCandidate(t)
+	employer := createRandomEmployer(t)
+
+	arg := CreateCandidateApplicationTxParams{
+		CreateCandidateApplicationParams: CreateCandidateApplicationParams{
+			CandidateID:        candidate.ID,
+			EmployerID:         employer.ID,
+			ElasticsearchDocID: util.RandomString(10),
+			JobDocID:           existing.JobDocID,
+			ApplicationStatus:  ApplicationStatusPending,
+		},
+		MaxApplications: 2,
+		AfterCreate: func(docID string, appDoc map[string]interface{}) error {
+			return nil
+		},
+	}
+	result, err := testStore.CreateCandidateApplicationTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), result.ApplicationCount)
+
+	other := createRandomCandidate(t)
+	arg.CandidateID = other.ID
+	arg.ElasticsearchDocID = util.RandomString(10)
+	_, err = testStore.CreateCandidateApplicationTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrApplicationLimitReached)
+
+	count, err := testStore.CountCandidateApplicationsByJob(context.Background(), existing.JobDocID)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+}
+
+func TestCreateCandidateApplicationTxAfterLimitReached(t *testing.T) {
+	existing := createRandomCandidateApplication(t, ApplicationStatusPending)
+	employer := createRandomEmployer(t)
+
+	var filled int
+	arg := CreateCandidateApplicationTxParams{
+		CreateCandidateApplicationParams: CreateCandidateApplicationParams{
+			CandidateID:        createRandomCandidate(t).ID,
+			EmployerID:         employer.ID,
+			ElasticsearchDocID: util.RandomString(10),
+			JobDocID:           existing.JobDocID,
+			ApplicationStatus:  ApplicationStatusPending,
+		},
+		MaxApplications: 2,
+		AfterCreate: func(docID string, appDoc map[string]interface{}) error {
+			return nil
+		},
+		AfterLimitReached: func() error {
+			filled++
+			return errors.New("enqueue failed")
+		},
 	}
+	// The application that fills the job is rolled back with the hook.
+	_, err := testStore.CreateCandidateApplicationTx(context.Background(), arg)
+	require.Error(t, err)
+	require.Equal(t, 1, filled)
+	count, err := testStore.CountCandidateApplicationsByJob(context.Background(), existing.JobDocID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	arg.AfterLimitReached = func() error {
+		filled++
+		return nil
+	}
+	_, err = testStore.CreateCandidateApplicationTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, 2, filled)
 }
 
-func TestGetCandidateApplicationsByStatusRejected(t *testing.T) {
-	application := createRandomCandidateApplication(t, ApplicationStatusRejected)
-	applications, err := testStore.GetCandidateApplicationsByStatusRejected(context.Background(), application.CandidateID)
+func TestCountApplicantsByJobs(t *testing.T) {
+	open := createRandomCandidateApplication(t, ApplicationStatusPending)
+	closed := createRandomCandidateApplication(t, ApplicationStatusRejected)
+	withdrawn := createRandomCandidateApplication(t, ApplicationStatusWithdrawn)
+
+	rows, err := testStore.CountApplicantsByJobs(context.Background(), []string{open.JobDocID, closed.JobDocID, withdrawn.JobDocID, util.RandomString(8)})
 	require.NoError(t, err)
-	require.NotEmpty(t, applications)
+	require.Len(t, rows, 3)
 
-	for _, app := range applications {
-		require.Equal(t, application.CandidateID, app.CandidateID)
-		require.Equal(t, ApplicationStatusRejected, app.ApplicationStatus)
+	counts := map[string]CountApplicantsByJobsRow{}
+	for _, row := range rows {
+		counts[row.JobDocID] = row
 	}
+	require.Equal(t, int64(1), counts[open.JobDocID].Applicants)
+	require.Equal(t, int64(1), counts[open.JobDocID].OpenApplicants)
+	require.Equal(t, int64(1), counts[closed.JobDocID].Applicants)
+	require.Zero(t, counts[closed.JobDocID].OpenApplicants)
+	require.Zero(t, counts[withdrawn.JobDocID].Applicants)
+	require.Zero(t, counts[withdrawn.JobDocID].OpenApplicants)
 }
 
-func TestGetCandidateApplicationsByStatusSubmitted(t *testing.T) {
+func TestWithdrawCandidateApplicationTx(t *testing.T) {
+	ctx := context.Background()
 	application := createRandomCandidateApplication(t, ApplicationStatusSubmitted)
-	applications, err := testStore.GetCandidateApplicationsByStatusSubmitted(context.Background(), application.CandidateID)
+	var hookCalls int
+	arg := WithdrawCandidateApplicationTxParams{
+		CandidateID: application.CandidateID,
+		JobDocID:    application.JobDocID,
+		Reason:      WithdrawalReasonAcceptedOtherOffer,
+		Note:        "Starting next week",
+		AfterUpdate: func(result TransitionApplicationStatusTxResult) error {
+			hookCalls++
+			return nil
+		},
+	}
+
+	result, err := testStore.WithdrawCandidateApplicationTx(ctx, arg)
 	require.NoError(t, err)
-	require.NotEmpty(t, applications)
+	require.True(t, result.Changed)
+	require.Equal(t, 1, hookCalls)
+	require.Equal(t, ApplicationStatusWithdrawn, result.CandidateApplication.ApplicationStatus)
+	require.Equal(t, WithdrawalReasonAcceptedOtherOffer, result.CandidateApplication.WithdrawalReason.WithdrawalReason)
+	require.Equal(t, "Starting next week", result.CandidateApplication.WithdrawalNote)
+	require.WithinDuration(t, time.Now(), result.CandidateApplication.WithdrawnAt.Time, time.Minute)
+	require.Equal(t, ApplicationStatusSubmitted, result.History.FromStatus)
+	require.Equal(t, "accepted_other_offer: Starting next week", result.History.Reason)
 
-	for _, app := range applications {
-		require.Equal(t, application.CandidateID, app.CandidateID)
-		require.Equal(t, ApplicationStatusSubmitted, app.ApplicationStatus)
+	// Withdrawing again changes nothing.
+	result, err = testStore.WithdrawCandidateApplicationTx(ctx, arg)
+	require.NoError(t, err)
+	require.False(t, result.Changed)
+	require.Equal(t, 1, hookCalls)
+
+	// Withdrawn applications stay out of the employer's pipeline unless asked
+	// for, and no longer count towards the job's limit.
+	pipeline, err := testStore.ListCandidateApplicationsNewestFirst(ctx, ListCandidateApplicationsNewestFirstParams{
+		EmployerID: application.EmployerID,
+		Statuses:   PipelineStatuses,
+		Limit:      10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, pipeline)
+	withdrawn, err := testStore.ListCandidateApplicationsNewestFirst(ctx, ListCandidateApplicationsNewestFirstParams{
+		EmployerID: application.EmployerID,
+		Statuses:   []string{string(ApplicationStatusWithdrawn)},
+		Limit:      10,
+	})
+	require.NoError(t, err)
+	require.Len(t, withdrawn, 1)
+	count, err := testStore.CountCandidateApplicationsByJob(ctx, application.JobDocID)
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
+
+func TestWithdrawCandidateApplicationTxDecided(t *testing.T) {
+	application := createRandomCandidateApplication(t, ApplicationStatusAccepted)
+	_, err := testStore.WithdrawCandidateApplicationTx(context.Background(), WithdrawCandidateApplicationTxParams{
+		CandidateID: application.CandidateID,
+		JobDocID:    application.JobDocID,
+		Reason:      WithdrawalReasonOther,
+	})
+	require.ErrorIs(t, err, ErrInvalidStatusTransition)
+}
+
+func TestCreateCandidateApplicationTxReapply(t *testing.T) {
+	ctx := context.Background()
+	application := createRandomCandidateApplication(t, ApplicationStatusSubmitted)
+	_, err := testStore.WithdrawCandidateApplicationTx(ctx, WithdrawCandidateApplicationTxParams{
+		CandidateID: application.CandidateID,
+		JobDocID:    application.JobDocID,
+		Reason:      WithdrawalReasonScheduleConflict,
+	})
+	require.NoError(t, err)
+
+	arg := CreateCandidateApplicationTxParams{
+		CreateCandidateApplicationParams: CreateCandidateApplicationParams{
+			CandidateID:        application.CandidateID,
+			EmployerID:         application.EmployerID,
+			ElasticsearchDocID: application.ElasticsearchDocID,
+			JobDocID:           application.JobDocID,
+			ApplicationStatus:  ApplicationStatusPending,
+		},
+		ReapplyCooldown: time.Hour,
+		AfterCreate: func(docID string, appDoc map[string]interface{}) error {
+			return nil
+		},
 	}
+	_, err = testStore.CreateCandidateApplicationTx(ctx, arg)
+	require.ErrorIs(t, err, ErrReapplyTooSoon)
+
+	arg.ReapplyCooldown = 0
+	result, err := testStore.CreateCandidateApplicationTx(ctx, arg)
+	require.NoError(t, err)
+	require.Equal(t, ApplicationStatusPending, result.CandidateApplication.ApplicationStatus)
+	require.False(t, result.CandidateApplication.WithdrawalReason.Valid)
+	require.False(t, result.CandidateApplication.WithdrawnAt.Valid)
+	require.Empty(t, result.CandidateApplication.WithdrawalNote)
+
+	// The withdrawal and the new application are both in the history.
+	history, err := testStore.ListApplicationStatusHistory(ctx, ListApplicationStatusHistoryParams{
+		ApplicationKind: string(ApplicationKindCandidate),
+		CandidateID:     pgtype.Int8{Int64: application.CandidateID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	require.Equal(t, ApplicationStatusWithdrawn, history[0].ToStatus)
+	require.Equal(t, ApplicationStatusWithdrawn, history[1].FromStatus)
+	require.Equal(t, ApplicationStatusPending, history[1].ToStatus)
+
+	// Applying again while the application is open still conflicts.
+	_, err = testStore.CreateCandidateApplicationTx(ctx, arg)
+	require.Equal(t, UniqueViolation, ErrorCode(err))
 }
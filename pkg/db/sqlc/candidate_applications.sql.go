@@ -11,6 +11,53 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countApplicantsByJobs = `-- name: CountApplicantsByJobs :many
+SELECT job_doc_id,
+       COUNT(*) FILTER (WHERE application_status <> 'withdrawn') AS applicants,
+       COUNT(*) FILTER (WHERE application_status IN ('pending', 'submitted')) AS open_applicants
+FROM candidate_applications
+WHERE job_doc_id = ANY($1::varchar[])
+GROUP BY job_doc_id
+`
+
+type CountApplicantsByJobsRow struct {
+	JobDocID       string `json:"job_doc_id"`
+	Applicants     int64  `json:"applicants"`
+	OpenApplicants int64  `json:"open_applicants"`
+}
+
+func (q *Queries) CountApplicantsByJobs(ctx context.Context, jobDocIds []string) ([]CountApplicantsByJobsRow, error) {
+	rows, err := q.db.Query(ctx, countApplicantsByJobs, jobDocIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CountApplicantsByJobsRow{}
+	for rows.Next() {
+		var i CountApplicantsByJobsRow
+		if err := rows.Scan(&i.JobDocID, &i.Applicants, &i.OpenApplicants); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countCandidateApplicationsByJob = `-- name: CountCandidateApplicationsByJob :one
+SELECT COUNT(*) FROM candidate_applications
+WHERE job_doc_id = $1 AND application_status <> 'withdrawn'
+`
+
+func (q *Queries) CountCandidateApplicationsByJob(ctx context.Context, jobDocID string) (int64, error) {
+	row := q.db.QueryRow(ctx, countCandidateApplicationsByJob, jobDocID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createCandidateApplication = `-- name: CreateCandidateApplication :one
 INSERT INTO candidate_applications (
     candidate_id,
@@ -20,7 +67,7 @@ INSERT INTO candidate_applications (
     application_status
 ) VALUES (
              $1, $2, $3, $4, $5
-         ) RETURNING candidate_id, employer_id, elasticsearch_doc_id, job_doc_id, application_status, created_at
+         ) RETURNING candidate_id, employer_id, elasticsearch_doc_id, job_doc_id, application_status, created_at, withdrawal_reason, withdrawal_note, withdrawn_at
 `
 
 type CreateCandidateApplicationParams struct {
@@ -47,27 +94,98 @@ func (q *Queries) CreateCandidateApplication(ctx context.Context, arg CreateCand
 		&i.JobDocID,
 		&i.ApplicationStatus,
 		&i.CreatedAt,
+		&i.WithdrawalReason,
+		&i.WithdrawalNote,
+		&i.WithdrawnAt,
 	)
 	return i, err
 }
 
-const deleteCandidateApplication = `-- name: DeleteCandidateApplication :exec
+const deleteCandidateApplication = `-- name: DeleteCandidateApplication :one
 DELETE FROM candidate_applications
-WHERE candidate_id = $1 AND employer_id = $2
+WHERE candidate_id = $1 AND job_doc_id = $2
+RETURNING candidate_id, employer_id, elasticsearch_doc_id, job_doc_id, application_status, created_at, withdrawal_reason, withdrawal_note, withdrawn_at
 `
 
 type DeleteCandidateApplicationParams struct {
-	CandidateID int64 `json:"candidate_id"`
-	EmployerID  int64 `json:"employer_id"`
+	CandidateID int64  `json:"candidate_id"`
+	JobDocID    string `json:"job_doc_id"`
 }
 
-func (q *Queries) DeleteCandidateApplication(ctx context.Context, arg DeleteCandidateApplicationParams) error {
-	_, err := q.db.Exec(ctx, deleteCandidateApplication, arg.CandidateID, arg.EmployerID)
-	return err
+func (q *Queries) DeleteCandidateApplication(ctx context.Context, arg DeleteCandidateApplicationParams) (CandidateApplication, error) {
+	row := q.db.QueryRow(ctx, deleteCandidateApplication, arg.CandidateID, arg.JobDocID)
+	var i CandidateApplication
+	err := row.Scan(
+		&i.CandidateID,
+		&i.EmployerID,
+		&i.ElasticsearchDocID,
+		&i.JobDocID,
+		&i.ApplicationStatus,
+		&i.CreatedAt,
+		&i.WithdrawalReason,
+		&i.WithdrawalNote,
+		&i.WithdrawnAt,
+	)
+	return i, err
+}
+
+const getCandidateApplication = `-- name: GetCandidateApplication :one
+SELECT candidate_id, employer_id, elasticsearch_doc_id, job_doc_id, application_status, created_at, withdrawal_reason, withdrawal_note, withdrawn_at FROM candidate_applications
+WHERE candidate_id = $1 AND job_doc_id = $2 LIMIT 1
+`
+
+type GetCandidateApplicationParams struct {
+	CandidateID int64  `json:"candidate_id"`
+	JobDocID    string `json:"job_doc_id"`
+}
+
+func (q *Queries) GetCandidateApplication(ctx context.Context, arg GetCandidateApplicationParams) (CandidateApplication, error) {
+	row := q.db.QueryRow(ctx, getCandidateApplication, arg.CandidateID, arg.JobDocID)
+	var i CandidateApplication
+	err := row.Scan(
+		&i.CandidateID,
+		&i.EmployerID,
+		&i.ElasticsearchDocID,
+		&i.JobDocID,
+		&i.ApplicationStatus,
+		&i.CreatedAt,
+		&i.WithdrawalReason,
+		&i.WithdrawalNote,
+		&i.WithdrawnAt,
+	)
+	return i, err
+}
+
+const getCandidateApplicationForUpdate = `-- name: GetCandidateApplicationForUpdate :one
+SELECT candidate_id, employer_id, elasticsearch_doc_id, job_doc_id, application_status, created_at, withdrawal_reason, withdrawal_note, withdrawn_at FROM candidate_applications
+WHERE candidate_id = $1 AND job_doc_id = $2 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+type GetCandidateApplicationForUpdateParams struct {
+	CandidateID int64  `json:"candidate_id"`
+	JobDocID    string `json:"job_doc_id"`
+}
+
+func (q *Queries) GetCandidateApplicationForUpdate(ctx context.Context, arg GetCandidateApplicationForUpdateParams) (CandidateApplication, error) {
+	row := q.db.QueryRow(ctx, getCandidateApplicationForUpdate, arg.CandidateID, arg.JobDocID)
+	var i CandidateApplication
+	err := row.Scan(
+		&i.CandidateID,
+		&i.EmployerID,
+		&i.ElasticsearchDocID,
+		&i.JobDocID,
+		&i.ApplicationStatus,
+		&i.CreatedAt,
+		&i.WithdrawalReason,
+		&i.WithdrawalNote,
+		&i.WithdrawnAt,
+	)
+	return i, err
 }
 
 const getCandidateApplicationsByEmployer = `-- name: GetCandidateApplicationsByEmployer :many
-SELECT candidate_id, employer_id, elasticsearch_doc_id, job_doc_id, application_status, created_at FROM candidate_applications
+SELECT candidate_id, employer_id, elasticsearch_doc_id, job_doc_id, application_status, created_at, withdrawal_reason, withdrawal_note, withdrawn_at FROM candidate_applications
 WHERE employer_id = $1
 ORDER BY created_at DESC
 `
@@ -88,6 +206,9 @@ func (q *Queries) GetCandidateApplicationsByEmployer(ctx context.Context, employ
 			&i.JobDocID,
 			&i.ApplicationStatus,
 			&i.CreatedAt,
+			&i.WithdrawalReason,
+			&i.WithdrawalNote,
+			&i.WithdrawnAt,
 		); err != nil {
 			return nil, err
 		}
@@ -99,14 +220,45 @@ func (q *Queries) GetCandidateApplicationsByEmployer(ctx context.Context, employ
 	return items, nil
 }
 
-const getCandidateApplicationsByStatusAccepted = `-- name: GetCandidateApplicationsByStatusAccepted :many
-SELECT candidate_id, employer_id, elasticsearch_doc_id, job_doc_id, application_status, created_at FROM candidate_applications
-WHERE application_status = 'accepted' AND candidate_id = $1
-ORDER BY created_at DESC
+const listCandidateApplicationsNewestFirst = `-- name: ListCandidateApplicationsNewestFirst :many
+SELECT candidate_id, employer_id, elasticsearch_doc_id, job_doc_id, application_status, created_at, withdrawal_reason, withdrawal_note, withdrawn_at FROM candidate_applications
+WHERE employer_id = $1
+  AND application_status = ANY($2::text[]::application_status[])
+  AND ($3::varchar[] IS NULL OR job_doc_id = ANY($3::varchar[]))
+  AND ($4::timestamptz IS NULL OR created_at >= $4)
+  AND ($5::timestamptz IS NULL OR created_at < $5)
+  AND (created_at, candidate_id, job_doc_id) < (
+    COALESCE($6::timestamptz, 'infinity'),
+    COALESCE($7::bigint, 0),
+    COALESCE($8::varchar, ''))
+ORDER BY created_at DESC, candidate_id DESC, job_doc_id DESC
+LIMIT $9
 `
 
-func (q *Queries) GetCandidateApplicationsByStatusAccepted(ctx context.Context, candidateID int64) ([]CandidateApplication, error) {
-	rows, err := q.db.Query(ctx, getCandidateApplicationsByStatusAccepted, candidateID)
+type ListCandidateApplicationsNewestFirstParams struct {
+	EmployerID      int64              `json:"employer_id"`
+	Statuses        []string           `json:"statuses"`
+	JobDocIds       []string           `json:"job_doc_ids"`
+	CreatedAfter    pgtype.Timestamptz `json:"created_after"`
+	CreatedBefore   pgtype.Timestamptz `json:"created_before"`
+	CursorCreatedAt pgtype.Timestamptz `json:"cursor_created_at"`
+	CursorID        pgtype.Int8        `json:"cursor_id"`
+	CursorJobDocID  pgtype.Text        `json:"cursor_job_doc_id"`
+	Limit           int32              `json:"limit"`
+}
+
+func (q *Queries) ListCandidateApplicationsNewestFirst(ctx context.Context, arg ListCandidateApplicationsNewestFirstParams) ([]CandidateApplication, error) {
+	rows, err := q.db.Query(ctx, listCandidateApplicationsNewestFirst,
+		arg.EmployerID,
+		arg.Statuses,
+		arg.JobDocIds,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.CursorJobDocID,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -121,6 +273,9 @@ func (q *Queries) GetCandidateApplicationsByStatusAccepted(ctx context.Context,
 			&i.JobDocID,
 			&i.ApplicationStatus,
 			&i.CreatedAt,
+			&i.WithdrawalReason,
+			&i.WithdrawalNote,
+			&i.WithdrawnAt,
 		); err != nil {
 			return nil, err
 		}
@@ -132,14 +287,45 @@ func (q *Queries) GetCandidateApplicationsByStatusAccepted(ctx context.Context,
 	return items, nil
 }
 
-const getCandidateApplicationsByStatusPending = `-- name: GetCandidateApplicationsByStatusPending :many
-SELECT candidate_id, employer_id, elasticsearch_doc_id, job_doc_id, application_status, created_at FROM candidate_applications
-WHERE application_status = 'pending' AND candidate_id = $1
-ORDER BY created_at DESC
+const listCandidateApplicationsOldestFirst = `-- name: ListCandidateApplicationsOldestFirst :many
+SELECT candidate_id, employer_id, elasticsearch_doc_id, job_doc_id, application_status, created_at, withdrawal_reason, withdrawal_note, withdrawn_at FROM candidate_applications
+WHERE employer_id = $1
+  AND application_status = ANY($2::text[]::application_status[])
+  AND ($3::varchar[] IS NULL OR job_doc_id = ANY($3::varchar[]))
+  AND ($4::timestamptz IS NULL OR created_at >= $4)
+  AND ($5::timestamptz IS NULL OR created_at < $5)
+  AND (created_at, candidate_id, job_doc_id) > (
+    COALESCE($6::timestamptz, '-infinity'),
+    COALESCE($7::bigint, 0),
+    COALESCE($8::varchar, ''))
+ORDER BY created_at, candidate_id, job_doc_id
+LIMIT $9
 `
 
-func (q *Queries) GetCandidateApplicationsByStatusPending(ctx context.Context, candidateID int64) ([]CandidateApplication, error) {
-	rows, err := q.db.Query(ctx, getCandidateApplicationsByStatusPending, candidateID)
+type ListCandidateApplicationsOldestFirstParams struct {
+	EmployerID      int64              `json:"employer_id"`
+	Statuses        []string           `json:"statuses"`
+	JobDocIds       []string           `json:"job_doc_ids"`
+	CreatedAfter    pgtype.Timestamptz `json:"created_after"`
+	CreatedBefore   pgtype.Timestamptz `json:"created_before"`
+	CursorCreatedAt pgtype.Timestamptz `json:"cursor_created_at"`
+	CursorID        pgtype.Int8        `json:"cursor_id"`
+	CursorJobDocID  pgtype.Text        `json:"cursor_job_doc_id"`
+	Limit           int32              `json:"limit"`
+}
+
+func (q *Queries) ListCandidateApplicationsOldestFirst(ctx context.Context, arg ListCandidateApplicationsOldestFirstParams) ([]CandidateApplication, error) {
+	rows, err := q.db.Query(ctx, listCandidateApplicationsOldestFirst,
+		arg.EmployerID,
+		arg.Statuses,
+		arg.JobDocIds,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.CursorJobDocID,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -154,6 +340,9 @@ func (q *Queries) GetCandidateApplicationsByStatusPending(ctx context.Context, c
 			&i.JobDocID,
 			&i.ApplicationStatus,
 			&i.CreatedAt,
+			&i.WithdrawalReason,
+			&i.WithdrawalNote,
+			&i.WithdrawnAt,
 		); err != nil {
 			return nil, err
 		}
@@ -165,32 +354,41 @@ func (q *Queries) GetCandidateApplicationsByStatusPending(ctx context.Context, c
 	return items, nil
 }
 
-const getCandidateApplicationsByStatusRejected = `-- name: GetCandidateApplicationsByStatusRejected :many
-SELECT candidate_id, employer_id, elasticsearch_doc_id, job_doc_id, application_status, created_at FROM candidate_applications
-WHERE application_status = 'rejected' AND candidate_id = $1
-ORDER BY created_at DESC
+const listCandidateApplicationJobIDs = `-- name: ListCandidateApplicationJobIDs :many
+SELECT DISTINCT job_doc_id FROM candidate_applications
+WHERE employer_id = $1
+  AND (CASE WHEN $2::text[] IS NULL THEN application_status <> 'withdrawn'
+    ELSE application_status = ANY($2::text[]::application_status[]) END)
+  AND ($3::timestamptz IS NULL OR created_at >= $3)
+  AND ($4::timestamptz IS NULL OR created_at < $4)
+ORDER BY job_doc_id
 `
 
-func (q *Queries) GetCandidateApplicationsByStatusRejected(ctx context.Context, candidateID int64) ([]CandidateApplication, error) {
-	rows, err := q.db.Query(ctx, getCandidateApplicationsByStatusRejected, candidateID)
+type ListCandidateApplicationJobIDsParams struct {
+	EmployerID    int64              `json:"employer_id"`
+	Statuses      []string           `json:"statuses"`
+	CreatedAfter  pgtype.Timestamptz `json:"created_after"`
+	CreatedBefore pgtype.Timestamptz `json:"created_before"`
+}
+
+func (q *Queries) ListCandidateApplicationJobIDs(ctx context.Context, arg ListCandidateApplicationJobIDsParams) ([]string, error) {
+	rows, err := q.db.Query(ctx, listCandidateApplicationJobIDs,
+		arg.EmployerID,
+		arg.Statuses,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []CandidateApplication{}
+	items := []string{}
 	for rows.Next() {
-		var i CandidateApplication
-		if err := rows.Scan(
-			&i.CandidateID,
-			&i.EmployerID,
-			&i.ElasticsearchDocID,
-			&i.JobDocID,
-			&i.ApplicationStatus,
-			&i.CreatedAt,
-		); err != nil {
+		var job_doc_id string
+		if err := rows.Scan(&job_doc_id); err != nil {
 			return nil, err
 		}
-		items = append(items, i)
+		items = append(items, job_doc_id)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
@@ -198,29 +396,66 @@ func (q *Queries) GetCandidateApplicationsByStatusRejected(ctx context.Context,
 	return items, nil
 }
 
-const getCandidateApplicationsByStatusSubmitted = `-- name: GetCandidateApplicationsByStatusSubmitted :many
-SELECT candidate_id, employer_id, elasticsearch_doc_id, job_doc_id, application_status, created_at FROM candidate_applications
-WHERE application_status = 'submitted' AND candidate_id = $1
-ORDER BY created_at DESC
+const listCandidateIDsByJob = `-- name: ListCandidateIDsByJob :many
+SELECT candidate_id FROM candidate_applications
+WHERE job_doc_id = $1
+  AND employer_id = $2
+  AND application_status = ANY($3::text[]::application_status[])
+ORDER BY candidate_id
 `
 
-func (q *Queries) GetCandidateApplicationsByStatusSubmitted(ctx context.Context, candidateID int64) ([]CandidateApplication, error) {
-	rows, err := q.db.Query(ctx, getCandidateApplicationsByStatusSubmitted, candidateID)
+type ListCandidateIDsByJobParams struct {
+	JobDocID   string   `json:"job_doc_id"`
+	EmployerID int64    `json:"employer_id"`
+	Statuses   []string `json:"statuses"`
+}
+
+func (q *Queries) ListCandidateIDsByJob(ctx context.Context, arg ListCandidateIDsByJobParams) ([]int64, error) {
+	rows, err := q.db.Query(ctx, listCandidateIDsByJob, arg.JobDocID, arg.EmployerID, arg.Statuses)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []CandidateApplication{}
+	items := []int64{}
 	for rows.Next() {
-		var i CandidateApplication
-		if err := rows.Scan(
-			&i.CandidateID,
-			&i.EmployerID,
-			&i.ElasticsearchDocID,
-			&i.JobDocID,
-			&i.ApplicationStatus,
-			&i.CreatedAt,
-		); err != nil {
+		var candidate_id int64
+		if err := rows.Scan(&candidate_id); err != nil {
+			return nil, err
+		}
+		items = append(items, candidate_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOpenApplicantsByJob = `-- name: ListOpenApplicantsByJob :many
+SELECT ca.candidate_id, c.full_name, u.email
+FROM candidate_applications ca
+JOIN candidates c ON c.id = ca.candidate_id
+JOIN users u ON u.username = c.username
+WHERE ca.job_doc_id = $1
+  AND ca.application_status IN ('pending', 'submitted')
+ORDER BY ca.created_at DESC
+`
+
+type ListOpenApplicantsByJobRow struct {
+	CandidateID int64  `json:"candidate_id"`
+	FullName    string `json:"full_name"`
+	Email       string `json:"email"`
+}
+
+func (q *Queries) ListOpenApplicantsByJob(ctx context.Context, jobDocID string) ([]ListOpenApplicantsByJobRow, error) {
+	rows, err := q.db.Query(ctx, listOpenApplicantsByJob, jobDocID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListOpenApplicantsByJobRow{}
+	for rows.Next() {
+		var i ListOpenApplicantsByJobRow
+		if err := rows.Scan(&i.CandidateID, &i.FullName, &i.Email); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -231,17 +466,70 @@ func (q *Queries) GetCandidateApplicationsByStatusSubmitted(ctx context.Context,
 	return items, nil
 }
 
+const lockJobApplications = `-- name: LockJobApplications :exec
+SELECT pg_advisory_xact_lock(hashtext($1::text))
+`
+
+func (q *Queries) LockJobApplications(ctx context.Context, jobDocID string) error {
+	_, err := q.db.Exec(ctx, lockJobApplications, jobDocID)
+	return err
+}
+
+const reopenCandidateApplication = `-- name: ReopenCandidateApplication :one
+UPDATE candidate_applications
+SET employer_id = $3,
+    elasticsearch_doc_id = $4,
+    application_status = $5,
+    withdrawal_reason = NULL,
+    withdrawal_note = '',
+    withdrawn_at = NULL,
+    created_at = now()
+WHERE candidate_id = $1 AND job_doc_id = $2
+RETURNING candidate_id, employer_id, elasticsearch_doc_id, job_doc_id, application_status, created_at, withdrawal_reason, withdrawal_note, withdrawn_at
+`
+
+type ReopenCandidateApplicationParams struct {
+	CandidateID        int64             `json:"candidate_id"`
+	JobDocID           string            `json:"job_doc_id"`
+	EmployerID         int64             `json:"employer_id"`
+	ElasticsearchDocID string            `json:"elasticsearch_doc_id"`
+	ApplicationStatus  ApplicationStatus `json:"application_status"`
+}
+
+func (q *Queries) ReopenCandidateApplication(ctx context.Context, arg ReopenCandidateApplicationParams) (CandidateApplication, error) {
+	row := q.db.QueryRow(ctx, reopenCandidateApplication,
+		arg.CandidateID,
+		arg.JobDocID,
+		arg.EmployerID,
+		arg.ElasticsearchDocID,
+		arg.ApplicationStatus,
+	)
+	var i CandidateApplication
+	err := row.Scan(
+		&i.CandidateID,
+		&i.EmployerID,
+		&i.ElasticsearchDocID,
+		&i.JobDocID,
+		&i.ApplicationStatus,
+		&i.CreatedAt,
+		&i.WithdrawalReason,
+		&i.WithdrawalNote,
+		&i.WithdrawnAt,
+	)
+	return i, err
+}
+
 const updateCandidateApplication = `-- name: UpdateCandidateApplication :one
 UPDATE candidate_applications
 SET application_status = COALESCE($3, application_status),
     elasticsearch_doc_id = COALESCE($4, elasticsearch_doc_id)
-WHERE candidate_id = $1 AND employer_id = $2
-RETURNING candidate_id, employer_id, elasticsearch_doc_id, job_doc_id, application_status, created_at
+WHERE candidate_id = $1 AND job_doc_id = $2
+RETURNING candidate_id, employer_id, elasticsearch_doc_id, job_doc_id, application_status, created_at, withdrawal_reason, withdrawal_note, withdrawn_at
 `
 
 type UpdateCandidateApplicationParams struct {
 	CandidateID        int64                 `json:"candidate_id"`
-	EmployerID         int64                 `json:"employer_id"`
+	JobDocID           string                `json:"job_doc_id"`
 	ApplicationStatus  NullApplicationStatus `json:"application_status"`
 	ElasticsearchDocID pgtype.Text           `json:"elasticsearch_doc_id"`
 }
@@ -249,7 +537,7 @@ type UpdateCandidateApplicationParams struct {
 func (q *Queries) UpdateCandidateApplication(ctx context.Context, arg UpdateCandidateApplicationParams) (CandidateApplication, error) {
 	row := q.db.QueryRow(ctx, updateCandidateApplication,
 		arg.CandidateID,
-		arg.EmployerID,
+		arg.JobDocID,
 		arg.ApplicationStatus,
 		arg.ElasticsearchDocID,
 	)
@@ -261,6 +549,9 @@ func (q *Queries) UpdateCandidateApplication(ctx context.Context, arg UpdateCand
 		&i.JobDocID,
 		&i.ApplicationStatus,
 		&i.CreatedAt,
+		&i.WithdrawalReason,
+		&i.WithdrawalNote,
+		&i.WithdrawnAt,
 	)
 	return i, err
 }
@@ -268,16 +559,54 @@ func (q *Queries) UpdateCandidateApplication(ctx context.Context, arg UpdateCand
 const updateCandidateApplicationStatus = `-- name: UpdateCandidateApplicationStatus :exec
 UPDATE candidate_applications
 SET application_status = $3
-WHERE candidate_id = $1 AND employer_id = $2
+WHERE candidate_id = $1 AND job_doc_id = $2
 `
 
 type UpdateCandidateApplicationStatusParams struct {
 	CandidateID       int64             `json:"candidate_id"`
-	EmployerID        int64             `json:"employer_id"`
+	JobDocID          string            `json:"job_doc_id"`
 	ApplicationStatus ApplicationStatus `json:"application_status"`
 }
 
 func (q *Queries) UpdateCandidateApplicationStatus(ctx context.Context, arg UpdateCandidateApplicationStatusParams) error {
-	_, err := q.db.Exec(ctx, updateCandidateApplicationStatus, arg.CandidateID, arg.EmployerID, arg.ApplicationStatus)
+	_, err := q.db.Exec(ctx, updateCandidateApplicationStatus, arg.CandidateID, arg.JobDocID, arg.ApplicationStatus)
 	return err
 }
+
+const withdrawCandidateApplication = `-- name: WithdrawCandidateApplication :one
+UPDATE candidate_applications
+SET withdrawal_reason = $3,
+    withdrawal_note = $4,
+    withdrawn_at = now()
+WHERE candidate_id = $1 AND job_doc_id = $2
+RETURNING candidate_id, employer_id, elasticsearch_doc_id, job_doc_id, application_status, created_at, withdrawal_reason, withdrawal_note, withdrawn_at
+`
+
+type WithdrawCandidateApplicationParams struct {
+	CandidateID      int64                `json:"candidate_id"`
+	JobDocID         string               `json:"job_doc_id"`
+	WithdrawalReason NullWithdrawalReason `json:"withdrawal_reason"`
+	WithdrawalNote   string               `json:"withdrawal_note"`
+}
+
+func (q *Queries) WithdrawCandidateApplication(ctx context.Context, arg WithdrawCandidateApplicationParams) (CandidateApplication, error) {
+	row := q.db.QueryRow(ctx, withdrawCandidateApplication,
+		arg.CandidateID,
+		arg.JobDocID,
+		arg.WithdrawalReason,
+		arg.WithdrawalNote,
+	)
+	var i CandidateApplication
+	err := row.Scan(
+		&i.CandidateID,
+		&i.EmployerID,
+		&i.ElasticsearchDocID,
+		&i.JobDocID,
+		&i.ApplicationStatus,
+		&i.CreatedAt,
+		&i.WithdrawalReason,
+		&i.WithdrawalNote,
+		&i.WithdrawnAt,
+	)
+	return i, err
+}
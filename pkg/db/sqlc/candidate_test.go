@@ -161,3 +161,54 @@ func TestListCandidates(t *testing.T) {
 		require.NotEmpty(t, candidate)
 	}
 }
+
+func TestListCandidateContacts(t *testing.T) {
+	first := createRandomCandidate(t)
+	second := createRandomCandidate(t)
+
+	contacts, err := testStore.ListCandidateContacts(context.Background(), []int64{second.ID, first.ID, -1})
+	require.NoError(t, err)
+	require.Len(t, contacts, 2)
+	require.Equal(t, first.ID, contacts[0].ID)
+	require.Equal(t, first.FullName, contacts[0].FullName)
+	require.NotEmpty(t, contacts[0].Email)
+	require.True(t, contacts[0].ApplicationDecisions)
+	require.Equal(t, second.ID, contacts[1].ID)
+
+	_, err = testStore.UpsertNotificationPreference(context.Background(), UpsertNotificationPreferenceParams{
+		Username:             second.Username,
+		ApplicationDecisions: pgtype.Bool{Bool: false, Valid: true},
+	})
+	require.NoError(t, err)
+	contacts, err = testStore.ListCandidateContacts(context.Background(), []int64{second.ID})
+	require.NoError(t, err)
+	require.Len(t, contacts, 1)
+	require.False(t, contacts[0].ApplicationDecisions)
+}
+
+func TestListCandidateProfiles(t *testing.T) {
+	candidate := createRandomCandidate(t)
+
+	profiles, err := testStore.ListCandidateProfiles(context.Background(), []int64{candidate.ID, -1})
+	require.NoError(t, err)
+	require.Len(t, profiles, 1)
+	require.Equal(t, candidate.ID, profiles[0].ID)
+	require.NotEmpty(t, profiles[0].Email)
+	require.Equal(t, candidate.PhoneNumber, profiles[0].PhoneNumber)
+	require.Equal(t, candidate.Location, profiles[0].Location)
+	require.Equal(t, candidate.Education, profiles[0].Education)
+	require.Equal(t, candidate.SkillSet, profiles[0].SkillSet)
+	require.Equal(t, candidate.Certificates, profiles[0].Certificates)
+}
+
+func TestListCandidatesByIDs(t *testing.T) {
+	candidate1 := createRandomCandidate(t)
+	candidate2 := createRandomCandidate(t)
+
+	candidates, err := testStore.ListCandidatesByIDs(context.Background(), []int64{candidate2.ID, candidate1.ID, -1})
+	require.NoError(t, err)
+	require.Len(t, candidates, 2)
+	require.Equal(t, candidate1.ID, candidates[0].ID)
+	require.Equal(t, candidate1.TimeAvailability, candidates[0].TimeAvailability)
+	require.Equal(t, candidate2.ID, candidates[1].ID)
+}
@@ -27,6 +27,17 @@ func (q *Queries) AddJobListing(ctx context.Context, arg AddJobListingParams) er
 	return err
 }
 
+const clearEmployerDescription = `-- name: ClearEmployerDescription :exec
+UPDATE employers
+SET business_description = ''
+WHERE id = $1
+`
+
+func (q *Queries) ClearEmployerDescription(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, clearEmployerDescription, id)
+	return err
+}
+
 const createEmployer = `-- name: CreateEmployer :one
 INSERT INTO employers (
     username,
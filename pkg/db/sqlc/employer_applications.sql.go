@@ -8,6 +8,8 @@ package db
 import (
 	"context"
 	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const createEmployerApplication = `-- name: CreateEmployerApplication :one
@@ -16,18 +18,22 @@ INSERT INTO employer_applications (
     candidate_id,
     message,
     application_status,
-    created_at
+    created_at,
+    job_doc_id,
+    expires_at
 ) VALUES (
-             $1, $2, $3, $4, $5
-         ) RETURNING employer_id, candidate_id, message, application_status, created_at
+             $1, $2, $3, $4, $5, $6, $7
+         ) RETURNING employer_id, candidate_id, message, application_status, created_at, job_doc_id, expires_at
 `
 
 type CreateEmployerApplicationParams struct {
-	EmployerID        int64             `json:"employer_id"`
-	CandidateID       int64             `json:"candidate_id"`
-	Message           string            `json:"message"`
-	ApplicationStatus ApplicationStatus `json:"application_status"`
-	CreatedAt         time.Time         `json:"created_at"`
+	EmployerID        int64              `json:"employer_id"`
+	CandidateID       int64              `json:"candidate_id"`
+	Message           string             `json:"message"`
+	ApplicationStatus ApplicationStatus  `json:"application_status"`
+	CreatedAt         time.Time          `json:"created_at"`
+	JobDocID          string             `json:"job_doc_id"`
+	ExpiresAt         pgtype.Timestamptz `json:"expires_at"`
 }
 
 func (q *Queries) CreateEmployerApplication(ctx context.Context, arg CreateEmployerApplicationParams) (EmployerApplication, error) {
@@ -37,6 +43,8 @@ func (q *Queries) CreateEmployerApplication(ctx context.Context, arg CreateEmplo
 		arg.Message,
 		arg.ApplicationStatus,
 		arg.CreatedAt,
+		arg.JobDocID,
+		arg.ExpiresAt,
 	)
 	var i EmployerApplication
 	err := row.Scan(
@@ -45,6 +53,8 @@ func (q *Queries) CreateEmployerApplication(ctx context.Context, arg CreateEmplo
 		&i.Message,
 		&i.ApplicationStatus,
 		&i.CreatedAt,
+		&i.JobDocID,
+		&i.ExpiresAt,
 	)
 	return i, err
 }
@@ -64,110 +74,65 @@ func (q *Queries) DeleteEmployerApplication(ctx context.Context, arg DeleteEmplo
 	return err
 }
 
-const getEmployerApplicationsByCandidate = `-- name: GetEmployerApplicationsByCandidate :many
-SELECT employer_id, candidate_id, message, application_status, created_at FROM employer_applications
-WHERE candidate_id = $1
-ORDER BY created_at DESC
+const getEmployerApplication = `-- name: GetEmployerApplication :one
+SELECT employer_id, candidate_id, message, application_status, created_at, job_doc_id, expires_at FROM employer_applications
+WHERE employer_id = $1 AND candidate_id = $2 LIMIT 1
 `
 
-func (q *Queries) GetEmployerApplicationsByCandidate(ctx context.Context, candidateID int64) ([]EmployerApplication, error) {
-	rows, err := q.db.Query(ctx, getEmployerApplicationsByCandidate, candidateID)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	items := []EmployerApplication{}
-	for rows.Next() {
-		var i EmployerApplication
-		if err := rows.Scan(
-			&i.EmployerID,
-			&i.CandidateID,
-			&i.Message,
-			&i.ApplicationStatus,
-			&i.CreatedAt,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+type GetEmployerApplicationParams struct {
+	EmployerID  int64 `json:"employer_id"`
+	CandidateID int64 `json:"candidate_id"`
 }
 
-const getEmployerApplicationsByStatusAccepted = `-- name: GetEmployerApplicationsByStatusAccepted :many
-SELECT employer_id, candidate_id, message, application_status, created_at FROM employer_applications
-WHERE application_status = 'accepted' AND candidate_id = $1
-ORDER BY created_at DESC
-`
-
-func (q *Queries) GetEmployerApplicationsByStatusAccepted(ctx context.Context, candidateID int64) ([]EmployerApplication, error) {
-	rows, err := q.db.Query(ctx, getEmployerApplicationsByStatusAccepted, candidateID)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	items := []EmployerApplication{}
-	for rows.Next() {
-		var i EmployerApplication
-		if err := rows.Scan(
-			&i.EmployerID,
-			&i.CandidateID,
-			&i.Message,
-			&i.ApplicationStatus,
-			&i.CreatedAt,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+func (q *Queries) GetEmployerApplication(ctx context.Context, arg GetEmployerApplicationParams) (EmployerApplication, error) {
+	row := q.db.QueryRow(ctx, getEmployerApplication, arg.EmployerID, arg.CandidateID)
+	var i EmployerApplication
+	err := row.Scan(
+		&i.EmployerID,
+		&i.CandidateID,
+		&i.Message,
+		&i.ApplicationStatus,
+		&i.CreatedAt,
+		&i.JobDocID,
+		&i.ExpiresAt,
+	)
+	return i, err
 }
 
-const getEmployerApplicationsByStatusPending = `-- name: GetEmployerApplicationsByStatusPending :many
-SELECT employer_id, candidate_id, message, application_status, created_at FROM employer_applications
-WHERE application_status = 'pending' AND candidate_id = $1
-ORDER BY created_at DESC
+const getEmployerApplicationForUpdate = `-- name: GetEmployerApplicationForUpdate :one
+SELECT employer_id, candidate_id, message, application_status, created_at, job_doc_id, expires_at FROM employer_applications
+WHERE employer_id = $1 AND candidate_id = $2 LIMIT 1
+FOR NO KEY UPDATE
 `
 
-func (q *Queries) GetEmployerApplicationsByStatusPending(ctx context.Context, candidateID int64) ([]EmployerApplication, error) {
-	rows, err := q.db.Query(ctx, getEmployerApplicationsByStatusPending, candidateID)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	items := []EmployerApplication{}
-	for rows.Next() {
-		var i EmployerApplication
-		if err := rows.Scan(
-			&i.EmployerID,
-			&i.CandidateID,
-			&i.Message,
-			&i.ApplicationStatus,
-			&i.CreatedAt,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+type GetEmployerApplicationForUpdateParams struct {
+	EmployerID  int64 `json:"employer_id"`
+	CandidateID int64 `json:"candidate_id"`
+}
+
+func (q *Queries) GetEmployerApplicationForUpdate(ctx context.Context, arg GetEmployerApplicationForUpdateParams) (EmployerApplication, error) {
+	row := q.db.QueryRow(ctx, getEmployerApplicationForUpdate, arg.EmployerID, arg.CandidateID)
+	var i EmployerApplication
+	err := row.Scan(
+		&i.EmployerID,
+		&i.CandidateID,
+		&i.Message,
+		&i.ApplicationStatus,
+		&i.CreatedAt,
+		&i.JobDocID,
+		&i.ExpiresAt,
+	)
+	return i, err
 }
 
-const getEmployerApplicationsByStatusRejected = `-- name: GetEmployerApplicationsByStatusRejected :many
-SELECT employer_id, candidate_id, message, application_status, created_at FROM employer_applications
-WHERE application_status = 'rejected' AND candidate_id = $1
+const getEmployerApplicationsByCandidate = `-- name: GetEmployerApplicationsByCandidate :many
+SELECT employer_id, candidate_id, message, application_status, created_at, job_doc_id, expires_at FROM employer_applications
+WHERE candidate_id = $1
 ORDER BY created_at DESC
 `
 
-func (q *Queries) GetEmployerApplicationsByStatusRejected(ctx context.Context, candidateID int64) ([]EmployerApplication, error) {
-	rows, err := q.db.Query(ctx, getEmployerApplicationsByStatusRejected, candidateID)
+func (q *Queries) GetEmployerApplicationsByCandidate(ctx context.Context, candidateID int64) ([]EmployerApplication, error) {
+	rows, err := q.db.Query(ctx, getEmployerApplicationsByCandidate, candidateID)
 	if err != nil {
 		return nil, err
 	}
@@ -181,6 +146,8 @@ func (q *Queries) GetEmployerApplicationsByStatusRejected(ctx context.Context, c
 			&i.Message,
 			&i.ApplicationStatus,
 			&i.CreatedAt,
+			&i.JobDocID,
+			&i.ExpiresAt,
 		); err != nil {
 			return nil, err
 		}
@@ -192,14 +159,45 @@ func (q *Queries) GetEmployerApplicationsByStatusRejected(ctx context.Context, c
 	return items, nil
 }
 
-const getEmployerApplicationsByStatusSubmitted = `-- name: GetEmployerApplicationsByStatusSubmitted :many
-SELECT employer_id, candidate_id, message, application_status, created_at FROM employer_applications
-WHERE application_status = 'submitted' AND candidate_id = $1
-ORDER BY created_at DESC
+const listEmployerApplications = `-- name: ListEmployerApplications :many
+SELECT employer_id, candidate_id, message, application_status, created_at, job_doc_id, expires_at FROM employer_applications
+WHERE candidate_id = $1
+  AND ($2::text[] IS NULL OR application_status = ANY($2::text[]::application_status[]))
+  AND ($3::timestamptz IS NULL OR created_at >= $3)
+  AND ($4::timestamptz IS NULL OR created_at < $4)
+  AND ($5::timestamptz IS NULL
+    OR ($6::boolean AND (created_at, employer_id) > ($5, $7::bigint))
+    OR (NOT $6::boolean AND (created_at, employer_id) < ($5, $7::bigint)))
+ORDER BY
+    CASE WHEN $6::boolean THEN created_at END,
+    CASE WHEN $6::boolean THEN employer_id END,
+    created_at DESC,
+    employer_id DESC
+LIMIT $8
 `
 
-func (q *Queries) GetEmployerApplicationsByStatusSubmitted(ctx context.Context, candidateID int64) ([]EmployerApplication, error) {
-	rows, err := q.db.Query(ctx, getEmployerApplicationsByStatusSubmitted, candidateID)
+type ListEmployerApplicationsParams struct {
+	CandidateID     int64              `json:"candidate_id"`
+	Statuses        []string           `json:"statuses"`
+	CreatedAfter    pgtype.Timestamptz `json:"created_after"`
+	CreatedBefore   pgtype.Timestamptz `json:"created_before"`
+	CursorCreatedAt pgtype.Timestamptz `json:"cursor_created_at"`
+	OldestFirst     bool               `json:"oldest_first"`
+	CursorID        pgtype.Int8        `json:"cursor_id"`
+	Limit           int32              `json:"limit"`
+}
+
+func (q *Queries) ListEmployerApplications(ctx context.Context, arg ListEmployerApplicationsParams) ([]EmployerApplication, error) {
+	rows, err := q.db.Query(ctx, listEmployerApplications,
+		arg.CandidateID,
+		arg.Statuses,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.CursorCreatedAt,
+		arg.OldestFirst,
+		arg.CursorID,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -213,6 +211,8 @@ func (q *Queries) GetEmployerApplicationsByStatusSubmitted(ctx context.Context,
 			&i.Message,
 			&i.ApplicationStatus,
 			&i.CreatedAt,
+			&i.JobDocID,
+			&i.ExpiresAt,
 		); err != nil {
 			return nil, err
 		}
@@ -228,7 +228,7 @@ const updateEmployerApplication = `-- name: UpdateEmployerApplication :one
 UPDATE employer_applications
 SET application_status = COALESCE($3, application_status)
 WHERE employer_id = $1 AND candidate_id = $2
-RETURNING employer_id, candidate_id, message, application_status, created_at
+RETURNING employer_id, candidate_id, message, application_status, created_at, job_doc_id, expires_at
 `
 
 type UpdateEmployerApplicationParams struct {
@@ -246,6 +246,8 @@ func (q *Queries) UpdateEmployerApplication(ctx context.Context, arg UpdateEmplo
 		&i.Message,
 		&i.ApplicationStatus,
 		&i.CreatedAt,
+		&i.JobDocID,
+		&i.ExpiresAt,
 	)
 	return i, err
 }
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/require"
 
 	"github.com/hankimmy/PtmrBackend/pkg/util"
@@ -99,50 +100,41 @@ func TestDeleteEmployerApplication(t *testing.T) {
 	require.NoError(t, err)
 	require.Empty(t, applications)
 }
-func TestGetEmployerApplicationsByStatusAccepted(t *testing.T) {
-	application := createRandomEmployerApplication(t, ApplicationStatusAccepted)
-	applications, err := testStore.GetEmployerApplicationsByStatusAccepted(context.Background(), application.CandidateID)
-	require.NoError(t, err)
-	require.NotEmpty(t, applications)
 
-	for _, app := range applications {
-		require.Equal(t, application.CandidateID, app.CandidateID)
-		require.Equal(t, ApplicationStatusAccepted, app.ApplicationStatus)
+func TestListEmployerApplications(t *testing.T) {
+	candidate := createRandomCandidate(t)
+	for _, status := range []ApplicationStatus{ApplicationStatusSubmitted, ApplicationStatusRejected, ApplicationStatusSubmitted} {
+		_, err := testStore.CreateEmployerApplication(context.Background(), CreateEmployerApplicationParams{
+			EmployerID:        createRandomEmployer(t).ID,
+			CandidateID:       candidate.ID,
+			Message:           util.RandomString(10),
+			ApplicationStatus: status,
+			CreatedAt:         time.Now(),
+		})
+		require.NoError(t, err)
 	}
-}
 
-func TestGetEmployerApplicationsByStatusPending(t *testing.T) {
-	application := createRandomEmployerApplication(t, ApplicationStatusPending)
-	applications, err := testStore.GetEmployerApplicationsByStatusPending(context.Background(), application.CandidateID)
-	require.NoError(t, err)
-	require.NotEmpty(t, applications)
-
-	for _, app := range applications {
-		require.Equal(t, application.CandidateID, app.CandidateID)
-		require.Equal(t, ApplicationStatusPending, app.ApplicationStatus)
+	arg := ListEmployerApplicationsParams{
+		CandidateID: candidate.ID,
+		Statuses:    []string{string(ApplicationStatusSubmitted)},
+		OldestFirst: true,
+		Limit:       1,
 	}
-}
-
-func TestGetEmployerApplicationsByStatusRejected(t *testing.T) {
-	application := createRandomEmployerApplication(t, ApplicationStatusRejected)
-	applications, err := testStore.GetEmployerApplicationsByStatusRejected(context.Background(), application.CandidateID)
+	firstPage, err := testStore.ListEmployerApplications(context.Background(), arg)
 	require.NoError(t, err)
-	require.NotEmpty(t, applications)
-
-	for _, app := range applications {
-		require.Equal(t, application.CandidateID, app.CandidateID)
-		require.Equal(t, ApplicationStatusRejected, app.ApplicationStatus)
-	}
-}
+	require.Len(t, firstPage, 1)
 
-func TestGetEmployerApplicationsByStatusSubmitted(t *testing.T) {
-	application := createRandomEmployerApplication(t, ApplicationStatusSubmitted)
-	applications, err := testStore.GetEmployerApplicationsByStatusSubmitted(context.Background(), application.CandidateID)
+	arg.CursorCreatedAt = pgtype.Timestamptz{Time: firstPage[0].CreatedAt, Valid: true}
+	arg.CursorID = pgtype.Int8{Int64: firstPage[0].EmployerID, Valid: true}
+	secondPage, err := testStore.ListEmployerApplications(context.Background(), arg)
 	require.NoError(t, err)
-	require.NotEmpty(t, applications)
+	require.Len(t, secondPage, 1)
+	require.NotEqual(t, firstPage[0].EmployerID, secondPage[0].EmployerID)
+	require.False(t, secondPage[0].CreatedAt.Before(firstPage[0].CreatedAt))
 
-	for _, app := range applications {
-		require.Equal(t, application.CandidateID, app.CandidateID)
-		require.Equal(t, ApplicationStatusSubmitted, app.ApplicationStatus)
-	}
+	arg.CursorCreatedAt = pgtype.Timestamptz{Time: secondPage[0].CreatedAt, Valid: true}
+	arg.CursorID = pgtype.Int8{Int64: secondPage[0].EmployerID, Valid: true}
+	lastPage, err := testStore.ListEmployerApplications(context.Background(), arg)
+	require.NoError(t, err)
+	require.Empty(t, lastPage)
 }
@@ -14,6 +14,31 @@ const (
 
 var ErrRecordNotFound = pgx.ErrNoRows
 
+var ErrApplicationLimitReached = errors.New("job has reached its maximum number of applications")
+
+var ErrInvitationExpired = errors.New("invitation has expired")
+
+var ErrReapplyTooSoon = errors.New("application was withdrawn too recently to apply again")
+
+var ErrMessagingNotAllowed = errors.New("messaging requires an accepted application or a match")
+
+var (
+	ErrInvalidStatusTransition    = errors.New("invalid application status transition")
+	ErrStatusTransitionNotAllowed = errors.New("application status transition not allowed")
+)
+
+var (
+	ErrApplicationNotAccepted = errors.New("interviews can only be arranged for accepted applications")
+	ErrInterviewNotProposed   = errors.New("interview has no slots to pick from")
+	ErrInterviewCancelled     = errors.New("interview has been cancelled")
+	ErrInterviewSlotPassed    = errors.New("interview slot has already passed")
+)
+
+var (
+	ErrShiftFull    = errors.New("shift has no open spots left")
+	ErrShiftStarted = errors.New("shift has already started")
+)
+
 var ErrUniqueViolation = &pgconn.PgError{
 	Code: UniqueViolation,
 }
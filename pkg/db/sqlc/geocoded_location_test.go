@@ -0,0 +1,55 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hankimmy/PtmrBackend/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpsertGeocodedLocation(t *testing.T) {
+	address := util.RandomUSAddress() + " " + util.RandomString(6)
+
+	_, err := testStore.GetGeocodedLocation(context.Background(), address)
+	require.ErrorIs(t, err, ErrRecordNotFound)
+
+	location, err := testStore.UpsertGeocodedLocation(context.Background(), UpsertGeocodedLocationParams{
+		Address:   address,
+		Latitude:  40.7,
+		Longitude: -74.0,
+	})
+	require.NoError(t, err)
+	require.Equal(t, address, location.Address)
+	require.Equal(t, 40.7, location.Latitude)
+	require.Equal(t, -74.0, location.Longitude)
+	require.NotZero(t, location.GeocodedAt)
+
+	updated, err := testStore.UpsertGeocodedLocation(context.Background(), UpsertGeocodedLocationParams{
+		Address:   address,
+		Latitude:  40.8,
+		Longitude: -73.9,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 40.8, updated.Latitude)
+	require.Equal(t, -73.9, updated.Longitude)
+
+	got, err := testStore.GetGeocodedLocation(context.Background(), address)
+	require.NoError(t, err)
+	require.Equal(t, updated, got)
+}
+
+func TestListGeocodedLocations(t *testing.T) {
+	known := util.RandomString(12)
+	_, err := testStore.UpsertGeocodedLocation(context.Background(), UpsertGeocodedLocationParams{
+		Address:   known,
+		Latitude:  1,
+		Longitude: 2,
+	})
+	require.NoError(t, err)
+
+	locations, err := testStore.ListGeocodedLocations(context.Background(), []string{known, util.RandomString(12)})
+	require.NoError(t, err)
+	require.Len(t, locations, 1)
+	require.Equal(t, known, locations[0].Address)
+}
@@ -0,0 +1,120 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomInterview(t *testing.T, application CandidateApplication, slots []time.Time) InterviewTxResult {
+	result, err := testStore.CreateInterviewTx(context.Background(), CreateInterviewTxParams{
+		CreateInterviewParams: CreateInterviewParams{
+			CandidateID:     application.CandidateID,
+			JobDocID:        application.JobDocID,
+			EmployerID:      application.EmployerID,
+			DurationMinutes: 30,
+			Location:        "1 Main St",
+		},
+		Slots: slots,
+	})
+	require.NoError(t, err)
+	require.Equal(t, InterviewStatusProposed, result.Interview.Status)
+	require.False(t, result.Interview.ScheduledAt.Valid)
+	require.Len(t, result.Slots, len(slots))
+	return result
+}
+
+func TestCreateInterviewTxRequiresAcceptedApplication(t *testing.T) {
+	application := createRandomCandidateApplication(t, ApplicationStatusSubmitted)
+
+	_, err := testStore.CreateInterviewTx(context.Background(), CreateInterviewTxParams{
+		CreateInterviewParams: CreateInterviewParams{
+			CandidateID:     application.CandidateID,
+			JobDocID:        application.JobDocID,
+			EmployerID:      application.EmployerID,
+			DurationMinutes: 30,
+		},
+		Slots: []time.Time{time.Now().Add(time.Hour)},
+	})
+	require.ErrorIs(t, err, ErrApplicationNotAccepted)
+}
+
+func TestInterviewLifecycle(t *testing.T) {
+	ctx := context.Background()
+	application := createRandomCandidateApplication(t, ApplicationStatusAccepted)
+	now := time.Now()
+	created := createRandomInterview(t, application, []time.Time{now.Add(24 * time.Hour), now.Add(48 * time.Hour)})
+	interviewID := created.Interview.ID
+
+	// Only one interview at a time per application.
+	_, err := testStore.CreateInterviewTx(ctx, CreateInterviewTxParams{
+		CreateInterviewParams: CreateInterviewParams{
+			CandidateID:     application.CandidateID,
+			JobDocID:        application.JobDocID,
+			EmployerID:      application.EmployerID,
+			DurationMinutes: 30,
+		},
+		Slots: []time.Time{now.Add(time.Hour)},
+	})
+	require.Equal(t, UniqueViolation, ErrorCode(err))
+
+	// The candidate picks the second slot.
+	scheduled, err := testStore.ScheduleInterviewTx(ctx, ScheduleInterviewTxParams{
+		InterviewID: interviewID,
+		SlotID:      created.Slots[1].ID,
+		Now:         now,
+	})
+	require.NoError(t, err)
+	require.Equal(t, InterviewStatusScheduled, scheduled.Interview.Status)
+	require.WithinDuration(t, created.Slots[1].StartsAt, scheduled.Interview.ScheduledAt.Time, time.Second)
+	require.Greater(t, scheduled.Interview.Sequence, created.Interview.Sequence)
+
+	_, err = testStore.ScheduleInterviewTx(ctx, ScheduleInterviewTxParams{
+		InterviewID: interviewID,
+		SlotID:      created.Slots[0].ID,
+		Now:         now,
+	})
+	require.ErrorIs(t, err, ErrInterviewNotProposed)
+
+	// Rescheduling replaces the slots and clears the scheduled time.
+	rescheduled, err := testStore.RescheduleInterviewTx(ctx, RescheduleInterviewTxParams{
+		InterviewID:     interviewID,
+		Slots:           []time.Time{now.Add(72 * time.Hour)},
+		DurationMinutes: pgtype.Int4{Int32: 45, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, InterviewStatusProposed, rescheduled.Interview.Status)
+	require.False(t, rescheduled.Interview.ScheduledAt.Valid)
+	require.Equal(t, int32(45), rescheduled.Interview.DurationMinutes)
+	require.Equal(t, "1 Main St", rescheduled.Interview.Location)
+	require.True(t, rescheduled.PreviousScheduledAt.Valid)
+	require.Len(t, rescheduled.Slots, 1)
+
+	// Slots from before the reschedule are gone, and slots that have passed
+	// can't be picked.
+	_, err = testStore.ScheduleInterviewTx(ctx, ScheduleInterviewTxParams{
+		InterviewID: interviewID,
+		SlotID:      created.Slots[0].ID,
+		Now:         now,
+	})
+	require.ErrorIs(t, err, ErrRecordNotFound)
+	_, err = testStore.ScheduleInterviewTx(ctx, ScheduleInterviewTxParams{
+		InterviewID: interviewID,
+		SlotID:      rescheduled.Slots[0].ID,
+		Now:         now.Add(100 * time.Hour),
+	})
+	require.ErrorIs(t, err, ErrInterviewSlotPassed)
+
+	cancelled, err := testStore.CancelInterviewTx(ctx, CancelInterviewTxParams{InterviewID: interviewID})
+	require.NoError(t, err)
+	require.Equal(t, InterviewStatusCancelled, cancelled.Interview.Status)
+
+	_, err = testStore.CancelInterviewTx(ctx, CancelInterviewTxParams{InterviewID: interviewID})
+	require.ErrorIs(t, err, ErrInterviewCancelled)
+
+	// A cancelled interview makes room for a new one.
+	createRandomInterview(t, application, []time.Time{now.Add(24 * time.Hour)})
+}
@@ -0,0 +1,145 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hankimmy/PtmrBackend/pkg/util"
+)
+
+func createRandomJobShift(t *testing.T, headcount int32, startsAt time.Time) JobShift {
+	employer := createRandomEmployer(t)
+	arg := CreateJobShiftParams{
+		JobID:      util.RandomString(10),
+		EmployerID: employer.ID,
+		StartsAt:   startsAt,
+		EndsAt:     startsAt.Add(6 * time.Hour),
+		Timezone:   "America/New_York",
+		Headcount:  headcount,
+	}
+
+	shift, err := testStore.CreateJobShift(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, shift.ID)
+	require.Equal(t, arg.JobID, shift.JobID)
+	require.Equal(t, arg.EmployerID, shift.EmployerID)
+	require.WithinDuration(t, arg.StartsAt, shift.StartsAt, time.Second)
+	require.WithinDuration(t, arg.EndsAt, shift.EndsAt, time.Second)
+	require.Equal(t, arg.Timezone, shift.Timezone)
+	require.Equal(t, arg.Headcount, shift.Headcount)
+	return shift
+}
+
+func TestCreateJobShiftRejectsInvalidTimes(t *testing.T) {
+	employer := createRandomEmployer(t)
+	startsAt := time.Now().Add(time.Hour)
+	_, err := testStore.CreateJobShift(context.Background(), CreateJobShiftParams{
+		JobID:      util.RandomString(10),
+		EmployerID: employer.ID,
+		StartsAt:   startsAt,
+		EndsAt:     startsAt,
+		Timezone:   "UTC",
+		Headcount:  1,
+	})
+	require.Error(t, err)
+}
+
+func TestClaimShiftTx(t *testing.T) {
+	shift := createRandomJobShift(t, 2, time.Now().Add(24*time.Hour))
+	first := createRandomCandidate(t)
+	second := createRandomCandidate(t)
+	third := createRandomCandidate(t)
+
+	claim := func(candidateID int64) (ClaimShiftTxResult, error) {
+		return testStore.ClaimShiftTx(context.Background(), ClaimShiftTxParams{
+			CreateShiftClaimParams: CreateShiftClaimParams{ShiftID: shift.ID, CandidateID: candidateID},
+			Now:                    time.Now(),
+		})
+	}
+
+	result, err := claim(first.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), result.Claimed)
+	require.Equal(t, shift.ID, result.Claim.ShiftID)
+
+	_, err = claim(first.ID)
+	require.Equal(t, UniqueViolation, ErrorCode(err))
+
+	result, err = claim(second.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), result.Claimed)
+
+	_, err = claim(third.ID)
+	require.ErrorIs(t, err, ErrShiftFull)
+
+	shifts, err := testStore.ListJobShifts(context.Background(), shift.JobID)
+	require.NoError(t, err)
+	require.Len(t, shifts, 1)
+	require.Equal(t, int32(2), shifts[0].Claimed)
+
+	require.NoError(t, testStore.DeleteShiftClaim(context.Background(), DeleteShiftClaimParams{
+		ShiftID:     shift.ID,
+		CandidateID: first.ID,
+	}))
+	_, err = claim(third.ID)
+	require.NoError(t, err)
+
+	claims, err := testStore.ListShiftClaims(context.Background(), shift.ID)
+	require.NoError(t, err)
+	require.Len(t, claims, 2)
+}
+
+func TestClaimShiftTxConcurrent(t *testing.T) {
+	shift := createRandomJobShift(t, 3, time.Now().Add(24*time.Hour))
+
+	n := 6
+	candidates := make([]Candidate, n)
+	for i := range candidates {
+		candidates[i] = createRandomCandidate(t)
+	}
+	errs := make(chan error, n)
+	for _, candidate := range candidates {
+		go func(candidateID int64) {
+			_, err := testStore.ClaimShiftTx(context.Background(), ClaimShiftTxParams{
+				CreateShiftClaimParams: CreateShiftClaimParams{ShiftID: shift.ID, CandidateID: candidateID},
+				Now:                    time.Now(),
+			})
+			errs <- err
+		}(candidate.ID)
+	}
+
+	var full int
+	for i := 0; i < n; i++ {
+		if err := <-errs; err != nil {
+			require.ErrorIs(t, err, ErrShiftFull)
+			full++
+		}
+	}
+	require.Equal(t, n-3, full)
+
+	count, err := testStore.CountShiftClaims(context.Background(), shift.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), count)
+}
+
+func TestClaimShiftTxStarted(t *testing.T) {
+	shift := createRandomJobShift(t, 1, time.Now().Add(-time.Hour))
+	candidate := createRandomCandidate(t)
+
+	_, err := testStore.ClaimShiftTx(context.Background(), ClaimShiftTxParams{
+		CreateShiftClaimParams: CreateShiftClaimParams{ShiftID: shift.ID, CandidateID: candidate.ID},
+		Now:                    time.Now(),
+	})
+	require.ErrorIs(t, err, ErrShiftStarted)
+}
+
+func TestDeleteJobShift(t *testing.T) {
+	shift := createRandomJobShift(t, 1, time.Now().Add(time.Hour))
+
+	require.NoError(t, testStore.DeleteJobShift(context.Background(), shift.ID))
+	_, err := testStore.GetJobShift(context.Background(), shift.ID)
+	require.ErrorIs(t, err, ErrRecordNotFound)
+}
@@ -0,0 +1,97 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hankimmy/PtmrBackend/pkg/util"
+)
+
+func statsDay(t time.Time) pgtype.Date {
+	return pgtype.Date{Time: t, Valid: true}
+}
+
+func TestIncrementJobDailyStats(t *testing.T) {
+	employer := createRandomEmployer(t)
+	jobID := util.RandomString(10)
+	otherJobID := util.RandomString(10)
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	yesterday := today.AddDate(0, 0, -1)
+
+	increment := func(day time.Time, jobIDs []string, arg IncrementJobDailyStatsParams) {
+		arg.JobIds = jobIDs
+		arg.EmployerIds = make([]int64, len(jobIDs))
+		for i := range jobIDs {
+			arg.EmployerIds[i] = employer.ID
+		}
+		arg.Day = statsDay(day)
+		require.NoError(t, testStore.IncrementJobDailyStats(context.Background(), arg))
+	}
+	increment(yesterday, []string{jobID, otherJobID}, IncrementJobDailyStatsParams{Impressions: 1})
+	increment(today, []string{jobID, otherJobID}, IncrementJobDailyStatsParams{Impressions: 1})
+	increment(today, []string{jobID}, IncrementJobDailyStatsParams{Views: 1})
+	increment(today, []string{jobID}, IncrementJobDailyStatsParams{RightSwipes: 1})
+	increment(today, []string{jobID}, IncrementJobDailyStatsParams{Applications: 1})
+
+	daily, err := testStore.ListJobDailyStats(context.Background(), ListJobDailyStatsParams{
+		JobID:   jobID,
+		FromDay: statsDay(yesterday),
+		ToDay:   statsDay(today),
+	})
+	require.NoError(t, err)
+	require.Len(t, daily, 2)
+	require.Equal(t, int64(1), daily[0].Impressions)
+	require.Zero(t, daily[0].Views)
+	require.Equal(t, JobDailyStat{
+		JobID:        jobID,
+		EmployerID:   employer.ID,
+		Day:          statsDay(today),
+		Views:        1,
+		Impressions:  1,
+		RightSwipes:  1,
+		Applications: 1,
+	}, daily[1])
+
+	summary, err := testStore.ListEmployerJobStats(context.Background(), ListEmployerJobStatsParams{
+		EmployerID: employer.ID,
+		FromDay:    statsDay(yesterday),
+		ToDay:      statsDay(today),
+	})
+	require.NoError(t, err)
+	require.Len(t, summary, 2)
+	for _, row := range summary {
+		require.Equal(t, int64(2), row.Impressions)
+		if row.JobID == jobID {
+			require.Equal(t, int64(1), row.Applications)
+		}
+	}
+}
+
+func TestIncrementJobDailyStatsSkipsMissingEmployers(t *testing.T) {
+	employer := createRandomEmployer(t)
+	jobID := util.RandomString(10)
+	orphanJobID := util.RandomString(10)
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	err := testStore.IncrementJobDailyStats(context.Background(), IncrementJobDailyStatsParams{
+		JobIds:      []string{jobID, orphanJobID},
+		EmployerIds: []int64{employer.ID, employer.ID + 1_000_000},
+		Day:         statsDay(today),
+		Views:       1,
+	})
+	require.NoError(t, err)
+
+	for id, views := range map[string]int{jobID: 1, orphanJobID: 0} {
+		daily, err := testStore.ListJobDailyStats(context.Background(), ListJobDailyStatsParams{
+			JobID:   id,
+			FromDay: statsDay(today),
+			ToDay:   statsDay(today),
+		})
+		require.NoError(t, err)
+		require.Len(t, daily, views)
+	}
+}
@@ -0,0 +1,94 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hankimmy/PtmrBackend/pkg/util"
+)
+
+func createRandomJobTemplate(t *testing.T, employerID int64) JobTemplate {
+	arg := CreateJobTemplateParams{
+		EmployerID:     employerID,
+		Name:           util.RandomString(10),
+		Title:          util.RandomString(8),
+		Description:    util.RandomString(50),
+		Industry:       util.RandomString(6),
+		EmploymentType: util.RandomString(6),
+		Wage:           18.5,
+		Tips:           4,
+		JobApplication: []byte(`[{"id":"q1","type":"text","question":"Name?","isRequired":true,"order":1}]`),
+	}
+
+	template, err := testStore.CreateJobTemplate(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, template.ID)
+	require.Equal(t, arg.EmployerID, template.EmployerID)
+	require.Equal(t, arg.Name, template.Name)
+	require.Equal(t, arg.Title, template.Title)
+	require.Equal(t, arg.Wage, template.Wage)
+	require.JSONEq(t, string(arg.JobApplication), string(template.JobApplication))
+	require.NotZero(t, template.CreatedAt)
+	return template
+}
+
+func TestCreateJobTemplate(t *testing.T) {
+	employer := createRandomEmployer(t)
+	template := createRandomJobTemplate(t, employer.ID)
+
+	_, err := testStore.CreateJobTemplate(context.Background(), CreateJobTemplateParams{
+		EmployerID:     employer.ID,
+		Name:           template.Name,
+		Title:          util.RandomString(8),
+		JobApplication: []byte(`[]`),
+	})
+	require.Error(t, err)
+	require.Equal(t, UniqueViolation, ErrorCode(err))
+}
+
+func TestListJobTemplates(t *testing.T) {
+	employer := createRandomEmployer(t)
+	for i := 0; i < 3; i++ {
+		createRandomJobTemplate(t, employer.ID)
+	}
+	createRandomJobTemplate(t, createRandomEmployer(t).ID)
+
+	templates, err := testStore.ListJobTemplates(context.Background(), employer.ID)
+	require.NoError(t, err)
+	require.Len(t, templates, 3)
+	for i, template := range templates {
+		require.Equal(t, employer.ID, template.EmployerID)
+		if i > 0 {
+			require.LessOrEqual(t, templates[i-1].Name, template.Name)
+		}
+	}
+}
+
+func TestUpdateJobTemplate(t *testing.T) {
+	template := createRandomJobTemplate(t, createRandomEmployer(t).ID)
+	newTitle := util.RandomString(8)
+
+	updated, err := testStore.UpdateJobTemplate(context.Background(), UpdateJobTemplateParams{
+		ID:    template.ID,
+		Title: pgtype.Text{String: newTitle, Valid: true},
+		Wage:  pgtype.Float4{Float32: 21, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, newTitle, updated.Title)
+	require.Equal(t, float32(21), updated.Wage)
+	require.Equal(t, template.Name, updated.Name)
+	require.Equal(t, template.Description, updated.Description)
+	require.JSONEq(t, string(template.JobApplication), string(updated.JobApplication))
+	require.True(t, updated.UpdatedAt.After(template.UpdatedAt))
+}
+
+func TestDeleteJobTemplate(t *testing.T) {
+	template := createRandomJobTemplate(t, createRandomEmployer(t).ID)
+
+	require.NoError(t, testStore.DeleteJobTemplate(context.Background(), template.ID))
+	_, err := testStore.GetJobTemplate(context.Background(), template.ID)
+	require.ErrorIs(t, err, ErrRecordNotFound)
+}
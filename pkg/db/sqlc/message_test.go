@@ -0,0 +1,99 @@
+package db
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendMessageTxNotAllowed(t *testing.T) {
+	application := createRandomCandidateApplication(t, ApplicationStatusSubmitted)
+
+	_, err := testStore.SendMessageTx(context.Background(), SendMessageTxParams{
+		CandidateID: application.CandidateID,
+		EmployerID:  application.EmployerID,
+		SenderRole:  RoleCandidate,
+		SenderID:    application.CandidateID,
+		Body:        "hello",
+	})
+	require.ErrorIs(t, err, ErrMessagingNotAllowed)
+}
+
+func TestConversationReadReceipts(t *testing.T) {
+	ctx := context.Background()
+	application := createRandomCandidateApplication(t, ApplicationStatusAccepted)
+
+	send := func(role Role, senderID int64, body string) SendMessageTxResult {
+		result, err := testStore.SendMessageTx(ctx, SendMessageTxParams{
+			CandidateID: application.CandidateID,
+			EmployerID:  application.EmployerID,
+			SenderRole:  role,
+			SenderID:    senderID,
+			Body:        body,
+		})
+		require.NoError(t, err)
+		require.Equal(t, body, result.Message.Body)
+		require.Equal(t, role, result.Message.SenderRole)
+		require.False(t, result.Message.ReadAt.Valid)
+		require.True(t, result.Conversation.LastMessageAt.Valid)
+		return result
+	}
+	first := send(RoleEmployer, application.EmployerID, "Can you start Monday?")
+	second := send(RoleEmployer, application.EmployerID, "The shift starts at 9.")
+	reply := send(RoleCandidate, application.CandidateID, "Yes, see you then.")
+	require.Equal(t, first.Conversation.ID, second.Conversation.ID)
+	require.Equal(t, first.Conversation.ID, reply.Conversation.ID)
+	conversationID := first.Conversation.ID
+
+	messages, err := testStore.ListMessages(ctx, ListMessagesParams{ConversationID: conversationID, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	require.Equal(t, reply.Message.ID, messages[0].ID)
+	require.Equal(t, second.Message.ID, messages[1].ID)
+
+	messages, err = testStore.ListMessages(ctx, ListMessagesParams{
+		ConversationID: conversationID,
+		BeforeID:       pgtype.Int8{Int64: second.Message.ID, Valid: true},
+		Limit:          2,
+	})
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	require.Equal(t, first.Message.ID, messages[0].ID)
+
+	conversations, err := testStore.ListConversations(ctx, ListConversationsParams{
+		ReaderRole:  RoleCandidate,
+		CandidateID: pgtype.Int8{Int64: application.CandidateID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Len(t, conversations, 1)
+	require.Equal(t, int64(2), conversations[0].UnreadCount)
+
+	// The candidate reads up to the first message, and marking never touches
+	// their own reply.
+	marked, err := testStore.MarkMessagesRead(ctx, MarkMessagesReadParams{
+		ConversationID: conversationID,
+		ReaderRole:     RoleCandidate,
+		UpToID:         first.Message.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), marked)
+
+	marked, err = testStore.MarkMessagesRead(ctx, MarkMessagesReadParams{
+		ConversationID: conversationID,
+		ReaderRole:     RoleCandidate,
+		UpToID:         math.MaxInt64,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), marked)
+
+	conversations, err = testStore.ListConversations(ctx, ListConversationsParams{
+		ReaderRole: RoleEmployer,
+		EmployerID: pgtype.Int8{Int64: application.EmployerID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Len(t, conversations, 1)
+	require.Equal(t, int64(1), conversations[0].UnreadCount)
+}
@@ -20,6 +20,7 @@ const (
 	ApplicationStatusSubmitted ApplicationStatus = "submitted"
 	ApplicationStatusAccepted  ApplicationStatus = "accepted"
 	ApplicationStatusRejected  ApplicationStatus = "rejected"
+	ApplicationStatusWithdrawn ApplicationStatus = "withdrawn"
 )
 
 func (e *ApplicationStatus) Scan(src interface{}) error {
@@ -102,6 +103,49 @@ func (ns NullEducation) Value() (driver.Value, error) {
 	return string(ns.Education), nil
 }
 
+type InterviewStatus string
+
+const (
+	InterviewStatusProposed  InterviewStatus = "proposed"
+	InterviewStatusScheduled InterviewStatus = "scheduled"
+	InterviewStatusCancelled InterviewStatus = "cancelled"
+)
+
+func (e *InterviewStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = InterviewStatus(s)
+	case string:
+		*e = InterviewStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for InterviewStatus: %T", src)
+	}
+	return nil
+}
+
+type NullInterviewStatus struct {
+	InterviewStatus InterviewStatus `json:"interview_status"`
+	Valid           bool            `json:"valid"` // Valid is true if InterviewStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullInterviewStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.InterviewStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.InterviewStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullInterviewStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.InterviewStatus), nil
+}
+
 type JobPreference string
 
 const (
@@ -146,11 +190,55 @@ func (ns NullJobPreference) Value() (driver.Value, error) {
 	return string(ns.JobPreference), nil
 }
 
+type ModerationDecision string
+
+const (
+	ModerationDecisionApproved    ModerationDecision = "approved"
+	ModerationDecisionNeedsReview ModerationDecision = "needs_review"
+	ModerationDecisionRejected    ModerationDecision = "rejected"
+)
+
+func (e *ModerationDecision) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ModerationDecision(s)
+	case string:
+		*e = ModerationDecision(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ModerationDecision: %T", src)
+	}
+	return nil
+}
+
+type NullModerationDecision struct {
+	ModerationDecision ModerationDecision `json:"moderation_decision"`
+	Valid              bool               `json:"valid"` // Valid is true if ModerationDecision is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullModerationDecision) Scan(value interface{}) error {
+	if value == nil {
+		ns.ModerationDecision, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ModerationDecision.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullModerationDecision) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ModerationDecision), nil
+}
+
 type Role string
 
 const (
 	RoleEmployer  Role = "employer"
 	RoleCandidate Role = "candidate"
+	RoleAdmin     Role = "admin"
 )
 
 func (e *Role) Scan(src interface{}) error {
@@ -230,6 +318,65 @@ func (ns NullSwipe) Value() (driver.Value, error) {
 	return string(ns.Swipe), nil
 }
 
+type WithdrawalReason string
+
+const (
+	WithdrawalReasonAcceptedOtherOffer WithdrawalReason = "accepted_other_offer"
+	WithdrawalReasonScheduleConflict   WithdrawalReason = "schedule_conflict"
+	WithdrawalReasonCompensation       WithdrawalReason = "compensation"
+	WithdrawalReasonNoLongerInterested WithdrawalReason = "no_longer_interested"
+	WithdrawalReasonOther              WithdrawalReason = "other"
+)
+
+func (e *WithdrawalReason) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = WithdrawalReason(s)
+	case string:
+		*e = WithdrawalReason(s)
+	default:
+		return fmt.Errorf("unsupported scan type for WithdrawalReason: %T", src)
+	}
+	return nil
+}
+
+type NullWithdrawalReason struct {
+	WithdrawalReason WithdrawalReason `json:"withdrawal_reason"`
+	Valid            bool             `json:"valid"` // Valid is true if WithdrawalReason is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullWithdrawalReason) Scan(value interface{}) error {
+	if value == nil {
+		ns.WithdrawalReason, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.WithdrawalReason.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullWithdrawalReason) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.WithdrawalReason), nil
+}
+
+type ApplicationStatusHistory struct {
+	ID              int64             `json:"id"`
+	ApplicationKind string            `json:"application_kind"`
+	CandidateID     int64             `json:"candidate_id"`
+	EmployerID      int64             `json:"employer_id"`
+	ActorRole       Role              `json:"actor_role"`
+	ActorID         int64             `json:"actor_id"`
+	FromStatus      ApplicationStatus `json:"from_status"`
+	ToStatus        ApplicationStatus `json:"to_status"`
+	Reason          string            `json:"reason"`
+	CreatedAt       time.Time         `json:"created_at"`
+	JobDocID        string            `json:"job_doc_id"`
+}
+
 type Candidate struct {
 	ID                 int64         `json:"id"`
 	Username           string        `json:"username"`
@@ -250,12 +397,15 @@ type Candidate struct {
 }
 
 type CandidateApplication struct {
-	CandidateID        int64             `json:"candidate_id"`
-	EmployerID         int64             `json:"employer_id"`
-	ElasticsearchDocID string            `json:"elasticsearch_doc_id"`
-	JobDocID           string            `json:"job_doc_id"`
-	ApplicationStatus  ApplicationStatus `json:"application_status"`
-	CreatedAt          time.Time         `json:"created_at"`
+	CandidateID        int64                `json:"candidate_id"`
+	EmployerID         int64                `json:"employer_id"`
+	ElasticsearchDocID string               `json:"elasticsearch_doc_id"`
+	JobDocID           string               `json:"job_doc_id"`
+	ApplicationStatus  ApplicationStatus    `json:"application_status"`
+	CreatedAt          time.Time            `json:"created_at"`
+	WithdrawalReason   NullWithdrawalReason `json:"withdrawal_reason"`
+	WithdrawalNote     string               `json:"withdrawal_note"`
+	WithdrawnAt        pgtype.Timestamptz   `json:"withdrawn_at"`
 }
 
 type CandidateSwipe struct {
@@ -265,6 +415,14 @@ type CandidateSwipe struct {
 	CreatedAt   time.Time `json:"created_at"`
 }
 
+type Conversation struct {
+	ID            int64              `json:"id"`
+	CandidateID   int64              `json:"candidate_id"`
+	EmployerID    int64              `json:"employer_id"`
+	LastMessageAt pgtype.Timestamptz `json:"last_message_at"`
+	CreatedAt     time.Time          `json:"created_at"`
+}
+
 type Employer struct {
 	ID                  int64     `json:"id"`
 	Username            string    `json:"username"`
@@ -280,11 +438,13 @@ type Employer struct {
 }
 
 type EmployerApplication struct {
-	EmployerID        int64             `json:"employer_id"`
-	CandidateID       int64             `json:"candidate_id"`
-	Message           string            `json:"message"`
-	ApplicationStatus ApplicationStatus `json:"application_status"`
-	CreatedAt         time.Time         `json:"created_at"`
+	EmployerID        int64              `json:"employer_id"`
+	CandidateID       int64              `json:"candidate_id"`
+	Message           string             `json:"message"`
+	ApplicationStatus ApplicationStatus  `json:"application_status"`
+	CreatedAt         time.Time          `json:"created_at"`
+	JobDocID          string             `json:"job_doc_id"`
+	ExpiresAt         pgtype.Timestamptz `json:"expires_at"`
 }
 
 type EmployerSwipe struct {
@@ -294,6 +454,101 @@ type EmployerSwipe struct {
 	CreatedAt   time.Time `json:"created_at"`
 }
 
+type GeocodedLocation struct {
+	Address    string    `json:"address"`
+	Latitude   float64   `json:"latitude"`
+	Longitude  float64   `json:"longitude"`
+	GeocodedAt time.Time `json:"geocoded_at"`
+}
+
+type Interview struct {
+	ID              int64              `json:"id"`
+	CandidateID     int64              `json:"candidate_id"`
+	JobDocID        string             `json:"job_doc_id"`
+	EmployerID      int64              `json:"employer_id"`
+	Status          InterviewStatus    `json:"status"`
+	ScheduledAt     pgtype.Timestamptz `json:"scheduled_at"`
+	DurationMinutes int32              `json:"duration_minutes"`
+	Location        string             `json:"location"`
+	Notes           string             `json:"notes"`
+	Sequence        int32              `json:"sequence"`
+	CreatedAt       time.Time          `json:"created_at"`
+	UpdatedAt       time.Time          `json:"updated_at"`
+}
+
+type InterviewSlot struct {
+	ID          int64     `json:"id"`
+	InterviewID int64     `json:"interview_id"`
+	StartsAt    time.Time `json:"starts_at"`
+}
+
+type JobDailyStat struct {
+	JobID        string      `json:"job_id"`
+	EmployerID   int64       `json:"employer_id"`
+	Day          pgtype.Date `json:"day"`
+	Views        int64       `json:"views"`
+	Impressions  int64       `json:"impressions"`
+	RightSwipes  int64       `json:"right_swipes"`
+	LeftSwipes   int64       `json:"left_swipes"`
+	Applications int64       `json:"applications"`
+}
+
+type JobShift struct {
+	ID         int64     `json:"id"`
+	JobID      string    `json:"job_id"`
+	EmployerID int64     `json:"employer_id"`
+	StartsAt   time.Time `json:"starts_at"`
+	EndsAt     time.Time `json:"ends_at"`
+	Timezone   string    `json:"timezone"`
+	Headcount  int32     `json:"headcount"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type JobTemplate struct {
+	ID             int64     `json:"id"`
+	EmployerID     int64     `json:"employer_id"`
+	Name           string    `json:"name"`
+	Title          string    `json:"title"`
+	Description    string    `json:"description"`
+	Industry       string    `json:"industry"`
+	EmploymentType string    `json:"employment_type"`
+	Wage           float32   `json:"wage"`
+	Tips           float32   `json:"tips"`
+	JobApplication []byte    `json:"job_application"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type Message struct {
+	ID             int64              `json:"id"`
+	ConversationID int64              `json:"conversation_id"`
+	SenderRole     Role               `json:"sender_role"`
+	SenderID       int64              `json:"sender_id"`
+	Body           string             `json:"body"`
+	ReadAt         pgtype.Timestamptz `json:"read_at"`
+	CreatedAt      time.Time          `json:"created_at"`
+}
+
+type ModerationReview struct {
+	ID         int64              `json:"id"`
+	ItemType   string             `json:"item_type"`
+	ItemID     string             `json:"item_id"`
+	Content    []byte             `json:"content"`
+	Findings   []byte             `json:"findings"`
+	Decision   ModerationDecision `json:"decision"`
+	ReviewedBy pgtype.Text        `json:"reviewed_by"`
+	Note       string             `json:"note"`
+	CreatedAt  time.Time          `json:"created_at"`
+	ReviewedAt pgtype.Timestamptz `json:"reviewed_at"`
+}
+
+type NotificationPreference struct {
+	Username             string    `json:"username"`
+	ApplicationReceived  bool      `json:"application_received"`
+	ApplicationDecisions bool      `json:"application_decisions"`
+	UpdatedAt            time.Time `json:"updated_at"`
+}
+
 type PastExperience struct {
 	ID          int64       `json:"id"`
 	CandidateID int64       `json:"candidate_id"`
@@ -318,6 +573,12 @@ type Session struct {
 	CreatedAt    time.Time `json:"created_at"`
 }
 
+type ShiftClaim struct {
+	ShiftID     int64     `json:"shift_id"`
+	CandidateID int64     `json:"candidate_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
 type User struct {
 	Username        string    `json:"username"`
 	Email           string    `json:"email"`
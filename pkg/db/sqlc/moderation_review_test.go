@@ -0,0 +1,150 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hankimmy/PtmrBackend/pkg/util"
+)
+
+func createRandomModerationReview(t *testing.T, itemType string) ModerationReview {
+	arg := CreateModerationReviewParams{
+		ItemType: itemType,
+		ItemID:   util.RandomString(12),
+		Content:  []byte(`[{"name":"description","text":"call 212-555-0134"}]`),
+		Findings: []byte(`[{"rule":"contact_info","field":"description","decision":"needs_review","reason":"phone number"}]`),
+	}
+
+	review, err := testStore.CreateModerationReview(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, review.ID)
+	require.Equal(t, arg.ItemType, review.ItemType)
+	require.Equal(t, arg.ItemID, review.ItemID)
+	require.JSONEq(t, string(arg.Content), string(review.Content))
+	require.JSONEq(t, string(arg.Findings), string(review.Findings))
+	require.Equal(t, ModerationDecisionNeedsReview, review.Decision)
+	require.False(t, review.ReviewedBy.Valid)
+	require.False(t, review.ReviewedAt.Valid)
+	require.NotZero(t, review.CreatedAt)
+	return review
+}
+
+func TestCreateModerationReview(t *testing.T) {
+	review := createRandomModerationReview(t, util.RandomString(6))
+
+	// A second submission while the first is still pending replaces its content.
+	updated, err := testStore.CreateModerationReview(context.Background(), CreateModerationReviewParams{
+		ItemType: review.ItemType,
+		ItemID:   review.ItemID,
+		Content:  []byte(`[{"name":"description","text":"updated"}]`),
+		Findings: []byte(`[]`),
+	})
+	require.NoError(t, err)
+	require.Equal(t, review.ID, updated.ID)
+	require.JSONEq(t, `[{"name":"description","text":"updated"}]`, string(updated.Content))
+}
+
+func TestGetModerationReview(t *testing.T) {
+	review := createRandomModerationReview(t, util.RandomString(6))
+
+	got, err := testStore.GetModerationReview(context.Background(), review.ID)
+	require.NoError(t, err)
+	require.Equal(t, review.ID, got.ID)
+	require.Equal(t, review.ItemID, got.ItemID)
+
+	_, err = testStore.GetModerationReview(context.Background(), 0)
+	require.ErrorIs(t, err, ErrRecordNotFound)
+}
+
+func TestListPendingModerationReviews(t *testing.T) {
+	itemType := util.RandomString(6)
+	for i := 0; i < 3; i++ {
+		createRandomModerationReview(t, itemType)
+	}
+
+	reviews, err := testStore.ListPendingModerationReviews(context.Background(), ListPendingModerationReviewsParams{
+		ItemType: pgtype.Text{String: itemType, Valid: true},
+		Limit:    2,
+		Offset:   1,
+	})
+	require.NoError(t, err)
+	require.Len(t, reviews, 2)
+	for _, review := range reviews {
+		require.Equal(t, itemType, review.ItemType)
+		require.Equal(t, ModerationDecisionNeedsReview, review.Decision)
+	}
+}
+
+func TestResolveModerationReview(t *testing.T) {
+	review := createRandomModerationReview(t, util.RandomString(6))
+
+	arg := ResolveModerationReviewParams{
+		ID:         review.ID,
+		Decision:   ModerationDecisionRejected,
+		ReviewedBy: pgtype.Text{String: util.RandomString(8), Valid: true},
+		Note:       "phone number in posting",
+	}
+	resolved, err := testStore.ResolveModerationReview(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Decision, resolved.Decision)
+	require.Equal(t, arg.ReviewedBy, resolved.ReviewedBy)
+	require.Equal(t, arg.Note, resolved.Note)
+	require.True(t, resolved.ReviewedAt.Valid)
+
+	// Reviews can only be resolved once.
+	_, err = testStore.ResolveModerationReview(context.Background(), arg)
+	require.ErrorIs(t, err, ErrRecordNotFound)
+
+	// Once resolved, the item can be queued for review again.
+	again, err := testStore.CreateModerationReview(context.Background(), CreateModerationReviewParams{
+		ItemType: review.ItemType,
+		ItemID:   review.ItemID,
+		Content:  review.Content,
+		Findings: review.Findings,
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, review.ID, again.ID)
+}
+
+func TestDeletePendingModerationReview(t *testing.T) {
+	resolved := createRandomModerationReview(t, util.RandomString(6))
+	_, err := testStore.ResolveModerationReview(context.Background(), ResolveModerationReviewParams{
+		ID:       resolved.ID,
+		Decision: ModerationDecisionApproved,
+	})
+	require.NoError(t, err)
+	pending, err := testStore.CreateModerationReview(context.Background(), CreateModerationReviewParams{
+		ItemType: resolved.ItemType,
+		ItemID:   resolved.ItemID,
+		Content:  resolved.Content,
+		Findings: resolved.Findings,
+	})
+	require.NoError(t, err)
+
+	// Only the pending review is deleted; decisions already made are kept.
+	err = testStore.DeletePendingModerationReview(context.Background(), DeletePendingModerationReviewParams{
+		ItemType: resolved.ItemType,
+		ItemID:   resolved.ItemID,
+	})
+	require.NoError(t, err)
+	_, err = testStore.GetModerationReview(context.Background(), pending.ID)
+	require.ErrorIs(t, err, ErrRecordNotFound)
+	kept, err := testStore.GetModerationReview(context.Background(), resolved.ID)
+	require.NoError(t, err)
+	require.Equal(t, ModerationDecisionApproved, kept.Decision)
+}
+
+func TestClearEmployerDescription(t *testing.T) {
+	employer := createRandomEmployer(t)
+
+	err := testStore.ClearEmployerDescription(context.Background(), employer.ID)
+	require.NoError(t, err)
+
+	got, err := testStore.GetEmployer(context.Background(), employer.ID)
+	require.NoError(t, err)
+	require.Empty(t, got.BusinessDescription)
+	require.Equal(t, employer.BusinessName, got.BusinessName)
+}
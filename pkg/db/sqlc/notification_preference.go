@@ -0,0 +1,11 @@
+package db
+
+// DefaultNotificationPreference is what a user who never changed their
+// preferences gets: every notification.
+func DefaultNotificationPreference(username string) NotificationPreference {
+	return NotificationPreference{
+		Username:             username,
+		ApplicationReceived:  true,
+		ApplicationDecisions: true,
+	}
+}
@@ -0,0 +1,39 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpsertNotificationPreference(t *testing.T) {
+	user := createRandomUser(t)
+
+	_, err := testStore.GetNotificationPreference(context.Background(), user.Username)
+	require.ErrorIs(t, err, ErrRecordNotFound)
+
+	preference, err := testStore.UpsertNotificationPreference(context.Background(), UpsertNotificationPreferenceParams{
+		Username:            user.Username,
+		ApplicationReceived: pgtype.Bool{Bool: false, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, user.Username, preference.Username)
+	require.False(t, preference.ApplicationReceived)
+	require.True(t, preference.ApplicationDecisions)
+
+	updated, err := testStore.UpsertNotificationPreference(context.Background(), UpsertNotificationPreferenceParams{
+		Username:             user.Username,
+		ApplicationDecisions: pgtype.Bool{Bool: false, Valid: true},
+	})
+	require.NoError(t, err)
+	require.False(t, updated.ApplicationReceived)
+	require.False(t, updated.ApplicationDecisions)
+	require.WithinDuration(t, preference.UpdatedAt, updated.UpdatedAt, time.Second)
+
+	got, err := testStore.GetNotificationPreference(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Equal(t, updated, got)
+}
@@ -147,6 +147,43 @@ func (q *Queries) ListPastExperiences(ctx context.Context, arg ListPastExperienc
 	return items, nil
 }
 
+const listPastExperiencesByCandidates = `-- name: ListPastExperiencesByCandidates :many
+SELECT id, candidate_id, industry, employer, job_title, start_date, end_date, present, description, created_at FROM past_experiences
+WHERE candidate_id = ANY($1::bigint[])
+ORDER BY candidate_id, id
+`
+
+func (q *Queries) ListPastExperiencesByCandidates(ctx context.Context, candidateIds []int64) ([]PastExperience, error) {
+	rows, err := q.db.Query(ctx, listPastExperiencesByCandidates, candidateIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PastExperience{}
+	for rows.Next() {
+		var i PastExperience
+		if err := rows.Scan(
+			&i.ID,
+			&i.CandidateID,
+			&i.Industry,
+			&i.Employer,
+			&i.JobTitle,
+			&i.StartDate,
+			&i.EndDate,
+			&i.Present,
+			&i.Description,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updatePastExperience = `-- name: UpdatePastExperience :one
 UPDATE past_experiences
 SET industry = COALESCE($2, industry),
@@ -145,3 +145,18 @@ func TestListPastExperiences(t *testing.T) {
 		require.Equal(t, candidate.ID, pastExperience.CandidateID)
 	}
 }
+
+func TestListPastExperiencesByCandidates(t *testing.T) {
+	candidate1 := createRandomCandidate(t)
+	candidate2 := createRandomCandidate(t)
+	other := createRandomCandidate(t)
+	experience1 := createRandomPastExperience(t, candidate1.ID)
+	experience2 := createRandomPastExperience(t, candidate2.ID)
+	createRandomPastExperience(t, other.ID)
+
+	experiences, err := testStore.ListPastExperiencesByCandidates(context.Background(), []int64{candidate1.ID, candidate2.ID})
+	require.NoError(t, err)
+	require.Len(t, experiences, 2)
+	require.Equal(t, experience1.ID, experiences[0].ID)
+	require.Equal(t, experience2.ID, experiences[1].ID)
+}
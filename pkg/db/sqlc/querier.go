@@ -12,58 +12,125 @@ import (
 
 type Querier interface {
 	AddJobListing(ctx context.Context, arg AddJobListingParams) error
+	// Candidates and employers can message each other once an application
+	// between them has been accepted, or once they have matched: the employer
+	// swiped right on the candidate and the candidate applied to one of their
+	// jobs.
+	CanMessage(ctx context.Context, arg CanMessageParams) (bool, error)
+	CancelInterview(ctx context.Context, id int64) (Interview, error)
+	ClearEmployerDescription(ctx context.Context, id int64) error
+	CountApplicantsByJobs(ctx context.Context, jobDocIds []string) ([]CountApplicantsByJobsRow, error)
+	CountCandidateApplicationsByJob(ctx context.Context, jobDocID string) (int64, error)
+	CountShiftClaims(ctx context.Context, shiftID int64) (int64, error)
+	CreateApplicationStatusHistory(ctx context.Context, arg CreateApplicationStatusHistoryParams) (ApplicationStatusHistory, error)
 	CreateCandidate(ctx context.Context, arg CreateCandidateParams) (Candidate, error)
 	CreateCandidateApplication(ctx context.Context, arg CreateCandidateApplicationParams) (CandidateApplication, error)
 	CreateCandidateSwipe(ctx context.Context, arg CreateCandidateSwipeParams) error
 	CreateEmployer(ctx context.Context, arg CreateEmployerParams) (Employer, error)
 	CreateEmployerApplication(ctx context.Context, arg CreateEmployerApplicationParams) (EmployerApplication, error)
 	CreateEmployerSwipes(ctx context.Context, arg CreateEmployerSwipesParams) error
+	CreateInterview(ctx context.Context, arg CreateInterviewParams) (Interview, error)
+	CreateInterviewSlot(ctx context.Context, arg CreateInterviewSlotParams) (InterviewSlot, error)
+	CreateJobShift(ctx context.Context, arg CreateJobShiftParams) (JobShift, error)
+	CreateJobTemplate(ctx context.Context, arg CreateJobTemplateParams) (JobTemplate, error)
+	CreateMessage(ctx context.Context, arg CreateMessageParams) (Message, error)
+	CreateModerationReview(ctx context.Context, arg CreateModerationReviewParams) (ModerationReview, error)
 	CreatePastExperience(ctx context.Context, arg CreatePastExperienceParams) (PastExperience, error)
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
+	CreateShiftClaim(ctx context.Context, arg CreateShiftClaimParams) (ShiftClaim, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
 	CreateVerifyEmail(ctx context.Context, arg CreateVerifyEmailParams) (VerifyEmail, error)
+	DeleteApplicationStatusHistory(ctx context.Context, arg DeleteApplicationStatusHistoryParams) error
 	DeleteCandidate(ctx context.Context, id int64) error
-	DeleteCandidateApplication(ctx context.Context, arg DeleteCandidateApplicationParams) error
+	DeleteCandidateApplication(ctx context.Context, arg DeleteCandidateApplicationParams) (CandidateApplication, error)
 	DeleteCandidateSwipe(ctx context.Context, arg DeleteCandidateSwipeParams) error
 	DeleteEmployer(ctx context.Context, id int64) error
 	DeleteEmployerApplication(ctx context.Context, arg DeleteEmployerApplicationParams) error
 	DeleteEmployerSwipe(ctx context.Context, arg DeleteEmployerSwipeParams) error
+	DeleteInterviewSlots(ctx context.Context, interviewID int64) error
+	DeleteJobShift(ctx context.Context, id int64) error
+	DeleteJobTemplate(ctx context.Context, id int64) error
 	DeletePastExperience(ctx context.Context, arg DeletePastExperienceParams) error
+	DeletePendingModerationReview(ctx context.Context, arg DeletePendingModerationReviewParams) error
+	DeleteShiftClaim(ctx context.Context, arg DeleteShiftClaimParams) error
 	GetCandidate(ctx context.Context, id int64) (Candidate, error)
+	GetCandidateApplication(ctx context.Context, arg GetCandidateApplicationParams) (CandidateApplication, error)
+	GetCandidateApplicationForUpdate(ctx context.Context, arg GetCandidateApplicationForUpdateParams) (CandidateApplication, error)
 	GetCandidateApplicationsByEmployer(ctx context.Context, employerID int64) ([]CandidateApplication, error)
-	GetCandidateApplicationsByStatusAccepted(ctx context.Context, candidateID int64) ([]CandidateApplication, error)
-	GetCandidateApplicationsByStatusPending(ctx context.Context, candidateID int64) ([]CandidateApplication, error)
-	GetCandidateApplicationsByStatusRejected(ctx context.Context, candidateID int64) ([]CandidateApplication, error)
-	GetCandidateApplicationsByStatusSubmitted(ctx context.Context, candidateID int64) ([]CandidateApplication, error)
 	GetCandidateIDsByEmployer(ctx context.Context, employerID int64) ([]int64, error)
 	GetCandidateIdByUsername(ctx context.Context, username string) (int64, error)
 	GetCandidateSwipe(ctx context.Context, arg GetCandidateSwipeParams) ([]CandidateSwipe, error)
+	GetConversation(ctx context.Context, id int64) (Conversation, error)
 	GetEmployer(ctx context.Context, id int64) (Employer, error)
+	GetEmployerApplication(ctx context.Context, arg GetEmployerApplicationParams) (EmployerApplication, error)
+	GetEmployerApplicationForUpdate(ctx context.Context, arg GetEmployerApplicationForUpdateParams) (EmployerApplication, error)
 	GetEmployerApplicationsByCandidate(ctx context.Context, candidateID int64) ([]EmployerApplication, error)
-	GetEmployerApplicationsByStatusAccepted(ctx context.Context, candidateID int64) ([]EmployerApplication, error)
-	GetEmployerApplicationsByStatusPending(ctx context.Context, candidateID int64) ([]EmployerApplication, error)
-	GetEmployerApplicationsByStatusRejected(ctx context.Context, candidateID int64) ([]EmployerApplication, error)
-	GetEmployerApplicationsByStatusSubmitted(ctx context.Context, candidateID int64) ([]EmployerApplication, error)
 	GetEmployerIdByUsername(ctx context.Context, username string) (int64, error)
 	GetEmployerSwipe(ctx context.Context, arg GetEmployerSwipeParams) (EmployerSwipe, error)
+	GetGeocodedLocation(ctx context.Context, address string) (GeocodedLocation, error)
+	GetInterview(ctx context.Context, id int64) (Interview, error)
+	GetInterviewForUpdate(ctx context.Context, id int64) (Interview, error)
+	GetInterviewSlot(ctx context.Context, arg GetInterviewSlotParams) (InterviewSlot, error)
 	GetJobIDsByCandidate(ctx context.Context, candidateID int64) ([]string, error)
+	GetJobShift(ctx context.Context, id int64) (JobShift, error)
+	GetJobShiftForUpdate(ctx context.Context, id int64) (JobShift, error)
+	GetJobTemplate(ctx context.Context, id int64) (JobTemplate, error)
+	GetModerationReview(ctx context.Context, id int64) (ModerationReview, error)
+	GetNotificationPreference(ctx context.Context, username string) (NotificationPreference, error)
 	GetPastExperience(ctx context.Context, id int64) (PastExperience, error)
 	GetRejectedCandidateIdsByEmployer(ctx context.Context, employerID int64) ([]int64, error)
 	GetRejectedJobIdsByCandidate(ctx context.Context, candidateID int64) ([]string, error)
 	GetSession(ctx context.Context, id uuid.UUID) (Session, error)
 	GetUser(ctx context.Context, username string) (User, error)
+	IncrementJobDailyStats(ctx context.Context, arg IncrementJobDailyStatsParams) error
+	ListApplicationStatusHistory(ctx context.Context, arg ListApplicationStatusHistoryParams) ([]ApplicationStatusHistory, error)
+	ListApplicationStatusHistoryByKeys(ctx context.Context, arg ListApplicationStatusHistoryByKeysParams) ([]ApplicationStatusHistory, error)
+	ListCandidateApplicationJobIDs(ctx context.Context, arg ListCandidateApplicationJobIDsParams) ([]string, error)
+	ListCandidateApplicationsNewestFirst(ctx context.Context, arg ListCandidateApplicationsNewestFirstParams) ([]CandidateApplication, error)
+	ListCandidateApplicationsOldestFirst(ctx context.Context, arg ListCandidateApplicationsOldestFirstParams) ([]CandidateApplication, error)
+	ListCandidateContacts(ctx context.Context, ids []int64) ([]ListCandidateContactsRow, error)
+	ListCandidateIDsByJob(ctx context.Context, arg ListCandidateIDsByJobParams) ([]int64, error)
+	ListCandidateProfiles(ctx context.Context, ids []int64) ([]ListCandidateProfilesRow, error)
 	ListCandidates(ctx context.Context, arg ListCandidatesParams) ([]Candidate, error)
+	ListCandidatesByIDs(ctx context.Context, ids []int64) ([]Candidate, error)
+	ListConversations(ctx context.Context, arg ListConversationsParams) ([]ListConversationsRow, error)
+	ListEmployerApplications(ctx context.Context, arg ListEmployerApplicationsParams) ([]EmployerApplication, error)
+	ListEmployerJobStats(ctx context.Context, arg ListEmployerJobStatsParams) ([]ListEmployerJobStatsRow, error)
 	ListEmployers(ctx context.Context, arg ListEmployersParams) ([]Employer, error)
+	ListGeocodedLocations(ctx context.Context, addresses []string) ([]GeocodedLocation, error)
+	ListInterviewSlots(ctx context.Context, interviewID int64) ([]InterviewSlot, error)
+	ListJobDailyStats(ctx context.Context, arg ListJobDailyStatsParams) ([]JobDailyStat, error)
+	ListJobShifts(ctx context.Context, jobID string) ([]ListJobShiftsRow, error)
+	ListJobTemplates(ctx context.Context, employerID int64) ([]JobTemplate, error)
+	ListMessages(ctx context.Context, arg ListMessagesParams) ([]Message, error)
+	ListOpenApplicantsByJob(ctx context.Context, jobDocID string) ([]ListOpenApplicantsByJobRow, error)
 	ListPastExperiences(ctx context.Context, arg ListPastExperiencesParams) ([]PastExperience, error)
+	ListPastExperiencesByCandidates(ctx context.Context, candidateIds []int64) ([]PastExperience, error)
+	ListPendingModerationReviews(ctx context.Context, arg ListPendingModerationReviewsParams) ([]ModerationReview, error)
+	ListShiftClaims(ctx context.Context, shiftID int64) ([]ShiftClaim, error)
+	LockJobApplications(ctx context.Context, jobDocID string) error
+	MarkMessagesRead(ctx context.Context, arg MarkMessagesReadParams) (int64, error)
+	ReopenCandidateApplication(ctx context.Context, arg ReopenCandidateApplicationParams) (CandidateApplication, error)
+	RescheduleInterview(ctx context.Context, arg RescheduleInterviewParams) (Interview, error)
+	ResolveModerationReview(ctx context.Context, arg ResolveModerationReviewParams) (ModerationReview, error)
+	ScheduleInterview(ctx context.Context, arg ScheduleInterviewParams) (Interview, error)
 	UpdateCandidate(ctx context.Context, arg UpdateCandidateParams) (Candidate, error)
 	UpdateCandidateApplication(ctx context.Context, arg UpdateCandidateApplicationParams) (CandidateApplication, error)
 	UpdateCandidateApplicationStatus(ctx context.Context, arg UpdateCandidateApplicationStatusParams) error
+	UpdateConversationLastMessage(ctx context.Context, arg UpdateConversationLastMessageParams) error
 	UpdateEmployer(ctx context.Context, arg UpdateEmployerParams) (Employer, error)
 	UpdateEmployerApplication(ctx context.Context, arg UpdateEmployerApplicationParams) (EmployerApplication, error)
 	UpdateEmployerApplicationStatus(ctx context.Context, arg UpdateEmployerApplicationStatusParams) error
+	UpdateJobTemplate(ctx context.Context, arg UpdateJobTemplateParams) (JobTemplate, error)
 	UpdatePastExperience(ctx context.Context, arg UpdatePastExperienceParams) (PastExperience, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
 	UpdateVerifyEmail(ctx context.Context, arg UpdateVerifyEmailParams) (VerifyEmail, error)
+	UpsertCandidateSwipe(ctx context.Context, arg UpsertCandidateSwipeParams) error
+	UpsertConversation(ctx context.Context, arg UpsertConversationParams) (Conversation, error)
+	UpsertEmployerSwipe(ctx context.Context, arg UpsertEmployerSwipeParams) error
+	UpsertGeocodedLocation(ctx context.Context, arg UpsertGeocodedLocationParams) (GeocodedLocation, error)
+	UpsertNotificationPreference(ctx context.Context, arg UpsertNotificationPreferenceParams) (NotificationPreference, error)
+	WithdrawCandidateApplication(ctx context.Context, arg WithdrawCandidateApplicationParams) (CandidateApplication, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -58,10 +58,12 @@ func RandomEmployer(username string) Employer {
 
 func RandomCandidateApplication(employerID int64) CandidateApplication {
 	cID := util.RandomInt(0, 100)
+	jobID := fmt.Sprintf("%d_%s", employerID, util.RandomString(6))
 	return CandidateApplication{
 		CandidateID:        cID,
 		EmployerID:         employerID,
-		ElasticsearchDocID: fmt.Sprintf("%d_%d", cID, employerID),
+		ElasticsearchDocID: fmt.Sprintf("%d_%s", cID, jobID),
+		JobDocID:           jobID,
 		ApplicationStatus:  ApplicationStatusPending,
 		CreatedAt:          time.Time{},
 	}
@@ -78,6 +80,19 @@ func RandomEmployerApplication(candidateID int64) EmployerApplication {
 	}
 }
 
+func RandomJobShift(jobID string, employerID int64) JobShift {
+	startsAt := time.Now().Add(time.Duration(util.RandomInt(1, 72)) * time.Hour).Truncate(time.Hour)
+	return JobShift{
+		ID:         util.RandomInt(1, 1000),
+		JobID:      jobID,
+		EmployerID: employerID,
+		StartsAt:   startsAt,
+		EndsAt:     startsAt.Add(4 * time.Hour),
+		Timezone:   "America/New_York",
+		Headcount:  int32(util.RandomInt(1, 5)),
+	}
+}
+
 func RandomJobPref() JobPreference {
 	jobPreferences := []JobPreference{
 		JobPreferenceInperson,
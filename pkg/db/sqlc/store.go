@@ -15,9 +15,19 @@ type Store interface {
 	UpdatePastExperienceTx(ctx context.Context, arg UpdatePastExperienceTxParams) (PastExperienceTxResult, error)
 	DeletePastExperienceTx(ctx context.Context, arg DeletePastExperienceTxParams) error
 	CreateCandidateApplicationTx(ctx context.Context, arg CreateCandidateApplicationTxParams) (CandidateAppTxResult, error)
+	CreateEmployerApplicationTx(ctx context.Context, arg CreateEmployerApplicationTxParams) (EmployerAppTxResult, error)
 	DeleteApplicationTx(ctx context.Context, arg DeleteApplicationTxParams) error
-	UpdateCandidateApplicationStatusTx(ctx context.Context, arg UpdateCandidateApplicationStatusTxParams) error
-	UpdateEmployerApplicationStatusTx(ctx context.Context, arg UpdateEmployerApplicationStatusTxParams) error
+	TransitionApplicationStatusTx(ctx context.Context, arg TransitionApplicationStatusTxParams) (TransitionApplicationStatusTxResult, error)
+	UpdateCandidateApplicationStatusTx(ctx context.Context, arg UpdateCandidateApplicationStatusTxParams) (TransitionApplicationStatusTxResult, error)
+	UpdateEmployerApplicationStatusTx(ctx context.Context, arg UpdateEmployerApplicationStatusTxParams) (TransitionApplicationStatusTxResult, error)
+	WithdrawCandidateApplicationTx(ctx context.Context, arg WithdrawCandidateApplicationTxParams) (TransitionApplicationStatusTxResult, error)
+	BulkTransitionApplicationsTx(ctx context.Context, arg BulkTransitionApplicationsTxParams) ([]BulkApplicationResult, error)
+	ClaimShiftTx(ctx context.Context, arg ClaimShiftTxParams) (ClaimShiftTxResult, error)
+	SendMessageTx(ctx context.Context, arg SendMessageTxParams) (SendMessageTxResult, error)
+	CreateInterviewTx(ctx context.Context, arg CreateInterviewTxParams) (InterviewTxResult, error)
+	ScheduleInterviewTx(ctx context.Context, arg ScheduleInterviewTxParams) (InterviewTxResult, error)
+	RescheduleInterviewTx(ctx context.Context, arg RescheduleInterviewTxParams) (InterviewTxResult, error)
+	CancelInterviewTx(ctx context.Context, arg CancelInterviewTxParams) (InterviewTxResult, error)
 }
 
 // SQLStore provides all functions to execute SQL queries and transactions
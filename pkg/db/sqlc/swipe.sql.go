@@ -245,3 +245,51 @@ func (q *Queries) GetRejectedJobIdsByCandidate(ctx context.Context, candidateID
 	}
 	return items, nil
 }
+
+const upsertCandidateSwipe = `-- name: UpsertCandidateSwipe :exec
+INSERT INTO candidate_swipes (
+    candidate_id,
+    job_id,
+    swipe
+) VALUES (
+             $1, $2, $3
+         )
+ON CONFLICT (candidate_id, job_id)
+DO UPDATE SET swipe = EXCLUDED.swipe,
+              created_at = now()
+`
+
+type UpsertCandidateSwipeParams struct {
+	CandidateID int64  `json:"candidate_id"`
+	JobID       string `json:"job_id"`
+	Swipe       Swipe  `json:"swipe"`
+}
+
+func (q *Queries) UpsertCandidateSwipe(ctx context.Context, arg UpsertCandidateSwipeParams) error {
+	_, err := q.db.Exec(ctx, upsertCandidateSwipe, arg.CandidateID, arg.JobID, arg.Swipe)
+	return err
+}
+
+const upsertEmployerSwipe = `-- name: UpsertEmployerSwipe :exec
+INSERT INTO employer_swipes (
+    employer_id,
+    candidate_id,
+    swipe
+) VALUES (
+             $1, $2, $3
+         )
+ON CONFLICT (employer_id, candidate_id)
+DO UPDATE SET swipe = EXCLUDED.swipe,
+              created_at = now()
+`
+
+type UpsertEmployerSwipeParams struct {
+	EmployerID  int64 `json:"employer_id"`
+	CandidateID int64 `json:"candidate_id"`
+	Swipe       Swipe `json:"swipe"`
+}
+
+func (q *Queries) UpsertEmployerSwipe(ctx context.Context, arg UpsertEmployerSwipeParams) error {
+	_, err := q.db.Exec(ctx, upsertEmployerSwipe, arg.EmployerID, arg.CandidateID, arg.Swipe)
+	return err
+}
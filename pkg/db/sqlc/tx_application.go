@@ -4,10 +4,27 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 )
 
 type CreateCandidateApplicationTxParams struct {
 	CreateCandidateApplicationParams
+	// MaxApplications is the job's application limit; zero means unlimited.
+	MaxApplications int32
+	// ReapplyCooldown is how long after withdrawing from the job the candidate
+	// has to wait before applying to it again. Zero lets them reapply at once.
+	ReapplyCooldown time.Duration
+	AppDoc          map[string]interface{}
+	AfterCreate     func(docID string, appDoc map[string]interface{}) error
+	// AfterLimitReached runs when the application takes the job's last slot.
+	// It runs inside the transaction, so the application is rolled back if
+	// it fails and a full job is never left open.
+	AfterLimitReached func() error
+}
+
+type CreateEmployerApplicationTxParams struct {
+	CreateEmployerApplicationParams
+	DocID       string
 	AppDoc      map[string]interface{}
 	AfterCreate func(docID string, appDoc map[string]interface{}) error
 }
@@ -22,16 +39,66 @@ type DeleteApplicationTxParams struct {
 
 type UpdateCandidateApplicationStatusTxParams struct {
 	UpdateCandidateApplicationStatusParams
-	AfterUpdate func(params CreateCandidateSwipeParams) error
+	// EmployerID is the employer deciding on the application.
+	EmployerID  int64
+	Reason      string
+	AfterUpdate func(result TransitionApplicationStatusTxResult) error
 }
 
 type UpdateEmployerApplicationStatusTxParams struct {
 	UpdateEmployerApplicationStatusParams
-	AfterUpdate func(params CreateEmployerSwipesParams) error
+	Reason string
+	// Application is the prefilled application that accepting an invitation
+	// to a job turns into. It is optional and only created on acceptance.
+	Application *CreateCandidateApplicationTxParams
+	AfterUpdate func(result TransitionApplicationStatusTxResult) error
+}
+
+type WithdrawCandidateApplicationTxParams struct {
+	CandidateID int64
+	JobDocID    string
+	Reason      WithdrawalReason
+	Note        string
+	AfterUpdate func(result TransitionApplicationStatusTxResult) error
+}
+
+type TransitionApplicationStatusTxParams struct {
+	Kind        ApplicationKind
+	CandidateID int64
+	// EmployerID keys employer applications. Candidate applications are keyed
+	// by JobDocID instead, and when EmployerID is set it must match the
+	// application's employer.
+	EmployerID int64
+	JobDocID   string
+	ActorRole  Role
+	ActorID    int64
+	Status     ApplicationStatus
+	Reason     string
+	// AfterUpdate is optional. It runs inside the transaction, only when the
+	// status changed.
+	AfterUpdate func(result TransitionApplicationStatusTxResult) error
+}
+
+type TransitionApplicationStatusTxResult struct {
+	// Only the application matching the params' Kind is set, except that an
+	// accepted invitation also sets the candidate application it turned into.
+	CandidateApplication CandidateApplication
+	EmployerApplication  EmployerApplication
+	// Changed is false when the application already had the status, in which
+	// case nothing is updated or recorded.
+	Changed bool
+	History ApplicationStatusHistory
+	// ApplicationCount is the job's application count including the one an
+	// accepted invitation created. It is only set when the job has an
+	// application limit.
+	ApplicationCount int64
 }
 
 type CandidateAppTxResult struct {
 	CandidateApplication CandidateApplication
+	// ApplicationCount is the job's application count including the new one.
+	// It is only set when the job has an application limit.
+	ApplicationCount int64
 }
 
 type EmployerAppTxResult struct {
@@ -42,11 +109,103 @@ func (store *SQLStore) CreateCandidateApplicationTx(ctx context.Context, arg Cre
 	var result CandidateAppTxResult
 	err := store.execTx(ctx, func(q *Queries) error {
 		var err error
+		result, err = applyToJob(ctx, q, arg)
+		return err
+	})
+	return result, err
+}
+
+// applyToJob runs CreateCandidateApplicationTx within an open transaction.
+// Applying to a job the candidate withdrew from reopens the withdrawn
+// application once the cooldown has passed, keeping its status history.
+func applyToJob(ctx context.Context, q *Queries, arg CreateCandidateApplicationTxParams) (CandidateAppTxResult, error) {
+	var result CandidateAppTxResult
+	existing, err := q.GetCandidateApplicationForUpdate(ctx, GetCandidateApplicationForUpdateParams{
+		CandidateID: arg.CandidateID,
+		JobDocID:    arg.JobDocID,
+	})
+	if err != nil && !errors.Is(err, ErrRecordNotFound) {
+		return result, err
+	}
+	reopen := err == nil && existing.ApplicationStatus == ApplicationStatusWithdrawn
+	if reopen && existing.WithdrawnAt.Valid {
+		if until := existing.WithdrawnAt.Time.Add(arg.ReapplyCooldown); time.Now().Before(until) {
+			return result, fmt.Errorf("%w: try again after %s", ErrReapplyTooSoon, until.Format(time.RFC3339))
+		}
+	}
+
+	if arg.MaxApplications > 0 {
+		// Serialize applications to the same job so concurrent requests
+		// cannot push it past its limit.
+		if err = q.LockJobApplications(ctx, arg.JobDocID); err != nil {
+			return result, err
+		}
+		count, err := q.CountCandidateApplicationsByJob(ctx, arg.JobDocID)
+		if err != nil {
+			return result, err
+		}
+		if count >= int64(arg.MaxApplications) {
+			return result, ErrApplicationLimitReached
+		}
+		result.ApplicationCount = count + 1
+	}
+	if !reopen {
 		result.CandidateApplication, err = q.CreateCandidateApplication(ctx, arg.CreateCandidateApplicationParams)
+		if err != nil {
+			return result, err
+		}
+		return result, afterApply(arg, result)
+	}
+
+	result.CandidateApplication, err = q.ReopenCandidateApplication(ctx, ReopenCandidateApplicationParams{
+		CandidateID:        arg.CandidateID,
+		JobDocID:           arg.JobDocID,
+		EmployerID:         arg.EmployerID,
+		ElasticsearchDocID: arg.ElasticsearchDocID,
+		ApplicationStatus:  arg.ApplicationStatus,
+	})
+	if err != nil {
+		return result, err
+	}
+	_, err = q.CreateApplicationStatusHistory(ctx, CreateApplicationStatusHistoryParams{
+		ApplicationKind: string(ApplicationKindCandidate),
+		CandidateID:     arg.CandidateID,
+		EmployerID:      arg.EmployerID,
+		JobDocID:        arg.JobDocID,
+		ActorRole:       RoleCandidate,
+		ActorID:         arg.CandidateID,
+		FromStatus:      ApplicationStatusWithdrawn,
+		ToStatus:        arg.ApplicationStatus,
+		Reason:          "reapplied",
+	})
+	if err != nil {
+		return result, err
+	}
+	return result, afterApply(arg, result)
+}
+
+func afterApply(arg CreateCandidateApplicationTxParams, result CandidateAppTxResult) error {
+	if err := arg.AfterCreate(result.CandidateApplication.ElasticsearchDocID, arg.AppDoc); err != nil {
+		return err
+	}
+	if arg.AfterLimitReached != nil && arg.MaxApplications > 0 && result.ApplicationCount >= int64(arg.MaxApplications) {
+		return arg.AfterLimitReached()
+	}
+	return nil
+}
+
+// CreateEmployerApplicationTx creates an employer's invitation to a
+// candidate. The AfterCreate hook runs inside the transaction, so the
+// invitation is rolled back if it fails.
+func (store *SQLStore) CreateEmployerApplicationTx(ctx context.Context, arg CreateEmployerApplicationTxParams) (EmployerAppTxResult, error) {
+	var result EmployerAppTxResult
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.EmployerAppResult, err = q.CreateEmployerApplication(ctx, arg.CreateEmployerApplicationParams)
 		if err != nil {
 			return err
 		}
-		return arg.AfterCreate(result.CandidateApplication.ElasticsearchDocID, arg.AppDoc)
+		return arg.AfterCreate(arg.DocID, arg.AppDoc)
 	})
 	return result, err
 }
@@ -58,9 +217,26 @@ func (store *SQLStore) DeleteApplicationTx(ctx context.Context, arg DeleteApplic
 			if err != nil {
 				return err
 			}
+			err = q.DeleteApplicationStatusHistory(ctx, DeleteApplicationStatusHistoryParams{
+				ApplicationKind: string(ApplicationKindEmployer),
+				CandidateID:     arg.DeleteEmployerApplicationParams.CandidateID,
+				EmployerID:      arg.DeleteEmployerApplicationParams.EmployerID,
+			})
+			if err != nil {
+				return err
+			}
 			return arg.AfterDelete(arg.DocID)
 		} else {
-			err := q.DeleteCandidateApplication(ctx, arg.DeleteCandidateApplicationParams)
+			deleted, err := q.DeleteCandidateApplication(ctx, arg.DeleteCandidateApplicationParams)
+			if err != nil {
+				return err
+			}
+			err = q.DeleteApplicationStatusHistory(ctx, DeleteApplicationStatusHistoryParams{
+				ApplicationKind: string(ApplicationKindCandidate),
+				CandidateID:     arg.DeleteCandidateApplicationParams.CandidateID,
+				EmployerID:      deleted.EmployerID,
+				JobDocID:        deleted.JobDocID,
+			})
 			if err != nil {
 				return err
 			}
@@ -69,44 +245,214 @@ func (store *SQLStore) DeleteApplicationTx(ctx context.Context, arg DeleteApplic
 	})
 }
 
-func (store *SQLStore) UpdateCandidateApplicationStatusTx(ctx context.Context, arg UpdateCandidateApplicationStatusTxParams) error {
-	return store.execTx(ctx, func(q *Queries) error {
-		err := q.UpdateCandidateApplicationStatus(ctx, arg.UpdateCandidateApplicationStatusParams)
-		if err != nil {
+// TransitionApplicationStatusTx moves an application to a new status if the
+// actor is allowed to, and records the change in the status history. The
+// application row is locked so that concurrent changes are validated against
+// the status they actually replace. Accepting or rejecting an application
+// also records the outcome as a swipe, so that the candidate's job feed and
+// the employer's candidate feed stop showing the other party. Candidates can't
+// answer an employer's invitation once it has expired.
+func (store *SQLStore) TransitionApplicationStatusTx(ctx context.Context, arg TransitionApplicationStatusTxParams) (TransitionApplicationStatusTxResult, error) {
+	var result TransitionApplicationStatusTxResult
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result, err = transitionApplicationStatus(ctx, q, arg)
+		if err != nil || !result.Changed || arg.AfterUpdate == nil {
 			return err
 		}
-		appStatusParams := arg.UpdateCandidateApplicationStatusParams
-		swipe, err := getSwipe(appStatusParams.ApplicationStatus)
+		return arg.AfterUpdate(result)
+	})
+	return result, err
+}
+
+// transitionApplicationStatus runs TransitionApplicationStatusTx within an
+// open transaction, without its AfterUpdate hook.
+func transitionApplicationStatus(ctx context.Context, q *Queries, arg TransitionApplicationStatusTxParams) (TransitionApplicationStatusTxResult, error) {
+	var result TransitionApplicationStatusTxResult
+	var from ApplicationStatus
+	var err error
+	if arg.Kind == ApplicationKindEmployer {
+		result.EmployerApplication, err = q.GetEmployerApplicationForUpdate(ctx, GetEmployerApplicationForUpdateParams{
+			EmployerID:  arg.EmployerID,
+			CandidateID: arg.CandidateID,
+		})
+		from = result.EmployerApplication.ApplicationStatus
+	} else {
+		result.CandidateApplication, err = q.GetCandidateApplicationForUpdate(ctx, GetCandidateApplicationForUpdateParams{
+			CandidateID: arg.CandidateID,
+			JobDocID:    arg.JobDocID,
+		})
+		from = result.CandidateApplication.ApplicationStatus
+	}
+	if err != nil {
+		return result, err
+	}
+	employerID, jobDocID := arg.EmployerID, ""
+	if arg.Kind != ApplicationKindEmployer {
+		if employerID != 0 && employerID != result.CandidateApplication.EmployerID {
+			return result, ErrRecordNotFound
+		}
+		employerID, jobDocID = result.CandidateApplication.EmployerID, result.CandidateApplication.JobDocID
+	}
+	if from == arg.Status {
+		return result, nil
+	}
+	if arg.Kind == ApplicationKindEmployer && arg.ActorRole == RoleCandidate && result.EmployerApplication.Expired(time.Now()) {
+		return result, ErrInvitationExpired
+	}
+	if err = ValidateApplicationTransition(arg.Kind, arg.ActorRole, from, arg.Status); err != nil {
+		return result, err
+	}
+
+	status := NullApplicationStatus{ApplicationStatus: arg.Status, Valid: true}
+	if arg.Kind == ApplicationKindEmployer {
+		result.EmployerApplication, err = q.UpdateEmployerApplication(ctx, UpdateEmployerApplicationParams{
+			EmployerID:        arg.EmployerID,
+			CandidateID:       arg.CandidateID,
+			ApplicationStatus: status,
+		})
+	} else {
+		result.CandidateApplication, err = q.UpdateCandidateApplication(ctx, UpdateCandidateApplicationParams{
+			CandidateID:       arg.CandidateID,
+			JobDocID:          arg.JobDocID,
+			ApplicationStatus: status,
+		})
+	}
+	if err != nil {
+		return result, err
+	}
+
+	result.History, err = q.CreateApplicationStatusHistory(ctx, CreateApplicationStatusHistoryParams{
+		ApplicationKind: string(arg.Kind),
+		CandidateID:     arg.CandidateID,
+		EmployerID:      employerID,
+		JobDocID:        jobDocID,
+		ActorRole:       arg.ActorRole,
+		ActorID:         arg.ActorID,
+		FromStatus:      from,
+		ToStatus:        arg.Status,
+		Reason:          arg.Reason,
+	})
+	if err != nil {
+		return result, err
+	}
+	result.Changed = true
+
+	if swipe, err := getSwipe(arg.Status); err == nil {
+		if arg.Kind == ApplicationKindEmployer {
+			err = q.UpsertEmployerSwipe(ctx, UpsertEmployerSwipeParams{
+				EmployerID:  arg.EmployerID,
+				CandidateID: arg.CandidateID,
+				Swipe:       swipe,
+			})
+		} else {
+			err = q.UpsertCandidateSwipe(ctx, UpsertCandidateSwipeParams{
+				CandidateID: arg.CandidateID,
+				JobID:       jobDocID,
+				Swipe:       swipe,
+			})
+		}
 		if err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// WithdrawCandidateApplicationTx lets a candidate take back an application
+// the employer hasn't decided on yet. Unlike deleting it, the application and
+// its document are kept with the reason for withdrawing, so the employer
+// still has the context and the withdrawal shows up in reporting.
+func (store *SQLStore) WithdrawCandidateApplicationTx(ctx context.Context, arg WithdrawCandidateApplicationTxParams) (TransitionApplicationStatusTxResult, error) {
+	var result TransitionApplicationStatusTxResult
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		reason := string(arg.Reason)
+		if arg.Note != "" {
+			reason += ": " + arg.Note
+		}
+		result, err = transitionApplicationStatus(ctx, q, TransitionApplicationStatusTxParams{
+			Kind:        ApplicationKindCandidate,
+			CandidateID: arg.CandidateID,
+			JobDocID:    arg.JobDocID,
+			ActorRole:   RoleCandidate,
+			ActorID:     arg.CandidateID,
+			Status:      ApplicationStatusWithdrawn,
+			Reason:      reason,
+		})
+		if err != nil || !result.Changed {
 			return err
 		}
-		params := CreateCandidateSwipeParams{
-			CandidateID: appStatusParams.CandidateID,
-			JobID:       fmt.Sprintf("%d_%d", appStatusParams.CandidateID, appStatusParams.EmployerID),
-			Swipe:       swipe,
+		result.CandidateApplication, err = q.WithdrawCandidateApplication(ctx, WithdrawCandidateApplicationParams{
+			CandidateID:      arg.CandidateID,
+			JobDocID:         arg.JobDocID,
+			WithdrawalReason: NullWithdrawalReason{WithdrawalReason: arg.Reason, Valid: true},
+			WithdrawalNote:   arg.Note,
+		})
+		if err != nil || arg.AfterUpdate == nil {
+			return err
 		}
-		return arg.AfterUpdate(params)
+		return arg.AfterUpdate(result)
 	})
+	return result, err
 }
 
-func (store *SQLStore) UpdateEmployerApplicationStatusTx(ctx context.Context, arg UpdateEmployerApplicationStatusTxParams) error {
-	return store.execTx(ctx, func(q *Queries) error {
-		err := q.UpdateEmployerApplicationStatus(ctx, arg.UpdateEmployerApplicationStatusParams)
-		if err != nil {
+// UpdateCandidateApplicationStatusTx records the employer's decision to
+// accept or reject a candidate's application.
+func (store *SQLStore) UpdateCandidateApplicationStatusTx(ctx context.Context, arg UpdateCandidateApplicationStatusTxParams) (TransitionApplicationStatusTxResult, error) {
+	if _, err := getSwipe(arg.ApplicationStatus); err != nil {
+		return TransitionApplicationStatusTxResult{}, err
+	}
+	return store.TransitionApplicationStatusTx(ctx, TransitionApplicationStatusTxParams{
+		Kind:        ApplicationKindCandidate,
+		CandidateID: arg.CandidateID,
+		EmployerID:  arg.EmployerID,
+		JobDocID:    arg.JobDocID,
+		ActorRole:   RoleEmployer,
+		ActorID:     arg.EmployerID,
+		Status:      arg.ApplicationStatus,
+		Reason:      arg.Reason,
+		AfterUpdate: arg.AfterUpdate,
+	})
+}
+
+// UpdateEmployerApplicationStatusTx records the candidate's decision to
+// accept or decline an employer's invitation. Accepting an invitation to a
+// job also creates the candidate's application to it, so the invitation is
+// rolled back if the job can't take the application.
+func (store *SQLStore) UpdateEmployerApplicationStatusTx(ctx context.Context, arg UpdateEmployerApplicationStatusTxParams) (TransitionApplicationStatusTxResult, error) {
+	if _, err := getSwipe(arg.ApplicationStatus); err != nil {
+		return TransitionApplicationStatusTxResult{}, err
+	}
+	var result TransitionApplicationStatusTxResult
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result, err = transitionApplicationStatus(ctx, q, TransitionApplicationStatusTxParams{
+			Kind:        ApplicationKindEmployer,
+			CandidateID: arg.CandidateID,
+			EmployerID:  arg.EmployerID,
+			ActorRole:   RoleCandidate,
+			ActorID:     arg.CandidateID,
+			Status:      arg.ApplicationStatus,
+			Reason:      arg.Reason,
+		})
+		if err != nil || !result.Changed {
 			return err
 		}
-		appStatusParams := arg.UpdateEmployerApplicationStatusParams
-		swipe, err := getSwipe(appStatusParams.ApplicationStatus)
-		if err != nil {
-			return err
+		if arg.Application != nil && arg.ApplicationStatus == ApplicationStatusAccepted {
+			created, err := applyToJob(ctx, q, *arg.Application)
+			if err != nil {
+				return err
+			}
+			result.CandidateApplication = created.CandidateApplication
+			result.ApplicationCount = created.ApplicationCount
 		}
-		params := CreateEmployerSwipesParams{
-			EmployerID:  appStatusParams.EmployerID,
-			CandidateID: appStatusParams.CandidateID,
-			Swipe:       swipe,
+		if arg.AfterUpdate == nil {
+			return nil
 		}
-		return arg.AfterUpdate(params)
+		return arg.AfterUpdate(result)
 	})
+	return result, err
 }
 
 func getSwipe(applicationStatus ApplicationStatus) (Swipe, error) {
@@ -115,6 +461,6 @@ func getSwipe(applicationStatus ApplicationStatus) (Swipe, error) {
 	} else if applicationStatus == ApplicationStatusRejected {
 		return SwipeReject, nil
 	} else {
-		return "", errors.New("invalid application status request: use accepted or rejected")
+		return "", fmt.Errorf("%w: use accepted or rejected", ErrInvalidStatusTransition)
 	}
 }
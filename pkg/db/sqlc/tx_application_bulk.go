@@ -0,0 +1,115 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"sort"
+)
+
+type BulkTransitionApplicationsTxParams struct {
+	// EmployerID is the employer deciding on the applications to their job.
+	EmployerID int64
+	JobDocID   string
+	// CandidateIDs picks the applications to update. When it is empty, every
+	// application to the job with one of FromStatuses is updated instead.
+	CandidateIDs []int64
+	FromStatuses []ApplicationStatus
+	Status       ApplicationStatus
+	Reason       string
+	// AfterUpdate is optional. It runs inside the transaction with the
+	// applications whose status changed, and only when there are any.
+	AfterUpdate func(changed []TransitionApplicationStatusTxResult) error
+}
+
+// BulkApplicationResult is the outcome for one application of a bulk update.
+type BulkApplicationResult struct {
+	CandidateID int64
+	TransitionApplicationStatusTxResult
+	// Err is why the application was left alone, if it was. It doesn't stop
+	// the other applications from being updated.
+	Err error
+}
+
+// BulkTransitionApplicationsTx accepts or rejects many applications to one of
+// an employer's jobs in a single transaction. Each application goes through
+// the same state machine as a single update, and applications that can't
+// make the change are reported in their result rather than failing the rest.
+// Applications are locked in candidate order so that concurrent bulk updates
+// to the same job can't deadlock.
+func (store *SQLStore) BulkTransitionApplicationsTx(ctx context.Context, arg BulkTransitionApplicationsTxParams) ([]BulkApplicationResult, error) {
+	if _, err := getSwipe(arg.Status); err != nil {
+		return nil, err
+	}
+	var results []BulkApplicationResult
+	err := store.execTx(ctx, func(q *Queries) error {
+		candidateIDs := uniqueSortedIDs(arg.CandidateIDs)
+		if len(candidateIDs) == 0 {
+			statuses := make([]string, 0, len(arg.FromStatuses))
+			for _, status := range arg.FromStatuses {
+				statuses = append(statuses, string(status))
+			}
+			var err error
+			candidateIDs, err = q.ListCandidateIDsByJob(ctx, ListCandidateIDsByJobParams{
+				JobDocID:   arg.JobDocID,
+				EmployerID: arg.EmployerID,
+				Statuses:   statuses,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		results = make([]BulkApplicationResult, 0, len(candidateIDs))
+		var changed []TransitionApplicationStatusTxResult
+		for _, candidateID := range candidateIDs {
+			result, err := transitionApplicationStatus(ctx, q, TransitionApplicationStatusTxParams{
+				Kind:        ApplicationKindCandidate,
+				CandidateID: candidateID,
+				EmployerID:  arg.EmployerID,
+				JobDocID:    arg.JobDocID,
+				ActorRole:   RoleEmployer,
+				ActorID:     arg.EmployerID,
+				Status:      arg.Status,
+				Reason:      arg.Reason,
+			})
+			if err != nil && !isBulkItemError(err) {
+				return err
+			}
+			results = append(results, BulkApplicationResult{
+				CandidateID:                         candidateID,
+				TransitionApplicationStatusTxResult: result,
+				Err:                                 err,
+			})
+			if err == nil && result.Changed {
+				changed = append(changed, result)
+			}
+		}
+		if len(changed) == 0 || arg.AfterUpdate == nil {
+			return nil
+		}
+		return arg.AfterUpdate(changed)
+	})
+	return results, err
+}
+
+// isBulkItemError reports whether an error only concerns one application of
+// a bulk update. None of them are database errors, so the transaction can
+// carry on.
+func isBulkItemError(err error) bool {
+	return errors.Is(err, ErrRecordNotFound) ||
+		errors.Is(err, ErrInvalidStatusTransition) ||
+		errors.Is(err, ErrStatusTransitionNotAllowed)
+}
+
+func uniqueSortedIDs(ids []int64) []int64 {
+	unique := make([]int64, 0, len(ids))
+	seen := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	sort.Slice(unique, func(i, j int) bool { return unique[i] < unique[j] })
+	return unique
+}
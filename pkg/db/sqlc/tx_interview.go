@@ -0,0 +1,187 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type CreateInterviewTxParams struct {
+	CreateInterviewParams
+	// Slots are the start times the employer offers the candidate.
+	Slots       []time.Time
+	AfterCreate func(result InterviewTxResult) error
+}
+
+type ScheduleInterviewTxParams struct {
+	InterviewID int64
+	SlotID      int64
+	// Now is the time of the pick; slots that have passed can't be picked.
+	Now         time.Time
+	AfterUpdate func(result InterviewTxResult) error
+}
+
+type RescheduleInterviewTxParams struct {
+	InterviewID     int64
+	Slots           []time.Time
+	DurationMinutes pgtype.Int4
+	Location        pgtype.Text
+	AfterUpdate     func(result InterviewTxResult) error
+}
+
+type CancelInterviewTxParams struct {
+	InterviewID int64
+	AfterUpdate func(result InterviewTxResult) error
+}
+
+type InterviewTxResult struct {
+	Interview Interview
+	Slots     []InterviewSlot
+	// PreviousScheduledAt is when the interview was scheduled before the
+	// change, if it was.
+	PreviousScheduledAt pgtype.Timestamptz
+}
+
+// CreateInterviewTx proposes an interview for an accepted candidate
+// application. The application is locked so that it can't be rejected while
+// the interview is being arranged.
+func (store *SQLStore) CreateInterviewTx(ctx context.Context, arg CreateInterviewTxParams) (InterviewTxResult, error) {
+	var result InterviewTxResult
+	err := store.execTx(ctx, func(q *Queries) error {
+		application, err := q.GetCandidateApplicationForUpdate(ctx, GetCandidateApplicationForUpdateParams{
+			CandidateID: arg.CandidateID,
+			JobDocID:    arg.JobDocID,
+		})
+		if err != nil {
+			return err
+		}
+		if application.ApplicationStatus != ApplicationStatusAccepted {
+			return ErrApplicationNotAccepted
+		}
+		result.Interview, err = q.CreateInterview(ctx, arg.CreateInterviewParams)
+		if err != nil {
+			return err
+		}
+		if result.Slots, err = createInterviewSlots(ctx, q, result.Interview.ID, arg.Slots); err != nil {
+			return err
+		}
+		if arg.AfterCreate != nil {
+			return arg.AfterCreate(result)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// ScheduleInterviewTx schedules an interview at the slot the candidate
+// picked.
+func (store *SQLStore) ScheduleInterviewTx(ctx context.Context, arg ScheduleInterviewTxParams) (InterviewTxResult, error) {
+	var result InterviewTxResult
+	err := store.execTx(ctx, func(q *Queries) error {
+		interview, err := q.GetInterviewForUpdate(ctx, arg.InterviewID)
+		if err != nil {
+			return err
+		}
+		if interview.Status != InterviewStatusProposed {
+			return ErrInterviewNotProposed
+		}
+		slot, err := q.GetInterviewSlot(ctx, GetInterviewSlotParams{ID: arg.SlotID, InterviewID: arg.InterviewID})
+		if err != nil {
+			return err
+		}
+		if !slot.StartsAt.After(arg.Now) {
+			return ErrInterviewSlotPassed
+		}
+		result.Interview, err = q.ScheduleInterview(ctx, ScheduleInterviewParams{
+			ID:          arg.InterviewID,
+			ScheduledAt: pgtype.Timestamptz{Time: slot.StartsAt, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+		if result.Slots, err = q.ListInterviewSlots(ctx, arg.InterviewID); err != nil {
+			return err
+		}
+		if arg.AfterUpdate != nil {
+			return arg.AfterUpdate(result)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// RescheduleInterviewTx replaces the interview's slots with new ones. A
+// scheduled interview goes back to waiting for the candidate to pick a slot.
+func (store *SQLStore) RescheduleInterviewTx(ctx context.Context, arg RescheduleInterviewTxParams) (InterviewTxResult, error) {
+	var result InterviewTxResult
+	err := store.execTx(ctx, func(q *Queries) error {
+		interview, err := q.GetInterviewForUpdate(ctx, arg.InterviewID)
+		if err != nil {
+			return err
+		}
+		if interview.Status == InterviewStatusCancelled {
+			return ErrInterviewCancelled
+		}
+		result.PreviousScheduledAt = interview.ScheduledAt
+		result.Interview, err = q.RescheduleInterview(ctx, RescheduleInterviewParams{
+			DurationMinutes: arg.DurationMinutes,
+			Location:        arg.Location,
+			ID:              arg.InterviewID,
+		})
+		if err != nil {
+			return err
+		}
+		if err := q.DeleteInterviewSlots(ctx, arg.InterviewID); err != nil {
+			return err
+		}
+		if result.Slots, err = createInterviewSlots(ctx, q, arg.InterviewID, arg.Slots); err != nil {
+			return err
+		}
+		if arg.AfterUpdate != nil {
+			return arg.AfterUpdate(result)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// CancelInterviewTx cancels an interview. The application can get a new
+// interview afterwards.
+func (store *SQLStore) CancelInterviewTx(ctx context.Context, arg CancelInterviewTxParams) (InterviewTxResult, error) {
+	var result InterviewTxResult
+	err := store.execTx(ctx, func(q *Queries) error {
+		interview, err := q.GetInterviewForUpdate(ctx, arg.InterviewID)
+		if err != nil {
+			return err
+		}
+		if interview.Status == InterviewStatusCancelled {
+			return ErrInterviewCancelled
+		}
+		result.PreviousScheduledAt = interview.ScheduledAt
+		result.Interview, err = q.CancelInterview(ctx, arg.InterviewID)
+		if err != nil {
+			return err
+		}
+		if result.Slots, err = q.ListInterviewSlots(ctx, arg.InterviewID); err != nil {
+			return err
+		}
+		if arg.AfterUpdate != nil {
+			return arg.AfterUpdate(result)
+		}
+		return nil
+	})
+	return result, err
+}
+
+func createInterviewSlots(ctx context.Context, q *Queries, interviewID int64, startTimes []time.Time) ([]InterviewSlot, error) {
+	slots := make([]InterviewSlot, 0, len(startTimes))
+	for _, startsAt := range startTimes {
+		slot, err := q.CreateInterviewSlot(ctx, CreateInterviewSlotParams{InterviewID: interviewID, StartsAt: startsAt})
+		if err != nil {
+			return nil, err
+		}
+		slots = append(slots, slot)
+	}
+	return slots, nil
+}
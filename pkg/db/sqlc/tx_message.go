@@ -0,0 +1,61 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type SendMessageTxParams struct {
+	CandidateID int64
+	EmployerID  int64
+	SenderRole  Role
+	SenderID    int64
+	Body        string
+}
+
+type SendMessageTxResult struct {
+	Conversation Conversation
+	Message      Message
+}
+
+// SendMessageTx adds a message to the conversation between a candidate and an
+// employer, starting the conversation if it is their first message. Only
+// parties with an accepted application or a match can message each other.
+func (store *SQLStore) SendMessageTx(ctx context.Context, arg SendMessageTxParams) (SendMessageTxResult, error) {
+	var result SendMessageTxResult
+	err := store.execTx(ctx, func(q *Queries) error {
+		allowed, err := q.CanMessage(ctx, CanMessageParams{
+			CandidateID: arg.CandidateID,
+			EmployerID:  arg.EmployerID,
+		})
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return ErrMessagingNotAllowed
+		}
+		result.Conversation, err = q.UpsertConversation(ctx, UpsertConversationParams{
+			CandidateID: arg.CandidateID,
+			EmployerID:  arg.EmployerID,
+		})
+		if err != nil {
+			return err
+		}
+		result.Message, err = q.CreateMessage(ctx, CreateMessageParams{
+			ConversationID: result.Conversation.ID,
+			SenderRole:     arg.SenderRole,
+			SenderID:       arg.SenderID,
+			Body:           arg.Body,
+		})
+		if err != nil {
+			return err
+		}
+		result.Conversation.LastMessageAt = pgtype.Timestamptz{Time: result.Message.CreatedAt, Valid: true}
+		return q.UpdateConversationLastMessage(ctx, UpdateConversationLastMessageParams{
+			ID:            result.Conversation.ID,
+			LastMessageAt: result.Conversation.LastMessageAt,
+		})
+	})
+	return result, err
+}
@@ -0,0 +1,49 @@
+package db
+
+import (
+	"context"
+	"time"
+)
+
+type ClaimShiftTxParams struct {
+	CreateShiftClaimParams
+	// Now is the time of the claim; shifts that have started can't be claimed.
+	Now time.Time
+}
+
+type ClaimShiftTxResult struct {
+	Shift JobShift
+	Claim ShiftClaim
+	// Claimed is the number of claims on the shift including the new one.
+	Claimed int64
+}
+
+// ClaimShiftTx gives the candidate one of the shift's spots. The shift row is
+// locked so that concurrent claims cannot exceed its headcount.
+func (store *SQLStore) ClaimShiftTx(ctx context.Context, arg ClaimShiftTxParams) (ClaimShiftTxResult, error) {
+	var result ClaimShiftTxResult
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.Shift, err = q.GetJobShiftForUpdate(ctx, arg.ShiftID)
+		if err != nil {
+			return err
+		}
+		if !result.Shift.StartsAt.After(arg.Now) {
+			return ErrShiftStarted
+		}
+		count, err := q.CountShiftClaims(ctx, arg.ShiftID)
+		if err != nil {
+			return err
+		}
+		if count >= int64(result.Shift.Headcount) {
+			return ErrShiftFull
+		}
+		result.Claim, err = q.CreateShiftClaim(ctx, arg.CreateShiftClaimParams)
+		if err != nil {
+			return err
+		}
+		result.Claimed = count + 1
+		return nil
+	})
+	return result, err
+}
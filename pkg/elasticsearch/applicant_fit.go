@@ -0,0 +1,241 @@
+package elasticsearch
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"time"
+
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	"github.com/hankimmy/PtmrBackend/pkg/util"
+)
+
+const (
+	// fitMaxDistanceMeters is how far a candidate can live from the job
+	// before the distance score drops to zero.
+	fitMaxDistanceMeters = 40000
+	// fitSkillTarget is how many of a candidate's skills the job has to
+	// mention for a full skill score.
+	fitSkillTarget = 3
+	// fitExperienceYears is how much relevant experience earns a full
+	// experience score.
+	fitExperienceYears = 2.0
+	// fitCertificateTarget is how many certificates earn a full certificate
+	// score. Certificates the job mentions count double.
+	fitCertificateTarget = 2.0
+)
+
+// fitWeights is how much each component counts towards the fit score.
+// Components that can't be computed for a job or candidate are left out and
+// the others weighted up.
+var fitWeights = struct {
+	distance, skills, availability, experience, certificates float64
+}{
+	distance:     0.25,
+	skills:       0.25,
+	availability: 0.2,
+	experience:   0.2,
+	certificates: 0.1,
+}
+
+// CandidateProfile is the part of a candidate that applicants are ranked on.
+type CandidateProfile struct {
+	Location         string              `json:"location"`
+	SkillSet         []string            `json:"skill_set"`
+	Certificates     []string            `json:"certificates"`
+	TimeAvailability []util.Availability `json:"time_availability"`
+	PastExperience   []ExperienceLength  `json:"past_experience"`
+}
+
+// ExperienceLength is a past experience with its length in years.
+type ExperienceLength struct {
+	Industry string  `json:"industry"`
+	JobTitle string  `json:"job_title"`
+	Length   float64 `json:"length"`
+}
+
+// ApplicantFit is how well a candidate fits a job. Every score is between 0
+// and 1; Distance and Availability are nil when they can't be computed, that
+// is when either location is unknown or the job has no shifts.
+type ApplicantFit struct {
+	Score           float64  `json:"score"`
+	Distance        *float64 `json:"distance"`
+	DistanceKm      *float64 `json:"distance_km,omitempty"`
+	Skills          float64  `json:"skills"`
+	MatchedSkills   []string `json:"matched_skills"`
+	Availability    *float64 `json:"availability"`
+	Experience      float64  `json:"experience"`
+	ExperienceYears float64  `json:"experience_years"`
+	Certificates    float64  `json:"certificates"`
+}
+
+// NewCandidateProfile builds the profile a candidate is ranked on from their
+// row and past experiences. Experiences without a start date are left out
+// and current ones last until now.
+func NewCandidateProfile(candidate db.Candidate, experiences []db.PastExperience, now time.Time) CandidateProfile {
+	profile := CandidateProfile{
+		Location:         candidate.Location,
+		SkillSet:         candidate.SkillSet,
+		Certificates:     candidate.Certificates,
+		TimeAvailability: candidateAvailability(candidate.TimeAvailability),
+	}
+	for _, experience := range experiences {
+		if !experience.StartDate.Valid {
+			continue
+		}
+		end := now
+		if !experience.Present && experience.EndDate.Valid {
+			end = experience.EndDate.Time
+		}
+		profile.PastExperience = append(profile.PastExperience, ExperienceLength{
+			Industry: experience.Industry,
+			JobTitle: experience.JobTitle,
+			Length:   math.Max(0, YearsBetween(experience.StartDate.Time, end)),
+		})
+	}
+	return profile
+}
+
+// candidateAvailability reads a candidate's stored time availability, a JSON
+// list of one JSON encoded availability per weekday. Availability that can't
+// be read is treated as unknown.
+func candidateAvailability(data []byte) []util.Availability {
+	var days []string
+	if err := json.Unmarshal(data, &days); err != nil {
+		return nil
+	}
+	availabilities := make([]util.Availability, 0, len(days))
+	for _, day := range days {
+		var availability util.Availability
+		if err := json.Unmarshal([]byte(day), &availability); err != nil {
+			return nil
+		}
+		availabilities = append(availabilities, availability)
+	}
+	return availabilities
+}
+
+// ScoreApplicantFit scores how well a candidate fits the job. location is
+// where the candidate lives, or nil when it isn't known.
+func ScoreApplicantFit(job *Job, profile CandidateProfile, location *GeoPoint) ApplicantFit {
+	jobWords := wordSet(job.Title + " " + job.Description)
+	fit := ApplicantFit{MatchedSkills: []string{}}
+	var total, weight float64
+
+	if location != nil && job.PreciseLocation != (GeoPoint{}) {
+		meters := haversineMeters(*location, job.PreciseLocation)
+		distance := roundScore(math.Max(0, 1-meters/fitMaxDistanceMeters))
+		km := math.Round(meters/100) / 10
+		fit.Distance, fit.DistanceKm = &distance, &km
+		total += fitWeights.distance * distance
+		weight += fitWeights.distance
+	}
+
+	for _, skill := range profile.SkillSet {
+		if mentions(jobWords, skill) {
+			fit.MatchedSkills = append(fit.MatchedSkills, skill)
+		}
+	}
+	fit.Skills = roundScore(math.Min(1, float64(len(fit.MatchedSkills))/fitSkillTarget))
+	total += fitWeights.skills * fit.Skills
+	weight += fitWeights.skills
+
+	if slots := jobSlots(job); len(slots) > 0 {
+		available := make(map[string]bool)
+		for _, slot := range AvailabilitySlots(profile.TimeAvailability) {
+			available[slot] = true
+		}
+		covered := 0
+		for _, slot := range slots {
+			if available[slot] {
+				covered++
+			}
+		}
+		availability := roundScore(float64(covered) / float64(len(slots)))
+		fit.Availability = &availability
+		total += fitWeights.availability * availability
+		weight += fitWeights.availability
+	}
+
+	titleWords := wordSet(job.Title)
+	for _, experience := range profile.PastExperience {
+		if relevantExperience(job, titleWords, experience) {
+			fit.ExperienceYears += experience.Length
+		}
+	}
+	fit.ExperienceYears = math.Round(fit.ExperienceYears*10) / 10
+	fit.Experience = roundScore(math.Min(1, fit.ExperienceYears/fitExperienceYears))
+	total += fitWeights.experience * fit.Experience
+	weight += fitWeights.experience
+
+	var certificates float64
+	for _, certificate := range profile.Certificates {
+		if mentions(jobWords, certificate) {
+			certificates += 2
+		} else {
+			certificates++
+		}
+	}
+	fit.Certificates = roundScore(math.Min(1, certificates/(2*fitCertificateTarget)))
+	total += fitWeights.certificates * fit.Certificates
+	weight += fitWeights.certificates
+
+	fit.Score = roundScore(total / weight)
+	return fit
+}
+
+// relevantExperience reports whether a past experience is in the job's
+// industry or had a title sharing a word with the job's.
+func relevantExperience(job *Job, titleWords map[string]bool, experience ExperienceLength) bool {
+	if job.Industry != "" && strings.EqualFold(strings.TrimSpace(job.Industry), strings.TrimSpace(experience.Industry)) {
+		return true
+	}
+	for _, word := range normalizeWords(experience.JobTitle) {
+		if titleWords[word] {
+			return true
+		}
+	}
+	return false
+}
+
+// jobSlots returns the slots covered by any of the job's shifts.
+func jobSlots(job *Job) []string {
+	var slots []string
+	seen := make(map[string]bool)
+	for _, shift := range job.Shifts {
+		for _, slot := range shift.Slots {
+			if !seen[slot] {
+				seen[slot] = true
+				slots = append(slots, slot)
+			}
+		}
+	}
+	return slots
+}
+
+func wordSet(text string) map[string]bool {
+	words := make(map[string]bool)
+	for _, word := range normalizeWords(text) {
+		words[word] = true
+	}
+	return words
+}
+
+// mentions reports whether every word of the phrase is in words, so the
+// skill "food safety" matches a job asking for "safety in food handling".
+func mentions(words map[string]bool, phrase string) bool {
+	phraseWords := normalizeWords(phrase)
+	if len(phraseWords) == 0 {
+		return false
+	}
+	for _, word := range phraseWords {
+		if !words[word] {
+			return false
+		}
+	}
+	return true
+}
+
+func roundScore(score float64) float64 {
+	return math.Round(score*100) / 100
+}
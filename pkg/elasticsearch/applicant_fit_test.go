@@ -0,0 +1,119 @@
+package elasticsearch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	"github.com/hankimmy/PtmrBackend/pkg/util"
+)
+
+func fitJob() *Job {
+	return &Job{
+		Title:           "Barista",
+		Industry:        "Restaurant",
+		Description:     "Make espresso drinks and keep the cash register balanced. Food handler card preferred.",
+		PreciseLocation: GeoPoint{Lat: 40.7501259, Lon: -73.9820676},
+		Shifts: []JobShift{
+			{Slots: []string{"saturday_morning", "saturday_afternoon"}},
+			{Slots: []string{"sunday_morning", "saturday_morning"}},
+		},
+	}
+}
+
+func TestScoreApplicantFit(t *testing.T) {
+	availability := make([]util.Availability, 7)
+	availability[time.Saturday] = util.Availability{Morning: true, Afternoon: true}
+	availability[time.Sunday] = util.Availability{Morning: true}
+	profile := CandidateProfile{
+		SkillSet:         []string{"Espresso", "cash register", "Forklift"},
+		Certificates:     []string{"Food Handler", "CPR"},
+		TimeAvailability: availability,
+		PastExperience: []ExperienceLength{
+			{Industry: "restaurant", JobTitle: "Server", Length: 1.5},
+			{Industry: "Cafe", JobTitle: "Head Barista", Length: 1},
+			{Industry: "Retail", JobTitle: "Cashier", Length: 3},
+		},
+	}
+	nearby := GeoPoint{Lat: 40.7502, Lon: -73.9821}
+
+	fit := ScoreApplicantFit(fitJob(), profile, &nearby)
+	require.Equal(t, []string{"Espresso", "cash register"}, fit.MatchedSkills)
+	require.Equal(t, 0.67, fit.Skills)
+	require.NotNil(t, fit.Distance)
+	require.Equal(t, 1.0, *fit.Distance)
+	require.NotNil(t, fit.Availability)
+	require.Equal(t, 1.0, *fit.Availability)
+	require.Equal(t, 2.5, fit.ExperienceYears)
+	require.Equal(t, 1.0, fit.Experience)
+	require.Equal(t, 0.75, fit.Certificates)
+	require.Greater(t, fit.Score, 0.85)
+
+	// A third of the shift slots and far away.
+	availability[time.Saturday] = util.Availability{Morning: true}
+	availability[time.Sunday] = util.Availability{}
+	farAway := GeoPoint{Lat: 40.7501259, Lon: -73.4}
+	worse := ScoreApplicantFit(fitJob(), profile, &farAway)
+	require.Equal(t, 0.33, *worse.Availability)
+	require.Zero(t, *worse.Distance)
+	require.Greater(t, *worse.DistanceKm, 40.0)
+	require.Less(t, worse.Score, fit.Score)
+
+	empty := ScoreApplicantFit(fitJob(), CandidateProfile{}, &nearby)
+	require.Empty(t, empty.MatchedSkills)
+	require.Zero(t, *empty.Availability)
+	require.Less(t, empty.Score, worse.Score)
+}
+
+func TestScoreApplicantFitUnknownComponents(t *testing.T) {
+	job := fitJob()
+	job.Shifts = nil
+	profile := CandidateProfile{SkillSet: []string{"espresso", "register", "cash"}}
+
+	fit := ScoreApplicantFit(job, profile, nil)
+	require.Nil(t, fit.Distance)
+	require.Nil(t, fit.DistanceKm)
+	require.Nil(t, fit.Availability)
+	// Only skills, experience and certificates count, skills fully.
+	require.Equal(t, 1.0, fit.Skills)
+	require.Equal(t, 0.45, fit.Score)
+}
+
+func TestNewCandidateProfile(t *testing.T) {
+	candidate := db.RandomCandidate(util.RandomString(6))
+	candidate.Certificates = []string{"CPR"}
+	start := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	now := start.AddDate(3, 0, 0)
+	experiences := []db.PastExperience{
+		{
+			CandidateID: candidate.ID,
+			Industry:    "Restaurant",
+			JobTitle:    "Barista",
+			StartDate:   pgtype.Date{Time: start, Valid: true},
+			EndDate:     pgtype.Date{Time: start.AddDate(1, 6, 0), Valid: true},
+		},
+		{
+			CandidateID: candidate.ID,
+			JobTitle:    "Cashier",
+			StartDate:   pgtype.Date{Time: start.AddDate(2, 0, 0), Valid: true},
+			Present:     true,
+		},
+		{CandidateID: candidate.ID, JobTitle: "Undated"},
+	}
+
+	profile := NewCandidateProfile(candidate, experiences, now)
+	require.Equal(t, candidate.Location, profile.Location)
+	require.Equal(t, candidate.SkillSet, profile.SkillSet)
+	require.Equal(t, candidate.Certificates, profile.Certificates)
+	require.Len(t, profile.TimeAvailability, 7)
+	require.Equal(t, []ExperienceLength{
+		{Industry: "Restaurant", JobTitle: "Barista", Length: 1.5},
+		{JobTitle: "Cashier", Length: 1},
+	}, profile.PastExperience)
+
+	candidate.TimeAvailability = []byte("not json")
+	require.Nil(t, NewCandidateProfile(candidate, nil, now).TimeAvailability)
+}
@@ -9,6 +9,20 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+// CandidateApplicationDocID returns the document ID of a candidate's
+// application to a job. Applications made before they were keyed by job keep
+// the ID stored with them, so callers with an application at hand should use
+// its ElasticsearchDocID instead.
+func CandidateApplicationDocID(candidateID int64, jobID string) string {
+	return fmt.Sprintf("%d_%s", candidateID, jobID)
+}
+
+// EmployerApplicationDocID returns the document ID of an employer's
+// invitation to a candidate.
+func EmployerApplicationDocID(employerID, candidateID int64) string {
+	return fmt.Sprintf("%d_%d", employerID, candidateID)
+}
+
 func (c *ESClientImpl) IndexCandidateApplication(ctx context.Context, id string, application map[string]interface{}) error {
 	return c.indexDocument(ctx, CandidateAppIdx, id, application)
 }
@@ -0,0 +1,241 @@
+package elasticsearch
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type QuestionType string
+
+const (
+	QuestionTypeText           QuestionType = "text"
+	QuestionTypeMultipleChoice QuestionType = "multiple-choice"
+	QuestionTypeYesNo          QuestionType = "yes-no"
+	QuestionTypeNumber         QuestionType = "number"
+	QuestionTypeDate           QuestionType = "date"
+	QuestionTypeFileUpload     QuestionType = "file-upload"
+)
+
+// AnswerDateFormat is the layout expected for answers to date questions.
+const AnswerDateFormat = "2006-01-02"
+
+// Question is a single entry of a job's application form. The JSON field
+// names follow the shape the clients already send.
+type Question struct {
+	ID               string       `json:"id"`
+	Type             QuestionType `json:"type"`
+	Question         string       `json:"question"`
+	Options          []string     `json:"options,omitempty"`
+	IsRequired       bool         `json:"isRequired"`
+	Order            int          `json:"order"`
+	Min              *float64     `json:"min,omitempty"`
+	Max              *float64     `json:"max,omitempty"`
+	AllowedFileTypes []string     `json:"allowedFileTypes,omitempty"`
+}
+
+type ApplicationForm []Question
+
+// UnmarshalJSON reads forms leniently. Jobs indexed before forms were typed,
+// including scraped ones, may hold any JSON under job_application; a form that
+// isn't a list of questions is read as empty rather than failing the whole
+// job. Requests that must reject malformed forms decode into []Question.
+func (f *ApplicationForm) UnmarshalJSON(data []byte) error {
+	var questions []Question
+	if err := json.Unmarshal(data, &questions); err != nil {
+		questions = nil
+	}
+	*f = questions
+	return nil
+}
+
+// FormValidationError collects every problem found in a form or in a set of
+// answers so clients can fix them all at once.
+type FormValidationError struct {
+	Problems []string
+}
+
+func (e *FormValidationError) Error() string {
+	return "invalid application form: " + strings.Join(e.Problems, "; ")
+}
+
+func (e *FormValidationError) add(format string, args ...interface{}) {
+	e.Problems = append(e.Problems, fmt.Sprintf(format, args...))
+}
+
+func (e *FormValidationError) orNil() error {
+	if len(e.Problems) == 0 {
+		return nil
+	}
+	return e
+}
+
+func (t QuestionType) Valid() bool {
+	switch t {
+	case QuestionTypeText, QuestionTypeMultipleChoice, QuestionTypeYesNo,
+		QuestionTypeNumber, QuestionTypeDate, QuestionTypeFileUpload:
+		return true
+	}
+	return false
+}
+
+// Validate checks the form definition an employer submits with a job.
+func (f ApplicationForm) Validate() error {
+	verr := &FormValidationError{}
+	seen := make(map[string]bool, len(f))
+	for i, q := range f {
+		if q.ID == "" {
+			verr.add("question %d has no id", i+1)
+		} else if seen[q.ID] {
+			verr.add("question id %q is used more than once", q.ID)
+		}
+		seen[q.ID] = true
+
+		if strings.TrimSpace(q.Question) == "" {
+			verr.add("question %q has no text", q.ID)
+		}
+		if !q.Type.Valid() {
+			verr.add("question %q has unsupported type %q", q.ID, q.Type)
+			continue
+		}
+
+		switch q.Type {
+		case QuestionTypeMultipleChoice:
+			if len(q.Options) < 2 {
+				verr.add("question %q needs at least two options", q.ID)
+			}
+			options := make(map[string]bool, len(q.Options))
+			for _, option := range q.Options {
+				if strings.TrimSpace(option) == "" {
+					verr.add("question %q has an empty option", q.ID)
+				} else if options[option] {
+					verr.add("question %q repeats option %q", q.ID, option)
+				}
+				options[option] = true
+			}
+		case QuestionTypeNumber:
+			if q.Min != nil && q.Max != nil && *q.Min > *q.Max {
+				verr.add("question %q has min greater than max", q.ID)
+			}
+		}
+		if q.Type != QuestionTypeMultipleChoice && len(q.Options) > 0 {
+			verr.add("question %q of type %q cannot have options", q.ID, q.Type)
+		}
+	}
+	return verr.orNil()
+}
+
+// ValidateAnswers checks a candidate's answers, keyed by question id, against
+// the form. Required questions must be answered, every answer must match its
+// question's type, and answers to unknown questions are rejected.
+func (f ApplicationForm) ValidateAnswers(answers map[string]interface{}) error {
+	verr := &FormValidationError{}
+	questions := make(map[string]Question, len(f))
+	for _, q := range f {
+		questions[q.ID] = q
+	}
+
+	for id := range answers {
+		if _, ok := questions[id]; !ok {
+			verr.add("answer given for unknown question %q", id)
+		}
+	}
+
+	for _, q := range f {
+		answer, ok := answers[q.ID]
+		if !ok || isEmptyAnswer(answer) {
+			if q.IsRequired {
+				verr.add("question %q is required", q.ID)
+			}
+			continue
+		}
+		if problem := q.checkAnswer(answer); problem != "" {
+			verr.add("question %q: %s", q.ID, problem)
+		}
+	}
+	return verr.orNil()
+}
+
+func (q Question) checkAnswer(answer interface{}) string {
+	switch q.Type {
+	case QuestionTypeText:
+		if _, ok := answer.(string); !ok {
+			return "answer must be text"
+		}
+	case QuestionTypeMultipleChoice:
+		choice, ok := answer.(string)
+		if !ok {
+			return "answer must be one of the options"
+		}
+		for _, option := range q.Options {
+			if option == choice {
+				return ""
+			}
+		}
+		return fmt.Sprintf("%q is not one of the options", choice)
+	case QuestionTypeYesNo:
+		switch v := answer.(type) {
+		case bool:
+		case string:
+			if v != "yes" && v != "no" {
+				return "answer must be yes or no"
+			}
+		default:
+			return "answer must be yes or no"
+		}
+	case QuestionTypeNumber:
+		n, ok := answer.(float64)
+		if !ok || math.IsNaN(n) || math.IsInf(n, 0) {
+			return "answer must be a number"
+		}
+		if q.Min != nil && n < *q.Min {
+			return fmt.Sprintf("answer must be at least %v", *q.Min)
+		}
+		if q.Max != nil && n > *q.Max {
+			return fmt.Sprintf("answer must be at most %v", *q.Max)
+		}
+	case QuestionTypeDate:
+		s, ok := answer.(string)
+		if !ok {
+			return "answer must be a date"
+		}
+		if _, err := time.Parse(AnswerDateFormat, s); err != nil {
+			return fmt.Sprintf("answer must be a date formatted as %s", AnswerDateFormat)
+		}
+	case QuestionTypeFileUpload:
+		s, ok := answer.(string)
+		if !ok {
+			return "answer must be a file URL"
+		}
+		u, err := url.Parse(s)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return "answer must be a file URL"
+		}
+		if len(q.AllowedFileTypes) > 0 && !hasAllowedExtension(u.Path, q.AllowedFileTypes) {
+			return fmt.Sprintf("file must be one of: %s", strings.Join(q.AllowedFileTypes, ", "))
+		}
+	}
+	return ""
+}
+
+func isEmptyAnswer(answer interface{}) bool {
+	if answer == nil {
+		return true
+	}
+	s, ok := answer.(string)
+	return ok && strings.TrimSpace(s) == ""
+}
+
+func hasAllowedExtension(path string, allowed []string) bool {
+	lower := strings.ToLower(path)
+	for _, ext := range allowed {
+		ext = strings.ToLower(strings.TrimPrefix(ext, "."))
+		if strings.HasSuffix(lower, "."+ext) {
+			return true
+		}
+	}
+	return false
+}
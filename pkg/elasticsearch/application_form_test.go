@@ -0,0 +1,165 @@
+package elasticsearch
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
+func testApplicationForm() ApplicationForm {
+	return ApplicationForm{
+		{ID: "name", Type: QuestionTypeText, Question: "Full name", IsRequired: true, Order: 1},
+		{ID: "shift", Type: QuestionTypeMultipleChoice, Question: "Preferred shift", Options: []string{"Morning", "Evening"}, IsRequired: true, Order: 2},
+		{ID: "car", Type: QuestionTypeYesNo, Question: "Do you own a car?", Order: 3},
+		{ID: "hours", Type: QuestionTypeNumber, Question: "Hours per week", Min: floatPtr(1), Max: floatPtr(40), Order: 4},
+		{ID: "start", Type: QuestionTypeDate, Question: "Earliest start date", Order: 5},
+		{ID: "resume", Type: QuestionTypeFileUpload, Question: "Resume", AllowedFileTypes: []string{"pdf", ".docx"}, Order: 6},
+	}
+}
+
+func TestValidateApplicationForm(t *testing.T) {
+	require.NoError(t, testApplicationForm().Validate())
+	require.NoError(t, ApplicationForm{}.Validate())
+
+	form := ApplicationForm{
+		{ID: "q1", Type: QuestionTypeText, Question: "Name"},
+		{ID: "q1", Type: QuestionTypeText, Question: "Name again"},
+		{ID: "", Type: QuestionTypeYesNo, Question: "Missing id"},
+		{ID: "q3", Type: "essay", Question: "Unsupported"},
+		{ID: "q4", Type: QuestionTypeMultipleChoice, Question: "One option", Options: []string{"Only"}},
+		{ID: "q5", Type: QuestionTypeMultipleChoice, Question: "Repeats", Options: []string{"A", "A"}},
+		{ID: "q6", Type: QuestionTypeNumber, Question: "Range", Min: floatPtr(10), Max: floatPtr(1)},
+		{ID: "q7", Type: QuestionTypeText, Question: "Text", Options: []string{"A", "B"}},
+		{ID: "q8", Type: QuestionTypeText, Question: " "},
+	}
+	err := form.Validate()
+	require.Error(t, err)
+
+	verr, ok := err.(*FormValidationError)
+	require.True(t, ok)
+	require.ElementsMatch(t, []string{
+		`question id "q1" is used more than once`,
+		`question 3 has no id`,
+		`question "q3" has unsupported type "essay"`,
+		`question "q4" needs at least two options`,
+		`question "q5" repeats option "A"`,
+		`question "q6" has min greater than max`,
+		`question "q7" of type "text" cannot have options`,
+		`question "q8" has no text`,
+	}, verr.Problems)
+}
+
+func TestValidateAnswers(t *testing.T) {
+	form := testApplicationForm()
+
+	testCases := []struct {
+		name    string
+		answers map[string]interface{}
+		problem string
+	}{
+		{
+			name: "OK",
+			answers: map[string]interface{}{
+				"name":   "Jane Doe",
+				"shift":  "Evening",
+				"car":    true,
+				"hours":  float64(20),
+				"start":  "2024-09-01",
+				"resume": "https://files.example.com/resumes/jane.PDF",
+			},
+		},
+		{
+			name:    "OnlyRequired",
+			answers: map[string]interface{}{"name": "Jane Doe", "shift": "Morning", "car": "", "hours": nil},
+		},
+		{
+			name:    "MissingRequired",
+			answers: map[string]interface{}{"name": "  "},
+			problem: `question "name" is required`,
+		},
+		{
+			name:    "UnknownQuestion",
+			answers: map[string]interface{}{"name": "Jane", "shift": "Morning", "pets": "yes"},
+			problem: `answer given for unknown question "pets"`,
+		},
+		{
+			name:    "InvalidOption",
+			answers: map[string]interface{}{"name": "Jane", "shift": "Night"},
+			problem: `question "shift": "Night" is not one of the options`,
+		},
+		{
+			name:    "InvalidYesNo",
+			answers: map[string]interface{}{"name": "Jane", "shift": "Morning", "car": "maybe"},
+			problem: `question "car": answer must be yes or no`,
+		},
+		{
+			name:    "NumberNotNumeric",
+			answers: map[string]interface{}{"name": "Jane", "shift": "Morning", "hours": "twenty"},
+			problem: `question "hours": answer must be a number`,
+		},
+		{
+			name:    "NumberOutOfRange",
+			answers: map[string]interface{}{"name": "Jane", "shift": "Morning", "hours": float64(60)},
+			problem: `question "hours": answer must be at most 40`,
+		},
+		{
+			name:    "InvalidDate",
+			answers: map[string]interface{}{"name": "Jane", "shift": "Morning", "start": "09/01/2024"},
+			problem: `question "start": answer must be a date formatted as 2006-01-02`,
+		},
+		{
+			name:    "FileNotURL",
+			answers: map[string]interface{}{"name": "Jane", "shift": "Morning", "resume": "resume.pdf"},
+			problem: `question "resume": answer must be a file URL`,
+		},
+		{
+			name:    "FileTypeNotAllowed",
+			answers: map[string]interface{}{"name": "Jane", "shift": "Morning", "resume": "https://files.example.com/jane.exe"},
+			problem: `question "resume": file must be one of: pdf, .docx`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := form.ValidateAnswers(tc.answers)
+			if tc.problem == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			verr, ok := err.(*FormValidationError)
+			require.True(t, ok)
+			require.Contains(t, verr.Problems, tc.problem)
+		})
+	}
+}
+
+func TestUnmarshalLegacyApplicationForm(t *testing.T) {
+	legacy := []string{
+		`{"id": "job1", "job_application": {"questions": [{"id": "q1"}]}}`,
+		`{"job_application": "https://example.com/apply"}`,
+		`{"job_application": ["What is your name?"]}`,
+		`{"job_application": [{"id": "q1", "order": "1", "isRequired": "yes"}]}`,
+		`{"job_application": null}`,
+	}
+	for _, doc := range legacy {
+		var job Job
+		require.NoError(t, json.Unmarshal([]byte(doc), &job), doc)
+		require.Empty(t, job.JobApplication, doc)
+	}
+
+	var job Job
+	require.NoError(t, json.Unmarshal([]byte(legacy[0]), &job))
+	require.Equal(t, "job1", job.ID)
+
+	data, err := json.Marshal(Job{JobApplication: testApplicationForm()})
+	require.NoError(t, err)
+	job = Job{}
+	require.NoError(t, json.Unmarshal(data, &job))
+	require.Equal(t, testApplicationForm(), job.JobApplication)
+}
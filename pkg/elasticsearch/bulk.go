@@ -0,0 +1,334 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"sync"
+
+	"github.com/olivere/elastic/v7"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	bulkWorkers    = 2
+	bulkActions    = 500
+	ErrBulkFailure = "failed to execute bulk request"
+	ErrMGetFailure = "failed to do ES MGET request"
+)
+
+// BulkItemError describes a single document that Elasticsearch rejected
+// as part of a bulk request.
+type BulkItemError struct {
+	Action string `json:"action"`
+	Index  string `json:"index"`
+	ID     string `json:"id"`
+	Status int    `json:"status"`
+	Reason string `json:"reason"`
+}
+
+// BulkResult reports how many documents of a bulk request were written and
+// which ones failed. A bulk call only returns an error when the processor
+// itself could not run; document level failures end up in Failed.
+type BulkResult struct {
+	Succeeded int             `json:"succeeded"`
+	Failed    []BulkItemError `json:"failed"`
+}
+
+func (r *BulkResult) HasErrors() bool {
+	return len(r.Failed) > 0
+}
+
+func (c *ESClientImpl) BulkIndexJobs(ctx context.Context, jobs []Job) (*BulkResult, error) {
+	requests := make([]elastic.BulkableRequest, 0, len(jobs))
+	for i := range jobs {
+		requests = append(requests, elastic.NewBulkIndexRequest().Index(JobIdx).Id(jobs[i].ID).Doc(jobs[i]))
+	}
+	return c.runBulk(ctx, "bulk-index-jobs", requests)
+}
+
+// BulkCreateJobs indexes jobs that must not exist yet. A job whose ID is
+// already taken is left untouched and reported in Failed with a 409 status.
+func (c *ESClientImpl) BulkCreateJobs(ctx context.Context, jobs []Job) (*BulkResult, error) {
+	requests := make([]elastic.BulkableRequest, 0, len(jobs))
+	for i := range jobs {
+		requests = append(requests, elastic.NewBulkCreateRequest().Index(JobIdx).Id(jobs[i].ID).Doc(jobs[i]))
+	}
+	return c.runBulk(ctx, "bulk-create-jobs", requests)
+}
+
+func (c *ESClientImpl) BulkUpdateJobs(ctx context.Context, jobs []Job) (*BulkResult, error) {
+	requests := make([]elastic.BulkableRequest, 0, len(jobs))
+	for i := range jobs {
+		requests = append(requests, elastic.NewBulkUpdateRequest().Index(JobIdx).Id(jobs[i].ID).Doc(jobs[i]))
+	}
+	return c.runBulk(ctx, "bulk-update-jobs", requests)
+}
+
+func (c *ESClientImpl) BulkDeleteJobs(ctx context.Context, ids []string) (*BulkResult, error) {
+	return c.bulkDeleteDocuments(ctx, JobIdx, ids)
+}
+
+func (c *ESClientImpl) BulkIndexCandidates(ctx context.Context, candidates []Candidate) (*BulkResult, error) {
+	requests := make([]elastic.BulkableRequest, 0, len(candidates))
+	for _, candidate := range candidates {
+		candidateMap, err := candidateDocument(candidate)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, elastic.NewBulkIndexRequest().Index(CandidateIdx).Id(candidate.UserUid).Doc(candidateMap))
+	}
+	return c.runBulk(ctx, "bulk-index-candidates", requests)
+}
+
+// BulkUpdateCandidates applies partial updates keyed by user UID. The
+// caller's updates are left as they are; docs whose time_availability needs
+// converting are copied first.
+func (c *ESClientImpl) BulkUpdateCandidates(ctx context.Context, updates map[string]map[string]interface{}) (*BulkResult, error) {
+	requests := make([]elastic.BulkableRequest, 0, len(updates))
+	for userUID, updateFields := range updates {
+		if ta, ok := updateFields["time_availability"].(string); ok {
+			availabilities, err := unmarshalTimeAvailabilityJSONV2(ta)
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert candidate time availability from binary to map: %v", err)
+			}
+			updateFields = maps.Clone(updateFields)
+			updateFields["time_availability"] = availabilities
+		}
+		requests = append(requests, elastic.NewBulkUpdateRequest().Index(CandidateIdx).Id(userUID).Doc(updateFields))
+	}
+	return c.runBulk(ctx, "bulk-update-candidates", requests)
+}
+
+func (c *ESClientImpl) BulkDeleteCandidates(ctx context.Context, userUIDs []string) (*BulkResult, error) {
+	return c.bulkDeleteDocuments(ctx, CandidateIdx, userUIDs)
+}
+
+func (c *ESClientImpl) BulkIndexCandidateApplications(ctx context.Context, applications map[string]map[string]interface{}) (*BulkResult, error) {
+	return c.bulkIndexDocuments(ctx, CandidateAppIdx, applications)
+}
+
+func (c *ESClientImpl) BulkUpdateCandidateApplications(ctx context.Context, applications map[string]map[string]interface{}) (*BulkResult, error) {
+	return c.bulkUpdateDocuments(ctx, CandidateAppIdx, applications)
+}
+
+func (c *ESClientImpl) BulkDeleteCandidateApplications(ctx context.Context, ids []string) (*BulkResult, error) {
+	return c.bulkDeleteDocuments(ctx, CandidateAppIdx, ids)
+}
+
+func (c *ESClientImpl) BulkIndexEmployerApplications(ctx context.Context, applications map[string]map[string]interface{}) (*BulkResult, error) {
+	return c.bulkIndexDocuments(ctx, EmployerAppIdx, applications)
+}
+
+func (c *ESClientImpl) BulkUpdateEmployerApplications(ctx context.Context, applications map[string]map[string]interface{}) (*BulkResult, error) {
+	return c.bulkUpdateDocuments(ctx, EmployerAppIdx, applications)
+}
+
+func (c *ESClientImpl) BulkDeleteEmployerApplications(ctx context.Context, ids []string) (*BulkResult, error) {
+	return c.bulkDeleteDocuments(ctx, EmployerAppIdx, ids)
+}
+
+// MGetJobs returns the jobs that exist for ids, in the order they were
+// requested. Missing ids are skipped.
+func (c *ESClientImpl) MGetJobs(ctx context.Context, ids []string) ([]Job, error) {
+	docs, err := c.mgetDocuments(ctx, JobIdx, ids)
+	if err != nil {
+		return nil, err
+	}
+	jobs := make([]Job, 0, len(docs))
+	for _, doc := range docs {
+		var job Job
+		if err := json.Unmarshal(doc.Source, &job); err != nil {
+			return nil, fmt.Errorf("%s: %w", ErrUnmarshalFailure, err)
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// MGetCandidates returns the candidates that exist for userUIDs, in the
+// order they were requested. Missing candidates are skipped.
+func (c *ESClientImpl) MGetCandidates(ctx context.Context, userUIDs []string) ([]Candidate, error) {
+	docs, err := c.mgetDocuments(ctx, CandidateIdx, userUIDs)
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([]Candidate, 0, len(docs))
+	for _, doc := range docs {
+		var candidate Candidate
+		if err := json.Unmarshal(doc.Source, &candidate); err != nil {
+			return nil, fmt.Errorf("%s: %w", ErrUnmarshalFailure, err)
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates, nil
+}
+
+func (c *ESClientImpl) MGetCandidateApplications(ctx context.Context, ids []string) (map[string]map[string]interface{}, error) {
+	return c.mgetDocumentMaps(ctx, CandidateAppIdx, ids)
+}
+
+func (c *ESClientImpl) MGetEmployerApplications(ctx context.Context, ids []string) (map[string]map[string]interface{}, error) {
+	return c.mgetDocumentMaps(ctx, EmployerAppIdx, ids)
+}
+
+func (c *ESClientImpl) bulkIndexDocuments(ctx context.Context, index string, documents map[string]map[string]interface{}) (*BulkResult, error) {
+	requests := make([]elastic.BulkableRequest, 0, len(documents))
+	for id, document := range documents {
+		requests = append(requests, elastic.NewBulkIndexRequest().Index(index).Id(id).Doc(document))
+	}
+	return c.runBulk(ctx, "bulk-index-"+index, requests)
+}
+
+func (c *ESClientImpl) bulkUpdateDocuments(ctx context.Context, index string, documents map[string]map[string]interface{}) (*BulkResult, error) {
+	requests := make([]elastic.BulkableRequest, 0, len(documents))
+	for id, document := range documents {
+		requests = append(requests, elastic.NewBulkUpdateRequest().Index(index).Id(id).Doc(document))
+	}
+	return c.runBulk(ctx, "bulk-update-"+index, requests)
+}
+
+func (c *ESClientImpl) bulkDeleteDocuments(ctx context.Context, index string, ids []string) (*BulkResult, error) {
+	requests := make([]elastic.BulkableRequest, 0, len(ids))
+	for _, id := range ids {
+		requests = append(requests, elastic.NewBulkDeleteRequest().Index(index).Id(id))
+	}
+	return c.runBulk(ctx, "bulk-delete-"+index, requests)
+}
+
+// runBulk pushes requests through a BulkProcessor and collects the outcome
+// of every item. Items the processor retried are only reported once, with
+// the status of their final attempt.
+func (c *ESClientImpl) runBulk(ctx context.Context, name string, requests []elastic.BulkableRequest) (*BulkResult, error) {
+	result := &BulkResult{Failed: []BulkItemError{}}
+	if len(requests) == 0 {
+		return result, nil
+	}
+
+	var mu sync.Mutex
+	after := func(_ int64, reqs []elastic.BulkableRequest, res *elastic.BulkResponse, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if res == nil {
+			for _, req := range reqs {
+				result.Failed = append(result.Failed, bulkRequestError(req, err))
+			}
+			return
+		}
+
+		failed := 0
+		for _, item := range res.Items {
+			for action, itemRes := range item {
+				if itemRes.Status >= 200 && itemRes.Status <= 299 {
+					continue
+				}
+				failed++
+				result.Failed = append(result.Failed, bulkResponseError(action, itemRes))
+			}
+		}
+		result.Succeeded += len(reqs) - failed
+	}
+
+	processor, err := c.Client.BulkProcessor().
+		Name(name).
+		Workers(bulkWorkers).
+		BulkActions(bulkActions).
+		After(after).
+		Do(ctx)
+	if err != nil {
+		log.Printf("Starting bulk processor failed: %v", err)
+		return nil, fmt.Errorf("%s: %w", ErrBulkFailure, err)
+	}
+
+	for _, req := range requests {
+		processor.Add(req)
+	}
+	if err := processor.Close(); err != nil {
+		log.Printf("Closing bulk processor failed: %v", err)
+		return nil, fmt.Errorf("%s: %w", ErrBulkFailure, err)
+	}
+	return result, nil
+}
+
+func bulkResponseError(action string, item *elastic.BulkResponseItem) BulkItemError {
+	itemErr := BulkItemError{
+		Action: action,
+		Index:  item.Index,
+		ID:     item.Id,
+		Status: item.Status,
+	}
+	if item.Error != nil {
+		itemErr.Reason = fmt.Sprintf("%s: %s", item.Error.Type, item.Error.Reason)
+	}
+	return itemErr
+}
+
+// bulkRequestError is used when a whole commit failed and there is no
+// response to read the item metadata from, so it is taken from the action
+// line of the request instead.
+func bulkRequestError(req elastic.BulkableRequest, err error) BulkItemError {
+	itemErr := BulkItemError{}
+	if err != nil {
+		itemErr.Reason = err.Error()
+	}
+	lines, srcErr := req.Source()
+	if srcErr != nil || len(lines) == 0 {
+		return itemErr
+	}
+	var meta map[string]struct {
+		Index string `json:"_index"`
+		ID    string `json:"_id"`
+	}
+	if json.Unmarshal([]byte(lines[0]), &meta) != nil {
+		return itemErr
+	}
+	for action, m := range meta {
+		itemErr.Action = action
+		itemErr.Index = m.Index
+		itemErr.ID = m.ID
+	}
+	return itemErr
+}
+
+func (c *ESClientImpl) mgetDocuments(ctx context.Context, index string, ids []string) ([]*elastic.GetResult, error) {
+	if len(ids) == 0 {
+		return []*elastic.GetResult{}, nil
+	}
+	items := make([]*elastic.MultiGetItem, 0, len(ids))
+	for _, id := range ids {
+		items = append(items, elastic.NewMultiGetItem().Index(index).Id(id))
+	}
+	res, err := c.Client.Mget().Add(items...).Do(ctx)
+	if err != nil {
+		log.Printf("Multi-getting documents failed: %v", err)
+		return nil, fmt.Errorf("%s: %w", ErrMGetFailure, err)
+	}
+
+	docs := make([]*elastic.GetResult, 0, len(res.Docs))
+	for _, doc := range res.Docs {
+		if doc == nil || !doc.Found {
+			continue
+		}
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
+
+func (c *ESClientImpl) mgetDocumentMaps(ctx context.Context, index string, ids []string) (map[string]map[string]interface{}, error) {
+	docs, err := c.mgetDocuments(ctx, index, ids)
+	if err != nil {
+		return nil, err
+	}
+	documents := make(map[string]map[string]interface{}, len(docs))
+	for _, doc := range docs {
+		var document map[string]interface{}
+		if err := json.Unmarshal(doc.Source, &document); err != nil {
+			log.Printf("Unmarshalling document failed: %v", err)
+			return nil, fmt.Errorf("%s: %w", ErrUnmarshalFailure, err)
+		}
+		documents[doc.Id] = document
+	}
+	return documents, nil
+}
@@ -0,0 +1,157 @@
+package elasticsearch
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hankimmy/PtmrBackend/pkg/util"
+)
+
+func TestBulkIndexJobs(t *testing.T) {
+	jobs := []Job{RandomJob(1), RandomJob(1), RandomJob(1)}
+
+	result, err := esClient.BulkIndexJobs(context.Background(), jobs)
+	require.NoError(t, err)
+	require.Equal(t, len(jobs), result.Succeeded)
+	require.False(t, result.HasErrors())
+
+	ids := []string{jobs[0].ID, jobs[1].ID, jobs[2].ID}
+	gotJobs, err := esClient.MGetJobs(context.Background(), ids)
+	require.NoError(t, err)
+	require.Len(t, gotJobs, len(jobs))
+	for i, gotJob := range gotJobs {
+		require.Equal(t, jobs[i].ID, gotJob.ID)
+		require.Equal(t, jobs[i].Title, gotJob.Title)
+	}
+}
+
+func TestBulkCreateJobsKeepsExistingJobs(t *testing.T) {
+	existing := RandomJob(1)
+	require.NoError(t, esClient.IndexJob(&existing))
+
+	replacement := existing
+	replacement.Description = util.RandomString(20)
+	jobs := []Job{replacement, RandomJob(1)}
+	result, err := esClient.BulkCreateJobs(context.Background(), jobs)
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Succeeded)
+	require.Len(t, result.Failed, 1)
+	require.Equal(t, existing.ID, result.Failed[0].ID)
+	require.Equal(t, http.StatusConflict, result.Failed[0].Status)
+
+	got, err := esClient.GetJob(existing.ID)
+	require.NoError(t, err)
+	require.Equal(t, existing.Description, got.Description)
+}
+
+func TestBulkUpdateJobs(t *testing.T) {
+	jobs := []Job{RandomJob(1), RandomJob(1)}
+	_, err := esClient.BulkIndexJobs(context.Background(), jobs)
+	require.NoError(t, err)
+
+	for i := range jobs {
+		jobs[i].Description = util.RandomString(20)
+	}
+	result, err := esClient.BulkUpdateJobs(context.Background(), jobs)
+	require.NoError(t, err)
+	require.Equal(t, len(jobs), result.Succeeded)
+
+	gotJobs, err := esClient.MGetJobs(context.Background(), []string{jobs[0].ID, jobs[1].ID})
+	require.NoError(t, err)
+	require.Len(t, gotJobs, len(jobs))
+	for i, gotJob := range gotJobs {
+		require.Equal(t, jobs[i].Description, gotJob.Description)
+	}
+}
+
+func TestBulkUpdateJobsReportsMissingItems(t *testing.T) {
+	job := RandomJob(1)
+	_, err := esClient.BulkIndexJobs(context.Background(), []Job{job})
+	require.NoError(t, err)
+
+	missing := RandomJob(1)
+	result, err := esClient.BulkUpdateJobs(context.Background(), []Job{job, missing})
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Succeeded)
+	require.Len(t, result.Failed, 1)
+	require.Equal(t, missing.ID, result.Failed[0].ID)
+	require.Equal(t, JobIdx, result.Failed[0].Index)
+	require.Equal(t, "update", result.Failed[0].Action)
+	require.Equal(t, 404, result.Failed[0].Status)
+}
+
+func TestBulkDeleteJobs(t *testing.T) {
+	jobs := []Job{RandomJob(1), RandomJob(1)}
+	_, err := esClient.BulkIndexJobs(context.Background(), jobs)
+	require.NoError(t, err)
+
+	ids := []string{jobs[0].ID, jobs[1].ID}
+	result, err := esClient.BulkDeleteJobs(context.Background(), ids)
+	require.NoError(t, err)
+	require.Equal(t, len(ids), result.Succeeded)
+
+	gotJobs, err := esClient.MGetJobs(context.Background(), ids)
+	require.NoError(t, err)
+	require.Empty(t, gotJobs)
+}
+
+func TestBulkUpdateCandidates(t *testing.T) {
+	candidate := randomCandidateV2(util.RandomString(5))
+	err := esClient.IndexCandidateV2(context.Background(), candidate)
+	require.NoError(t, err)
+
+	candidateMap, err := structToMapV2(candidate)
+	require.NoError(t, err)
+	availability := candidateMap["time_availability"]
+	candidateMap["full_name"] = "Jane A. Doe"
+	candidate.FullName = "Jane A. Doe"
+	result, err := esClient.BulkUpdateCandidates(context.Background(), map[string]map[string]interface{}{
+		candidate.UserUid: candidateMap,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Succeeded)
+	require.Equal(t, availability, candidateMap["time_availability"])
+
+	body := getCandidateDocV2(t, candidate.UserUid)
+	requireBodyMatchCandidateV2(t, &body, candidate)
+}
+
+func TestBulkCandidateApplications(t *testing.T) {
+	applications := map[string]map[string]interface{}{
+		util.RandomString(6): {"field": util.RandomString(10)},
+		util.RandomString(6): {"field": util.RandomString(10)},
+	}
+	ids := make([]string, 0, len(applications))
+	for id := range applications {
+		ids = append(ids, id)
+	}
+
+	result, err := esClient.BulkIndexCandidateApplications(context.Background(), applications)
+	require.NoError(t, err)
+	require.Equal(t, len(applications), result.Succeeded)
+
+	gotApplications, err := esClient.MGetCandidateApplications(context.Background(), ids)
+	require.NoError(t, err)
+	require.Equal(t, applications, gotApplications)
+
+	result, err = esClient.BulkDeleteCandidateApplications(context.Background(), ids)
+	require.NoError(t, err)
+	require.Equal(t, len(ids), result.Succeeded)
+
+	gotApplications, err = esClient.MGetCandidateApplications(context.Background(), ids)
+	require.NoError(t, err)
+	require.Empty(t, gotApplications)
+}
+
+func TestMGetEmptyIDs(t *testing.T) {
+	jobs, err := esClient.MGetJobs(context.Background(), nil)
+	require.NoError(t, err)
+	require.Empty(t, jobs)
+
+	candidates, err := esClient.MGetCandidates(context.Background(), []string{})
+	require.NoError(t, err)
+	require.Empty(t, candidates)
+}
@@ -37,18 +37,10 @@ func (c *ESClientImpl) IndexCandidate(ctx context.Context, candidate db.Candidat
 }
 
 func (c *ESClientImpl) IndexCandidateV2(ctx context.Context, candidate Candidate) error {
-	candidateMap, err := structToMapV2(candidate)
+	candidateMap, err := candidateDocument(candidate)
 	if err != nil {
-		return fmt.Errorf("failed to convert candidate struct to map: %v", err)
+		return err
 	}
-	if ta, ok := candidateMap["time_availability"].(string); ok {
-		availabilities, err := unmarshalTimeAvailabilityJSONV2(ta)
-		if err != nil {
-			return fmt.Errorf("failed to convert candidate time availability from binary to map: %v", err)
-		}
-		candidateMap["time_availability"] = availabilities
-	}
-	candidateMap["rating"] = util.RandomRating()
 
 	_, err = c.Client.Index().
 		Index(CandidateIdx).
@@ -61,6 +53,22 @@ func (c *ESClientImpl) IndexCandidateV2(ctx context.Context, candidate Candidate
 	return nil
 }
 
+func candidateDocument(candidate Candidate) (map[string]interface{}, error) {
+	candidateMap, err := structToMapV2(candidate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert candidate struct to map: %v", err)
+	}
+	if ta, ok := candidateMap["time_availability"].(string); ok {
+		availabilities, err := unmarshalTimeAvailabilityJSONV2(ta)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert candidate time availability from binary to map: %v", err)
+		}
+		candidateMap["time_availability"] = availabilities
+	}
+	candidateMap["rating"] = util.RandomRating()
+	return candidateMap, nil
+}
+
 func (c *ESClientImpl) UpdateCandidate(ctx context.Context, candidate db.Candidate) error {
 	candidateMap, err := structToMap(candidate)
 	if err != nil {
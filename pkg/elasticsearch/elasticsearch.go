@@ -4,10 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/olivere/elastic/v7"
 
 	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	"github.com/hankimmy/PtmrBackend/pkg/google"
 )
 
 const (
@@ -25,9 +27,18 @@ const (
 
 type ESClient interface {
 	IndexJob(job *Job) error
+	CreateJob(job *Job) error
 	GetJob(id string) (*Job, error)
 	UpdateJob(id string, job *Job) error
 	DeleteJob(id string) error
+	UpdateJobStatus(id string, status JobStatus) error
+	UpdateJobPlace(ctx context.Context, id string, details *google.PlaceDetailsResponse, now time.Time) error
+	RefreshJobPlace(ctx context.Context, id string, details *google.PlaceDetailsResponse, now time.Time) error
+	UpdateJobEnrichmentStatus(ctx context.Context, id string, status EnrichmentStatus, now time.Time) error
+	UpdateJobShifts(ctx context.Context, id string, shifts []JobShift) error
+	FindDuplicateJob(ctx context.Context, job *Job) (*Job, error)
+	MarkJobDuplicate(ctx context.Context, id, originalID string) error
+	UpdateJobModerationStatus(ctx context.Context, id string, status ModerationStatus) error
 	IndexCandidate(ctx context.Context, candidate db.Candidate) error
 	IndexCandidateV2(ctx context.Context, candidate Candidate) error
 	UpdateCandidate(ctx context.Context, candidate db.Candidate) error
@@ -47,7 +58,30 @@ type ESClient interface {
 	GetEmployerApplication(ctx context.Context, id string) (map[string]interface{}, error)
 	UpdateEmployerApplication(ctx context.Context, id string, application map[string]interface{}) error
 	DeleteEmployerApplication(ctx context.Context, id string) error
+	BulkIndexJobs(ctx context.Context, jobs []Job) (*BulkResult, error)
+	BulkCreateJobs(ctx context.Context, jobs []Job) (*BulkResult, error)
+	BulkUpdateJobs(ctx context.Context, jobs []Job) (*BulkResult, error)
+	BulkDeleteJobs(ctx context.Context, ids []string) (*BulkResult, error)
+	BulkIndexCandidates(ctx context.Context, candidates []Candidate) (*BulkResult, error)
+	BulkUpdateCandidates(ctx context.Context, updates map[string]map[string]interface{}) (*BulkResult, error)
+	BulkDeleteCandidates(ctx context.Context, userUIDs []string) (*BulkResult, error)
+	BulkIndexCandidateApplications(ctx context.Context, applications map[string]map[string]interface{}) (*BulkResult, error)
+	BulkUpdateCandidateApplications(ctx context.Context, applications map[string]map[string]interface{}) (*BulkResult, error)
+	BulkDeleteCandidateApplications(ctx context.Context, ids []string) (*BulkResult, error)
+	BulkIndexEmployerApplications(ctx context.Context, applications map[string]map[string]interface{}) (*BulkResult, error)
+	BulkUpdateEmployerApplications(ctx context.Context, applications map[string]map[string]interface{}) (*BulkResult, error)
+	BulkDeleteEmployerApplications(ctx context.Context, ids []string) (*BulkResult, error)
+	MGetJobs(ctx context.Context, ids []string) ([]Job, error)
+	MGetCandidates(ctx context.Context, userUIDs []string) ([]Candidate, error)
+	MGetCandidateApplications(ctx context.Context, ids []string) (map[string]map[string]interface{}, error)
+	MGetEmployerApplications(ctx context.Context, ids []string) (map[string]map[string]interface{}, error)
 	SearchJobs(industry, employmentType, title, distance string, candidateLocation GeoPoint) ([]Job, error)
+	SearchJobsWithShifts(ctx context.Context, industry, employmentType, title, distance string,
+		candidateLocation GeoPoint, slots []string) ([]Job, error)
+	SearchEmployerJobs(ctx context.Context, arg EmployerJobsParams) (*EmployerJobsResult, error)
+	SearchJobsDueToPublish(ctx context.Context, now time.Time, after []interface{}) ([]Job, []interface{}, error)
+	SearchJobsDueToClose(ctx context.Context, now time.Time, after []interface{}) ([]Job, []interface{}, error)
+	SearchJobsForPlaceRefresh(ctx context.Context, before time.Time) ([]Job, error)
 }
 
 type ESClientImpl struct {
@@ -0,0 +1,98 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/olivere/elastic/v7"
+)
+
+type JobSort string
+
+const (
+	JobSortNewest JobSort = "newest"
+	JobSortOldest JobSort = "oldest"
+	JobSortTitle  JobSort = "title"
+	JobSortWage   JobSort = "wage"
+)
+
+// sorter returns the sort for s, defaulting to the newest jobs first.
+func (s JobSort) sorter() elastic.Sorter {
+	switch s {
+	case JobSortOldest:
+		return elastic.NewFieldSort("date_posted").Asc()
+	case JobSortTitle:
+		return elastic.NewFieldSort("title.keyword").Asc()
+	case JobSortWage:
+		return elastic.NewFieldSort("wage").Desc()
+	default:
+		return elastic.NewFieldSort("date_posted").Desc()
+	}
+}
+
+type EmployerJobsParams struct {
+	EmployerID int64
+	// Statuses restricts the results to jobs in one of the statuses. All
+	// statuses are returned when it is empty.
+	Statuses []JobStatus
+	Sort     JobSort
+	From     int
+	Size     int
+}
+
+type EmployerJobsResult struct {
+	Jobs  []Job `json:"jobs"`
+	Total int64 `json:"total"`
+}
+
+// SearchEmployerJobs returns one page of the employer's jobs along with the
+// total number of jobs matching the filters.
+func (c *ESClientImpl) SearchEmployerJobs(ctx context.Context, arg EmployerJobsParams) (*EmployerJobsResult, error) {
+	res, err := c.Client.Search().
+		Index(JobIdx).
+		Query(employerJobsQuery(arg.EmployerID, arg.Statuses)).
+		SortBy(arg.Sort.sorter(), elastic.NewFieldSort("_doc")).
+		From(arg.From).
+		Size(arg.Size).
+		TrackTotalHits(true).
+		Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search employer jobs: %v", err)
+	}
+
+	result := &EmployerJobsResult{
+		Jobs:  make([]Job, 0, len(res.Hits.Hits)),
+		Total: res.TotalHits(),
+	}
+	for _, hit := range res.Hits.Hits {
+		var job Job
+		if err := json.Unmarshal(hit.Source, &job); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal job: %v", err)
+		}
+		result.Jobs = append(result.Jobs, job)
+	}
+	return result, nil
+}
+
+func employerJobsQuery(employerID int64, statuses []JobStatus) elastic.Query {
+	query := elastic.NewBoolQuery().Filter(elastic.NewTermQuery("employer_id", employerID))
+	if len(statuses) == 0 {
+		return query
+	}
+
+	terms := make([]interface{}, 0, len(statuses))
+	legacy := false
+	for _, status := range statuses {
+		terms = append(terms, status)
+		legacy = legacy || status == JobStatusPublished
+	}
+	byStatus := elastic.NewBoolQuery().
+		Should(elastic.NewTermsQuery("status", terms...)).
+		MinimumNumberShouldMatch(1)
+	if legacy {
+		// Jobs indexed before statuses existed count as published.
+		byStatus.Should(elastic.NewBoolQuery().MustNot(elastic.NewExistsQuery("status")))
+	}
+	return query.Filter(byStatus)
+}
@@ -0,0 +1,65 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hankimmy/PtmrBackend/pkg/util"
+)
+
+func TestEmployerJobsQuery(t *testing.T) {
+	src, err := employerJobsQuery(7, nil).Source()
+	require.NoError(t, err)
+	data, err := json.Marshal(src)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"employer_id":7`)
+	require.NotContains(t, string(data), `"status"`)
+
+	src, err = employerJobsQuery(7, []JobStatus{JobStatusPaused}).Source()
+	require.NoError(t, err)
+	data, err = json.Marshal(src)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"status":["paused"]`)
+	require.NotContains(t, string(data), `"exists"`)
+
+	src, err = employerJobsQuery(7, []JobStatus{JobStatusPublished}).Source()
+	require.NoError(t, err)
+	data, err = json.Marshal(src)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"exists":{"field":"status"}`)
+}
+
+func TestSearchEmployerJobs(t *testing.T) {
+	employerID := util.RandomInt(100000, 999999)
+	jobs := []Job{RandomJob(employerID), RandomJob(employerID), RandomJob(employerID)}
+	jobs[0].Status = JobStatusPaused
+	jobs[1].Status = ""
+	_, err := esClient.BulkIndexJobs(context.Background(), append(jobs, RandomJob(employerID+1)))
+	require.NoError(t, err)
+	_, err = esClient.Client.Refresh(JobIdx).Do(context.Background())
+	require.NoError(t, err)
+
+	result, err := esClient.SearchEmployerJobs(context.Background(), EmployerJobsParams{
+		EmployerID: employerID,
+		Sort:       JobSortTitle,
+		Size:       2,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(len(jobs)), result.Total)
+	require.Len(t, result.Jobs, 2)
+	require.LessOrEqual(t, result.Jobs[0].Title, result.Jobs[1].Title)
+
+	result, err = esClient.SearchEmployerJobs(context.Background(), EmployerJobsParams{
+		EmployerID: employerID,
+		Statuses:   []JobStatus{JobStatusPublished},
+		Size:       10,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), result.Total)
+	for _, job := range result.Jobs {
+		require.Equal(t, JobStatusPublished, job.CurrentStatus())
+	}
+}
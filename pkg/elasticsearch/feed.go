@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/olivere/elastic/v7"
 )
@@ -12,7 +13,23 @@ const ResultSize = 20
 
 func (c *ESClientImpl) SearchJobs(industry, employmentType, title, distance string,
 	candidateLocation GeoPoint) ([]Job, error) {
-	query := elastic.NewBoolQuery().
+	query := feedQuery(industry, employmentType, title, distance, candidateLocation, time.Now())
+	return c.searchFeed(context.Background(), query)
+}
+
+// SearchJobsWithShifts is SearchJobs restricted to jobs with an upcoming shift
+// that still has open spots and overlaps one of the candidate's slots.
+func (c *ESClientImpl) SearchJobsWithShifts(ctx context.Context, industry, employmentType, title, distance string,
+	candidateLocation GeoPoint, slots []string) ([]Job, error) {
+	now := time.Now()
+	query := feedQuery(industry, employmentType, title, distance, candidateLocation, now).
+		Filter(upcomingShiftsQuery(slots, now))
+	return c.searchFeed(ctx, query)
+}
+
+func feedQuery(industry, employmentType, title, distance string, candidateLocation GeoPoint,
+	now time.Time) *elastic.BoolQuery {
+	return elastic.NewBoolQuery().
 		Must(
 			elastic.NewTermQuery("industry", industry),
 			elastic.NewTermQuery("employment_type", employmentType),
@@ -23,13 +40,21 @@ func (c *ESClientImpl) SearchJobs(industry, employmentType, title, distance stri
 				Lat(candidateLocation.Lat).
 				Lon(candidateLocation.Lon).
 				Distance(distance),
+			publishedJobsQuery(),
+			openScheduleQuery(now),
+		).
+		MustNot(
+			elastic.NewExistsQuery("duplicate_of"),
+			hiddenByModerationQuery(),
 		)
+}
 
+func (c *ESClientImpl) searchFeed(ctx context.Context, query elastic.Query) ([]Job, error) {
 	res, err := c.Client.Search().
 		Index(JobIdx).
 		Query(query).
 		Size(ResultSize).
-		Do(context.Background())
+		Do(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search jobs: %v", err)
 	}
@@ -49,3 +74,14 @@ func (c *ESClientImpl) SearchJobs(industry, employmentType, title, distance stri
 
 	return jobs, nil
 }
+
+// publishedJobsQuery matches published jobs as well as jobs indexed before
+// job statuses existed, which have no status field.
+func publishedJobsQuery() elastic.Query {
+	return elastic.NewBoolQuery().
+		Should(
+			elastic.NewTermQuery("status", JobStatusPublished),
+			elastic.NewBoolQuery().MustNot(elastic.NewExistsQuery("status")),
+		).
+		MinimumNumberShouldMatch(1)
+}
@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/hankimmy/PtmrBackend/pkg/moderation"
 )
 
 func TestSearchJobs(t *testing.T) {
@@ -209,3 +211,60 @@ func TestSearchJobs_FuzzyMatching(t *testing.T) {
 
 	clearIndex(JobIdx)
 }
+
+func TestSearchJobs_OnlyPublished(t *testing.T) {
+	published := RandomJob(1)
+	published.Industry = "Hospitality"
+	published.EmploymentType = "Part-time"
+	published.Title = "Barista"
+	err := esClient.IndexJob(&published)
+	require.NoError(t, err)
+
+	for _, status := range []JobStatus{JobStatusDraft, JobStatusPaused, JobStatusFilled, JobStatusClosed} {
+		job := RandomJob(2)
+		job.Industry = published.Industry
+		job.EmploymentType = published.EmploymentType
+		job.Title = published.Title
+		job.Status = status
+		err = esClient.IndexJob(&job)
+		require.NoError(t, err)
+	}
+
+	time.Sleep(2 * time.Second)
+
+	jobs, err := esClient.SearchJobs(published.Industry, published.EmploymentType, published.Title, "10mi", published.PreciseLocation)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	require.Equal(t, published.ID, jobs[0].ID)
+
+	clearIndex(JobIdx)
+}
+
+func TestSearchJobs_HidesModerated(t *testing.T) {
+	approved := RandomJob(1)
+	approved.Industry = "Hospitality"
+	approved.EmploymentType = "Part-time"
+	approved.Title = "Host"
+	approved.ModerationStatus = moderation.DecisionApproved
+	err := esClient.IndexJob(&approved)
+	require.NoError(t, err)
+
+	for _, status := range []ModerationStatus{moderation.DecisionNeedsReview, moderation.DecisionRejected} {
+		job := RandomJob(2)
+		job.Industry = approved.Industry
+		job.EmploymentType = approved.EmploymentType
+		job.Title = approved.Title
+		job.ModerationStatus = status
+		err = esClient.IndexJob(&job)
+		require.NoError(t, err)
+	}
+
+	time.Sleep(2 * time.Second)
+
+	jobs, err := esClient.SearchJobs(approved.Industry, approved.EmploymentType, approved.Title, "10mi", approved.PreciseLocation)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	require.Equal(t, approved.ID, jobs[0].ID)
+
+	clearIndex(JobIdx)
+}
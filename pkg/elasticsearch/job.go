@@ -3,10 +3,14 @@ package elasticsearch
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/olivere/elastic/v7"
 )
 
+// ErrJobExists is returned by CreateJob when a job with the same ID exists.
+var ErrJobExists = errors.New("job already exists")
+
 func (c *ESClientImpl) IndexJob(job *Job) error {
 	_, err := c.Client.Index().
 		Index(JobIdx).
@@ -19,6 +23,24 @@ func (c *ESClientImpl) IndexJob(job *Job) error {
 	return nil
 }
 
+// CreateJob indexes a new job and never overwrites an existing one. It
+// returns ErrJobExists when the job's ID is taken.
+func (c *ESClientImpl) CreateJob(job *Job) error {
+	_, err := c.Client.Index().
+		Index(JobIdx).
+		Id(job.ID).
+		OpType("create").
+		BodyJson(job).
+		Do(context.Background())
+	if elastic.IsConflict(err) {
+		return ErrJobExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create job: %v", err)
+	}
+	return nil
+}
+
 func (c *ESClientImpl) GetJob(id string) (*Job, error) {
 	res, err := c.Client.Get().
 		Index(JobIdx).
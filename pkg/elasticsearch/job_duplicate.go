@@ -0,0 +1,196 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/olivere/elastic/v7"
+)
+
+const (
+	// duplicateRadiusMeters is how far apart two jobs at the same business can
+	// be geocoded, since scraped addresses rarely resolve to the same point.
+	duplicateRadiusMeters = 150
+	// duplicateCandidates caps how many jobs at the same location are compared
+	// with a new job.
+	duplicateCandidates = 10
+	// minTitleSimilarity and minDescriptionSimilarity are the Jaccard
+	// similarities above which two jobs at the same location are duplicates.
+	minTitleSimilarity       = 0.6
+	minDescriptionSimilarity = 0.5
+	descriptionShingleSize   = 3
+)
+
+// normalizeWords lowercases the text and splits it into words, dropping
+// punctuation so "Server - Part Time" and "server (part-time)" match.
+func normalizeWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// shingles returns the set of k consecutive words in the text. Texts shorter
+// than k words are a single shingle.
+func shingles(text string, k int) map[string]struct{} {
+	words := normalizeWords(text)
+	set := make(map[string]struct{})
+	if len(words) == 0 {
+		return set
+	}
+	if len(words) < k {
+		set[strings.Join(words, " ")] = struct{}{}
+		return set
+	}
+	for i := 0; i+k <= len(words); i++ {
+		set[strings.Join(words[i:i+k], " ")] = struct{}{}
+	}
+	return set
+}
+
+func jaccard(a, b map[string]struct{}) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	shared := 0
+	for s := range a {
+		if _, ok := b[s]; ok {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+// TextSimilarity compares the titles and descriptions of two jobs. Titles are
+// compared word by word, ignoring order; descriptions by runs of words.
+func TextSimilarity(a, b *Job) (title, description float64) {
+	title = jaccard(shingles(a.Title, 1), shingles(b.Title, 1))
+	description = jaccard(shingles(a.Description, descriptionShingleSize), shingles(b.Description, descriptionShingleSize))
+	return title, description
+}
+
+// SameLocation reports whether the jobs are at the same place, either by
+// Google place, by address or by being geocoded close together.
+func SameLocation(a, b *Job) bool {
+	if a.PlaceID != "" && a.PlaceID == b.PlaceID {
+		return true
+	}
+	if a.JobLocation != "" && strings.Join(normalizeWords(a.JobLocation), " ") == strings.Join(normalizeWords(b.JobLocation), " ") {
+		return true
+	}
+	if a.PreciseLocation == (GeoPoint{}) || b.PreciseLocation == (GeoPoint{}) {
+		return false
+	}
+	return haversineMeters(a.PreciseLocation, b.PreciseLocation) <= duplicateRadiusMeters
+}
+
+// IsLikelyDuplicate reports whether b looks like a re-post of a.
+func IsLikelyDuplicate(a, b *Job) bool {
+	if !SameLocation(a, b) {
+		return false
+	}
+	title, description := TextSimilarity(a, b)
+	return title >= minTitleSimilarity && description >= minDescriptionSimilarity
+}
+
+func haversineMeters(a, b GeoPoint) float64 {
+	const earthRadius = 6371000
+	lat1, lat2 := a.Lat*math.Pi/180, b.Lat*math.Pi/180
+	dLat := lat2 - lat1
+	dLon := (b.Lon - a.Lon) * math.Pi / 180
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(h))
+}
+
+// duplicateCandidatesQuery finds open jobs at the same location as the job
+// with similar text. It returns nil when the job has no location to compare.
+func duplicateCandidatesQuery(job *Job) elastic.Query {
+	location := elastic.NewBoolQuery().MinimumNumberShouldMatch(1)
+	hasLocation := false
+	if job.PlaceID != "" {
+		location.Should(elastic.NewTermQuery("place_id", job.PlaceID))
+		hasLocation = true
+	}
+	if job.JobLocation != "" {
+		location.Should(elastic.NewTermQuery("job_location", job.JobLocation))
+		hasLocation = true
+	}
+	if job.PreciseLocation != (GeoPoint{}) {
+		location.Should(elastic.NewGeoDistanceQuery("precise_location").
+			Lat(job.PreciseLocation.Lat).
+			Lon(job.PreciseLocation.Lon).
+			Distance(fmt.Sprintf("%dm", duplicateRadiusMeters)))
+		hasLocation = true
+	}
+	if !hasLocation {
+		return nil
+	}
+
+	return elastic.NewBoolQuery().
+		Must(elastic.NewMoreLikeThisQuery().
+			Field("title", "description").
+			LikeText(job.Title+"\n"+job.Description).
+			MinTermFreq(1).
+			MinDocFreq(1).
+			MinimumShouldMatch("30%")).
+		Filter(location).
+		MustNot(
+			elastic.NewIdsQuery().Ids(job.ID),
+			elastic.NewTermQuery("status", JobStatusClosed),
+			// Compare against the original rather than earlier duplicates.
+			elastic.NewExistsQuery("duplicate_of"),
+		)
+}
+
+// FindDuplicateJob returns the open job the given job most likely duplicates,
+// or nil if there is none.
+func (c *ESClientImpl) FindDuplicateJob(ctx context.Context, job *Job) (*Job, error) {
+	query := duplicateCandidatesQuery(job)
+	if query == nil {
+		return nil, nil
+	}
+	res, err := c.Client.Search().
+		Index(JobIdx).
+		Query(query).
+		Size(duplicateCandidates).
+		Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search duplicate jobs: %v", err)
+	}
+
+	candidates := make([]Job, 0, len(res.Hits.Hits))
+	for _, hit := range res.Hits.Hits {
+		var candidate Job
+		if err := json.Unmarshal(hit.Source, &candidate); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal job: %v", err)
+		}
+		candidates = append(candidates, candidate)
+	}
+	return MostSimilarDuplicate(job, candidates), nil
+}
+
+// MostSimilarDuplicate returns the candidate the job most likely duplicates,
+// or nil if none of them is a likely duplicate.
+func MostSimilarDuplicate(job *Job, candidates []Job) *Job {
+	var best *Job
+	bestScore := 0.0
+	for i := range candidates {
+		if candidates[i].ID == job.ID || !IsLikelyDuplicate(&candidates[i], job) {
+			continue
+		}
+		title, description := TextSimilarity(&candidates[i], job)
+		if score := title + description; best == nil || score > bestScore {
+			best, bestScore = &candidates[i], score
+		}
+	}
+	return best
+}
+
+// MarkJobDuplicate flags the job as a likely duplicate of the original job.
+// Flagged jobs stay visible to their employer but are left out of the feed.
+func (c *ESClientImpl) MarkJobDuplicate(ctx context.Context, id, originalID string) error {
+	return c.updateJobFields(ctx, id, map[string]interface{}{"duplicate_of": originalID})
+}
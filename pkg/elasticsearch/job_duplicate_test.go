@@ -0,0 +1,99 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hankimmy/PtmrBackend/pkg/util"
+)
+
+const duplicateDescription = "Join our busy downtown team serving lunch and dinner guests. " +
+	"Prior restaurant experience preferred and weekend availability required."
+
+func TestTextSimilarity(t *testing.T) {
+	a := &Job{Title: "Server - Part Time", Description: duplicateDescription}
+	b := &Job{Title: "part-time SERVER", Description: duplicateDescription + " Apply today!"}
+	title, description := TextSimilarity(a, b)
+	require.Equal(t, 1.0, title)
+	require.Greater(t, description, minDescriptionSimilarity)
+
+	c := &Job{Title: "Line Cook", Description: "Prep and cook menu items on the line during evening service."}
+	title, description = TextSimilarity(a, c)
+	require.Zero(t, title)
+	require.Less(t, description, minDescriptionSimilarity)
+}
+
+func TestIsLikelyDuplicate(t *testing.T) {
+	original := Job{
+		ID:              "1_Server",
+		Title:           "Server",
+		Description:     duplicateDescription,
+		JobLocation:     "13 E 37th St, New York, NY 10016",
+		PlaceID:         "place-1",
+		PreciseLocation: GeoPoint{Lat: 40.7501259, Lon: -73.9820676},
+	}
+
+	samePlace := original
+	samePlace.ID, samePlace.JobLocation, samePlace.PreciseLocation = "2_Server", "", GeoPoint{}
+	require.True(t, IsLikelyDuplicate(&original, &samePlace))
+
+	sameAddress := original
+	sameAddress.ID, sameAddress.PlaceID, sameAddress.JobLocation = "3_Server", "", "13 e 37th st new york ny 10016"
+	sameAddress.PreciseLocation = GeoPoint{}
+	require.True(t, IsLikelyDuplicate(&original, &sameAddress))
+
+	nearby := original
+	nearby.ID, nearby.PlaceID, nearby.JobLocation = "4_Server", "", "13 East 37th Street"
+	nearby.PreciseLocation = GeoPoint{Lat: 40.7502, Lon: -73.9821}
+	require.True(t, IsLikelyDuplicate(&original, &nearby))
+
+	farAway := nearby
+	farAway.PreciseLocation = GeoPoint{Lat: 40.7128, Lon: -74.0060}
+	require.False(t, IsLikelyDuplicate(&original, &farAway))
+
+	otherRole := samePlace
+	otherRole.Title = "Dishwasher"
+	require.False(t, IsLikelyDuplicate(&original, &otherRole))
+
+	require.Nil(t, MostSimilarDuplicate(&original, []Job{original, otherRole, farAway}))
+	require.Equal(t, "2_Server", MostSimilarDuplicate(&original, []Job{otherRole, samePlace}).ID)
+}
+
+func TestDuplicateCandidatesQuery(t *testing.T) {
+	require.Nil(t, duplicateCandidatesQuery(&Job{ID: "1_Server", Title: "Server"}))
+
+	src, err := duplicateCandidatesQuery(&Job{ID: "1_Server", Title: "Server", PlaceID: "place-1"}).Source()
+	require.NoError(t, err)
+	data, err := json.Marshal(src)
+	require.NoError(t, err)
+	body := string(data)
+	require.Contains(t, body, `"place_id":"place-1"`)
+	require.Contains(t, body, `"more_like_this"`)
+	require.Contains(t, body, `"duplicate_of"`)
+	require.NotContains(t, body, `"geo_distance"`)
+}
+
+func TestFindDuplicateJob(t *testing.T) {
+	original := RandomJob(util.RandomInt(100000, 999999))
+	original.Title = "Barista " + util.RandomString(6)
+	original.Description = duplicateDescription
+	require.NoError(t, esClient.IndexJob(&original))
+	_, err := esClient.Client.Refresh(JobIdx).Do(context.Background())
+	require.NoError(t, err)
+
+	repost := original
+	repost.ID = "scraped_" + util.RandomString(8)
+	repost.EmployerID = original.EmployerID + 1
+	duplicate, err := esClient.FindDuplicateJob(context.Background(), &repost)
+	require.NoError(t, err)
+	require.NotNil(t, duplicate)
+	require.Equal(t, original.ID, duplicate.ID)
+
+	require.NoError(t, esClient.MarkJobDuplicate(context.Background(), original.ID, "other"))
+	gotJob, err := esClient.GetJob(original.ID)
+	require.NoError(t, err)
+	require.Equal(t, "other", gotJob.DuplicateOf)
+}
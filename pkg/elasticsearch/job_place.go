@@ -0,0 +1,134 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/olivere/elastic/v7"
+
+	"github.com/hankimmy/PtmrBackend/pkg/google"
+)
+
+// EnrichmentStatus tracks copying Google Places data onto a job, which
+// happens in the background after the job is created.
+type EnrichmentStatus string
+
+const (
+	EnrichmentPending  EnrichmentStatus = "pending"
+	EnrichmentEnriched EnrichmentStatus = "enriched"
+	EnrichmentFailed   EnrichmentStatus = "failed"
+)
+
+// placeRefreshSize caps how many jobs a single place refresh run picks up.
+// The rest are caught on the next run.
+const placeRefreshSize = 200
+
+var postalSuffix = regexp.MustCompile(`,?\s*[A-Z]{2}\s*\d{5}(?:-\d{4})?$`)
+
+// PlaceQuery is the text search used to find the job's business on Google,
+// e.g. "CHILI 13 E 37th St, New York". State and ZIP code are dropped since
+// they make the search worse.
+func (j *Job) PlaceQuery() string {
+	address := strings.TrimSpace(postalSuffix.ReplaceAllString(j.JobLocation, ""))
+	address = strings.TrimSuffix(address, ",")
+	return j.HiringOrganization + " " + address
+}
+
+// placeFields are the job fields filled in from a place when the job is
+// enriched.
+func placeFields(details *google.PlaceDetailsResponse) map[string]interface{} {
+	fields := placeRefreshFields(details)
+	fields["place_id"] = details.ID
+	fields["display_name"] = details.DisplayName.Text
+	fields["business_types"] = details.Types
+	fields["formatted_address"] = details.FormattedAddress
+	fields["precise_location"] = GeoPoint{Lat: details.Location.Latitude, Lon: details.Location.Longitude}
+	fields["photos"] = details.Photos
+	fields["price_level"] = details.PriceLevel
+	fields["website_uri"] = details.WebsiteURI
+	fields["google_maps_uri"] = details.GoogleMapsURI
+	return fields
+}
+
+// placeRefreshFields are the place fields that go stale and are refreshed
+// periodically.
+func placeRefreshFields(details *google.PlaceDetailsResponse) map[string]interface{} {
+	return map[string]interface{}{
+		"rating":        details.Rating,
+		"opening_hours": details.RegularOpeningHours,
+		"phone_number":  details.NationalPhoneNumber,
+	}
+}
+
+// UpdateJobPlace copies the place details onto the job and marks it enriched.
+func (c *ESClientImpl) UpdateJobPlace(ctx context.Context, id string, details *google.PlaceDetailsResponse, now time.Time) error {
+	fields := placeFields(details)
+	fields["enrichment_status"] = EnrichmentEnriched
+	fields["place_refreshed_at"] = now
+	return c.updateJobFields(ctx, id, fields)
+}
+
+// RefreshJobPlace updates the job's rating, opening hours and phone number.
+func (c *ESClientImpl) RefreshJobPlace(ctx context.Context, id string, details *google.PlaceDetailsResponse, now time.Time) error {
+	fields := placeRefreshFields(details)
+	fields["place_refreshed_at"] = now
+	return c.updateJobFields(ctx, id, fields)
+}
+
+// UpdateJobEnrichmentStatus records an enrichment attempt that did not
+// produce place details.
+func (c *ESClientImpl) UpdateJobEnrichmentStatus(ctx context.Context, id string, status EnrichmentStatus, now time.Time) error {
+	return c.updateJobFields(ctx, id, map[string]interface{}{
+		"enrichment_status":  status,
+		"place_refreshed_at": now,
+	})
+}
+
+func (c *ESClientImpl) updateJobFields(ctx context.Context, id string, fields map[string]interface{}) error {
+	_, err := c.Client.Update().
+		Index(JobIdx).
+		Id(id).
+		Doc(fields).
+		Do(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to update job: %v", err)
+	}
+	return nil
+}
+
+// SearchJobsForPlaceRefresh returns jobs that are not closed or filled and
+// whose place data was last refreshed before the given time, oldest first.
+// Jobs that were never enriched have no refresh time and come first.
+func (c *ESClientImpl) SearchJobsForPlaceRefresh(ctx context.Context, before time.Time) ([]Job, error) {
+	query := elastic.NewBoolQuery().
+		MustNot(elastic.NewTermsQuery("status", JobStatusFilled, JobStatusClosed)).
+		Should(
+			elastic.NewBoolQuery().MustNot(elastic.NewExistsQuery("place_refreshed_at")),
+			elastic.NewRangeQuery("place_refreshed_at").Lt(before),
+		).
+		MinimumNumberShouldMatch(1)
+
+	res, err := c.Client.Search().
+		Index(JobIdx).
+		Query(query).
+		SortBy(elastic.NewFieldSort("place_refreshed_at").Asc().Missing("_first").UnmappedType("date")).
+		Size(placeRefreshSize).
+		Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search jobs for place refresh: %v", err)
+	}
+
+	jobs := make([]Job, 0, len(res.Hits.Hits))
+	for _, hit := range res.Hits.Hits {
+		var job Job
+		if err := json.Unmarshal(hit.Source, &job); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal job: %v", err)
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
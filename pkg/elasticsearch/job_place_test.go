@@ -0,0 +1,96 @@
+package elasticsearch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hankimmy/PtmrBackend/pkg/google"
+)
+
+func TestPlaceQuery(t *testing.T) {
+	job := Job{HiringOrganization: "CHILI", JobLocation: "13 E 37th St, New York, NY 10016"}
+	require.Equal(t, "CHILI 13 E 37th St, New York", job.PlaceQuery())
+
+	job.JobLocation = "13 E 37th St, New York"
+	require.Equal(t, "CHILI 13 E 37th St, New York", job.PlaceQuery())
+}
+
+func TestPlaceFields(t *testing.T) {
+	details := &google.PlaceDetailsResponse{
+		ID:                  "place",
+		NationalPhoneNumber: "(646) 882-0666",
+		Rating:              4.5,
+		Location:            google.Location{Latitude: 40.75, Longitude: -73.98},
+	}
+
+	refresh := placeRefreshFields(details)
+	require.Len(t, refresh, 3)
+	require.Equal(t, float32(4.5), refresh["rating"])
+	require.Equal(t, "(646) 882-0666", refresh["phone_number"])
+
+	fields := placeFields(details)
+	require.Equal(t, "place", fields["place_id"])
+	require.Equal(t, GeoPoint{Lat: 40.75, Lon: -73.98}, fields["precise_location"])
+	require.Equal(t, float32(4.5), fields["rating"])
+}
+
+func TestUpdateJobPlace(t *testing.T) {
+	job := RandomJob(1)
+	job.EnrichmentStatus = EnrichmentPending
+	job.PlaceID = ""
+	job.Rating = 0
+	require.NoError(t, esClient.IndexJob(&job))
+
+	details := &google.PlaceDetailsResponse{ID: "place", Rating: 4.5, NationalPhoneNumber: "(646) 882-0666"}
+	now := time.Now().UTC().Truncate(time.Second)
+	require.NoError(t, esClient.UpdateJobPlace(context.Background(), job.ID, details, now))
+
+	updated, err := esClient.GetJob(job.ID)
+	require.NoError(t, err)
+	require.Equal(t, EnrichmentEnriched, updated.EnrichmentStatus)
+	require.Equal(t, "place", updated.PlaceID)
+	require.Equal(t, float32(4.5), updated.Rating)
+	require.True(t, now.Equal(*updated.PlaceRefreshedAt))
+	require.Equal(t, job.Title, updated.Title)
+
+	details.Rating = 3.5
+	require.NoError(t, esClient.RefreshJobPlace(context.Background(), job.ID, details, now.Add(time.Hour)))
+	updated, err = esClient.GetJob(job.ID)
+	require.NoError(t, err)
+	require.Equal(t, float32(3.5), updated.Rating)
+	require.Equal(t, "place", updated.PlaceID)
+}
+
+func TestSearchJobsForPlaceRefresh(t *testing.T) {
+	now := time.Now().UTC()
+
+	pending := RandomJob(1)
+	pending.EnrichmentStatus = EnrichmentPending
+	stale := RandomJob(1)
+	stale.PlaceRefreshedAt = timePtr(now.Add(-48 * time.Hour))
+	fresh := RandomJob(1)
+	fresh.PlaceRefreshedAt = timePtr(now)
+	closed := RandomJob(1)
+	closed.Status = JobStatusClosed
+
+	for _, job := range []*Job{&pending, &stale, &fresh, &closed} {
+		require.NoError(t, esClient.IndexJob(job))
+	}
+	_, err := esClient.Client.Refresh(JobIdx).Do(context.Background())
+	require.NoError(t, err)
+
+	jobs, err := esClient.SearchJobsForPlaceRefresh(context.Background(), now.Add(-24*time.Hour))
+	require.NoError(t, err)
+
+	ids := make(map[string]bool, len(jobs))
+	for _, job := range jobs {
+		ids[job.ID] = true
+	}
+	require.True(t, ids[pending.ID])
+	require.True(t, ids[stale.ID])
+	require.False(t, ids[fresh.ID])
+	require.False(t, ids[closed.ID])
+}
@@ -0,0 +1,355 @@
+package elasticsearch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DatePostedFormat is the layout of Job.DatePosted.
+const DatePostedFormat = "2006-January-02"
+
+const (
+	schemaContext        = "https://schema.org/"
+	schemaJobPostingType = "JobPosting"
+	// SalaryCurrency is the only currency wages are stored in.
+	SalaryCurrency = "USD"
+)
+
+// hoursPerUnit converts a schema.org salary unit to the hourly wage we store,
+// assuming full-time hours for the longer periods.
+var hoursPerUnit = map[string]float64{
+	"HOUR":  1,
+	"DAY":   8,
+	"WEEK":  40,
+	"MONTH": 2080.0 / 12,
+	"YEAR":  2080,
+}
+
+var schemaEmploymentTypes = map[string]bool{
+	"FULL_TIME":  true,
+	"PART_TIME":  true,
+	"CONTRACTOR": true,
+	"TEMPORARY":  true,
+	"INTERN":     true,
+	"VOLUNTEER":  true,
+	"PER_DIEM":   true,
+	"OTHER":      true,
+}
+
+// JobPosting is a schema.org JobPosting, as used by Google for Jobs. Only the
+// properties we can map to a Job are modelled.
+type JobPosting struct {
+	Context            string             `json:"@context,omitempty"`
+	Type               string             `json:"@type,omitempty"`
+	Identifier         *PropertyValue     `json:"identifier,omitempty"`
+	Title              string             `json:"title"`
+	Description        string             `json:"description"`
+	DatePosted         string             `json:"datePosted,omitempty"`
+	ValidThrough       *time.Time         `json:"validThrough,omitempty"`
+	EmploymentType     SchemaList         `json:"employmentType,omitempty"`
+	HiringOrganization SchemaOrganization `json:"hiringOrganization"`
+	JobLocation        PlaceList          `json:"jobLocation,omitempty"`
+	BaseSalary         *MonetaryAmount    `json:"baseSalary,omitempty"`
+	Industry           string             `json:"industry,omitempty"`
+	URL                string             `json:"url,omitempty"`
+	DirectApply        bool               `json:"directApply,omitempty"`
+}
+
+type PropertyValue struct {
+	Type  string `json:"@type,omitempty"`
+	Name  string `json:"name,omitempty"`
+	Value string `json:"value"`
+}
+
+type SchemaOrganization struct {
+	Type   string `json:"@type,omitempty"`
+	Name   string `json:"name"`
+	SameAs string `json:"sameAs,omitempty"`
+}
+
+type SchemaPlace struct {
+	Type    string          `json:"@type,omitempty"`
+	Address PostalAddress   `json:"address"`
+	Geo     *GeoCoordinates `json:"geo,omitempty"`
+}
+
+type PostalAddress struct {
+	Type            string `json:"@type,omitempty"`
+	StreetAddress   string `json:"streetAddress,omitempty"`
+	AddressLocality string `json:"addressLocality,omitempty"`
+	AddressRegion   string `json:"addressRegion,omitempty"`
+	PostalCode      string `json:"postalCode,omitempty"`
+	AddressCountry  string `json:"addressCountry,omitempty"`
+}
+
+type GeoCoordinates struct {
+	Type      string  `json:"@type,omitempty"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+type MonetaryAmount struct {
+	Type     string            `json:"@type,omitempty"`
+	Currency string            `json:"currency"`
+	Value    QuantitativeValue `json:"value"`
+}
+
+type QuantitativeValue struct {
+	Type     string   `json:"@type,omitempty"`
+	Value    *float64 `json:"value,omitempty"`
+	MinValue *float64 `json:"minValue,omitempty"`
+	MaxValue *float64 `json:"maxValue,omitempty"`
+	UnitText string   `json:"unitText"`
+}
+
+// SchemaList is a property that schema.org allows as a single value or an
+// array. It is written back as a single value when it holds one.
+type SchemaList []string
+
+func (l *SchemaList) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*l = SchemaList{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("expected a string or a list of strings: %w", err)
+	}
+	*l = list
+	return nil
+}
+
+func (l SchemaList) MarshalJSON() ([]byte, error) {
+	if len(l) == 1 {
+		return json.Marshal(l[0])
+	}
+	return json.Marshal([]string(l))
+}
+
+// PlaceList is jobLocation, which may be a single Place or an array of them.
+type PlaceList []SchemaPlace
+
+func (l *PlaceList) UnmarshalJSON(data []byte) error {
+	var single SchemaPlace
+	if err := json.Unmarshal(data, &single); err == nil {
+		*l = PlaceList{single}
+		return nil
+	}
+	var list []SchemaPlace
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("expected a place or a list of places: %w", err)
+	}
+	*l = list
+	return nil
+}
+
+func (l PlaceList) MarshalJSON() ([]byte, error) {
+	if len(l) == 1 {
+		return json.Marshal(l[0])
+	}
+	return json.Marshal([]SchemaPlace(l))
+}
+
+// ToJobPosting renders the job as a schema.org JobPosting.
+func (j *Job) ToJobPosting() JobPosting {
+	posting := JobPosting{
+		Context:     schemaContext,
+		Type:        schemaJobPostingType,
+		Title:       j.Title,
+		Description: j.Description,
+		Industry:    j.Industry,
+		URL:         j.DestinationURL,
+		HiringOrganization: SchemaOrganization{
+			Type:   "Organization",
+			Name:   j.HiringOrganization,
+			SameAs: j.WebsiteURI,
+		},
+		ValidThrough: j.CloseAt,
+		DirectApply:  j.IsUserCreated,
+	}
+	if j.ID != "" {
+		posting.Identifier = &PropertyValue{Type: "PropertyValue", Name: j.HiringOrganization, Value: j.ID}
+	}
+	if posted, err := time.Parse(DatePostedFormat, j.DatePosted); err == nil {
+		posting.DatePosted = posted.Format("2006-01-02")
+	}
+	if j.EmploymentType != "" {
+		posting.EmploymentType = SchemaList{employmentTypeToSchema(j.EmploymentType)}
+	}
+
+	address := j.FormattedAddress
+	if address == "" {
+		address = j.JobLocation
+	}
+	if address != "" {
+		place := SchemaPlace{Type: "Place", Address: parsePostalAddress(address)}
+		if j.PreciseLocation != (GeoPoint{}) {
+			place.Geo = &GeoCoordinates{
+				Type:      "GeoCoordinates",
+				Latitude:  j.PreciseLocation.Lat,
+				Longitude: j.PreciseLocation.Lon,
+			}
+		}
+		posting.JobLocation = PlaceList{place}
+	}
+
+	if j.Wage > 0 {
+		wage := float64(j.Wage)
+		posting.BaseSalary = &MonetaryAmount{
+			Type:     "MonetaryAmount",
+			Currency: SalaryCurrency,
+			Value: QuantitativeValue{
+				Type:     "QuantitativeValue",
+				Value:    &wage,
+				UnitText: "HOUR",
+			},
+		}
+	}
+	return posting
+}
+
+// ToJob converts an imported JobPosting into a job owned by employerID. The
+// caller still sets the ID, status and anything else the posting lacks.
+func (p *JobPosting) ToJob(employerID int64) (Job, error) {
+	if p.Type != "" && p.Type != schemaJobPostingType {
+		return Job{}, fmt.Errorf("unsupported @type %q", p.Type)
+	}
+	if strings.TrimSpace(p.Title) == "" {
+		return Job{}, errors.New("title is required")
+	}
+	if strings.TrimSpace(p.HiringOrganization.Name) == "" {
+		return Job{}, errors.New("hiringOrganization.name is required")
+	}
+
+	job := Job{
+		EmployerID:         employerID,
+		HiringOrganization: p.HiringOrganization.Name,
+		WebsiteURI:         p.HiringOrganization.SameAs,
+		Title:              p.Title,
+		Description:        p.Description,
+		Industry:           p.Industry,
+		DestinationURL:     p.URL,
+		CloseAt:            p.ValidThrough,
+	}
+
+	if p.DatePosted != "" {
+		posted, err := parseSchemaDate(p.DatePosted)
+		if err != nil {
+			return Job{}, fmt.Errorf("invalid datePosted: %w", err)
+		}
+		job.DatePosted = posted.Format(DatePostedFormat)
+	}
+	if len(p.EmploymentType) > 0 {
+		job.EmploymentType = employmentTypeFromSchema(p.EmploymentType[0])
+	}
+	if len(p.JobLocation) > 0 {
+		place := p.JobLocation[0]
+		job.JobLocation = place.Address.String()
+		job.FormattedAddress = job.JobLocation
+		if place.Geo != nil {
+			job.PreciseLocation = GeoPoint{Lat: place.Geo.Latitude, Lon: place.Geo.Longitude}
+		}
+	}
+	if p.BaseSalary != nil {
+		wage, err := p.BaseSalary.hourlyWage()
+		if err != nil {
+			return Job{}, fmt.Errorf("invalid baseSalary: %w", err)
+		}
+		job.Wage = float32(wage)
+	}
+	return job, nil
+}
+
+func (m *MonetaryAmount) hourlyWage() (float64, error) {
+	if m.Currency != "" && !strings.EqualFold(m.Currency, SalaryCurrency) {
+		return 0, fmt.Errorf("currency %q is not supported", m.Currency)
+	}
+	hours, ok := hoursPerUnit[strings.ToUpper(m.Value.UnitText)]
+	if !ok {
+		return 0, fmt.Errorf("unitText %q is not supported", m.Value.UnitText)
+	}
+
+	var amount *float64
+	switch {
+	case m.Value.Value != nil:
+		amount = m.Value.Value
+	case m.Value.MinValue != nil:
+		amount = m.Value.MinValue
+	case m.Value.MaxValue != nil:
+		amount = m.Value.MaxValue
+	default:
+		return 0, errors.New("value, minValue or maxValue is required")
+	}
+	if *amount < 0 {
+		return 0, errors.New("salary cannot be negative")
+	}
+	return *amount / hours, nil
+}
+
+// String joins the address the way Google formats addresses, e.g.
+// "13 E 37th St, New York, NY 10016, USA".
+func (a PostalAddress) String() string {
+	regionPostal := strings.TrimSpace(a.AddressRegion + " " + a.PostalCode)
+	var parts []string
+	for _, part := range []string{a.StreetAddress, a.AddressLocality, regionPostal, a.AddressCountry} {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// parsePostalAddress splits a formatted address back into its components. An
+// address it cannot split is kept whole as the street address.
+func parsePostalAddress(address string) PostalAddress {
+	parts := strings.Split(address, ",")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	if len(parts) < 3 {
+		return PostalAddress{Type: "PostalAddress", StreetAddress: strings.TrimSpace(address)}
+	}
+
+	postal := PostalAddress{
+		Type:            "PostalAddress",
+		StreetAddress:   parts[0],
+		AddressLocality: parts[1],
+	}
+	regionPostal := strings.Fields(parts[2])
+	if len(regionPostal) > 0 {
+		postal.AddressRegion = regionPostal[0]
+	}
+	if len(regionPostal) > 1 {
+		postal.PostalCode = strings.Join(regionPostal[1:], " ")
+	}
+	if len(parts) > 3 {
+		postal.AddressCountry = strings.Join(parts[3:], ", ")
+	}
+	return postal
+}
+
+func parseSchemaDate(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+// employmentTypeToSchema maps our free-form employment types ("part-time",
+// "Full Time") onto the schema.org enumeration.
+func employmentTypeToSchema(employmentType string) string {
+	value := strings.ToUpper(strings.TrimSpace(employmentType))
+	value = strings.NewReplacer("-", "_", " ", "_").Replace(value)
+	if schemaEmploymentTypes[value] {
+		return value
+	}
+	return "OTHER"
+}
+
+func employmentTypeFromSchema(employmentType string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(employmentType)), "_", "-")
+}
@@ -0,0 +1,156 @@
+package elasticsearch
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobToJobPosting(t *testing.T) {
+	job := RandomJob(1)
+	job.EmploymentType = "part-time"
+	job.Wage = 18.5
+	job.DatePosted = "2024-March-05"
+	closeAt := time.Date(2024, time.April, 1, 17, 0, 0, 0, time.UTC)
+	job.CloseAt = &closeAt
+
+	posting := job.ToJobPosting()
+	require.Equal(t, "https://schema.org/", posting.Context)
+	require.Equal(t, "JobPosting", posting.Type)
+	require.Equal(t, job.Title, posting.Title)
+	require.Equal(t, job.ID, posting.Identifier.Value)
+	require.Equal(t, "2024-03-05", posting.DatePosted)
+	require.Equal(t, &closeAt, posting.ValidThrough)
+	require.Equal(t, SchemaList{"PART_TIME"}, posting.EmploymentType)
+	require.Equal(t, job.HiringOrganization, posting.HiringOrganization.Name)
+	require.Equal(t, job.WebsiteURI, posting.HiringOrganization.SameAs)
+
+	require.Len(t, posting.JobLocation, 1)
+	address := posting.JobLocation[0].Address
+	require.Equal(t, "13 E 37th St", address.StreetAddress)
+	require.Equal(t, "New York", address.AddressLocality)
+	require.Equal(t, "NY", address.AddressRegion)
+	require.Equal(t, "10016", address.PostalCode)
+	require.Equal(t, "USA", address.AddressCountry)
+	require.Equal(t, job.PreciseLocation.Lat, posting.JobLocation[0].Geo.Latitude)
+
+	require.Equal(t, "USD", posting.BaseSalary.Currency)
+	require.Equal(t, "HOUR", posting.BaseSalary.Value.UnitText)
+	require.InDelta(t, 18.5, *posting.BaseSalary.Value.Value, 0.001)
+
+	data, err := json.Marshal(posting)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"employmentType":"PART_TIME"`)
+	require.Contains(t, string(data), `"jobLocation":{"@type":"Place"`)
+}
+
+func TestJobPostingToJob(t *testing.T) {
+	doc := `{
+		"@context": "https://schema.org/",
+		"@type": "JobPosting",
+		"title": "Barista",
+		"description": "<p>Make great coffee.</p>",
+		"datePosted": "2024-03-05",
+		"validThrough": "2030-04-01T17:00:00Z",
+		"employmentType": ["PART_TIME", "TEMPORARY"],
+		"hiringOrganization": {"@type": "Organization", "name": "Bean There", "sameAs": "https://beanthere.example.com"},
+		"jobLocation": [{
+			"@type": "Place",
+			"address": {
+				"@type": "PostalAddress",
+				"streetAddress": "1 Main St",
+				"addressLocality": "Brooklyn",
+				"addressRegion": "NY",
+				"postalCode": "11201",
+				"addressCountry": "US"
+			},
+			"geo": {"@type": "GeoCoordinates", "latitude": 40.69, "longitude": -73.99}
+		}],
+		"baseSalary": {
+			"@type": "MonetaryAmount",
+			"currency": "USD",
+			"value": {"@type": "QuantitativeValue", "minValue": 640, "maxValue": 800, "unitText": "WEEK"}
+		}
+	}`
+	var posting JobPosting
+	require.NoError(t, json.Unmarshal([]byte(doc), &posting))
+
+	job, err := posting.ToJob(7)
+	require.NoError(t, err)
+	require.Equal(t, int64(7), job.EmployerID)
+	require.Equal(t, "Barista", job.Title)
+	require.Equal(t, "Bean There", job.HiringOrganization)
+	require.Equal(t, "https://beanthere.example.com", job.WebsiteURI)
+	require.Equal(t, "2024-March-05", job.DatePosted)
+	require.Equal(t, "part-time", job.EmploymentType)
+	require.Equal(t, "1 Main St, Brooklyn, NY 11201, US", job.JobLocation)
+	require.Equal(t, GeoPoint{Lat: 40.69, Lon: -73.99}, job.PreciseLocation)
+	require.InDelta(t, 16, job.Wage, 0.001)
+	require.Equal(t, time.Date(2030, time.April, 1, 17, 0, 0, 0, time.UTC), *job.CloseAt)
+}
+
+func TestJobPostingToJobErrors(t *testing.T) {
+	wage := 20.0
+	testCases := []struct {
+		name    string
+		posting JobPosting
+		problem string
+	}{
+		{
+			name:    "WrongType",
+			posting: JobPosting{Type: "Event", Title: "Barista", HiringOrganization: SchemaOrganization{Name: "Bean There"}},
+			problem: `unsupported @type "Event"`,
+		},
+		{
+			name:    "MissingTitle",
+			posting: JobPosting{HiringOrganization: SchemaOrganization{Name: "Bean There"}},
+			problem: "title is required",
+		},
+		{
+			name:    "MissingOrganization",
+			posting: JobPosting{Title: "Barista"},
+			problem: "hiringOrganization.name is required",
+		},
+		{
+			name: "InvalidDate",
+			posting: JobPosting{
+				Title: "Barista", HiringOrganization: SchemaOrganization{Name: "Bean There"},
+				DatePosted: "March 5th",
+			},
+			problem: "invalid datePosted",
+		},
+		{
+			name: "UnsupportedCurrency",
+			posting: JobPosting{
+				Title: "Barista", HiringOrganization: SchemaOrganization{Name: "Bean There"},
+				BaseSalary: &MonetaryAmount{Currency: "EUR", Value: QuantitativeValue{Value: &wage, UnitText: "HOUR"}},
+			},
+			problem: `currency "EUR" is not supported`,
+		},
+		{
+			name: "UnsupportedUnit",
+			posting: JobPosting{
+				Title: "Barista", HiringOrganization: SchemaOrganization{Name: "Bean There"},
+				BaseSalary: &MonetaryAmount{Currency: "USD", Value: QuantitativeValue{Value: &wage, UnitText: "SHIFT"}},
+			},
+			problem: `unitText "SHIFT" is not supported`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.posting.ToJob(1)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.problem)
+		})
+	}
+}
+
+func TestEmploymentTypeToSchema(t *testing.T) {
+	require.Equal(t, "FULL_TIME", employmentTypeToSchema("Full Time"))
+	require.Equal(t, "PART_TIME", employmentTypeToSchema("part-time"))
+	require.Equal(t, "OTHER", employmentTypeToSchema("gig"))
+	require.Equal(t, "per-diem", employmentTypeFromSchema("PER_DIEM"))
+}
@@ -0,0 +1,121 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/olivere/elastic/v7"
+)
+
+// scheduleSweepSize is the page size the schedule sweep reads due jobs in.
+const scheduleSweepSize = 500
+
+// ValidateJobSchedule checks the scheduling fields an employer submits with a
+// job.
+func ValidateJobSchedule(publishAt, closeAt *time.Time, maxApplications int32, now time.Time) error {
+	if maxApplications < 0 {
+		return errors.New("max_applications cannot be negative")
+	}
+	if closeAt != nil && !closeAt.After(now) {
+		return errors.New("close_at must be in the future")
+	}
+	if publishAt != nil && closeAt != nil && !closeAt.After(*publishAt) {
+		return errors.New("close_at must be after publish_at")
+	}
+	return nil
+}
+
+// ScheduledStatus returns the status the job's publish_at and close_at say it
+// should be in at now, if that requires a transition from its current status.
+// Filled jobs are left alone: their applicants were notified when they filled.
+func (j *Job) ScheduledStatus(now time.Time) (JobStatus, bool) {
+	current := j.CurrentStatus()
+	if j.CloseAt != nil && !j.CloseAt.After(now) &&
+		current != JobStatusFilled && current.CanTransitionTo(JobStatusClosed) {
+		return JobStatusClosed, true
+	}
+	if current == JobStatusDraft && j.PublishAt != nil && !j.PublishAt.After(now) {
+		return JobStatusPublished, true
+	}
+	return "", false
+}
+
+// ReachedMaxApplications reports whether count applications fill the job.
+// Jobs without max_applications never fill up.
+func (j *Job) ReachedMaxApplications(count int64) bool {
+	return j.MaxApplications > 0 && count >= int64(j.MaxApplications)
+}
+
+// AcceptsApplications reports whether candidates may apply to the job at now.
+// The application limit is checked separately when the application is stored.
+func (j *Job) AcceptsApplications(now time.Time) bool {
+	if j.CurrentStatus() != JobStatusPublished || j.HiddenByModeration() {
+		return false
+	}
+	if j.PublishAt != nil && j.PublishAt.After(now) {
+		return false
+	}
+	return j.CloseAt == nil || j.CloseAt.After(now)
+}
+
+// SearchJobsDueToPublish returns a page of drafts whose publish_at has
+// passed, oldest first. Pass the cursor of the previous page as after to get
+// the next one; the returned cursor is nil once there are no more pages.
+func (c *ESClientImpl) SearchJobsDueToPublish(ctx context.Context, now time.Time, after []interface{}) ([]Job, []interface{}, error) {
+	query := elastic.NewBoolQuery().Filter(
+		elastic.NewTermQuery("status", JobStatusDraft),
+		elastic.NewRangeQuery("publish_at").Lte(now),
+	)
+	return c.searchJobsDue(ctx, query, "publish_at", after)
+}
+
+// SearchJobsDueToClose returns a page of open jobs whose close_at has passed,
+// oldest first, paged like SearchJobsDueToPublish.
+func (c *ESClientImpl) SearchJobsDueToClose(ctx context.Context, now time.Time, after []interface{}) ([]Job, []interface{}, error) {
+	query := elastic.NewBoolQuery().Filter(
+		elastic.NewTermsQuery("status", JobStatusDraft, JobStatusPublished, JobStatusPaused),
+		elastic.NewRangeQuery("close_at").Lte(now),
+	)
+	return c.searchJobsDue(ctx, query, "close_at", after)
+}
+
+func (c *ESClientImpl) searchJobsDue(ctx context.Context, query elastic.Query, field string, after []interface{}) ([]Job, []interface{}, error) {
+	search := c.Client.Search().
+		Index(JobIdx).
+		Query(query).
+		SortBy(elastic.NewFieldSort(field).Asc(), elastic.NewFieldSort("_doc")).
+		Size(scheduleSweepSize)
+	if len(after) > 0 {
+		search = search.SearchAfter(after...)
+	}
+	res, err := search.Do(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to search jobs due by %s: %v", field, err)
+	}
+
+	jobs := make([]Job, 0, len(res.Hits.Hits))
+	for _, hit := range res.Hits.Hits {
+		var job Job
+		if err := json.Unmarshal(hit.Source, &job); err != nil {
+			return nil, nil, fmt.Errorf("failed to unmarshal job: %v", err)
+		}
+		jobs = append(jobs, job)
+	}
+	if len(res.Hits.Hits) < scheduleSweepSize {
+		return jobs, nil, nil
+	}
+	return jobs, res.Hits.Hits[len(res.Hits.Hits)-1].Sort, nil
+}
+
+// openScheduleQuery excludes jobs that are waiting for publish_at or whose
+// close_at has passed but have not been swept yet.
+func openScheduleQuery(now time.Time) elastic.Query {
+	return elastic.NewBoolQuery().
+		MustNot(
+			elastic.NewRangeQuery("publish_at").Gt(now),
+			elastic.NewRangeQuery("close_at").Lte(now),
+		)
+}
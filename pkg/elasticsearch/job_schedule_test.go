@@ -0,0 +1,125 @@
+package elasticsearch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hankimmy/PtmrBackend/pkg/moderation"
+)
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func TestValidateJobSchedule(t *testing.T) {
+	now := time.Now()
+	require.NoError(t, ValidateJobSchedule(nil, nil, 0, now))
+	require.NoError(t, ValidateJobSchedule(timePtr(now.Add(time.Hour)), timePtr(now.Add(2*time.Hour)), 10, now))
+	require.Error(t, ValidateJobSchedule(nil, nil, -1, now))
+	require.Error(t, ValidateJobSchedule(nil, timePtr(now.Add(-time.Minute)), 0, now))
+	require.Error(t, ValidateJobSchedule(timePtr(now.Add(2*time.Hour)), timePtr(now.Add(time.Hour)), 0, now))
+}
+
+func TestScheduledStatus(t *testing.T) {
+	now := time.Now()
+	past := timePtr(now.Add(-time.Minute))
+	future := timePtr(now.Add(time.Hour))
+
+	testCases := []struct {
+		name   string
+		job    Job
+		status JobStatus
+		due    bool
+	}{
+		{name: "DraftWaiting", job: Job{Status: JobStatusDraft, PublishAt: future}},
+		{name: "DraftDue", job: Job{Status: JobStatusDraft, PublishAt: past}, status: JobStatusPublished, due: true},
+		{name: "DraftWithoutSchedule", job: Job{Status: JobStatusDraft}},
+		{name: "PublishedOpen", job: Job{Status: JobStatusPublished, CloseAt: future}},
+		{name: "PublishedExpired", job: Job{Status: JobStatusPublished, CloseAt: past}, status: JobStatusClosed, due: true},
+		{name: "PausedExpired", job: Job{Status: JobStatusPaused, CloseAt: past}, status: JobStatusClosed, due: true},
+		{name: "LegacyExpired", job: Job{CloseAt: past}, status: JobStatusClosed, due: true},
+		{name: "DraftPastBothDates", job: Job{Status: JobStatusDraft, PublishAt: past, CloseAt: past}, status: JobStatusClosed, due: true},
+		{name: "FilledExpired", job: Job{Status: JobStatusFilled, CloseAt: past}},
+		{name: "AlreadyClosed", job: Job{Status: JobStatusClosed, CloseAt: past}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, due := tc.job.ScheduledStatus(now)
+			require.Equal(t, tc.due, due)
+			require.Equal(t, tc.status, status)
+		})
+	}
+}
+
+func TestAcceptsApplications(t *testing.T) {
+	now := time.Now()
+	require.True(t, (&Job{Status: JobStatusPublished}).AcceptsApplications(now))
+	require.True(t, (&Job{}).AcceptsApplications(now))
+	require.True(t, (&Job{Status: JobStatusPublished, CloseAt: timePtr(now.Add(time.Hour))}).AcceptsApplications(now))
+	require.False(t, (&Job{Status: JobStatusPublished, CloseAt: timePtr(now.Add(-time.Hour))}).AcceptsApplications(now))
+	require.False(t, (&Job{Status: JobStatusPublished, PublishAt: timePtr(now.Add(time.Hour))}).AcceptsApplications(now))
+	require.False(t, (&Job{Status: JobStatusDraft}).AcceptsApplications(now))
+	require.False(t, (&Job{Status: JobStatusPaused}).AcceptsApplications(now))
+	require.True(t, (&Job{Status: JobStatusPublished, ModerationStatus: moderation.DecisionApproved}).AcceptsApplications(now))
+	require.False(t, (&Job{Status: JobStatusPublished, ModerationStatus: moderation.DecisionNeedsReview}).AcceptsApplications(now))
+	require.False(t, (&Job{Status: JobStatusPublished, ModerationStatus: moderation.DecisionRejected}).AcceptsApplications(now))
+}
+
+func TestReachedMaxApplications(t *testing.T) {
+	require.False(t, (&Job{}).ReachedMaxApplications(100))
+	require.False(t, (&Job{MaxApplications: 3}).ReachedMaxApplications(2))
+	require.True(t, (&Job{MaxApplications: 3}).ReachedMaxApplications(3))
+}
+
+func TestSearchJobsDue(t *testing.T) {
+	now := time.Now().UTC()
+
+	toPublish := RandomJob(1)
+	toPublish.Status = JobStatusDraft
+	toPublish.PublishAt = timePtr(now.Add(-time.Minute))
+	toClose := RandomJob(1)
+	toClose.CloseAt = timePtr(now.Add(-time.Minute))
+	limited := RandomJob(1)
+	limited.MaxApplications = 5
+	notDue := RandomJob(1)
+	notDue.Status = JobStatusDraft
+	notDue.PublishAt = timePtr(now.Add(time.Hour))
+
+	for _, job := range []*Job{&toPublish, &toClose, &limited, &notDue} {
+		require.NoError(t, esClient.IndexJob(job))
+	}
+	_, err := esClient.Client.Refresh(JobIdx).Do(context.Background())
+	require.NoError(t, err)
+
+	publish := searchAllJobsDue(t, esClient.SearchJobsDueToPublish, now)
+	require.True(t, publish[toPublish.ID])
+	require.False(t, publish[toClose.ID])
+	require.False(t, publish[limited.ID])
+	require.False(t, publish[notDue.ID])
+
+	closing := searchAllJobsDue(t, esClient.SearchJobsDueToClose, now)
+	require.True(t, closing[toClose.ID])
+	require.False(t, closing[toPublish.ID])
+	require.False(t, closing[limited.ID])
+	require.False(t, closing[notDue.ID])
+}
+
+func searchAllJobsDue(t *testing.T, search func(context.Context, time.Time, []interface{}) ([]Job, []interface{}, error), now time.Time) map[string]bool {
+	ids := make(map[string]bool)
+	var after []interface{}
+	for {
+		jobs, next, err := search(context.Background(), now, after)
+		require.NoError(t, err)
+		for _, job := range jobs {
+			ids[job.ID] = true
+		}
+		if next == nil {
+			return ids
+		}
+		after = next
+	}
+}
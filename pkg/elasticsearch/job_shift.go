@@ -0,0 +1,140 @@
+package elasticsearch
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/olivere/elastic/v7"
+
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	"github.com/hankimmy/PtmrBackend/pkg/util"
+)
+
+// JobShift is a copy of one of a job's shifts kept on the job document so the
+// feed can filter on it. Postgres holds the shifts and their claims; the copy
+// is rewritten whenever either changes.
+type JobShift struct {
+	ID        int64     `json:"id"`
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	Timezone  string    `json:"timezone"`
+	Headcount int32     `json:"headcount"`
+	SpotsLeft int32     `json:"spots_left"`
+	// Slots are the parts of the week the shift covers in its own timezone,
+	// e.g. "saturday_evening", matched against candidate availability.
+	Slots []string `json:"slots"`
+}
+
+// NewJobShift builds the indexed copy of a shift from its row and claim count.
+func NewJobShift(shift db.ListJobShiftsRow) JobShift {
+	loc, err := time.LoadLocation(shift.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	spotsLeft := shift.Headcount - shift.Claimed
+	if spotsLeft < 0 {
+		spotsLeft = 0
+	}
+	return JobShift{
+		ID:        shift.ID,
+		StartsAt:  shift.StartsAt,
+		EndsAt:    shift.EndsAt,
+		Timezone:  shift.Timezone,
+		Headcount: shift.Headcount,
+		SpotsLeft: spotsLeft,
+		Slots:     ShiftSlots(shift.StartsAt, shift.EndsAt, loc),
+	}
+}
+
+type DayPart string
+
+const (
+	DayPartMorning   DayPart = "morning"   // 06:00 to 12:00
+	DayPartAfternoon DayPart = "afternoon" // 12:00 to 17:00
+	DayPartEvening   DayPart = "evening"   // 17:00 to 22:00
+	DayPartNight     DayPart = "night"     // 22:00 to 06:00
+)
+
+func dayPartAt(hour int) DayPart {
+	switch {
+	case hour >= 6 && hour < 12:
+		return DayPartMorning
+	case hour >= 12 && hour < 17:
+		return DayPartAfternoon
+	case hour >= 17 && hour < 22:
+		return DayPartEvening
+	default:
+		return DayPartNight
+	}
+}
+
+func slotName(day time.Weekday, part DayPart) string {
+	return strings.ToLower(day.String()) + "_" + string(part)
+}
+
+// ShiftSlots returns the slots a shift from start to end covers in loc, in
+// order. Night hours after midnight count towards the day they fall on.
+func ShiftSlots(start, end time.Time, loc *time.Location) []string {
+	var slots []string
+	seen := make(map[string]bool)
+	endLocal := end.In(loc)
+	for t := start.In(loc); t.Before(endLocal); {
+		slot := slotName(t.Weekday(), dayPartAt(t.Hour()))
+		if !seen[slot] {
+			seen[slot] = true
+			slots = append(slots, slot)
+		}
+		t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+	}
+	return slots
+}
+
+// AvailabilitySlots converts a candidate's weekly availability into slots.
+// The entries are indexed by time.Weekday, starting on Sunday.
+func AvailabilitySlots(availability []util.Availability) []string {
+	var slots []string
+	for i, day := range availability {
+		if i > int(time.Saturday) {
+			break
+		}
+		weekday := time.Weekday(i)
+		parts := []struct {
+			part      DayPart
+			available bool
+		}{
+			{DayPartMorning, day.Morning},
+			{DayPartAfternoon, day.Afternoon},
+			{DayPartEvening, day.Evening},
+			{DayPartNight, day.Night},
+		}
+		for _, p := range parts {
+			if p.available {
+				slots = append(slots, slotName(weekday, p.part))
+			}
+		}
+	}
+	return slots
+}
+
+// UpdateJobShifts replaces the shifts stored on the job document.
+func (c *ESClientImpl) UpdateJobShifts(ctx context.Context, id string, shifts []JobShift) error {
+	if shifts == nil {
+		shifts = []JobShift{}
+	}
+	return c.updateJobFields(ctx, id, map[string]interface{}{"shifts": shifts})
+}
+
+// upcomingShiftsQuery matches jobs with a shift that has not started yet,
+// still has open spots and overlaps one of the slots.
+func upcomingShiftsQuery(slots []string, now time.Time) elastic.Query {
+	values := make([]interface{}, len(slots))
+	for i, slot := range slots {
+		values[i] = slot
+	}
+	return elastic.NewNestedQuery("shifts", elastic.NewBoolQuery().Filter(
+		elastic.NewRangeQuery("shifts.starts_at").Gt(now),
+		elastic.NewRangeQuery("shifts.spots_left").Gt(0),
+		elastic.NewTermsQuery("shifts.slots", values...),
+	))
+}
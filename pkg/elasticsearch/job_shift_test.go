@@ -0,0 +1,102 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hankimmy/PtmrBackend/pkg/util"
+)
+
+func TestShiftSlots(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	// Saturday 5pm to 11pm in New York.
+	start := time.Date(2024, time.March, 16, 17, 0, 0, 0, newYork)
+	require.Equal(t, []string{"saturday_evening", "saturday_night"},
+		ShiftSlots(start.UTC(), start.Add(6*time.Hour).UTC(), newYork))
+
+	// Friday 10pm to Saturday 7am runs into Saturday morning.
+	start = time.Date(2024, time.March, 15, 22, 0, 0, 0, newYork)
+	require.Equal(t, []string{"friday_night", "saturday_night", "saturday_morning"},
+		ShiftSlots(start, start.Add(9*time.Hour), newYork))
+
+	// The same instant falls on different slots in different timezones.
+	require.Equal(t, []string{"saturday_night"},
+		ShiftSlots(start, start.Add(time.Hour), time.UTC))
+
+	require.Empty(t, ShiftSlots(start, start, newYork))
+}
+
+func TestAvailabilitySlots(t *testing.T) {
+	availability := make([]util.Availability, 7)
+	availability[time.Monday] = util.Availability{Morning: true, Night: true}
+	availability[time.Saturday] = util.Availability{Evening: true}
+
+	require.Equal(t, []string{"monday_morning", "monday_night", "saturday_evening"}, AvailabilitySlots(availability))
+	require.Empty(t, AvailabilitySlots(nil))
+}
+
+func TestUpcomingShiftsQuery(t *testing.T) {
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	src, err := upcomingShiftsQuery([]string{"saturday_evening"}, now).Source()
+	require.NoError(t, err)
+	data, err := json.Marshal(src)
+	require.NoError(t, err)
+
+	body := string(data)
+	require.Contains(t, body, `"path":"shifts"`)
+	require.Contains(t, body, `"shifts.slots":["saturday_evening"]`)
+	require.Contains(t, body, `"shifts.spots_left"`)
+	require.Contains(t, body, `"shifts.starts_at"`)
+}
+
+func TestSearchJobsWithShifts(t *testing.T) {
+	upcoming := time.Now().Add(48 * time.Hour)
+	shift := JobShift{
+		ID:        1,
+		StartsAt:  upcoming,
+		EndsAt:    upcoming.Add(4 * time.Hour),
+		Timezone:  "UTC",
+		Headcount: 3,
+		SpotsLeft: 2,
+		Slots:     ShiftSlots(upcoming, upcoming.Add(4*time.Hour), time.UTC),
+	}
+
+	matching := RandomJob(1)
+	matching.Title = "Line Cook"
+	matching.Shifts = []JobShift{shift}
+	require.NoError(t, esClient.IndexJob(&matching))
+
+	full := RandomJob(2)
+	full.Title = matching.Title
+	full.EmploymentType = matching.EmploymentType
+	fullShift := shift
+	fullShift.SpotsLeft = 0
+	full.Shifts = []JobShift{fullShift}
+	require.NoError(t, esClient.IndexJob(&full))
+
+	noShifts := RandomJob(3)
+	noShifts.Title = matching.Title
+	noShifts.EmploymentType = matching.EmploymentType
+	require.NoError(t, esClient.IndexJob(&noShifts))
+
+	time.Sleep(2 * time.Second)
+
+	jobs, err := esClient.SearchJobsWithShifts(context.Background(), matching.Industry, matching.EmploymentType,
+		matching.Title, "10mi", matching.PreciseLocation, shift.Slots)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	require.Equal(t, matching.ID, jobs[0].ID)
+
+	jobs, err = esClient.SearchJobsWithShifts(context.Background(), matching.Industry, matching.EmploymentType,
+		matching.Title, "10mi", matching.PreciseLocation, []string{"no_such_slot"})
+	require.NoError(t, err)
+	require.Empty(t, jobs)
+
+	clearIndex(JobIdx)
+}
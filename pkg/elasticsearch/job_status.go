@@ -0,0 +1,67 @@
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+)
+
+type JobStatus string
+
+const (
+	JobStatusDraft     JobStatus = "draft"
+	JobStatusPublished JobStatus = "published"
+	JobStatusPaused    JobStatus = "paused"
+	JobStatusFilled    JobStatus = "filled"
+	JobStatusClosed    JobStatus = "closed"
+)
+
+// jobStatusTransitions lists the statuses a job may move to from each status.
+// Closed is terminal; a filled job can be reopened if the hire falls through.
+var jobStatusTransitions = map[JobStatus][]JobStatus{
+	JobStatusDraft:     {JobStatusPublished, JobStatusClosed},
+	JobStatusPublished: {JobStatusPaused, JobStatusFilled, JobStatusClosed},
+	JobStatusPaused:    {JobStatusPublished, JobStatusFilled, JobStatusClosed},
+	JobStatusFilled:    {JobStatusPublished, JobStatusClosed},
+	JobStatusClosed:    {},
+}
+
+func (s JobStatus) Valid() bool {
+	_, ok := jobStatusTransitions[s]
+	return ok
+}
+
+func (s JobStatus) CanTransitionTo(next JobStatus) bool {
+	for _, allowed := range jobStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// NotifiesApplicants reports whether moving into s ends the hiring process
+// for candidates that still have an open application.
+func (s JobStatus) NotifiesApplicants() bool {
+	return s == JobStatusFilled || s == JobStatusClosed
+}
+
+// CurrentStatus returns the job's status. Jobs indexed before statuses were
+// introduced have none and are treated as published.
+func (j *Job) CurrentStatus() JobStatus {
+	if j.Status == "" {
+		return JobStatusPublished
+	}
+	return j.Status
+}
+
+func (c *ESClientImpl) UpdateJobStatus(id string, status JobStatus) error {
+	_, err := c.Client.Update().
+		Index(JobIdx).
+		Id(id).
+		Doc(map[string]interface{}{"status": status}).
+		Do(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to update job status: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package elasticsearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobStatusTransitions(t *testing.T) {
+	require.True(t, JobStatusDraft.CanTransitionTo(JobStatusPublished))
+	require.True(t, JobStatusPublished.CanTransitionTo(JobStatusPaused))
+	require.True(t, JobStatusPaused.CanTransitionTo(JobStatusPublished))
+	require.True(t, JobStatusPublished.CanTransitionTo(JobStatusFilled))
+	require.True(t, JobStatusFilled.CanTransitionTo(JobStatusClosed))
+
+	require.False(t, JobStatusDraft.CanTransitionTo(JobStatusPaused))
+	require.False(t, JobStatusDraft.CanTransitionTo(JobStatusFilled))
+	require.False(t, JobStatusPublished.CanTransitionTo(JobStatusDraft))
+	require.False(t, JobStatusPublished.CanTransitionTo(JobStatusPublished))
+	require.False(t, JobStatusClosed.CanTransitionTo(JobStatusPublished))
+	require.False(t, JobStatus("archived").CanTransitionTo(JobStatusPublished))
+}
+
+func TestUpdateJobStatus(t *testing.T) {
+	job := RandomJob(1)
+	err := esClient.IndexJob(&job)
+	require.NoError(t, err)
+
+	err = esClient.UpdateJobStatus(job.ID, JobStatusPaused)
+	require.NoError(t, err)
+
+	gotJob, err := esClient.GetJob(job.ID)
+	require.NoError(t, err)
+	require.Equal(t, JobStatusPaused, gotJob.Status)
+	require.Equal(t, job.Title, gotJob.Title)
+}
+
+func TestCurrentStatusDefaultsToPublished(t *testing.T) {
+	job := RandomJob(1)
+	job.Status = ""
+	require.Equal(t, JobStatusPublished, job.CurrentStatus())
+}
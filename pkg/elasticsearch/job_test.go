@@ -32,6 +32,20 @@ func TestIndexJob(t *testing.T) {
 	requireBodyMatchJob(t, &body, job)
 }
 
+func TestCreateJobKeepsExistingJob(t *testing.T) {
+	job := RandomJob(1)
+	require.NoError(t, esClient.CreateJob(&job))
+
+	replacement := job
+	replacement.Description = job.Description + " again"
+	err := esClient.CreateJob(&replacement)
+	require.ErrorIs(t, err, ErrJobExists)
+
+	got, err := esClient.GetJob(job.ID)
+	require.NoError(t, err)
+	require.Equal(t, job.Description, got.Description)
+}
+
 func TestGetJob(t *testing.T) {
 	job := RandomJob(1)
 
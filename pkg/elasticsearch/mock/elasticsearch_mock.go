@@ -7,10 +7,13 @@ package mockes
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
 	elasticsearch "github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
+	google "github.com/hankimmy/PtmrBackend/pkg/google"
+	moderation "github.com/hankimmy/PtmrBackend/pkg/moderation"
 )
 
 // MockESClient is a mock of ESClient interface.
@@ -50,6 +53,215 @@ func (mr *MockESClientMockRecorder) AddPastExperienceToCandidate(arg0, arg1, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPastExperienceToCandidate", reflect.TypeOf((*MockESClient)(nil).AddPastExperienceToCandidate), arg0, arg1, arg2)
 }
 
+// BulkCreateJobs mocks base method.
+func (m *MockESClient) BulkCreateJobs(arg0 context.Context, arg1 []elasticsearch.Job) (*elasticsearch.BulkResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkCreateJobs", arg0, arg1)
+	ret0, _ := ret[0].(*elasticsearch.BulkResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkCreateJobs indicates an expected call of BulkCreateJobs.
+func (mr *MockESClientMockRecorder) BulkCreateJobs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkCreateJobs", reflect.TypeOf((*MockESClient)(nil).BulkCreateJobs), arg0, arg1)
+}
+
+// BulkDeleteCandidateApplications mocks base method.
+func (m *MockESClient) BulkDeleteCandidateApplications(arg0 context.Context, arg1 []string) (*elasticsearch.BulkResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkDeleteCandidateApplications", arg0, arg1)
+	ret0, _ := ret[0].(*elasticsearch.BulkResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkDeleteCandidateApplications indicates an expected call of BulkDeleteCandidateApplications.
+func (mr *MockESClientMockRecorder) BulkDeleteCandidateApplications(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkDeleteCandidateApplications", reflect.TypeOf((*MockESClient)(nil).BulkDeleteCandidateApplications), arg0, arg1)
+}
+
+// BulkDeleteCandidates mocks base method.
+func (m *MockESClient) BulkDeleteCandidates(arg0 context.Context, arg1 []string) (*elasticsearch.BulkResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkDeleteCandidates", arg0, arg1)
+	ret0, _ := ret[0].(*elasticsearch.BulkResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkDeleteCandidates indicates an expected call of BulkDeleteCandidates.
+func (mr *MockESClientMockRecorder) BulkDeleteCandidates(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkDeleteCandidates", reflect.TypeOf((*MockESClient)(nil).BulkDeleteCandidates), arg0, arg1)
+}
+
+// BulkDeleteEmployerApplications mocks base method.
+func (m *MockESClient) BulkDeleteEmployerApplications(arg0 context.Context, arg1 []string) (*elasticsearch.BulkResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkDeleteEmployerApplications", arg0, arg1)
+	ret0, _ := ret[0].(*elasticsearch.BulkResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkDeleteEmployerApplications indicates an expected call of BulkDeleteEmployerApplications.
+func (mr *MockESClientMockRecorder) BulkDeleteEmployerApplications(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkDeleteEmployerApplications", reflect.TypeOf((*MockESClient)(nil).BulkDeleteEmployerApplications), arg0, arg1)
+}
+
+// BulkDeleteJobs mocks base method.
+func (m *MockESClient) BulkDeleteJobs(arg0 context.Context, arg1 []string) (*elasticsearch.BulkResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkDeleteJobs", arg0, arg1)
+	ret0, _ := ret[0].(*elasticsearch.BulkResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkDeleteJobs indicates an expected call of BulkDeleteJobs.
+func (mr *MockESClientMockRecorder) BulkDeleteJobs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkDeleteJobs", reflect.TypeOf((*MockESClient)(nil).BulkDeleteJobs), arg0, arg1)
+}
+
+// BulkIndexCandidateApplications mocks base method.
+func (m *MockESClient) BulkIndexCandidateApplications(arg0 context.Context, arg1 map[string]map[string]interface{}) (*elasticsearch.BulkResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkIndexCandidateApplications", arg0, arg1)
+	ret0, _ := ret[0].(*elasticsearch.BulkResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkIndexCandidateApplications indicates an expected call of BulkIndexCandidateApplications.
+func (mr *MockESClientMockRecorder) BulkIndexCandidateApplications(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkIndexCandidateApplications", reflect.TypeOf((*MockESClient)(nil).BulkIndexCandidateApplications), arg0, arg1)
+}
+
+// BulkIndexCandidates mocks base method.
+func (m *MockESClient) BulkIndexCandidates(arg0 context.Context, arg1 []elasticsearch.Candidate) (*elasticsearch.BulkResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkIndexCandidates", arg0, arg1)
+	ret0, _ := ret[0].(*elasticsearch.BulkResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkIndexCandidates indicates an expected call of BulkIndexCandidates.
+func (mr *MockESClientMockRecorder) BulkIndexCandidates(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkIndexCandidates", reflect.TypeOf((*MockESClient)(nil).BulkIndexCandidates), arg0, arg1)
+}
+
+// BulkIndexEmployerApplications mocks base method.
+func (m *MockESClient) BulkIndexEmployerApplications(arg0 context.Context, arg1 map[string]map[string]interface{}) (*elasticsearch.BulkResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkIndexEmployerApplications", arg0, arg1)
+	ret0, _ := ret[0].(*elasticsearch.BulkResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkIndexEmployerApplications indicates an expected call of BulkIndexEmployerApplications.
+func (mr *MockESClientMockRecorder) BulkIndexEmployerApplications(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkIndexEmployerApplications", reflect.TypeOf((*MockESClient)(nil).BulkIndexEmployerApplications), arg0, arg1)
+}
+
+// BulkIndexJobs mocks base method.
+func (m *MockESClient) BulkIndexJobs(arg0 context.Context, arg1 []elasticsearch.Job) (*elasticsearch.BulkResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkIndexJobs", arg0, arg1)
+	ret0, _ := ret[0].(*elasticsearch.BulkResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkIndexJobs indicates an expected call of BulkIndexJobs.
+func (mr *MockESClientMockRecorder) BulkIndexJobs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkIndexJobs", reflect.TypeOf((*MockESClient)(nil).BulkIndexJobs), arg0, arg1)
+}
+
+// BulkUpdateCandidateApplications mocks base method.
+func (m *MockESClient) BulkUpdateCandidateApplications(arg0 context.Context, arg1 map[string]map[string]interface{}) (*elasticsearch.BulkResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkUpdateCandidateApplications", arg0, arg1)
+	ret0, _ := ret[0].(*elasticsearch.BulkResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkUpdateCandidateApplications indicates an expected call of BulkUpdateCandidateApplications.
+func (mr *MockESClientMockRecorder) BulkUpdateCandidateApplications(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpdateCandidateApplications", reflect.TypeOf((*MockESClient)(nil).BulkUpdateCandidateApplications), arg0, arg1)
+}
+
+// BulkUpdateCandidates mocks base method.
+func (m *MockESClient) BulkUpdateCandidates(arg0 context.Context, arg1 map[string]map[string]interface{}) (*elasticsearch.BulkResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkUpdateCandidates", arg0, arg1)
+	ret0, _ := ret[0].(*elasticsearch.BulkResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkUpdateCandidates indicates an expected call of BulkUpdateCandidates.
+func (mr *MockESClientMockRecorder) BulkUpdateCandidates(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpdateCandidates", reflect.TypeOf((*MockESClient)(nil).BulkUpdateCandidates), arg0, arg1)
+}
+
+// BulkUpdateEmployerApplications mocks base method.
+func (m *MockESClient) BulkUpdateEmployerApplications(arg0 context.Context, arg1 map[string]map[string]interface{}) (*elasticsearch.BulkResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkUpdateEmployerApplications", arg0, arg1)
+	ret0, _ := ret[0].(*elasticsearch.BulkResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkUpdateEmployerApplications indicates an expected call of BulkUpdateEmployerApplications.
+func (mr *MockESClientMockRecorder) BulkUpdateEmployerApplications(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpdateEmployerApplications", reflect.TypeOf((*MockESClient)(nil).BulkUpdateEmployerApplications), arg0, arg1)
+}
+
+// BulkUpdateJobs mocks base method.
+func (m *MockESClient) BulkUpdateJobs(arg0 context.Context, arg1 []elasticsearch.Job) (*elasticsearch.BulkResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkUpdateJobs", arg0, arg1)
+	ret0, _ := ret[0].(*elasticsearch.BulkResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkUpdateJobs indicates an expected call of BulkUpdateJobs.
+func (mr *MockESClientMockRecorder) BulkUpdateJobs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpdateJobs", reflect.TypeOf((*MockESClient)(nil).BulkUpdateJobs), arg0, arg1)
+}
+
+// CreateJob mocks base method.
+func (m *MockESClient) CreateJob(arg0 *elasticsearch.Job) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateJob", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateJob indicates an expected call of CreateJob.
+func (mr *MockESClientMockRecorder) CreateJob(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJob", reflect.TypeOf((*MockESClient)(nil).CreateJob), arg0)
+}
+
 // DeleteCandidate mocks base method.
 func (m *MockESClient) DeleteCandidate(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -120,6 +332,21 @@ func (mr *MockESClientMockRecorder) DeletePastExperienceFromCandidate(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePastExperienceFromCandidate", reflect.TypeOf((*MockESClient)(nil).DeletePastExperienceFromCandidate), arg0, arg1, arg2)
 }
 
+// FindDuplicateJob mocks base method.
+func (m *MockESClient) FindDuplicateJob(arg0 context.Context, arg1 *elasticsearch.Job) (*elasticsearch.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDuplicateJob", arg0, arg1)
+	ret0, _ := ret[0].(*elasticsearch.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDuplicateJob indicates an expected call of FindDuplicateJob.
+func (mr *MockESClientMockRecorder) FindDuplicateJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDuplicateJob", reflect.TypeOf((*MockESClient)(nil).FindDuplicateJob), arg0, arg1)
+}
+
 // GetCandidate mocks base method.
 func (m *MockESClient) GetCandidate(arg0 context.Context, arg1 string) (*elasticsearch.Candidate, error) {
 	m.ctrl.T.Helper()
@@ -280,6 +507,109 @@ func (mr *MockESClientMockRecorder) ListPastExperiences(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPastExperiences", reflect.TypeOf((*MockESClient)(nil).ListPastExperiences), arg0, arg1)
 }
 
+// MGetCandidateApplications mocks base method.
+func (m *MockESClient) MGetCandidateApplications(arg0 context.Context, arg1 []string) (map[string]map[string]interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MGetCandidateApplications", arg0, arg1)
+	ret0, _ := ret[0].(map[string]map[string]interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MGetCandidateApplications indicates an expected call of MGetCandidateApplications.
+func (mr *MockESClientMockRecorder) MGetCandidateApplications(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MGetCandidateApplications", reflect.TypeOf((*MockESClient)(nil).MGetCandidateApplications), arg0, arg1)
+}
+
+// MGetCandidates mocks base method.
+func (m *MockESClient) MGetCandidates(arg0 context.Context, arg1 []string) ([]elasticsearch.Candidate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MGetCandidates", arg0, arg1)
+	ret0, _ := ret[0].([]elasticsearch.Candidate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MGetCandidates indicates an expected call of MGetCandidates.
+func (mr *MockESClientMockRecorder) MGetCandidates(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MGetCandidates", reflect.TypeOf((*MockESClient)(nil).MGetCandidates), arg0, arg1)
+}
+
+// MGetEmployerApplications mocks base method.
+func (m *MockESClient) MGetEmployerApplications(arg0 context.Context, arg1 []string) (map[string]map[string]interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MGetEmployerApplications", arg0, arg1)
+	ret0, _ := ret[0].(map[string]map[string]interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MGetEmployerApplications indicates an expected call of MGetEmployerApplications.
+func (mr *MockESClientMockRecorder) MGetEmployerApplications(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MGetEmployerApplications", reflect.TypeOf((*MockESClient)(nil).MGetEmployerApplications), arg0, arg1)
+}
+
+// MGetJobs mocks base method.
+func (m *MockESClient) MGetJobs(arg0 context.Context, arg1 []string) ([]elasticsearch.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MGetJobs", arg0, arg1)
+	ret0, _ := ret[0].([]elasticsearch.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MGetJobs indicates an expected call of MGetJobs.
+func (mr *MockESClientMockRecorder) MGetJobs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MGetJobs", reflect.TypeOf((*MockESClient)(nil).MGetJobs), arg0, arg1)
+}
+
+// MarkJobDuplicate mocks base method.
+func (m *MockESClient) MarkJobDuplicate(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkJobDuplicate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkJobDuplicate indicates an expected call of MarkJobDuplicate.
+func (mr *MockESClientMockRecorder) MarkJobDuplicate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkJobDuplicate", reflect.TypeOf((*MockESClient)(nil).MarkJobDuplicate), arg0, arg1, arg2)
+}
+
+// RefreshJobPlace mocks base method.
+func (m *MockESClient) RefreshJobPlace(arg0 context.Context, arg1 string, arg2 *google.PlaceDetailsResponse, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshJobPlace", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefreshJobPlace indicates an expected call of RefreshJobPlace.
+func (mr *MockESClientMockRecorder) RefreshJobPlace(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshJobPlace", reflect.TypeOf((*MockESClient)(nil).RefreshJobPlace), arg0, arg1, arg2, arg3)
+}
+
+// SearchEmployerJobs mocks base method.
+func (m *MockESClient) SearchEmployerJobs(arg0 context.Context, arg1 elasticsearch.EmployerJobsParams) (*elasticsearch.EmployerJobsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchEmployerJobs", arg0, arg1)
+	ret0, _ := ret[0].(*elasticsearch.EmployerJobsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchEmployerJobs indicates an expected call of SearchEmployerJobs.
+func (mr *MockESClientMockRecorder) SearchEmployerJobs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchEmployerJobs", reflect.TypeOf((*MockESClient)(nil).SearchEmployerJobs), arg0, arg1)
+}
+
 // SearchJobs mocks base method.
 func (m *MockESClient) SearchJobs(arg0, arg1, arg2, arg3 string, arg4 elasticsearch.GeoPoint) ([]elasticsearch.Job, error) {
 	m.ctrl.T.Helper()
@@ -295,6 +625,68 @@ func (mr *MockESClientMockRecorder) SearchJobs(arg0, arg1, arg2, arg3, arg4 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchJobs", reflect.TypeOf((*MockESClient)(nil).SearchJobs), arg0, arg1, arg2, arg3, arg4)
 }
 
+// SearchJobsDueToClose mocks base method.
+func (m *MockESClient) SearchJobsDueToClose(arg0 context.Context, arg1 time.Time, arg2 []interface{}) ([]elasticsearch.Job, []interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchJobsDueToClose", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]elasticsearch.Job)
+	ret1, _ := ret[1].([]interface{})
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SearchJobsDueToClose indicates an expected call of SearchJobsDueToClose.
+func (mr *MockESClientMockRecorder) SearchJobsDueToClose(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchJobsDueToClose", reflect.TypeOf((*MockESClient)(nil).SearchJobsDueToClose), arg0, arg1, arg2)
+}
+
+// SearchJobsDueToPublish mocks base method.
+func (m *MockESClient) SearchJobsDueToPublish(arg0 context.Context, arg1 time.Time, arg2 []interface{}) ([]elasticsearch.Job, []interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchJobsDueToPublish", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]elasticsearch.Job)
+	ret1, _ := ret[1].([]interface{})
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SearchJobsDueToPublish indicates an expected call of SearchJobsDueToPublish.
+func (mr *MockESClientMockRecorder) SearchJobsDueToPublish(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchJobsDueToPublish", reflect.TypeOf((*MockESClient)(nil).SearchJobsDueToPublish), arg0, arg1, arg2)
+}
+
+// SearchJobsForPlaceRefresh mocks base method.
+func (m *MockESClient) SearchJobsForPlaceRefresh(arg0 context.Context, arg1 time.Time) ([]elasticsearch.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchJobsForPlaceRefresh", arg0, arg1)
+	ret0, _ := ret[0].([]elasticsearch.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchJobsForPlaceRefresh indicates an expected call of SearchJobsForPlaceRefresh.
+func (mr *MockESClientMockRecorder) SearchJobsForPlaceRefresh(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchJobsForPlaceRefresh", reflect.TypeOf((*MockESClient)(nil).SearchJobsForPlaceRefresh), arg0, arg1)
+}
+
+// SearchJobsWithShifts mocks base method.
+func (m *MockESClient) SearchJobsWithShifts(arg0 context.Context, arg1, arg2, arg3, arg4 string, arg5 elasticsearch.GeoPoint, arg6 []string) ([]elasticsearch.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchJobsWithShifts", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret0, _ := ret[0].([]elasticsearch.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchJobsWithShifts indicates an expected call of SearchJobsWithShifts.
+func (mr *MockESClientMockRecorder) SearchJobsWithShifts(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchJobsWithShifts", reflect.TypeOf((*MockESClient)(nil).SearchJobsWithShifts), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+}
+
 // UpdateCandidate mocks base method.
 func (m *MockESClient) UpdateCandidate(arg0 context.Context, arg1 db.Candidate) error {
 	m.ctrl.T.Helper()
@@ -365,6 +757,76 @@ func (mr *MockESClientMockRecorder) UpdateJob(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateJob", reflect.TypeOf((*MockESClient)(nil).UpdateJob), arg0, arg1)
 }
 
+// UpdateJobEnrichmentStatus mocks base method.
+func (m *MockESClient) UpdateJobEnrichmentStatus(arg0 context.Context, arg1 string, arg2 elasticsearch.EnrichmentStatus, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateJobEnrichmentStatus", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateJobEnrichmentStatus indicates an expected call of UpdateJobEnrichmentStatus.
+func (mr *MockESClientMockRecorder) UpdateJobEnrichmentStatus(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateJobEnrichmentStatus", reflect.TypeOf((*MockESClient)(nil).UpdateJobEnrichmentStatus), arg0, arg1, arg2, arg3)
+}
+
+// UpdateJobModerationStatus mocks base method.
+func (m *MockESClient) UpdateJobModerationStatus(arg0 context.Context, arg1 string, arg2 moderation.Decision) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateJobModerationStatus", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateJobModerationStatus indicates an expected call of UpdateJobModerationStatus.
+func (mr *MockESClientMockRecorder) UpdateJobModerationStatus(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateJobModerationStatus", reflect.TypeOf((*MockESClient)(nil).UpdateJobModerationStatus), arg0, arg1, arg2)
+}
+
+// UpdateJobPlace mocks base method.
+func (m *MockESClient) UpdateJobPlace(arg0 context.Context, arg1 string, arg2 *google.PlaceDetailsResponse, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateJobPlace", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateJobPlace indicates an expected call of UpdateJobPlace.
+func (mr *MockESClientMockRecorder) UpdateJobPlace(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateJobPlace", reflect.TypeOf((*MockESClient)(nil).UpdateJobPlace), arg0, arg1, arg2, arg3)
+}
+
+// UpdateJobShifts mocks base method.
+func (m *MockESClient) UpdateJobShifts(arg0 context.Context, arg1 string, arg2 []elasticsearch.JobShift) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateJobShifts", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateJobShifts indicates an expected call of UpdateJobShifts.
+func (mr *MockESClientMockRecorder) UpdateJobShifts(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateJobShifts", reflect.TypeOf((*MockESClient)(nil).UpdateJobShifts), arg0, arg1, arg2)
+}
+
+// UpdateJobStatus mocks base method.
+func (m *MockESClient) UpdateJobStatus(arg0 string, arg1 elasticsearch.JobStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateJobStatus", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateJobStatus indicates an expected call of UpdateJobStatus.
+func (mr *MockESClientMockRecorder) UpdateJobStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateJobStatus", reflect.TypeOf((*MockESClient)(nil).UpdateJobStatus), arg0, arg1)
+}
+
 // UpdatePastExperienceInCandidate mocks base method.
 func (m *MockESClient) UpdatePastExperienceInCandidate(arg0 context.Context, arg1 string, arg2 elasticsearch.PastExperience) error {
 	m.ctrl.T.Helper()
@@ -1,7 +1,6 @@
 package elasticsearch
 
 import (
-	"encoding/json"
 	"fmt"
 	"math/rand"
 	"time"
@@ -25,7 +24,15 @@ type Job struct {
 	Tips               float32         `json:"tips"`
 	DestinationURL     string          `json:"destination_URL"`
 	IsUserCreated      bool            `json:"user_created"`
-	JobApplication     json.RawMessage `json:"job_application"`
+	JobApplication     ApplicationForm `json:"job_application"`
+	Status             JobStatus       `json:"status,omitempty"`
+	PublishAt          *time.Time      `json:"publish_at,omitempty"`
+	CloseAt            *time.Time      `json:"close_at,omitempty"`
+	MaxApplications    int32           `json:"max_applications,omitempty"`
+	Shifts             []JobShift      `json:"shifts,omitempty"`
+	DuplicateOf        string          `json:"duplicate_of,omitempty"`
+	// Set from the moderation review of the title and description
+	ModerationStatus ModerationStatus `json:"moderation_status,omitempty"`
 	// Google Business Data Related
 	PlaceID          string              `json:"place_id"`
 	DisplayName      string              `json:"display_name"`
@@ -39,6 +46,8 @@ type Job struct {
 	OpeningHours     google.OpeningHours `json:"opening_hours"`
 	WebsiteURI       string              `json:"website_uri"`
 	GoogleMapsURI    string              `json:"google_maps_uri"`
+	EnrichmentStatus EnrichmentStatus    `json:"enrichment_status,omitempty"`
+	PlaceRefreshedAt *time.Time          `json:"place_refreshed_at,omitempty"`
 }
 
 type GeoPoint struct {
@@ -80,6 +89,8 @@ type Candidate struct {
 	ProfilePhoto       string        `json:"profile_photo"`
 	Description        string        `json:"description"`
 	CreatedAt          time.Time     `json:"created_at"`
+	// Set from the moderation review of the description
+	ModerationStatus ModerationStatus `json:"moderation_status,omitempty"`
 }
 
 type PastExperience struct {
@@ -114,36 +125,30 @@ const (
 
 func RandomJob(employerID int64) Job {
 	title := util.RandomString(5)
-	applicationQuestions := []map[string]interface{}{
+	jobApplication := ApplicationForm{
 		{
-			"id":         "q1",
-			"type":       "text",
-			"question":   "What is your full name?",
-			"isRequired": true,
-			"order":      1,
+			ID:         "q1",
+			Type:       QuestionTypeText,
+			Question:   "What is your full name?",
+			IsRequired: true,
+			Order:      1,
 		},
 		{
-			"id":         "q3",
-			"type":       "multiple-choice",
-			"question":   "What is your highest level of education?",
-			"options":    []string{"High School", "Associate Degree", "Bachelor's Degree", "Master's Degree", "Doctorate"},
-			"isRequired": true,
-			"order":      2,
+			ID:         "q3",
+			Type:       QuestionTypeMultipleChoice,
+			Question:   "What is your highest level of education?",
+			Options:    []string{"High School", "Associate Degree", "Bachelor's Degree", "Master's Degree", "Doctorate"},
+			IsRequired: true,
+			Order:      2,
 		},
 		{
-			"id":         "q4",
-			"type":       "yes-no",
-			"question":   "Do you have a valid driver's license?",
-			"isRequired": false,
-			"order":      3,
+			ID:         "q4",
+			Type:       QuestionTypeYesNo,
+			Question:   "Do you have a valid driver's license?",
+			IsRequired: false,
+			Order:      3,
 		},
 	}
-
-	// Marshal the application questions into JSON
-	jobApplication, err := json.Marshal(applicationQuestions)
-	if err != nil {
-		panic("failed to marshal job application questions: " + err.Error())
-	}
 	return Job{
 		ID:                 fmt.Sprintf("%d_%s", employerID, title),
 		EmployerID:         employerID,
@@ -151,7 +156,7 @@ func RandomJob(employerID int64) Job {
 		Title:              title,
 		Industry:           "Restaurant",
 		JobLocation:        util.RandomUSAddress(),
-		DatePosted:         time.Now().Format("2006-January-02"),
+		DatePosted:         time.Now().Format(DatePostedFormat),
 		Description:        util.RandomString(30),
 		EmploymentType:     util.RandomString(5),
 		Wage:               rand.Float32(),
@@ -159,6 +164,7 @@ func RandomJob(employerID int64) Job {
 		DestinationURL:     util.RandomString(5),
 		IsUserCreated:      true,
 		JobApplication:     jobApplication,
+		Status:             JobStatusPublished,
 		PlaceID:            "ChIJJS3mqONZwokR9KlP3H_7MNg",
 		DisplayName:        "CHILI",
 		PhoneNumber:        "(646) 882-0666",
@@ -172,11 +178,12 @@ func RandomJob(employerID int64) Job {
 				HeightPx: 100,
 			},
 		},
-		Rating:        rand.Float32() + 4,
-		PriceLevel:    "PRICE_LEVEL_MODERATE",
-		OpeningHours:  google.OpeningHours{},
-		WebsiteURI:    "https://www.chilinyc.com/",
-		GoogleMapsURI: "https://www.google.com/maps/place/CHILI/@40.7501259,-73.9820676,15z/data=!4m2!3m1!1s0x0:0xd830fb7fdc4fa9f4?sa=X&ved=1t:2428&ictx=111",
+		Rating:           rand.Float32() + 4,
+		PriceLevel:       "PRICE_LEVEL_MODERATE",
+		OpeningHours:     google.OpeningHours{},
+		WebsiteURI:       "https://www.chilinyc.com/",
+		GoogleMapsURI:    "https://www.google.com/maps/place/CHILI/@40.7501259,-73.9820676,15z/data=!4m2!3m1!1s0x0:0xd830fb7fdc4fa9f4?sa=X&ved=1t:2428&ictx=111",
+		EnrichmentStatus: EnrichmentEnriched,
 	}
 }
 
@@ -0,0 +1,32 @@
+package elasticsearch
+
+import (
+	"context"
+
+	"github.com/olivere/elastic/v7"
+
+	"github.com/hankimmy/PtmrBackend/pkg/moderation"
+)
+
+// ModerationStatus is the moderation decision stored on a job or candidate.
+// Documents indexed before moderation existed have no status and are
+// treated as approved.
+type ModerationStatus = moderation.Decision
+
+// hiddenByModerationQuery matches documents that are waiting on a reviewer
+// or were rejected by one.
+func hiddenByModerationQuery() elastic.Query {
+	return elastic.NewTermsQuery("moderation_status", moderation.DecisionNeedsReview, moderation.DecisionRejected)
+}
+
+// HiddenByModeration reports whether the job is waiting on a reviewer or was
+// rejected by one.
+func (j *Job) HiddenByModeration() bool {
+	return j.ModerationStatus == moderation.DecisionNeedsReview || j.ModerationStatus == moderation.DecisionRejected
+}
+
+// UpdateJobModerationStatus records a reviewer's decision on the job. Jobs
+// that are not approved are left out of the feed.
+func (c *ESClientImpl) UpdateJobModerationStatus(ctx context.Context, id string, status ModerationStatus) error {
+	return c.updateJobFields(ctx, id, map[string]interface{}{"moderation_status": status})
+}
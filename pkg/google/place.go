@@ -2,12 +2,16 @@ package google
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"os"
 )
 
+// ErrPlaceNotFound is returned by GetPlaceID when the search has no results.
+var ErrPlaceNotFound = errors.New("no results found")
+
 type PlaceDetailsResponse struct {
 	Name             string   `json:"name"`
 	ID               string   `json:"id"`
@@ -79,7 +83,7 @@ func (g *Service) GetPlaceID(query string) (string, error) {
 	if len(searchResponse.Results) > 0 {
 		return searchResponse.Results[0].PlaceID, nil
 	}
-	return "", fmt.Errorf("no results found")
+	return "", ErrPlaceNotFound
 }
 
 func (g *Service) GetPlaceDetails(placeID string) (*PlaceDetailsResponse, error) {
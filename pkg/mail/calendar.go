@@ -0,0 +1,114 @@
+package mail
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const calendarTimeFormat = "20060102T150405Z"
+
+// CalendarEvent is an event sent as an iCalendar (.ics) attachment, which
+// mail clients offer to add to the recipient's calendar.
+type CalendarEvent struct {
+	// UID identifies the event across updates. Clients replace an event
+	// they already have when they get one with the same UID and a higher
+	// Sequence.
+	UID         string
+	Sequence    int32
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Description string
+	Location    string
+	Organizer   string
+	Attendees   []string
+	// Cancelled removes the event from calendars that have it.
+	Cancelled bool
+}
+
+// ICS renders the event as an iCalendar object.
+func (event CalendarEvent) ICS(now time.Time) []byte {
+	method, status := "REQUEST", "CONFIRMED"
+	if event.Cancelled {
+		method, status = "CANCEL", "CANCELLED"
+	}
+
+	var b strings.Builder
+	line := func(format string, args ...interface{}) {
+		b.WriteString(foldCalendarLine(fmt.Sprintf(format, args...)))
+		b.WriteString("\r\n")
+	}
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//Part-Timer//Interviews//EN")
+	line("METHOD:%s", method)
+	line("BEGIN:VEVENT")
+	line("UID:%s", event.UID)
+	line("SEQUENCE:%d", event.Sequence)
+	line("DTSTAMP:%s", now.UTC().Format(calendarTimeFormat))
+	line("DTSTART:%s", event.Start.UTC().Format(calendarTimeFormat))
+	line("DTEND:%s", event.End.UTC().Format(calendarTimeFormat))
+	line("SUMMARY:%s", escapeCalendarText(event.Summary))
+	if event.Description != "" {
+		line("DESCRIPTION:%s", escapeCalendarText(event.Description))
+	}
+	if event.Location != "" {
+		line("LOCATION:%s", escapeCalendarText(event.Location))
+	}
+	if event.Organizer != "" {
+		line("ORGANIZER:mailto:%s", event.Organizer)
+	}
+	for _, attendee := range event.Attendees {
+		line("ATTENDEE;ROLE=REQ-PARTICIPANT:mailto:%s", attendee)
+	}
+	line("STATUS:%s", status)
+	line("END:VEVENT")
+	line("END:VCALENDAR")
+	return []byte(b.String())
+}
+
+// WriteCalendarFile writes the event to an invite.ics file in dir, so that it
+// can be passed to SendEmail as an attachment, and returns its path.
+func WriteCalendarFile(dir string, event CalendarEvent, now time.Time) (string, error) {
+	path := filepath.Join(dir, "invite.ics")
+	if err := os.WriteFile(path, event.ICS(now), 0o600); err != nil {
+		return "", fmt.Errorf("failed to write calendar file: %w", err)
+	}
+	return path, nil
+}
+
+var calendarTextEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+)
+
+func escapeCalendarText(text string) string {
+	return calendarTextEscaper.Replace(text)
+}
+
+// foldCalendarLine splits lines longer than 75 octets, continuing them on
+// lines that start with a space. Runes are never split.
+func foldCalendarLine(line string) string {
+	const maxOctets = 75
+	if len(line) <= maxOctets {
+		return line
+	}
+	var b strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > maxOctets {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	return b.String()
+}
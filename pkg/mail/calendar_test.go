@@ -0,0 +1,73 @@
+package mail
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendarEventICS(t *testing.T) {
+	start := time.Date(2024, time.March, 4, 9, 30, 0, 0, time.FixedZone("EST", -5*60*60))
+	event := CalendarEvent{
+		UID:         "interview-7@part-timer",
+		Sequence:    2,
+		Start:       start,
+		End:         start.Add(30 * time.Minute),
+		Summary:     "Interview: Barista, Blue Bottle",
+		Description: "Bring your resume.\nAsk for Sam; she's at the counter.",
+		Location:    "1 Main St",
+		Organizer:   "owner@example.com",
+		Attendees:   []string{"candidate@example.com", "owner@example.com"},
+	}
+
+	ics := string(event.ICS(start))
+	require.True(t, strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\n"))
+	require.True(t, strings.HasSuffix(ics, "END:VCALENDAR\r\n"))
+	require.Contains(t, ics, "METHOD:REQUEST\r\n")
+	require.Contains(t, ics, "UID:interview-7@part-timer\r\n")
+	require.Contains(t, ics, "SEQUENCE:2\r\n")
+	require.Contains(t, ics, "DTSTART:20240304T143000Z\r\n")
+	require.Contains(t, ics, "DTEND:20240304T150000Z\r\n")
+	require.Contains(t, ics, `SUMMARY:Interview: Barista\, Blue Bottle`)
+	require.Contains(t, ics, `DESCRIPTION:Bring your resume.\nAsk for Sam\; she's at the counter.`)
+	require.Contains(t, ics, "ATTENDEE;ROLE=REQ-PARTICIPANT:mailto:candidate@example.com\r\n")
+	require.Contains(t, ics, "STATUS:CONFIRMED\r\n")
+
+	event.Cancelled = true
+	ics = string(event.ICS(start))
+	require.Contains(t, ics, "METHOD:CANCEL\r\n")
+	require.Contains(t, ics, "STATUS:CANCELLED\r\n")
+}
+
+func TestCalendarEventICSFoldsLongLines(t *testing.T) {
+	start := time.Now()
+	event := CalendarEvent{
+		UID:         "interview-1@part-timer",
+		Start:       start,
+		End:         start.Add(time.Hour),
+		Summary:     "Interview",
+		Description: strings.Repeat("é", 100),
+	}
+
+	ics := string(event.ICS(start))
+	for _, line := range strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+		require.LessOrEqual(t, len(line), 75)
+	}
+	require.Contains(t, strings.ReplaceAll(ics, "\r\n ", ""), "DESCRIPTION:"+strings.Repeat("é", 100))
+}
+
+func TestWriteCalendarFile(t *testing.T) {
+	start := time.Now()
+	event := CalendarEvent{UID: "interview-1@part-timer", Start: start, End: start.Add(time.Hour), Summary: "Interview"}
+
+	path, err := WriteCalendarFile(t.TempDir(), event, start)
+	require.NoError(t, err)
+	require.True(t, strings.HasSuffix(path, ".ics"))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, event.ICS(start), data)
+}
@@ -0,0 +1,94 @@
+package moderation
+
+// Decision is the outcome of moderating a piece of user submitted content.
+type Decision string
+
+const (
+	DecisionApproved    Decision = "approved"
+	DecisionNeedsReview Decision = "needs_review"
+	DecisionRejected    Decision = "rejected"
+)
+
+// Item types recorded on moderation reviews.
+const (
+	ItemJob       = "job"
+	ItemCandidate = "candidate"
+	ItemEmployer  = "employer"
+)
+
+func (d Decision) severity() int {
+	switch d {
+	case DecisionRejected:
+		return 2
+	case DecisionNeedsReview:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Field is a named piece of text to moderate, e.g. a job description.
+type Field struct {
+	Name string `json:"name"`
+	Text string `json:"text"`
+}
+
+// Finding is a single rule match on a field.
+type Finding struct {
+	Rule     string   `json:"rule"`
+	Field    string   `json:"field"`
+	Decision Decision `json:"decision"`
+	Reason   string   `json:"reason"`
+}
+
+// Result is the combined outcome of all rules. The decision is the most
+// severe decision among the findings, or approved when nothing matched.
+type Result struct {
+	Decision Decision  `json:"decision"`
+	Findings []Finding `json:"findings"`
+}
+
+// Rule checks a single field and reports anything it objects to.
+type Rule interface {
+	Name() string
+	Check(field Field) []Finding
+}
+
+// Moderator runs content through a set of rules before it is stored.
+type Moderator interface {
+	Moderate(fields ...Field) Result
+}
+
+type ruleModerator struct {
+	rules []Rule
+}
+
+// NewModerator returns a Moderator that applies the given rules to every
+// field.
+func NewModerator(rules ...Rule) Moderator {
+	return &ruleModerator{rules: rules}
+}
+
+// NewDefaultModerator returns a Moderator with the default rules, extended
+// with the given banned terms.
+func NewDefaultModerator(bannedTerms []string) Moderator {
+	return NewModerator(DefaultRules(bannedTerms)...)
+}
+
+func (m *ruleModerator) Moderate(fields ...Field) Result {
+	result := Result{Decision: DecisionApproved, Findings: []Finding{}}
+	for _, field := range fields {
+		if field.Text == "" {
+			continue
+		}
+		for _, rule := range m.rules {
+			for _, finding := range rule.Check(field) {
+				result.Findings = append(result.Findings, finding)
+				if finding.Decision.severity() > result.Decision.severity() {
+					result.Decision = finding.Decision
+				}
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,139 @@
+package moderation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestModerate(t *testing.T) {
+	moderator := NewDefaultModerator([]string{"forbidden thing"})
+
+	testCases := []struct {
+		name     string
+		text     string
+		decision Decision
+		rules    []string
+	}{
+		{
+			name:     "Clean",
+			text:     "Line cook needed for weekend brunch shifts. Experience with a flat top is a plus.",
+			decision: DecisionApproved,
+		},
+		{
+			name:     "Empty",
+			text:     "",
+			decision: DecisionApproved,
+		},
+		{
+			name:     "DefaultBannedTerm",
+			text:     "Looking for an Escort for events",
+			decision: DecisionRejected,
+			rules:    []string{"banned_terms"},
+		},
+		{
+			name:     "ConfiguredBannedTerm",
+			text:     "We sell the forbidden thing here",
+			decision: DecisionRejected,
+			rules:    []string{"banned_terms"},
+		},
+		{
+			name:     "BannedTermInsideWord",
+			text:     "Our escorting service desk needs a host",
+			decision: DecisionApproved,
+		},
+		{
+			name:     "Email",
+			text:     "Send your resume to jobs@example.com today",
+			decision: DecisionNeedsReview,
+			rules:    []string{"contact_info"},
+		},
+		{
+			name:     "Phone",
+			text:     "Call us at (212) 555-0134 to apply",
+			decision: DecisionNeedsReview,
+			rules:    []string{"contact_info"},
+		},
+		{
+			name:     "SSN",
+			text:     "My number is 123-45-6789",
+			decision: DecisionNeedsReview,
+			rules:    []string{"contact_info"},
+		},
+		{
+			name:     "PayAFee",
+			text:     "Start right away, you just need to pay a small fee for your uniform",
+			decision: DecisionRejected,
+			rules:    []string{"scam_patterns"},
+		},
+		{
+			name:     "RegistrationFee",
+			text:     "A registration fee of $50 is required",
+			decision: DecisionRejected,
+			rules:    []string{"scam_patterns"},
+		},
+		{
+			name:     "GiftCards",
+			text:     "You will buy gift cards for the office",
+			decision: DecisionNeedsReview,
+			rules:    []string{"scam_patterns"},
+		},
+		{
+			name:     "ExcessiveCaps",
+			text:     "HIRING NOW GREAT PAY APPLY TODAY",
+			decision: DecisionNeedsReview,
+			rules:    []string{"excessive_caps"},
+		},
+		{
+			name:     "ShortCaps",
+			text:     "NYC BBQ",
+			decision: DecisionApproved,
+		},
+		{
+			name:     "ExcessiveLinks",
+			text:     "See https://a.example https://b.example and www.c.example",
+			decision: DecisionNeedsReview,
+			rules:    []string{"excessive_links"},
+		},
+		{
+			name:     "MostSevereWins",
+			text:     "Email hr@example.com and pay a fee via western union",
+			decision: DecisionRejected,
+			rules:    []string{"contact_info", "scam_patterns", "scam_patterns"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := moderator.Moderate(Field{Name: "description", Text: tc.text})
+			require.Equal(t, tc.decision, result.Decision)
+			require.Len(t, result.Findings, len(tc.rules))
+			for i, finding := range result.Findings {
+				require.Equal(t, tc.rules[i], finding.Rule)
+				require.Equal(t, "description", finding.Field)
+				require.NotEmpty(t, finding.Reason)
+			}
+		})
+	}
+}
+
+func TestModerateMultipleFields(t *testing.T) {
+	moderator := NewDefaultModerator(nil)
+
+	result := moderator.Moderate(
+		Field{Name: "title", Text: "Barista"},
+		Field{Name: "description", Text: "Text 917-555-0101 for details"},
+	)
+	require.Equal(t, DecisionNeedsReview, result.Decision)
+	require.Len(t, result.Findings, 1)
+	require.Equal(t, "description", result.Findings[0].Field)
+}
+
+func TestNewModeratorWithoutRules(t *testing.T) {
+	moderator := NewModerator()
+
+	result := moderator.Moderate(Field{Name: "description", Text: strings.Repeat("PAY A FEE ", 10)})
+	require.Equal(t, DecisionApproved, result.Decision)
+	require.Empty(t, result.Findings)
+}
@@ -0,0 +1,198 @@
+package moderation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// defaultBannedTerms are terms that are never allowed in a posting or
+// profile. More can be added through configuration.
+var defaultBannedTerms = []string{
+	"escort",
+	"sugar daddy",
+	"sugar baby",
+	"webcam model",
+}
+
+// DefaultRules are the rules applied to postings and profiles.
+func DefaultRules(bannedTerms []string) []Rule {
+	return []Rule{
+		BannedTerms(append(append([]string{}, defaultBannedTerms...), bannedTerms...)),
+		ContactInfo(),
+		ScamPatterns(),
+		ExcessiveCaps(0.5, 20),
+		ExcessiveLinks(2),
+	}
+}
+
+type pattern struct {
+	re       *regexp.Regexp
+	decision Decision
+	reason   string
+}
+
+type patternRule struct {
+	name     string
+	patterns []pattern
+}
+
+func (r *patternRule) Name() string {
+	return r.name
+}
+
+func (r *patternRule) Check(field Field) []Finding {
+	var findings []Finding
+	for _, p := range r.patterns {
+		if match := p.re.FindString(field.Text); match != "" {
+			findings = append(findings, Finding{
+				Rule:     r.name,
+				Field:    field.Name,
+				Decision: p.decision,
+				Reason:   fmt.Sprintf("%s: %q", p.reason, match),
+			})
+		}
+	}
+	return findings
+}
+
+// BannedTerms rejects text containing any of the terms as whole words,
+// ignoring case.
+func BannedTerms(terms []string) Rule {
+	rule := &patternRule{name: "banned_terms"}
+	for _, term := range terms {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		rule.patterns = append(rule.patterns, pattern{
+			re:       regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(term) + `\b`),
+			decision: DecisionRejected,
+			reason:   "banned term",
+		})
+	}
+	return rule
+}
+
+// ContactInfo flags email addresses, phone numbers and social security
+// numbers. Postings are meant to be applied to through the app, so contact
+// details in the text are a common way of taking candidates off platform.
+func ContactInfo() Rule {
+	return &patternRule{
+		name: "contact_info",
+		patterns: []pattern{
+			{
+				re:       regexp.MustCompile(`(?i)[a-z0-9._%+\-]+@[a-z0-9.\-]+\.[a-z]{2,}`),
+				decision: DecisionNeedsReview,
+				reason:   "email address",
+			},
+			{
+				re:       regexp.MustCompile(`(?:\+?1[\s.\-]?)?\(?\b\d{3}\)?[\s.\-]?\d{3}[\s.\-]?\d{4}\b`),
+				decision: DecisionNeedsReview,
+				reason:   "phone number",
+			},
+			{
+				re:       regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`),
+				decision: DecisionNeedsReview,
+				reason:   "social security number",
+			},
+		},
+	}
+}
+
+// ScamPatterns rejects text asking candidates for money up front and flags
+// payment methods commonly used in job scams.
+func ScamPatterns() Rule {
+	return &patternRule{
+		name: "scam_patterns",
+		patterns: []pattern{
+			{
+				re:       regexp.MustCompile(`(?i)\bpay\s+(?:a|an|the|your)?\s*(?:small\s+|one[\s\-]time\s+)?(?:fee|deposit)\b`),
+				decision: DecisionRejected,
+				reason:   "asks for payment",
+			},
+			{
+				re:       regexp.MustCompile(`(?i)\b(?:registration|training|application|processing|starter kit)\s+fee\b`),
+				decision: DecisionRejected,
+				reason:   "asks for payment",
+			},
+			{
+				re:       regexp.MustCompile(`(?i)\b(?:western union|moneygram|wire transfer)\b`),
+				decision: DecisionRejected,
+				reason:   "untraceable payment",
+			},
+			{
+				re:       regexp.MustCompile(`(?i)\b(?:gift cards?|cashier'?s checks?|bitcoin|crypto(?:currency)?)\b`),
+				decision: DecisionNeedsReview,
+				reason:   "unusual payment method",
+			},
+		},
+	}
+}
+
+type capsRule struct {
+	maxRatio   float64
+	minLetters int
+}
+
+// ExcessiveCaps flags text where more than maxRatio of the letters are
+// upper case. Text with fewer than minLetters letters is ignored so short
+// acronyms don't trip it.
+func ExcessiveCaps(maxRatio float64, minLetters int) Rule {
+	return &capsRule{maxRatio: maxRatio, minLetters: minLetters}
+}
+
+func (r *capsRule) Name() string {
+	return "excessive_caps"
+}
+
+func (r *capsRule) Check(field Field) []Finding {
+	letters, upper := 0, 0
+	for _, c := range field.Text {
+		if !unicode.IsLetter(c) {
+			continue
+		}
+		letters++
+		if unicode.IsUpper(c) {
+			upper++
+		}
+	}
+	if letters < r.minLetters || float64(upper)/float64(letters) <= r.maxRatio {
+		return nil
+	}
+	return []Finding{{
+		Rule:     r.Name(),
+		Field:    field.Name,
+		Decision: DecisionNeedsReview,
+		Reason:   fmt.Sprintf("%d of %d letters are upper case", upper, letters),
+	}}
+}
+
+var linkPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)\S+`)
+
+type linksRule struct {
+	max int
+}
+
+// ExcessiveLinks flags text with more than max links.
+func ExcessiveLinks(max int) Rule {
+	return &linksRule{max: max}
+}
+
+func (r *linksRule) Name() string {
+	return "excessive_links"
+}
+
+func (r *linksRule) Check(field Field) []Finding {
+	links := linkPattern.FindAllString(field.Text, -1)
+	if len(links) <= r.max {
+		return nil
+	}
+	return []Finding{{
+		Rule:     r.Name(),
+		Field:    field.Name,
+		Decision: DecisionNeedsReview,
+		Reason:   fmt.Sprintf("%d links", len(links)),
+	}}
+}
@@ -0,0 +1,89 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSVWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(FormatCSV, &buf, "ignored")
+	require.NoError(t, err)
+	require.NoError(t, w.WriteRow([]string{"name", "answer"}))
+	require.NoError(t, w.WriteRow([]string{"Ann, Jr.", "=HYPERLINK(\"x\")"}))
+	require.NoError(t, w.WriteRow([]string{"-12.5", "@sum"}))
+	require.NoError(t, w.Close())
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, [][]string{
+		{"name", "answer"},
+		{"Ann, Jr.", "'=HYPERLINK(\"x\")"},
+		{"-12.5", "'@sum"},
+	}, rows)
+}
+
+type xlsxSheet struct {
+	Rows []struct {
+		R     int `xml:"r,attr"`
+		Cells []struct {
+			R    string `xml:"r,attr"`
+			Text string `xml:"is>t"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+func TestXLSXWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(FormatXLSX, &buf, "Applicants: [Barista]")
+	require.NoError(t, err)
+	header := make([]string, 28)
+	for i := range header {
+		header[i] = columnName(i)
+	}
+	require.NoError(t, w.WriteRow(header))
+	require.NoError(t, w.Flush())
+	require.NoError(t, w.WriteRow([]string{"<b>Tom & Jerry</b>", "line\nbreak\x01"}))
+	require.NoError(t, w.Close())
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	parts := make(map[string][]byte)
+	for _, f := range archive.File {
+		r, err := f.Open()
+		require.NoError(t, err)
+		parts[f.Name], err = io.ReadAll(r)
+		require.NoError(t, err)
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels"} {
+		require.Contains(t, parts, name)
+	}
+	require.Contains(t, string(parts["xl/workbook.xml"]), `name="Applicants Barista"`)
+
+	var sheet xlsxSheet
+	require.NoError(t, xml.Unmarshal(parts["xl/worksheets/sheet1.xml"], &sheet))
+	require.Len(t, sheet.Rows, 2)
+	require.Equal(t, 1, sheet.Rows[0].R)
+	require.Len(t, sheet.Rows[0].Cells, 28)
+	require.Equal(t, "AB1", sheet.Rows[0].Cells[27].R)
+	require.Equal(t, "AB", sheet.Rows[0].Cells[27].Text)
+	require.Equal(t, "B2", sheet.Rows[1].Cells[1].R)
+	require.Equal(t, "<b>Tom & Jerry</b>", sheet.Rows[1].Cells[0].Text)
+	require.Equal(t, "line\nbreak", sheet.Rows[1].Cells[1].Text)
+}
+
+func TestUnsupportedFormat(t *testing.T) {
+	_, err := NewWriter(Format("ods"), io.Discard, "")
+	require.Error(t, err)
+}
+
+func TestSheetName(t *testing.T) {
+	require.Equal(t, "Sheet1", sheetName("[]"))
+	require.Equal(t, 31, len(sheetName("Applications from 2024-01-01 to 2024-02-01")))
+}
@@ -23,6 +23,9 @@ type Config struct {
 	S3AccessKey          string        `mapstructure:"S3_ACCESS_KEY"`
 	S3SecretKey          string        `mapstructure:"S3_SECRET_KEY"`
 	S3Bucket             string        `mapstructure:"S3_BUCKET"`
+	BannedTerms          []string      `mapstructure:"BANNED_TERMS"`
+	InvitationDuration   time.Duration `mapstructure:"INVITATION_DURATION"`
+	ReapplyCooldown      time.Duration `mapstructure:"REAPPLY_COOLDOWN"`
 }
 
 func LoadConfig(path string) (config Config, err error) {
@@ -60,6 +60,76 @@ type TaskDistributor interface {
 		payload *PayloadDeleteApplication,
 		opts ...asynq.Option,
 	) error
+	DistributeTaskNotifyJobClosed(
+		ctx context.Context,
+		payload *PayloadNotifyJobClosed,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskSendNotificationEmail(
+		ctx context.Context,
+		payload *PayloadSendNotificationEmail,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskNotifyApplicationDecision(
+		ctx context.Context,
+		payload *PayloadNotifyApplicationDecision,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskNotifyApplicationDecisions(
+		ctx context.Context,
+		payload *PayloadNotifyApplicationDecisions,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskNotifyApplicationReceived(
+		ctx context.Context,
+		payload *PayloadNotifyApplicationReceived,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskNotifyApplicationWithdrawn(
+		ctx context.Context,
+		payload *PayloadNotifyApplicationWithdrawn,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskNotifyJobInvitation(
+		ctx context.Context,
+		payload *PayloadNotifyJobInvitation,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskApplyJobSchedule(
+		ctx context.Context,
+		payload *PayloadApplyJobSchedule,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskEnrichJobPlace(
+		ctx context.Context,
+		payload *PayloadEnrichJobPlace,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskIncrementJobStats(
+		ctx context.Context,
+		payload *PayloadIncrementJobStats,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskSyncJobShifts(
+		ctx context.Context,
+		payload *PayloadSyncJobShifts,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskNotifyInterview(
+		ctx context.Context,
+		payload *PayloadNotifyInterview,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskSendInterviewReminder(
+		ctx context.Context,
+		payload *PayloadSendInterviewReminder,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskGeocodeLocation(
+		ctx context.Context,
+		payload *PayloadGeocodeLocation,
+		opts ...asynq.Option,
+	) error
 }
 
 type RedisTaskDistributor struct {
@@ -55,6 +55,25 @@ func (mr *MockTaskDistributorMockRecorder) DistributeTaskAddPastExperience(arg0,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskAddPastExperience", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskAddPastExperience), varargs...)
 }
 
+// DistributeTaskApplyJobSchedule mocks base method.
+func (m *MockTaskDistributor) DistributeTaskApplyJobSchedule(arg0 context.Context, arg1 *worker.PayloadApplyJobSchedule, arg2 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskApplyJobSchedule", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskApplyJobSchedule indicates an expected call of DistributeTaskApplyJobSchedule.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskApplyJobSchedule(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskApplyJobSchedule", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskApplyJobSchedule), varargs...)
+}
+
 // DistributeTaskCreateCandidate mocks base method.
 func (m *MockTaskDistributor) DistributeTaskCreateCandidate(arg0 context.Context, arg1 *worker.PayloadCandidate, arg2 ...asynq.Option) error {
 	m.ctrl.T.Helper()
@@ -169,6 +188,234 @@ func (mr *MockTaskDistributorMockRecorder) DistributeTaskDeletePastExperience(ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskDeletePastExperience", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskDeletePastExperience), varargs...)
 }
 
+// DistributeTaskEnrichJobPlace mocks base method.
+func (m *MockTaskDistributor) DistributeTaskEnrichJobPlace(arg0 context.Context, arg1 *worker.PayloadEnrichJobPlace, arg2 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskEnrichJobPlace", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskEnrichJobPlace indicates an expected call of DistributeTaskEnrichJobPlace.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskEnrichJobPlace(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskEnrichJobPlace", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskEnrichJobPlace), varargs...)
+}
+
+// DistributeTaskGeocodeLocation mocks base method.
+func (m *MockTaskDistributor) DistributeTaskGeocodeLocation(arg0 context.Context, arg1 *worker.PayloadGeocodeLocation, arg2 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskGeocodeLocation", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskGeocodeLocation indicates an expected call of DistributeTaskGeocodeLocation.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskGeocodeLocation(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskGeocodeLocation", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskGeocodeLocation), varargs...)
+}
+
+// DistributeTaskIncrementJobStats mocks base method.
+func (m *MockTaskDistributor) DistributeTaskIncrementJobStats(arg0 context.Context, arg1 *worker.PayloadIncrementJobStats, arg2 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskIncrementJobStats", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskIncrementJobStats indicates an expected call of DistributeTaskIncrementJobStats.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskIncrementJobStats(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskIncrementJobStats", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskIncrementJobStats), varargs...)
+}
+
+// DistributeTaskNotifyApplicationDecision mocks base method.
+func (m *MockTaskDistributor) DistributeTaskNotifyApplicationDecision(arg0 context.Context, arg1 *worker.PayloadNotifyApplicationDecision, arg2 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskNotifyApplicationDecision", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskNotifyApplicationDecision indicates an expected call of DistributeTaskNotifyApplicationDecision.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskNotifyApplicationDecision(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskNotifyApplicationDecision", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskNotifyApplicationDecision), varargs...)
+}
+
+// DistributeTaskNotifyApplicationDecisions mocks base method.
+func (m *MockTaskDistributor) DistributeTaskNotifyApplicationDecisions(arg0 context.Context, arg1 *worker.PayloadNotifyApplicationDecisions, arg2 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskNotifyApplicationDecisions", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskNotifyApplicationDecisions indicates an expected call of DistributeTaskNotifyApplicationDecisions.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskNotifyApplicationDecisions(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskNotifyApplicationDecisions", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskNotifyApplicationDecisions), varargs...)
+}
+
+// DistributeTaskNotifyApplicationReceived mocks base method.
+func (m *MockTaskDistributor) DistributeTaskNotifyApplicationReceived(arg0 context.Context, arg1 *worker.PayloadNotifyApplicationReceived, arg2 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskNotifyApplicationReceived", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskNotifyApplicationReceived indicates an expected call of DistributeTaskNotifyApplicationReceived.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskNotifyApplicationReceived(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskNotifyApplicationReceived", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskNotifyApplicationReceived), varargs...)
+}
+
+// DistributeTaskNotifyApplicationWithdrawn mocks base method.
+func (m *MockTaskDistributor) DistributeTaskNotifyApplicationWithdrawn(arg0 context.Context, arg1 *worker.PayloadNotifyApplicationWithdrawn, arg2 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskNotifyApplicationWithdrawn", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskNotifyApplicationWithdrawn indicates an expected call of DistributeTaskNotifyApplicationWithdrawn.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskNotifyApplicationWithdrawn(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskNotifyApplicationWithdrawn", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskNotifyApplicationWithdrawn), varargs...)
+}
+
+// DistributeTaskNotifyInterview mocks base method.
+func (m *MockTaskDistributor) DistributeTaskNotifyInterview(arg0 context.Context, arg1 *worker.PayloadNotifyInterview, arg2 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskNotifyInterview", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskNotifyInterview indicates an expected call of DistributeTaskNotifyInterview.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskNotifyInterview(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskNotifyInterview", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskNotifyInterview), varargs...)
+}
+
+// DistributeTaskNotifyJobClosed mocks base method.
+func (m *MockTaskDistributor) DistributeTaskNotifyJobClosed(arg0 context.Context, arg1 *worker.PayloadNotifyJobClosed, arg2 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskNotifyJobClosed", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskNotifyJobClosed indicates an expected call of DistributeTaskNotifyJobClosed.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskNotifyJobClosed(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskNotifyJobClosed", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskNotifyJobClosed), varargs...)
+}
+
+// DistributeTaskNotifyJobInvitation mocks base method.
+func (m *MockTaskDistributor) DistributeTaskNotifyJobInvitation(arg0 context.Context, arg1 *worker.PayloadNotifyJobInvitation, arg2 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskNotifyJobInvitation", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskNotifyJobInvitation indicates an expected call of DistributeTaskNotifyJobInvitation.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskNotifyJobInvitation(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskNotifyJobInvitation", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskNotifyJobInvitation), varargs...)
+}
+
+// DistributeTaskSendInterviewReminder mocks base method.
+func (m *MockTaskDistributor) DistributeTaskSendInterviewReminder(arg0 context.Context, arg1 *worker.PayloadSendInterviewReminder, arg2 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskSendInterviewReminder", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskSendInterviewReminder indicates an expected call of DistributeTaskSendInterviewReminder.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskSendInterviewReminder(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskSendInterviewReminder", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskSendInterviewReminder), varargs...)
+}
+
+// DistributeTaskSendNotificationEmail mocks base method.
+func (m *MockTaskDistributor) DistributeTaskSendNotificationEmail(arg0 context.Context, arg1 *worker.PayloadSendNotificationEmail, arg2 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskSendNotificationEmail", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskSendNotificationEmail indicates an expected call of DistributeTaskSendNotificationEmail.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskSendNotificationEmail(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskSendNotificationEmail", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskSendNotificationEmail), varargs...)
+}
+
 // DistributeTaskSendVerifyEmail mocks base method.
 func (m *MockTaskDistributor) DistributeTaskSendVerifyEmail(arg0 context.Context, arg1 *worker.PayloadSendVerifyEmail, arg2 ...asynq.Option) error {
 	m.ctrl.T.Helper()
@@ -188,6 +435,25 @@ func (mr *MockTaskDistributorMockRecorder) DistributeTaskSendVerifyEmail(arg0, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskSendVerifyEmail", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskSendVerifyEmail), varargs...)
 }
 
+// DistributeTaskSyncJobShifts mocks base method.
+func (m *MockTaskDistributor) DistributeTaskSyncJobShifts(arg0 context.Context, arg1 *worker.PayloadSyncJobShifts, arg2 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskSyncJobShifts", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskSyncJobShifts indicates an expected call of DistributeTaskSyncJobShifts.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskSyncJobShifts(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskSyncJobShifts", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskSyncJobShifts), varargs...)
+}
+
 // DistributeTaskUpdateCandidate mocks base method.
 func (m *MockTaskDistributor) DistributeTaskUpdateCandidate(arg0 context.Context, arg1 *worker.PayloadCandidate, arg2 ...asynq.Option) error {
 	m.ctrl.T.Helper()
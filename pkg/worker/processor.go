@@ -9,12 +9,16 @@ import (
 
 	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
 	"github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
+	"github.com/hankimmy/PtmrBackend/pkg/google"
 	"github.com/hankimmy/PtmrBackend/pkg/mail"
 )
 
 const (
 	QueueCritical = "critical"
 	QueueDefault  = "default"
+	// QueueMail holds tasks that send email. Only processors with a mailer
+	// consume it, so services without one never pick those tasks up.
+	QueueMail = "mail"
 )
 
 type TaskProcessor interface {
@@ -30,26 +34,49 @@ type TaskProcessor interface {
 	ProcessTaskCreateEmployerApplication(ctx context.Context, task *asynq.Task) error
 	ProcessTaskDeleteCandidateApplication(ctx context.Context, task *asynq.Task) error
 	ProcessTaskDeleteEmployerApplication(ctx context.Context, task *asynq.Task) error
+	ProcessTaskNotifyJobClosed(ctx context.Context, task *asynq.Task) error
+	ProcessTaskSendNotificationEmail(ctx context.Context, task *asynq.Task) error
+	ProcessTaskNotifyApplicationDecision(ctx context.Context, task *asynq.Task) error
+	ProcessTaskNotifyApplicationDecisions(ctx context.Context, task *asynq.Task) error
+	ProcessTaskNotifyApplicationReceived(ctx context.Context, task *asynq.Task) error
+	ProcessTaskNotifyApplicationWithdrawn(ctx context.Context, task *asynq.Task) error
+	ProcessTaskNotifyJobInvitation(ctx context.Context, task *asynq.Task) error
+	ProcessTaskApplyJobSchedule(ctx context.Context, task *asynq.Task) error
+	ProcessTaskSweepJobSchedules(ctx context.Context, task *asynq.Task) error
+	ProcessTaskEnrichJobPlace(ctx context.Context, task *asynq.Task) error
+	ProcessTaskRefreshJobPlaces(ctx context.Context, task *asynq.Task) error
+	ProcessTaskIncrementJobStats(ctx context.Context, task *asynq.Task) error
+	ProcessTaskSyncJobShifts(ctx context.Context, task *asynq.Task) error
+	ProcessTaskNotifyInterview(ctx context.Context, task *asynq.Task) error
+	ProcessTaskSendInterviewReminder(ctx context.Context, task *asynq.Task) error
+	ProcessTaskGeocodeLocation(ctx context.Context, task *asynq.Task) error
 }
 
 type RedisTaskProcessor struct {
-	server   *asynq.Server
-	store    db.Store
-	esClient elasticsearch.ESClient
-	mailer   mail.EmailSender
+	server      *asynq.Server
+	store       db.Store
+	esClient    elasticsearch.ESClient
+	mailer      mail.EmailSender
+	gapi        google.GAPI
+	distributor TaskDistributor
 }
 
-func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store, esClient elasticsearch.ESClient, mailer mail.EmailSender) TaskProcessor {
+func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store, esClient elasticsearch.ESClient, mailer mail.EmailSender,
+	gapi google.GAPI) TaskProcessor {
 	logger := NewLogger()
 	redis.SetLogger(logger)
 
+	queues := map[string]int{
+		QueueCritical: 10,
+		QueueDefault:  5,
+	}
+	if mailer != nil {
+		queues[QueueMail] = 5
+	}
 	server := asynq.NewServer(
 		redisOpt,
 		asynq.Config{
-			Queues: map[string]int{
-				QueueCritical: 10,
-				QueueDefault:  5,
-			},
+			Queues: queues,
 			ErrorHandler: asynq.ErrorHandlerFunc(func(ctx context.Context, task *asynq.Task, err error) {
 				log.Error().Err(err).Str("type", task.Type()).
 					Bytes("payload", task.Payload()).Msg("process task failed")
@@ -59,10 +86,12 @@ func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store, esClie
 	)
 
 	return &RedisTaskProcessor{
-		server:   server,
-		store:    store,
-		esClient: esClient,
-		mailer:   mailer,
+		server:      server,
+		store:       store,
+		esClient:    esClient,
+		mailer:      mailer,
+		gapi:        gapi,
+		distributor: NewRedisTaskDistributor(redisOpt),
 	}
 }
 
@@ -79,6 +108,22 @@ func (processor *RedisTaskProcessor) Start() error {
 	mux.HandleFunc(TaskCreateEmployerApp, processor.ProcessTaskCreateEmployerApplication)
 	mux.HandleFunc(TaskDeleteCandidateApp, processor.ProcessTaskDeleteCandidateApplication)
 	mux.HandleFunc(TaskDeleteEmployerApp, processor.ProcessTaskDeleteEmployerApplication)
+	mux.HandleFunc(TaskNotifyJobClosed, processor.ProcessTaskNotifyJobClosed)
+	mux.HandleFunc(TaskSendNotificationEmail, processor.ProcessTaskSendNotificationEmail)
+	mux.HandleFunc(TaskNotifyApplicationDecision, processor.ProcessTaskNotifyApplicationDecision)
+	mux.HandleFunc(TaskNotifyApplicationDecisions, processor.ProcessTaskNotifyApplicationDecisions)
+	mux.HandleFunc(TaskNotifyApplicationReceived, processor.ProcessTaskNotifyApplicationReceived)
+	mux.HandleFunc(TaskNotifyApplicationWithdrawn, processor.ProcessTaskNotifyApplicationWithdrawn)
+	mux.HandleFunc(TaskNotifyJobInvitation, processor.ProcessTaskNotifyJobInvitation)
+	mux.HandleFunc(TaskApplyJobSchedule, processor.ProcessTaskApplyJobSchedule)
+	mux.HandleFunc(TaskSweepJobSchedules, processor.ProcessTaskSweepJobSchedules)
+	mux.HandleFunc(TaskEnrichJobPlace, processor.ProcessTaskEnrichJobPlace)
+	mux.HandleFunc(TaskRefreshJobPlaces, processor.ProcessTaskRefreshJobPlaces)
+	mux.HandleFunc(TaskIncrementJobStats, processor.ProcessTaskIncrementJobStats)
+	mux.HandleFunc(TaskSyncJobShifts, processor.ProcessTaskSyncJobShifts)
+	mux.HandleFunc(TaskNotifyInterview, processor.ProcessTaskNotifyInterview)
+	mux.HandleFunc(TaskSendInterviewReminder, processor.ProcessTaskSendInterviewReminder)
+	mux.HandleFunc(TaskGeocodeLocation, processor.ProcessTaskGeocodeLocation)
 
 	return processor.server.Start(mux)
 }
@@ -0,0 +1,61 @@
+package worker
+
+import (
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// jobScheduleSweepSpec is how often the job schedule sweeper runs. Delayed
+	// tasks do the real work; the sweep only catches what they missed.
+	jobScheduleSweepSpec = "@every 5m"
+	// jobPlaceRefreshSpec is how often stale Google Places data is refreshed.
+	jobPlaceRefreshSpec = "@every 1h"
+)
+
+var periodicTasks = []struct {
+	spec     string
+	taskType string
+}{
+	{spec: jobScheduleSweepSpec, taskType: TaskSweepJobSchedules},
+	{spec: jobPlaceRefreshSpec, taskType: TaskRefreshJobPlaces},
+}
+
+type TaskScheduler interface {
+	Start() error
+	Shutdown()
+}
+
+type RedisTaskScheduler struct {
+	scheduler *asynq.Scheduler
+}
+
+func NewRedisTaskScheduler(redisOpt asynq.RedisClientOpt) TaskScheduler {
+	scheduler := asynq.NewScheduler(redisOpt, &asynq.SchedulerOpts{
+		Logger: NewLogger(),
+		PostEnqueueFunc: func(info *asynq.TaskInfo, err error) {
+			if err != nil {
+				log.Error().Err(err).Msg("failed to enqueue periodic task")
+			}
+		},
+	})
+	return &RedisTaskScheduler{
+		scheduler: scheduler,
+	}
+}
+
+func (s *RedisTaskScheduler) Start() error {
+	for _, periodic := range periodicTasks {
+		task := asynq.NewTask(periodic.taskType, nil)
+		if _, err := s.scheduler.Register(periodic.spec, task, asynq.Queue(QueueDefault), asynq.MaxRetry(0)); err != nil {
+			return fmt.Errorf("failed to register %s: %w", periodic.taskType, err)
+		}
+	}
+	return s.scheduler.Start()
+}
+
+func (s *RedisTaskScheduler) Shutdown() {
+	s.scheduler.Shutdown()
+}
@@ -28,7 +28,7 @@ type PayloadDeleteApplication struct {
 func (processor *RedisTaskProcessor) processCreateApplicationTask(
 	ctx context.Context,
 	task *asynq.Task,
-	payload PayloadCreateApplication,
+	payload *PayloadCreateApplication,
 	processFunc func(context.Context, string, map[string]interface{}) error,
 ) error {
 	if err := json.Unmarshal(task.Payload(), payload); err != nil {
@@ -47,7 +47,7 @@ func (processor *RedisTaskProcessor) processCreateApplicationTask(
 func (processor *RedisTaskProcessor) processDeleteApplicationTask(
 	ctx context.Context,
 	task *asynq.Task,
-	payload PayloadDeleteApplication,
+	payload *PayloadDeleteApplication,
 	processFunc func(context.Context, string) error,
 ) error {
 	if err := json.Unmarshal(task.Payload(), payload); err != nil {
@@ -96,20 +96,20 @@ func (distributor *RedisTaskDistributor) DistributeTaskDeleteEmployerApplication
 
 func (processor *RedisTaskProcessor) ProcessTaskCreateCandidateApplication(ctx context.Context, task *asynq.Task) error {
 	var payload PayloadCreateApplication
-	return processor.processCreateApplicationTask(ctx, task, payload, processor.esClient.IndexCandidateApplication)
+	return processor.processCreateApplicationTask(ctx, task, &payload, processor.esClient.IndexCandidateApplication)
 }
 
 func (processor *RedisTaskProcessor) ProcessTaskCreateEmployerApplication(ctx context.Context, task *asynq.Task) error {
 	var payload PayloadCreateApplication
-	return processor.processCreateApplicationTask(ctx, task, payload, processor.esClient.IndexEmployerApplication)
+	return processor.processCreateApplicationTask(ctx, task, &payload, processor.esClient.IndexEmployerApplication)
 }
 
 func (processor *RedisTaskProcessor) ProcessTaskDeleteCandidateApplication(ctx context.Context, task *asynq.Task) error {
 	var payload PayloadDeleteApplication
-	return processor.processDeleteApplicationTask(ctx, task, payload, processor.esClient.DeleteCandidateApplication)
+	return processor.processDeleteApplicationTask(ctx, task, &payload, processor.esClient.DeleteCandidateApplication)
 }
 
 func (processor *RedisTaskProcessor) ProcessTaskDeleteEmployerApplication(ctx context.Context, task *asynq.Task) error {
 	var payload PayloadDeleteApplication
-	return processor.processDeleteApplicationTask(ctx, task, payload, processor.esClient.DeleteEmployerApplication)
+	return processor.processDeleteApplicationTask(ctx, task, &payload, processor.esClient.DeleteEmployerApplication)
 }
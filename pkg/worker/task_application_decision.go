@@ -0,0 +1,217 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+)
+
+const (
+	TaskNotifyApplicationDecision  = "task:notify_application_decision"
+	TaskNotifyApplicationDecisions = "task:notify_application_decisions"
+)
+
+// PayloadNotifyApplicationDecision tells the sender of an application that
+// the other party accepted or rejected it.
+type PayloadNotifyApplicationDecision struct {
+	Kind        db.ApplicationKind   `json:"kind"`
+	CandidateID int64                `json:"candidate_id"`
+	EmployerID  int64                `json:"employer_id"`
+	JobID       string               `json:"job_id,omitempty"`
+	Status      db.ApplicationStatus `json:"status"`
+	Reason      string               `json:"reason,omitempty"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskNotifyApplicationDecision(
+	ctx context.Context,
+	payload *PayloadNotifyApplicationDecision,
+	opts ...asynq.Option,
+) error {
+	return distributor.distributeTask(ctx, TaskNotifyApplicationDecision, payload, opts...)
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskNotifyApplicationDecision(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadNotifyApplicationDecision
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+	if payload.CandidateID == 0 || payload.EmployerID == 0 ||
+		(payload.Status != db.ApplicationStatusAccepted && payload.Status != db.ApplicationStatusRejected) {
+		return fmt.Errorf("invalid application decision payload: %w", asynq.SkipRetry)
+	}
+	if processor.mailer == nil {
+		return errors.New("no mailer configured to notify application decisions")
+	}
+
+	candidate, err := processor.store.GetCandidate(ctx, payload.CandidateID)
+	if err != nil {
+		return decisionLookupError("candidate", err)
+	}
+	employer, err := processor.store.GetEmployer(ctx, payload.EmployerID)
+	if err != nil {
+		return decisionLookupError("employer", err)
+	}
+
+	// The notification goes to whoever sent the application.
+	recipient := candidate.Username
+	if payload.Kind == db.ApplicationKindEmployer {
+		recipient = employer.Username
+	}
+	preference, err := processor.notificationPreference(ctx, recipient)
+	if err != nil {
+		return err
+	}
+	if !preference.ApplicationDecisions {
+		log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+			Msg("skipped task, recipient turned off application decision notifications")
+		return nil
+	}
+
+	var to, subject, content string
+	if payload.Kind == db.ApplicationKindEmployer {
+		// The candidate answered the employer's application.
+		to = employer.BusinessEmail
+		subject = fmt.Sprintf("%s %s your application", candidate.FullName, payload.Status)
+		content = applicationDecisionEmailContent(employer.BusinessName, candidate.FullName, "your application", payload)
+	} else {
+		user, err := processor.store.GetUser(ctx, candidate.Username)
+		if err != nil {
+			return decisionLookupError("candidate user", err)
+		}
+		position := "your application"
+		if payload.JobID != "" {
+			if job, err := processor.esClient.GetJob(payload.JobID); err == nil {
+				position = fmt.Sprintf("your application for %s", job.Title)
+			}
+		}
+		to = user.Email
+		subject = fmt.Sprintf("Update on your application to %s", employer.BusinessName)
+		content = applicationDecisionEmailContent(candidate.FullName, employer.BusinessName, position, payload)
+	}
+
+	if err := processor.mailer.SendEmail(subject, content, []string{to}, nil, nil, nil, nil); err != nil {
+		log.Error().Err(err).Msgf("failed to send application decision email to: %s", to)
+		return fmt.Errorf("failed to send application decision email: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Str("email", to).Msg("processed task")
+	return nil
+}
+
+// PayloadNotifyApplicationDecisions tells a batch of candidates that an
+// employer accepted or rejected their applications to the same job at once.
+type PayloadNotifyApplicationDecisions struct {
+	EmployerID   int64                `json:"employer_id"`
+	JobID        string               `json:"job_id"`
+	CandidateIDs []int64              `json:"candidate_ids"`
+	Status       db.ApplicationStatus `json:"status"`
+	Reason       string               `json:"reason,omitempty"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskNotifyApplicationDecisions(
+	ctx context.Context,
+	payload *PayloadNotifyApplicationDecisions,
+	opts ...asynq.Option,
+) error {
+	return distributor.distributeTask(ctx, TaskNotifyApplicationDecisions, payload, opts...)
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskNotifyApplicationDecisions(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadNotifyApplicationDecisions
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+	if payload.EmployerID == 0 || len(payload.CandidateIDs) == 0 ||
+		(payload.Status != db.ApplicationStatusAccepted && payload.Status != db.ApplicationStatusRejected) {
+		return fmt.Errorf("invalid application decisions payload: %w", asynq.SkipRetry)
+	}
+
+	employer, err := processor.store.GetEmployer(ctx, payload.EmployerID)
+	if err != nil {
+		return decisionLookupError("employer", err)
+	}
+	// Candidates who have since deleted their account, or who turned off
+	// decision notifications, are skipped.
+	candidates, err := processor.store.ListCandidateContacts(ctx, payload.CandidateIDs)
+	if err != nil {
+		return fmt.Errorf("failed to list candidates: %w", err)
+	}
+	position := "your application"
+	if payload.JobID != "" {
+		if job, err := processor.esClient.GetJob(payload.JobID); err == nil {
+			position = fmt.Sprintf("your application for %s", job.Title)
+		}
+	}
+
+	subject := fmt.Sprintf("Update on your application to %s", employer.BusinessName)
+	decision := PayloadNotifyApplicationDecision{
+		Kind:       db.ApplicationKindCandidate,
+		EmployerID: payload.EmployerID,
+		JobID:      payload.JobID,
+		Status:     payload.Status,
+		Reason:     payload.Reason,
+	}
+	emails := make([]PayloadSendNotificationEmail, 0, len(candidates))
+	for _, candidate := range candidates {
+		if !candidate.ApplicationDecisions {
+			continue
+		}
+		decision.CandidateID = candidate.ID
+		emails = append(emails, PayloadSendNotificationEmail{
+			To:      candidate.Email,
+			Subject: subject,
+			Content: applicationDecisionEmailContent(candidate.FullName, employer.BusinessName, position, decision),
+		})
+	}
+	if err := processor.fanOutNotificationEmails(ctx, emails); err != nil {
+		return err
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Int("notified", len(emails)).Msg("processed task")
+	return nil
+}
+
+// decisionLookupError stops retrying once an account is gone, since the
+// notification can never be sent.
+func decisionLookupError(account string, err error) error {
+	if errors.Is(err, db.ErrRecordNotFound) {
+		return fmt.Errorf("%s not found: %w", account, asynq.SkipRetry)
+	}
+	return fmt.Errorf("failed to get %s: %w", account, err)
+}
+
+// applicationDecisionEmailContent picks the template for the decision.
+func applicationDecisionEmailContent(name, decidedBy, position string, payload PayloadNotifyApplicationDecision) string {
+	reason := ""
+	if payload.Reason != "" {
+		reason = fmt.Sprintf("<p>They said: %s</p>", html.EscapeString(payload.Reason))
+	}
+	if payload.Status == db.ApplicationStatusAccepted {
+		return applicationAcceptedEmailContent(name, decidedBy, position, reason)
+	}
+	return applicationRejectedEmailContent(name, decidedBy, position, reason)
+}
+
+func applicationAcceptedEmailContent(name, decidedBy, position, reason string) string {
+	return fmt.Sprintf(`<p>Hi %s,</p>
+	<p>Good news! %s has accepted %s.</p>
+	%s
+	<p>You can now message each other in the app to talk about next steps.</p>
+	<p>&copy; 2024 Part-Timer. All rights reserved.</p>`, name, decidedBy, position, reason)
+}
+
+func applicationRejectedEmailContent(name, decidedBy, position, reason string) string {
+	return fmt.Sprintf(`<p>Hi %s,</p>
+	<p>%s has decided not to move forward with %s.</p>
+	%s
+	<p>&copy; 2024 Part-Timer. All rights reserved.</p>`, name, decidedBy, position, reason)
+}
@@ -0,0 +1,98 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+)
+
+const TaskNotifyApplicationReceived = "task:notify_application_received"
+
+// PayloadNotifyApplicationReceived tells an employer that a candidate applied
+// to one of the employer's jobs.
+type PayloadNotifyApplicationReceived struct {
+	CandidateID int64  `json:"candidate_id"`
+	EmployerID  int64  `json:"employer_id"`
+	JobID       string `json:"job_id"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskNotifyApplicationReceived(
+	ctx context.Context,
+	payload *PayloadNotifyApplicationReceived,
+	opts ...asynq.Option,
+) error {
+	return distributor.distributeTask(ctx, TaskNotifyApplicationReceived, payload, opts...)
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskNotifyApplicationReceived(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadNotifyApplicationReceived
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+	if payload.CandidateID == 0 || payload.EmployerID == 0 || payload.JobID == "" {
+		return fmt.Errorf("invalid application received payload: %w", asynq.SkipRetry)
+	}
+	if processor.mailer == nil {
+		return errors.New("no mailer configured to notify received applications")
+	}
+
+	employer, err := processor.store.GetEmployer(ctx, payload.EmployerID)
+	if err != nil {
+		return decisionLookupError("employer", err)
+	}
+	preference, err := processor.notificationPreference(ctx, employer.Username)
+	if err != nil {
+		return err
+	}
+	if !preference.ApplicationReceived {
+		log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+			Msg("skipped task, employer turned off application received notifications")
+		return nil
+	}
+	candidate, err := processor.store.GetCandidate(ctx, payload.CandidateID)
+	if err != nil {
+		return decisionLookupError("candidate", err)
+	}
+	position := "one of your jobs"
+	subject := fmt.Sprintf("New application from %s", candidate.FullName)
+	if job, err := processor.esClient.GetJob(payload.JobID); err == nil {
+		position = job.Title
+		subject = fmt.Sprintf("New application for %s", job.Title)
+	}
+
+	to := employer.BusinessEmail
+	content := applicationReceivedEmailContent(employer.BusinessName, candidate.FullName, position)
+	if err := processor.mailer.SendEmail(subject, content, []string{to}, nil, nil, nil, nil); err != nil {
+		log.Error().Err(err).Msgf("failed to send application received email to: %s", to)
+		return fmt.Errorf("failed to send application received email: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Str("email", to).Msg("processed task")
+	return nil
+}
+
+// notificationPreference returns what the user wants to be emailed about.
+func (processor *RedisTaskProcessor) notificationPreference(ctx context.Context, username string) (db.NotificationPreference, error) {
+	preference, err := processor.store.GetNotificationPreference(ctx, username)
+	if errors.Is(err, db.ErrRecordNotFound) {
+		return db.DefaultNotificationPreference(username), nil
+	}
+	if err != nil {
+		return preference, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+	return preference, nil
+}
+
+func applicationReceivedEmailContent(name, candidateName, position string) string {
+	return fmt.Sprintf(`<p>Hi %s,</p>
+	<p>%s has applied for %s.</p>
+	<p>Their profile and answers are waiting in your pipeline, where you can accept or reject the application.</p>
+	<p>&copy; 2024 Part-Timer. All rights reserved.</p>`, name, candidateName, position)
+}
@@ -0,0 +1,86 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+)
+
+const TaskNotifyApplicationWithdrawn = "task:notify_application_withdrawn"
+
+// PayloadNotifyApplicationWithdrawn tells an employer that a candidate
+// withdrew their application to one of the employer's jobs.
+type PayloadNotifyApplicationWithdrawn struct {
+	CandidateID int64               `json:"candidate_id"`
+	EmployerID  int64               `json:"employer_id"`
+	JobID       string              `json:"job_id"`
+	Reason      db.WithdrawalReason `json:"reason"`
+	Note        string              `json:"note,omitempty"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskNotifyApplicationWithdrawn(
+	ctx context.Context,
+	payload *PayloadNotifyApplicationWithdrawn,
+	opts ...asynq.Option,
+) error {
+	return distributor.distributeTask(ctx, TaskNotifyApplicationWithdrawn, payload, opts...)
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskNotifyApplicationWithdrawn(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadNotifyApplicationWithdrawn
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+	if payload.CandidateID == 0 || payload.EmployerID == 0 || payload.JobID == "" {
+		return fmt.Errorf("invalid application withdrawal payload: %w", asynq.SkipRetry)
+	}
+	if processor.mailer == nil {
+		return errors.New("no mailer configured to notify application withdrawals")
+	}
+
+	candidate, err := processor.store.GetCandidate(ctx, payload.CandidateID)
+	if err != nil {
+		return decisionLookupError("candidate", err)
+	}
+	employer, err := processor.store.GetEmployer(ctx, payload.EmployerID)
+	if err != nil {
+		return decisionLookupError("employer", err)
+	}
+	position := "one of your jobs"
+	if job, err := processor.esClient.GetJob(payload.JobID); err == nil {
+		position = job.Title
+	}
+
+	to := employer.BusinessEmail
+	subject := fmt.Sprintf("%s withdrew their application", candidate.FullName)
+	content := applicationWithdrawnEmailContent(employer.BusinessName, candidate.FullName, position, payload)
+	if err := processor.mailer.SendEmail(subject, content, []string{to}, nil, nil, nil, nil); err != nil {
+		log.Error().Err(err).Msgf("failed to send application withdrawal email to: %s", to)
+		return fmt.Errorf("failed to send application withdrawal email: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Str("email", to).Msg("processed task")
+	return nil
+}
+
+func applicationWithdrawnEmailContent(name, candidateName, position string, payload PayloadNotifyApplicationWithdrawn) string {
+	reason := strings.ReplaceAll(string(payload.Reason), "_", " ")
+	note := ""
+	if payload.Note != "" {
+		note = fmt.Sprintf("<p>They added: %s</p>", html.EscapeString(payload.Note))
+	}
+	return fmt.Sprintf(`<p>Hi %s,</p>
+	<p>%s has withdrawn their application for %s. Reason: %s.</p>
+	%s
+	<p>The application stays in your records, but no longer shows up in your pipeline.</p>
+	<p>&copy; 2024 Part-Timer. All rights reserved.</p>`, name, candidateName, position, reason, note)
+}
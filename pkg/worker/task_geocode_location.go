@@ -0,0 +1,66 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+)
+
+const TaskGeocodeLocation = "task:geocode_location"
+
+type PayloadGeocodeLocation struct {
+	Address string `json:"address"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskGeocodeLocation(
+	ctx context.Context,
+	payload *PayloadGeocodeLocation,
+	opts ...asynq.Option,
+) error {
+	return distributor.distributeTask(ctx, TaskGeocodeLocation, payload, opts...)
+}
+
+// ProcessTaskGeocodeLocation stores the coordinates of a profile address so
+// that readers such as applicant ranking can look them up instead of calling
+// the geocoder. Addresses that are already stored are left alone.
+func (processor *RedisTaskProcessor) ProcessTaskGeocodeLocation(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadGeocodeLocation
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+	address := strings.TrimSpace(payload.Address)
+	if address == "" {
+		return fmt.Errorf("empty address: %w", asynq.SkipRetry)
+	}
+
+	_, err := processor.store.GetGeocodedLocation(ctx, address)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, db.ErrRecordNotFound) {
+		return fmt.Errorf("failed to get geocoded location: %w", err)
+	}
+
+	lat, lon, err := processor.gapi.GetLatLon(address)
+	if err != nil {
+		return fmt.Errorf("failed to geocode address: %w", err)
+	}
+	_, err = processor.store.UpsertGeocodedLocation(ctx, db.UpsertGeocodedLocationParams{
+		Address:   address,
+		Latitude:  lat,
+		Longitude: lon,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store geocoded location: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).Msg("processed task")
+	return nil
+}
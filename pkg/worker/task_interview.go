@@ -0,0 +1,332 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	"github.com/hankimmy/PtmrBackend/pkg/mail"
+)
+
+const (
+	TaskNotifyInterview       = "task:notify_interview"
+	TaskSendInterviewReminder = "task:send_interview_reminder"
+)
+
+const interviewTimeFormat = "Monday, January 2 at 3:04 PM MST"
+
+// InterviewEvent is the change to an interview that the parties are told
+// about.
+type InterviewEvent string
+
+const (
+	InterviewEventProposed    InterviewEvent = "proposed"
+	InterviewEventScheduled   InterviewEvent = "scheduled"
+	InterviewEventRescheduled InterviewEvent = "rescheduled"
+	InterviewEventCancelled   InterviewEvent = "cancelled"
+)
+
+// PayloadNotifyInterview tells the parties of an interview that it changed.
+type PayloadNotifyInterview struct {
+	InterviewID int64          `json:"interview_id"`
+	Event       InterviewEvent `json:"event"`
+	Reason      string         `json:"reason,omitempty"`
+	// PreviousScheduledAt is set when a scheduled interview is rescheduled,
+	// so that the event already in the parties' calendars can be cancelled.
+	PreviousScheduledAt *time.Time `json:"previous_scheduled_at,omitempty"`
+}
+
+// PayloadSendInterviewReminder reminds both parties of an interview shortly
+// before it starts. Reminders for a time the interview is no longer
+// scheduled at are dropped.
+type PayloadSendInterviewReminder struct {
+	InterviewID int64         `json:"interview_id"`
+	ScheduledAt time.Time     `json:"scheduled_at"`
+	Lead        time.Duration `json:"lead"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskNotifyInterview(
+	ctx context.Context,
+	payload *PayloadNotifyInterview,
+	opts ...asynq.Option,
+) error {
+	return distributor.distributeTask(ctx, TaskNotifyInterview, payload, opts...)
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskSendInterviewReminder(
+	ctx context.Context,
+	payload *PayloadSendInterviewReminder,
+	opts ...asynq.Option,
+) error {
+	return distributor.distributeTask(ctx, TaskSendInterviewReminder, payload, opts...)
+}
+
+// interviewParties is everything the interview emails are written from.
+type interviewParties struct {
+	interview      db.Interview
+	candidate      db.Candidate
+	candidateEmail string
+	employer       db.Employer
+	position       string
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskNotifyInterview(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadNotifyInterview
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+	if payload.InterviewID == 0 {
+		return fmt.Errorf("invalid interview payload: %w", asynq.SkipRetry)
+	}
+	if processor.mailer == nil {
+		return errors.New("no mailer configured to notify interviews")
+	}
+
+	parties, err := processor.getInterviewParties(ctx, payload.InterviewID)
+	if err != nil {
+		return err
+	}
+	interview := parties.interview
+
+	switch payload.Event {
+	case InterviewEventProposed, InterviewEventRescheduled:
+		slots, err := processor.store.ListInterviewSlots(ctx, interview.ID)
+		if err != nil {
+			return fmt.Errorf("failed to list interview slots: %w", err)
+		}
+		var cancelled *mail.CalendarEvent
+		if payload.PreviousScheduledAt != nil {
+			event := parties.calendarEvent(*payload.PreviousScheduledAt)
+			event.Cancelled = true
+			cancelled = &event
+		}
+		subject := fmt.Sprintf("%s wants to interview you", parties.employer.BusinessName)
+		if payload.Event == InterviewEventRescheduled {
+			subject = fmt.Sprintf("%s rescheduled your interview", parties.employer.BusinessName)
+		}
+		content := fmt.Sprintf(`<p>Hi %s,</p>
+	<p>%s would like to interview you for %s. Pick one of these times in the app:</p>
+	<ul>%s</ul>
+	%s
+	<p>&copy; 2024 Part-Timer. All rights reserved.</p>`,
+			parties.candidate.FullName, parties.employer.BusinessName, parties.position, interviewSlotList(slots), interviewDetails(interview, ""))
+		if err := processor.sendInterviewEmail(subject, content, parties.candidateEmail, cancelled); err != nil {
+			return err
+		}
+		if cancelled != nil {
+			subject := fmt.Sprintf("Interview with %s was moved", parties.candidate.FullName)
+			content := fmt.Sprintf(`<p>Hi %s,</p>
+	<p>Your interview with %s on %s was removed from your calendar. They will pick one of the new times you offered.</p>
+	<p>&copy; 2024 Part-Timer. All rights reserved.</p>`,
+				parties.employer.BusinessName, parties.candidate.FullName, payload.PreviousScheduledAt.UTC().Format(interviewTimeFormat))
+			if err := processor.sendInterviewEmail(subject, content, parties.employer.BusinessEmail, cancelled); err != nil {
+				return err
+			}
+		}
+	case InterviewEventScheduled:
+		if interview.Status != db.InterviewStatusScheduled {
+			log.Info().Int64("interview_id", interview.ID).Msg("interview is no longer scheduled, skipping confirmation")
+			return nil
+		}
+		event := parties.calendarEvent(interview.ScheduledAt.Time)
+		when := interview.ScheduledAt.Time.UTC().Format(interviewTimeFormat)
+		for _, recipient := range parties.recipients() {
+			subject := fmt.Sprintf("Interview confirmed: %s", parties.position)
+			content := fmt.Sprintf(`<p>Hi %s,</p>
+	<p>Your interview with %s for %s is confirmed for %s.</p>
+	%s
+	<p>The attached invite adds it to your calendar.</p>
+	<p>&copy; 2024 Part-Timer. All rights reserved.</p>`,
+				recipient.name, recipient.otherParty, parties.position, when, interviewDetails(interview, ""))
+			if err := processor.sendInterviewEmail(subject, content, recipient.email, &event); err != nil {
+				return err
+			}
+		}
+	case InterviewEventCancelled:
+		var cancelled *mail.CalendarEvent
+		if interview.ScheduledAt.Valid {
+			event := parties.calendarEvent(interview.ScheduledAt.Time)
+			event.Cancelled = true
+			cancelled = &event
+		}
+		for _, recipient := range parties.recipients() {
+			subject := fmt.Sprintf("Interview cancelled: %s", parties.position)
+			content := fmt.Sprintf(`<p>Hi %s,</p>
+	<p>Your interview with %s for %s has been cancelled.</p>
+	%s
+	<p>&copy; 2024 Part-Timer. All rights reserved.</p>`,
+				recipient.name, recipient.otherParty, parties.position, interviewDetails(interview, payload.Reason))
+			if err := processor.sendInterviewEmail(subject, content, recipient.email, cancelled); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unknown interview event %q: %w", payload.Event, asynq.SkipRetry)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).Msg("processed task")
+	return nil
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskSendInterviewReminder(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendInterviewReminder
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+	if payload.InterviewID == 0 || payload.ScheduledAt.IsZero() {
+		return fmt.Errorf("invalid interview reminder payload: %w", asynq.SkipRetry)
+	}
+	if processor.mailer == nil {
+		return errors.New("no mailer configured to send interview reminders")
+	}
+
+	parties, err := processor.getInterviewParties(ctx, payload.InterviewID)
+	if err != nil {
+		return err
+	}
+	interview := parties.interview
+	// Reminders can't be taken back once enqueued, so the ones left over
+	// from a rescheduled or cancelled interview are dropped here.
+	if interview.Status != db.InterviewStatusScheduled || !interview.ScheduledAt.Time.Equal(payload.ScheduledAt) {
+		log.Info().Int64("interview_id", interview.ID).Msg("interview was moved, skipping stale reminder")
+		return nil
+	}
+
+	when := interview.ScheduledAt.Time.UTC().Format(interviewTimeFormat)
+	for _, recipient := range parties.recipients() {
+		subject := fmt.Sprintf("Reminder: interview in %s", reminderLead(payload.Lead))
+		content := fmt.Sprintf(`<p>Hi %s,</p>
+	<p>This is a reminder of your interview with %s for %s on %s.</p>
+	%s
+	<p>&copy; 2024 Part-Timer. All rights reserved.</p>`,
+			recipient.name, recipient.otherParty, parties.position, when, interviewDetails(interview, ""))
+		if err := processor.sendInterviewEmail(subject, content, recipient.email, nil); err != nil {
+			return err
+		}
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).Msg("processed task")
+	return nil
+}
+
+func (processor *RedisTaskProcessor) getInterviewParties(ctx context.Context, interviewID int64) (interviewParties, error) {
+	var parties interviewParties
+	var err error
+	parties.interview, err = processor.store.GetInterview(ctx, interviewID)
+	if err != nil {
+		return parties, decisionLookupError("interview", err)
+	}
+	parties.candidate, err = processor.store.GetCandidate(ctx, parties.interview.CandidateID)
+	if err != nil {
+		return parties, decisionLookupError("candidate", err)
+	}
+	user, err := processor.store.GetUser(ctx, parties.candidate.Username)
+	if err != nil {
+		return parties, decisionLookupError("candidate user", err)
+	}
+	parties.candidateEmail = user.Email
+	parties.employer, err = processor.store.GetEmployer(ctx, parties.interview.EmployerID)
+	if err != nil {
+		return parties, decisionLookupError("employer", err)
+	}
+	parties.position = "the position"
+	if job, err := processor.esClient.GetJob(parties.interview.JobDocID); err == nil {
+		parties.position = job.Title
+	}
+	return parties, nil
+}
+
+type interviewRecipient struct {
+	name       string
+	email      string
+	otherParty string
+}
+
+func (parties interviewParties) recipients() []interviewRecipient {
+	return []interviewRecipient{
+		{name: parties.candidate.FullName, email: parties.candidateEmail, otherParty: parties.employer.BusinessName},
+		{name: parties.employer.BusinessName, email: parties.employer.BusinessEmail, otherParty: parties.candidate.FullName},
+	}
+}
+
+// calendarEvent describes the interview at the given time. Every version of
+// an interview shares a UID so that calendars update the event in place.
+func (parties interviewParties) calendarEvent(startsAt time.Time) mail.CalendarEvent {
+	interview := parties.interview
+	return mail.CalendarEvent{
+		UID:         fmt.Sprintf("interview-%d@part-timer", interview.ID),
+		Sequence:    interview.Sequence,
+		Start:       startsAt,
+		End:         startsAt.Add(time.Duration(interview.DurationMinutes) * time.Minute),
+		Summary:     fmt.Sprintf("Interview: %s, %s and %s", parties.position, parties.employer.BusinessName, parties.candidate.FullName),
+		Description: interview.Notes,
+		Location:    interview.Location,
+		Organizer:   parties.employer.BusinessEmail,
+		Attendees:   []string{parties.candidateEmail, parties.employer.BusinessEmail},
+	}
+}
+
+// sendInterviewEmail sends one interview email, attaching the calendar event
+// when there is one.
+func (processor *RedisTaskProcessor) sendInterviewEmail(subject, content, to string, event *mail.CalendarEvent) error {
+	var attachments []string
+	if event != nil {
+		dir, err := os.MkdirTemp("", "interview-")
+		if err != nil {
+			return fmt.Errorf("failed to create calendar dir: %w", err)
+		}
+		defer os.RemoveAll(dir)
+		path, err := mail.WriteCalendarFile(dir, *event, time.Now())
+		if err != nil {
+			return err
+		}
+		attachments = []string{path}
+	}
+	if err := processor.mailer.SendEmail(subject, content, []string{to}, nil, nil, attachments, nil); err != nil {
+		log.Error().Err(err).Msgf("failed to send interview email to: %s", to)
+		return fmt.Errorf("failed to send interview email: %w", err)
+	}
+	return nil
+}
+
+func interviewSlotList(slots []db.InterviewSlot) string {
+	var b strings.Builder
+	for _, slot := range slots {
+		fmt.Fprintf(&b, "<li>%s</li>", slot.StartsAt.UTC().Format(interviewTimeFormat))
+	}
+	return b.String()
+}
+
+func interviewDetails(interview db.Interview, reason string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "<p>The interview takes %d minutes.</p>", interview.DurationMinutes)
+	if interview.Location != "" {
+		fmt.Fprintf(&b, "<p>Where: %s</p>", interview.Location)
+	}
+	if interview.Notes != "" {
+		fmt.Fprintf(&b, "<p>Notes: %s</p>", interview.Notes)
+	}
+	if reason != "" {
+		fmt.Fprintf(&b, "<p>They said: %s</p>", reason)
+	}
+	return b.String()
+}
+
+func reminderLead(lead time.Duration) string {
+	if lead >= time.Hour && lead%time.Hour == 0 {
+		hours := int(lead / time.Hour)
+		if hours == 1 {
+			return "1 hour"
+		}
+		return fmt.Sprintf("%d hours", hours)
+	}
+	return lead.String()
+}
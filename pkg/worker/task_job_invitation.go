@@ -0,0 +1,78 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskNotifyJobInvitation = "task:notify_job_invitation"
+
+// PayloadNotifyJobInvitation tells a candidate that an employer invited them
+// to apply to a job.
+type PayloadNotifyJobInvitation struct {
+	CandidateID int64     `json:"candidate_id"`
+	EmployerID  int64     `json:"employer_id"`
+	JobID       string    `json:"job_id"`
+	Message     string    `json:"message"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskNotifyJobInvitation(
+	ctx context.Context,
+	payload *PayloadNotifyJobInvitation,
+	opts ...asynq.Option,
+) error {
+	return distributor.distributeTask(ctx, TaskNotifyJobInvitation, payload, opts...)
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskNotifyJobInvitation(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadNotifyJobInvitation
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+	if payload.CandidateID == 0 || payload.EmployerID == 0 || payload.JobID == "" {
+		return fmt.Errorf("invalid job invitation payload: %w", asynq.SkipRetry)
+	}
+	if processor.mailer == nil {
+		return errors.New("no mailer configured to notify job invitations")
+	}
+
+	candidate, err := processor.store.GetCandidate(ctx, payload.CandidateID)
+	if err != nil {
+		return decisionLookupError("candidate", err)
+	}
+	user, err := processor.store.GetUser(ctx, candidate.Username)
+	if err != nil {
+		return decisionLookupError("candidate user", err)
+	}
+	employer, err := processor.store.GetEmployer(ctx, payload.EmployerID)
+	if err != nil {
+		return decisionLookupError("employer", err)
+	}
+	position := "one of their jobs"
+	if job, err := processor.esClient.GetJob(payload.JobID); err == nil {
+		position = job.Title
+	}
+
+	subject := fmt.Sprintf("%s invited you to apply", employer.BusinessName)
+	content := fmt.Sprintf(`<p>Hi %s,</p>
+	<p>%s has invited you to apply for %s.</p>
+	<p>They said: %s</p>
+	<p>The invitation expires on %s. Accept it to start your application, or decline it if you're not interested.</p>
+	<p>&copy; 2024 Part-Timer. All rights reserved.</p>`,
+		candidate.FullName, employer.BusinessName, position, payload.Message, payload.ExpiresAt.Format("January 2, 2006"))
+	if err := processor.mailer.SendEmail(subject, content, []string{user.Email}, nil, nil, nil, nil); err != nil {
+		log.Error().Err(err).Msgf("failed to send job invitation email to: %s", user.Email)
+		return fmt.Errorf("failed to send job invitation email: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Str("email", user.Email).Msg("processed task")
+	return nil
+}
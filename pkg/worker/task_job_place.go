@@ -0,0 +1,153 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+
+	es "github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
+	"github.com/hankimmy/PtmrBackend/pkg/google"
+)
+
+const (
+	TaskEnrichJobPlace    = "task:enrich_job_place"
+	TaskRefreshJobPlaces  = "task:refresh_job_places"
+	placeRefreshStaleness = 24 * time.Hour
+)
+
+type PayloadEnrichJobPlace struct {
+	JobID string `json:"job_id"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskEnrichJobPlace(
+	ctx context.Context,
+	payload *PayloadEnrichJobPlace,
+	opts ...asynq.Option,
+) error {
+	return distributor.distributeTask(ctx, TaskEnrichJobPlace, payload, opts...)
+}
+
+// ProcessTaskEnrichJobPlace looks the job's business up on Google Places and
+// copies the place details onto the job. Google errors are retried; once the
+// retries run out, or the business cannot be found, the job is marked failed
+// and left for the periodic refresh to try again.
+func (processor *RedisTaskProcessor) ProcessTaskEnrichJobPlace(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadEnrichJobPlace
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	job, err := processor.esClient.GetJob(payload.JobID)
+	if err != nil {
+		return err
+	}
+	if job == nil {
+		log.Info().Str("job_id", payload.JobID).Msg("skipping place enrichment for deleted job")
+		return nil
+	}
+
+	if err := processor.enrichJobPlace(ctx, job); err != nil {
+		retried, _ := asynq.GetRetryCount(ctx)
+		maxRetry, _ := asynq.GetMaxRetry(ctx)
+		if errors.Is(err, google.ErrPlaceNotFound) || retried >= maxRetry {
+			if err := processor.esClient.UpdateJobEnrichmentStatus(ctx, job.ID, es.EnrichmentFailed, time.Now()); err != nil {
+				return err
+			}
+			return fmt.Errorf("failed to enrich job %s: %v: %w", job.ID, err, asynq.SkipRetry)
+		}
+		return err
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).Msg("processed task")
+	return nil
+}
+
+// ProcessTaskRefreshJobPlaces refreshes the rating, opening hours and phone
+// number of jobs still open to candidates. Jobs that were never enriched get
+// a new enrichment task instead. It is registered with the periodic scheduler.
+func (processor *RedisTaskProcessor) ProcessTaskRefreshJobPlaces(ctx context.Context, task *asynq.Task) error {
+	now := time.Now()
+	jobs, err := processor.esClient.SearchJobsForPlaceRefresh(ctx, now.Add(-placeRefreshStaleness))
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for i := range jobs {
+		if err := processor.refreshJobPlace(ctx, &jobs[i], now); err != nil {
+			errs = append(errs, fmt.Errorf("job %s: %w", jobs[i].ID, err))
+		}
+	}
+
+	log.Info().Str("type", task.Type()).Int("jobs", len(jobs)).
+		Int("failed", len(errs)).Msg("processed task")
+	return errors.Join(errs...)
+}
+
+func (processor *RedisTaskProcessor) enrichJobPlace(ctx context.Context, job *es.Job) error {
+	placeID, err := processor.gapi.GetPlaceID(job.PlaceQuery())
+	if err != nil {
+		return fmt.Errorf("failed to find place: %w", err)
+	}
+	details, err := processor.gapi.GetPlaceDetails(placeID)
+	if err != nil {
+		return fmt.Errorf("failed to get place details: %w", err)
+	}
+	if err := processor.esClient.UpdateJobPlace(ctx, job.ID, details, time.Now()); err != nil {
+		return err
+	}
+	processor.flagDuplicateJob(ctx, job, details)
+	return nil
+}
+
+// flagDuplicateJob checks the job against the other jobs at the place it was
+// just matched to, which catches re-posts whose address was written
+// differently. Failures are only logged since the job is already enriched.
+func (processor *RedisTaskProcessor) flagDuplicateJob(ctx context.Context, job *es.Job, details *google.PlaceDetailsResponse) {
+	if job.DuplicateOf != "" {
+		return
+	}
+	job.PlaceID = details.ID
+	job.PreciseLocation = es.GeoPoint{Lat: details.Location.Latitude, Lon: details.Location.Longitude}
+
+	original, err := processor.esClient.FindDuplicateJob(ctx, job)
+	if err != nil {
+		log.Error().Err(err).Str("job_id", job.ID).Msg("failed to check for duplicate jobs")
+		return
+	}
+	if original == nil {
+		return
+	}
+	if err := processor.esClient.MarkJobDuplicate(ctx, job.ID, original.ID); err != nil {
+		log.Error().Err(err).Str("job_id", job.ID).Msg("failed to flag duplicate job")
+		return
+	}
+	log.Info().Str("job_id", job.ID).Str("duplicate_of", original.ID).Msg("flagged duplicate job")
+}
+
+func (processor *RedisTaskProcessor) refreshJobPlace(ctx context.Context, job *es.Job, now time.Time) error {
+	if job.PlaceID == "" {
+		payload := &PayloadEnrichJobPlace{JobID: job.ID}
+		opts := []asynq.Option{
+			asynq.MaxRetry(5),
+			asynq.Queue(QueueDefault),
+			asynq.Unique(time.Hour),
+		}
+		err := processor.distributor.DistributeTaskEnrichJobPlace(ctx, payload, opts...)
+		if errors.Is(err, asynq.ErrDuplicateTask) {
+			return nil
+		}
+		return err
+	}
+
+	details, err := processor.gapi.GetPlaceDetails(job.PlaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get place details: %w", err)
+	}
+	return processor.esClient.RefreshJobPlace(ctx, job.ID, details, now)
+}
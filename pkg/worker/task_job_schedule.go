@@ -0,0 +1,148 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+
+	es "github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
+)
+
+const (
+	TaskApplyJobSchedule  = "task:apply_job_schedule"
+	TaskSweepJobSchedules = "task:sweep_job_schedules"
+)
+
+type PayloadApplyJobSchedule struct {
+	JobID string `json:"job_id"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskApplyJobSchedule(
+	ctx context.Context,
+	payload *PayloadApplyJobSchedule,
+	opts ...asynq.Option,
+) error {
+	return distributor.distributeTask(ctx, TaskApplyJobSchedule, payload, opts...)
+}
+
+// ProcessTaskApplyJobSchedule runs at a job's publish_at or close_at, or as
+// soon as an application fills it. The job is re-read and only moved when it
+// is actually due, so tasks left over from an edited schedule are no-ops.
+func (processor *RedisTaskProcessor) ProcessTaskApplyJobSchedule(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadApplyJobSchedule
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	job, err := processor.esClient.GetJob(payload.JobID)
+	if err != nil {
+		return err
+	}
+	if job == nil {
+		log.Info().Str("job_id", payload.JobID).Msg("skipping schedule for deleted job")
+		return nil
+	}
+	target, due := job.ScheduledStatus(time.Now())
+	if !due {
+		if target, due, err = processor.applicationLimitStatus(ctx, job); err != nil {
+			return err
+		}
+	}
+	if due {
+		if err := processor.applyJobSchedule(ctx, job, target); err != nil {
+			return err
+		}
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).Msg("processed task")
+	return nil
+}
+
+// ProcessTaskSweepJobSchedules catches jobs whose delayed task was lost or
+// never enqueued. It is registered with the periodic scheduler. Jobs that
+// fill up are closed by the task ApplicationService enqueues in the same
+// transaction as the application that fills them, not here.
+func (processor *RedisTaskProcessor) ProcessTaskSweepJobSchedules(ctx context.Context, task *asynq.Task) error {
+	now := time.Now()
+	var swept int
+	var errs []error
+	for _, search := range []func(context.Context, time.Time, []interface{}) ([]es.Job, []interface{}, error){
+		processor.esClient.SearchJobsDueToPublish,
+		processor.esClient.SearchJobsDueToClose,
+	} {
+		var after []interface{}
+		for {
+			jobs, next, err := search(ctx, now, after)
+			if err != nil {
+				errs = append(errs, err)
+				break
+			}
+			for i := range jobs {
+				target, due := jobs[i].ScheduledStatus(now)
+				if !due {
+					continue
+				}
+				swept++
+				if err := processor.applyJobSchedule(ctx, &jobs[i], target); err != nil {
+					errs = append(errs, fmt.Errorf("job %s: %w", jobs[i].ID, err))
+				}
+			}
+			if next == nil {
+				break
+			}
+			after = next
+		}
+	}
+
+	log.Info().Str("type", task.Type()).Int("jobs", swept).
+		Int("failed", len(errs)).Msg("processed task")
+	return errors.Join(errs...)
+}
+
+// applicationLimitStatus reports whether an open job has as many applications
+// as its max_applications allows and should be closed.
+func (processor *RedisTaskProcessor) applicationLimitStatus(ctx context.Context, job *es.Job) (es.JobStatus, bool, error) {
+	current := job.CurrentStatus()
+	if job.MaxApplications <= 0 || (current != es.JobStatusPublished && current != es.JobStatusPaused) {
+		return "", false, nil
+	}
+	count, err := processor.store.CountCandidateApplicationsByJob(ctx, job.ID)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to count applications: %w", err)
+	}
+	if !job.ReachedMaxApplications(count) {
+		return "", false, nil
+	}
+	return es.JobStatusClosed, true, nil
+}
+
+func (processor *RedisTaskProcessor) applyJobSchedule(ctx context.Context, job *es.Job, target es.JobStatus) error {
+	current := job.CurrentStatus()
+	if err := processor.esClient.UpdateJobStatus(job.ID, target); err != nil {
+		return err
+	}
+	log.Info().Str("job_id", job.ID).Str("from", string(current)).
+		Str("to", string(target)).Msg("applied job schedule")
+
+	if target.NotifiesApplicants() {
+		payload := &PayloadNotifyJobClosed{
+			JobID:              job.ID,
+			Title:              job.Title,
+			HiringOrganization: job.HiringOrganization,
+			Status:             target,
+		}
+		opts := []asynq.Option{
+			asynq.MaxRetry(10),
+			asynq.Queue(QueueMail),
+		}
+		if err := processor.distributor.DistributeTaskNotifyJobClosed(ctx, payload, opts...); err != nil {
+			log.Error().Err(err).Str("job_id", job.ID).Msg("failed to enqueue job closed notification")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+
+	es "github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
+)
+
+const TaskSyncJobShifts = "task:sync_job_shifts"
+
+type PayloadSyncJobShifts struct {
+	JobID string `json:"job_id"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskSyncJobShifts(
+	ctx context.Context,
+	payload *PayloadSyncJobShifts,
+	opts ...asynq.Option,
+) error {
+	return distributor.distributeTask(ctx, TaskSyncJobShifts, payload, opts...)
+}
+
+// ProcessTaskSyncJobShifts copies a job's shifts and their open spots from
+// Postgres to the job document the feed searches. It always writes the
+// current state, so tasks may run in any order.
+func (processor *RedisTaskProcessor) ProcessTaskSyncJobShifts(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSyncJobShifts
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	job, err := processor.esClient.GetJob(payload.JobID)
+	if err != nil {
+		return fmt.Errorf("failed to get job: %w", err)
+	}
+	if job == nil {
+		log.Info().Str("type", task.Type()).Str("job_id", payload.JobID).Msg("job no longer exists, skipping")
+		return nil
+	}
+
+	rows, err := processor.store.ListJobShifts(ctx, payload.JobID)
+	if err != nil {
+		return fmt.Errorf("failed to list job shifts: %w", err)
+	}
+	shifts := make([]es.JobShift, 0, len(rows))
+	for _, row := range rows {
+		shifts = append(shifts, es.NewJobShift(row))
+	}
+	if err := processor.esClient.UpdateJobShifts(ctx, payload.JobID, shifts); err != nil {
+		return fmt.Errorf("failed to update job shifts: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Str("job_id", payload.JobID).
+		Int("shifts", len(shifts)).Msg("processed task")
+	return nil
+}
@@ -0,0 +1,127 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+
+	db "github.com/hankimmy/PtmrBackend/pkg/db/sqlc"
+	es "github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
+)
+
+const TaskIncrementJobStats = "task:increment_job_stats"
+
+// JobStatEvent is something that happened to a job that employers see in its
+// statistics.
+type JobStatEvent string
+
+const (
+	JobStatView        JobStatEvent = "view"
+	JobStatImpression  JobStatEvent = "impression"
+	JobStatRightSwipe  JobStatEvent = "right_swipe"
+	JobStatLeftSwipe   JobStatEvent = "left_swipe"
+	JobStatApplication JobStatEvent = "application"
+)
+
+type JobStatTarget struct {
+	JobID      string `json:"job_id"`
+	EmployerID int64  `json:"employer_id"`
+}
+
+type PayloadIncrementJobStats struct {
+	Event      JobStatEvent    `json:"event"`
+	Jobs       []JobStatTarget `json:"jobs"`
+	OccurredAt time.Time       `json:"occurred_at"`
+}
+
+// NewPayloadIncrementJobStats counts one event for each of the jobs.
+func NewPayloadIncrementJobStats(event JobStatEvent, occurredAt time.Time, jobs ...es.Job) *PayloadIncrementJobStats {
+	payload := &PayloadIncrementJobStats{
+		Event:      event,
+		Jobs:       make([]JobStatTarget, 0, len(jobs)),
+		OccurredAt: occurredAt,
+	}
+	for _, job := range jobs {
+		payload.Jobs = append(payload.Jobs, JobStatTarget{JobID: job.ID, EmployerID: job.EmployerID})
+	}
+	return payload
+}
+
+// RecordJobStat counts one event for each of the jobs in the background, so
+// the request that caused it is not slowed down. Failures are only logged:
+// statistics are best effort and the event itself has already happened.
+func RecordJobStat(ctx context.Context, distributor TaskDistributor, event JobStatEvent, jobs ...es.Job) {
+	payload := NewPayloadIncrementJobStats(event, time.Now(), jobs...)
+	opts := []asynq.Option{
+		asynq.MaxRetry(3),
+		asynq.Queue(QueueDefault),
+	}
+	if err := distributor.DistributeTaskIncrementJobStats(ctx, payload, opts...); err != nil {
+		log.Error().Err(err).Str("event", string(event)).Msg("failed to enqueue job stats")
+	}
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskIncrementJobStats(
+	ctx context.Context,
+	payload *PayloadIncrementJobStats,
+	opts ...asynq.Option,
+) error {
+	return distributor.distributeTask(ctx, TaskIncrementJobStats, payload, opts...)
+}
+
+// ProcessTaskIncrementJobStats adds the event to the daily rollup of every job
+// in the payload in a single statement, so a failed task never leaves some of
+// its jobs counted. A task retried after it committed, for example because its
+// completion was lost, counts the event again; statistics are best effort.
+// Jobs whose employer has no row, such as scraped ones, are skipped.
+func (processor *RedisTaskProcessor) ProcessTaskIncrementJobStats(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadIncrementJobStats
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	arg := db.IncrementJobDailyStatsParams{
+		Day: pgtype.Date{Time: payload.OccurredAt.UTC().Truncate(24 * time.Hour), Valid: true},
+	}
+	switch payload.Event {
+	case JobStatView:
+		arg.Views = 1
+	case JobStatImpression:
+		arg.Impressions = 1
+	case JobStatRightSwipe:
+		arg.RightSwipes = 1
+	case JobStatLeftSwipe:
+		arg.LeftSwipes = 1
+	case JobStatApplication:
+		arg.Applications = 1
+	default:
+		return fmt.Errorf("unknown job stat event %q: %w", payload.Event, asynq.SkipRetry)
+	}
+
+	// A job can only be counted once per statement.
+	seen := make(map[string]bool, len(payload.Jobs))
+	for _, job := range payload.Jobs {
+		if job.JobID == "" || job.EmployerID == 0 || seen[job.JobID] {
+			continue
+		}
+		seen[job.JobID] = true
+		arg.JobIds = append(arg.JobIds, job.JobID)
+		arg.EmployerIds = append(arg.EmployerIds, job.EmployerID)
+	}
+	if len(arg.JobIds) == 0 {
+		return nil
+	}
+
+	if err := processor.store.IncrementJobDailyStats(ctx, arg); err != nil {
+		return fmt.Errorf("failed to increment job stats: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Str("event", string(payload.Event)).
+		Int("jobs", len(arg.JobIds)).Msg("processed task")
+	return nil
+}
@@ -0,0 +1,70 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+
+	es "github.com/hankimmy/PtmrBackend/pkg/elasticsearch"
+)
+
+const TaskNotifyJobClosed = "task:notify_job_closed"
+
+type PayloadNotifyJobClosed struct {
+	JobID              string       `json:"job_id"`
+	Title              string       `json:"title"`
+	HiringOrganization string       `json:"hiring_organization"`
+	Status             es.JobStatus `json:"status"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskNotifyJobClosed(
+	ctx context.Context,
+	payload *PayloadNotifyJobClosed,
+	opts ...asynq.Option,
+) error {
+	return distributor.distributeTask(ctx, TaskNotifyJobClosed, payload, opts...)
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskNotifyJobClosed(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadNotifyJobClosed
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+	if payload.JobID == "" || !payload.Status.NotifiesApplicants() {
+		return fmt.Errorf("invalid job closed payload: %w", asynq.SkipRetry)
+	}
+	applicants, err := processor.store.ListOpenApplicantsByJob(ctx, payload.JobID)
+	if err != nil {
+		return fmt.Errorf("failed to list open applicants: %w", err)
+	}
+
+	subject := fmt.Sprintf("Update on your application for %s", payload.Title)
+	emails := make([]PayloadSendNotificationEmail, len(applicants))
+	for i, applicant := range applicants {
+		emails[i] = PayloadSendNotificationEmail{
+			To:      applicant.Email,
+			Subject: subject,
+			Content: jobClosedEmailContent(applicant.FullName, payload),
+		}
+	}
+	if err := processor.fanOutNotificationEmails(ctx, emails); err != nil {
+		return err
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Int("notified", len(applicants)).Msg("processed task")
+	return nil
+}
+
+func jobClosedEmailContent(name string, payload PayloadNotifyJobClosed) string {
+	reason := "is no longer accepting applications"
+	if payload.Status == es.JobStatusFilled {
+		reason = "has been filled"
+	}
+	return fmt.Sprintf(`<p>Hi %s,</p>
+	<p>The %s position at %s %s. Thank you for your interest, and good luck with your search on Part-Timer!</p>
+	<p>&copy; 2024 Part-Timer. All rights reserved.</p>`, name, payload.Title, payload.HiringOrganization, reason)
+}
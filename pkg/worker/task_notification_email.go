@@ -0,0 +1,80 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskSendNotificationEmail = "task:send_notification_email"
+
+// notificationEmailRetention keeps a sent email's task around long enough to
+// outlast every retry of the task that fanned it out, so a retry can't enqueue
+// the same email again.
+const notificationEmailRetention = 24 * time.Hour
+
+// PayloadSendNotificationEmail is one email of a notification that goes to
+// many recipients. Each recipient gets their own task, so a failed email is
+// retried on its own without resending the ones that went out.
+type PayloadSendNotificationEmail struct {
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Content string `json:"content"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskSendNotificationEmail(
+	ctx context.Context,
+	payload *PayloadSendNotificationEmail,
+	opts ...asynq.Option,
+) error {
+	return distributor.distributeTask(ctx, TaskSendNotificationEmail, payload, opts...)
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskSendNotificationEmail(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendNotificationEmail
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+	if payload.To == "" || payload.Subject == "" || payload.Content == "" {
+		return fmt.Errorf("invalid notification email payload: %w", asynq.SkipRetry)
+	}
+	if processor.mailer == nil {
+		return errors.New("no mailer configured to send notification emails")
+	}
+
+	if err := processor.mailer.SendEmail(payload.Subject, payload.Content, []string{payload.To}, nil, nil, nil, nil); err != nil {
+		log.Error().Err(err).Msgf("failed to send notification email to: %s", payload.To)
+		return fmt.Errorf("failed to send notification email: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Str("email", payload.To).Msg("processed task")
+	return nil
+}
+
+// fanOutNotificationEmails enqueues one task per email. The task IDs are
+// derived from the fanning-out task's ID, which stays the same across its
+// retries, so emails enqueued by an earlier attempt are skipped.
+func (processor *RedisTaskProcessor) fanOutNotificationEmails(ctx context.Context, emails []PayloadSendNotificationEmail) error {
+	parentID, ok := asynq.GetTaskID(ctx)
+	if !ok {
+		return errors.New("notification emails must be fanned out from a task")
+	}
+	for i := range emails {
+		opts := []asynq.Option{
+			asynq.TaskID(fmt.Sprintf("%s:%s", parentID, emails[i].To)),
+			asynq.MaxRetry(10),
+			asynq.Retention(notificationEmailRetention),
+			asynq.Queue(QueueMail),
+		}
+		err := processor.distributor.DistributeTaskSendNotificationEmail(ctx, &emails[i], opts...)
+		if err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+			return fmt.Errorf("failed to enqueue notification email: %w", err)
+		}
+	}
+	return nil
+}
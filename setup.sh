@@ -20,10 +20,35 @@ JOB_INDEX_MAPPING='{
       },
       "job_location": { "type": "keyword" },
       "employment_type": { "type": "keyword" },
-      "title": { "type": "text" },
+      "title": {
+        "type": "text",
+        "fields": { "keyword": { "type": "keyword" } }
+      },
+      "employer_id": { "type": "long" },
+      "place_id": { "type": "keyword" },
+      "duplicate_of": { "type": "keyword" },
+      "moderation_status": { "type": "keyword" },
       "industry": { "type": "keyword" },
       "wage": { "type": "half_float" },
       "user_created": { "type": "boolean" },
+      "status": { "type": "keyword" },
+      "publish_at": { "type": "date" },
+      "close_at": { "type": "date" },
+      "max_applications": { "type": "integer" },
+      "enrichment_status": { "type": "keyword" },
+      "place_refreshed_at": { "type": "date" },
+      "shifts": {
+        "type": "nested",
+        "properties": {
+          "id": { "type": "long" },
+          "starts_at": { "type": "date" },
+          "ends_at": { "type": "date" },
+          "timezone": { "type": "keyword" },
+          "headcount": { "type": "integer" },
+          "spots_left": { "type": "integer" },
+          "slots": { "type": "keyword" }
+        }
+      },
       "rating": { "type": "half_float" },
       "price_level": { "type": "keyword" },
       "requirements": { "type": "keyword" },
@@ -73,6 +98,7 @@ CANDIDATE_INDEX_MAPPING='
       },
       "account_verified": { "type": "boolean" },
       "has_resume": {"type": "boolean" },
+      "moderation_status": { "type": "keyword" },
       "rating": { "type": "integer" },
       "past_experience": {
         "type": "nested",